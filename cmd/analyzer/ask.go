@@ -0,0 +1,184 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/olegiv/go-logger"
+	"github.com/olegiv/logwatch-ai-go/internal/ai"
+	"github.com/olegiv/logwatch-ai-go/internal/config"
+	"github.com/olegiv/logwatch-ai-go/internal/logging"
+	"github.com/olegiv/logwatch-ai-go/internal/storage"
+)
+
+// askOptions holds arguments for the ask subcommand
+type askOptions struct {
+	SummaryID int64
+	Question  string
+}
+
+func parseAskArgs(args []string) (*askOptions, error) {
+	fs := flag.NewFlagSet("ask", flag.ContinueOnError)
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(fs.Output(), "Usage: %s ask <summary-id> \"question\"\n\n", os.Args[0])
+		_, _ = fmt.Fprintf(fs.Output(), "Asks a follow-up question about a stored analysis. The archived prompt\n")
+		_, _ = fmt.Fprintf(fs.Output(), "input of that run and its analysis are sent to the configured LLM provider.\n")
+		_, _ = fmt.Fprintf(fs.Output(), "Summary IDs are logged after each run (\"Summary saved to database\").\n")
+		_, _ = fmt.Fprintf(fs.Output(), "\nExample:\n")
+		_, _ = fmt.Fprintf(fs.Output(), "  %s ask 42 \"Which IPs were behind the SSH brute force attempts?\"\n", os.Args[0])
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if fs.NArg() < 2 {
+		fs.Usage()
+		return nil, fmt.Errorf("ask requires a summary ID and a question")
+	}
+
+	id, err := strconv.ParseInt(fs.Arg(0), 10, 64)
+	if err != nil || id <= 0 {
+		return nil, fmt.Errorf("invalid summary ID: %s", fs.Arg(0))
+	}
+
+	return &askOptions{
+		SummaryID: id,
+		Question:  strings.Join(fs.Args()[1:], " "),
+	}, nil
+}
+
+// runAskCommand implements the ask subcommand
+func runAskCommand(args []string) int {
+	opts, err := parseAskArgs(args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitSuccess
+		}
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	cfg, err := config.LoadLLMConfig()
+	if err == nil {
+		err = cfg.ValidateLLMProvider()
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+		return exitFailure
+	}
+
+	// Keep the console free for the answer; details go to the log file.
+	baseLog := logger.New(logger.Config{
+		Level:      cfg.LogLevel,
+		LogDir:     "./logs",
+		Filename:   "ask.log",
+		MaxSizeMB:  10,
+		MaxBackups: 5,
+		Console:    false,
+	})
+	log := logging.NewSecure(baseLog)
+	defer func() { _ = log.Close() }()
+
+	if err := runAsk(ctx, cfg, opts, os.Stdout, os.Stderr, log); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	return exitSuccess
+}
+
+// runAsk reloads a stored analysis with its archived prompts and sends the
+// question as a follow-up turn. The answer goes to stdout, usage to stderr.
+func runAsk(ctx context.Context, cfg *config.Config, opts *askOptions, stdout, stderr io.Writer, log *logging.SecureLogger) error {
+	if !cfg.EnableDatabase {
+		return fmt.Errorf("ask requires ENABLE_DATABASE=true")
+	}
+	if _, err := os.Stat(cfg.DatabasePath); err != nil {
+		return fmt.Errorf("database not found at %s: %w", cfg.DatabasePath, err)
+	}
+
+	store, err := storage.New(cfg.DatabasePath)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	summary, err := store.GetSummary(opts.SummaryID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("summary %d not found", opts.SummaryID)
+		}
+		return err
+	}
+
+	archive, err := store.GetPromptArchive(opts.SummaryID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("no archived prompt input for summary %d (archives are kept for PROMPT_ARCHIVE_RETENTION_DAYS=%d days and only for runs made while archiving was enabled)",
+				opts.SummaryID, cfg.PromptArchiveRetentionDays)
+		}
+		return err
+	}
+
+	analysis := &ai.Analysis{
+		SystemStatus:    summary.SystemStatus,
+		Summary:         summary.Summary,
+		CriticalIssues:  summary.CriticalIssues,
+		Warnings:        summary.Warnings,
+		Recommendations: summary.Recommendations,
+		Metrics:         summary.Metrics,
+	}
+	messages, err := ai.BuildFollowUpMessages(archive.UserPrompt, analysis, opts.Question)
+	if err != nil {
+		return err
+	}
+
+	askCfg := *cfg
+	askCfg.LogSourceType = summary.LogSourceType
+	provider, err := createLLMClient(ctx, &askCfg, log)
+	if err != nil {
+		return err
+	}
+	followUp, ok := provider.(ai.FollowUpProvider)
+	if !ok {
+		return fmt.Errorf("provider %s does not support follow-up questions", provider.GetProviderName())
+	}
+
+	log.Info().
+		Int64("summary_id", summary.ID).
+		Str("source_type", summary.LogSourceType).
+		Str("provider", provider.GetProviderName()).
+		Msg("Asking follow-up question")
+
+	answer, stats, err := followUp.FollowUp(ctx, ai.FollowUpSystemPrompt(archive.SystemPrompt), messages)
+	if err != nil {
+		return fmt.Errorf("follow-up question failed: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(stderr, "Summary #%d (%s, %s, status %s)\n\n",
+		summary.ID, summary.LogSourceType, summary.Timestamp.Format("2006-01-02 15:04"), summary.SystemStatus)
+	if _, err := fmt.Fprintln(stdout, answer); err != nil {
+		return err
+	}
+	if stats != nil {
+		_, _ = fmt.Fprintf(stderr, "\n%s %s: %d input / %d output tokens, $%.4f, %.1fs\n",
+			stats.Provider, stats.Model, stats.InputTokens, stats.OutputTokens, stats.CostUSD, stats.DurationSeconds)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/olegiv/go-logger"
+	"github.com/olegiv/logwatch-ai-go/internal/config"
+	"github.com/olegiv/logwatch-ai-go/internal/logging"
+	"github.com/olegiv/logwatch-ai-go/internal/storage"
+)
+
+func TestParseAskArgs(t *testing.T) {
+	opts, err := parseAskArgs([]string{"42", "Which", "IPs?"})
+	if err != nil {
+		t.Fatalf("parseAskArgs() error = %v", err)
+	}
+	if opts.SummaryID != 42 || opts.Question != "Which IPs?" {
+		t.Errorf("unexpected options: %+v", opts)
+	}
+
+	for _, args := range [][]string{{}, {"42"}, {"abc", "q"}, {"0", "q"}} {
+		if _, err := parseAskArgs(args); err == nil {
+			t.Errorf("parseAskArgs(%q) expected error", args)
+		}
+	}
+}
+
+func TestRunAsk_MockProvider(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "summaries.db")
+	mockDir := filepath.Join(dir, "mock")
+	if err := os.MkdirAll(filepath.Join(mockDir, "logwatch"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(mockDir, "logwatch", "default.answer.txt"), []byte("From 203.0.113.5."), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := storage.New(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	archived := &storage.Summary{Timestamp: time.Now(), LogSourceType: "logwatch", SystemStatus: "Bad", Summary: "SSH brute force"}
+	if err := store.SaveSummary(archived); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SavePromptArchive(&storage.PromptArchive{SummaryID: archived.ID, SystemPrompt: "system", UserPrompt: "logs"}); err != nil {
+		t.Fatal(err)
+	}
+	unarchived := &storage.Summary{Timestamp: time.Now(), LogSourceType: "logwatch", SystemStatus: "Good", Summary: "ok"}
+	if err := store.SaveSummary(unarchived); err != nil {
+		t.Fatal(err)
+	}
+	_ = store.Close()
+
+	cfg := &config.Config{
+		LLMProvider:                "mock",
+		MockDir:                    mockDir,
+		MockMode:                   config.MockModeFixture,
+		EnableDatabase:             true,
+		DatabasePath:               dbPath,
+		PromptArchiveRetentionDays: 14,
+		AIMaxTokens:                8000,
+	}
+	log := logging.NewSecure(logger.New(logger.Config{Level: "error", LogDir: dir, Filename: "ask.log", Console: false}))
+	defer func() { _ = log.Close() }()
+
+	var stdout, stderr bytes.Buffer
+	if err := runAsk(context.Background(), cfg, &askOptions{SummaryID: archived.ID, Question: "Which IPs?"}, &stdout, &stderr, log); err != nil {
+		t.Fatalf("runAsk() error = %v", err)
+	}
+	if strings.TrimSpace(stdout.String()) != "From 203.0.113.5." {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "status Bad") {
+		t.Errorf("stderr missing summary header: %q", stderr.String())
+	}
+
+	err = runAsk(context.Background(), cfg, &askOptions{SummaryID: unarchived.ID, Question: "Why?"}, &stdout, &stderr, log)
+	if err == nil || !strings.Contains(err.Error(), "no archived prompt input") {
+		t.Errorf("runAsk(unarchived) error = %v", err)
+	}
+
+	err = runAsk(context.Background(), cfg, &askOptions{SummaryID: 999, Question: "Why?"}, &stdout, &stderr, log)
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("runAsk(missing) error = %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/olegiv/logwatch-ai-go/internal/config"
+)
+
+// handleListCustomSources lists the source types declared in
+// custom-sources.json.
+func handleListCustomSources(cli *config.CLIOptions) int {
+	sourcesConfig, configPath, err := config.LoadCustomSourcesConfig(cli.CustomSourcesConfig)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	if sourcesConfig == nil {
+		_, _ = fmt.Fprintf(os.Stderr, "No custom-sources.json configuration file found.\n")
+		_, _ = fmt.Fprintf(os.Stderr, "\nSearch locations:\n")
+		_, _ = fmt.Fprintf(os.Stderr, "  - ./custom-sources.json\n")
+		_, _ = fmt.Fprintf(os.Stderr, "  - ./configs/custom-sources.json\n")
+		_, _ = fmt.Fprintf(os.Stderr, "  - /opt/logwatch-ai/custom-sources.json\n")
+		_, _ = fmt.Fprintf(os.Stderr, "  - ~/.config/logwatch-ai/custom-sources.json\n")
+		_, _ = fmt.Fprintf(os.Stderr, "\nUse -custom-sources-config to specify a custom path.\n")
+		return exitFailure
+	}
+
+	fmt.Printf("Custom sources configuration: %s\n", configPath)
+	fmt.Printf("Version: %s\n\n", sourcesConfig.Version)
+	fmt.Printf("Available sources (use as -source-type):\n")
+
+	for _, sourceType := range sourcesConfig.ListSources() {
+		definition := sourcesConfig.Sources[sourceType]
+		displayName := definition.Name
+		if displayName == "" {
+			displayName = sourceType
+		}
+
+		fmt.Printf("  %-20s %s\n", sourceType, displayName)
+		fmt.Printf("    Log path:           %s\n", definition.LogPath)
+		if len(definition.GroupBy) > 0 {
+			fmt.Printf("    Group by:           %s\n", strings.Join(definition.GroupBy, ", "))
+		}
+		if len(definition.PriorityKeywords) > 0 {
+			fmt.Printf("    Priority keywords:  %s\n", strings.Join(definition.PriorityKeywords, ", "))
+		}
+		fmt.Println()
+	}
+
+	return exitSuccess
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+	"github.com/olegiv/logwatch-ai-go/internal/config"
+	"github.com/olegiv/logwatch-ai-go/internal/customlog"
+)
+
+func TestNewSourceRegistry(t *testing.T) {
+	cfg := &config.Config{
+		LogSourceType:          "logwatch",
+		MaxLogSizeMB:           1,
+		MaxPreprocessingTokens: 1000,
+		AccessLogFormat:        "combined",
+		CustomSourcesConfig: &config.CustomSourcesConfig{
+			Sources: map[string]customlog.Definition{
+				"orders": {Name: "Order Service", LineRegex: `^(?P<level>[A-Z]+) (?P<message>.*)$`},
+			},
+		},
+	}
+
+	registry, err := newSourceRegistry(cfg)
+	if err != nil {
+		t.Fatalf("newSourceRegistry() error = %v", err)
+	}
+	for _, sourceType := range []string{"logwatch", "drupal_watchdog", "ocms", "journald", "access_log", "syslog", "docker", "orders"} {
+		if !registry.Has(analyzer.LogSourceType(sourceType)) {
+			t.Errorf("registry has no %s source", sourceType)
+		}
+	}
+
+	// Invalid settings of one source do not affect the others
+	cfg.DockerLabels = "=invalid"
+	if _, err := registry.Create(analyzer.LogSourceDocker); err == nil {
+		t.Error("Create(docker) expected error for invalid labels")
+	}
+	source, err := registry.Create(analyzer.LogSourceAccessLog)
+	if err != nil || source.Type != analyzer.LogSourceAccessLog {
+		t.Fatalf("Create(access_log) = %v, %v", source, err)
+	}
+
+	source, err = registry.Create("orders")
+	if err != nil {
+		t.Fatalf("Create(orders) error = %v", err)
+	}
+	if source.PromptBuilder.GetLogType() != "orders" {
+		t.Errorf("GetLogType() = %q, want orders", source.PromptBuilder.GetLogType())
+	}
+	content, err := source.Reader.(analyzer.ContentReader).ReadContent("ERROR payment failed for order 12\nINFO order 13 placed\n")
+	if err != nil {
+		t.Fatalf("ReadContent() error = %v", err)
+	}
+	if !strings.Contains(content, "=== ORDER SERVICE LOG DIGEST ===") || !strings.Contains(content, "## Error\n- payment failed for order 12") {
+		t.Errorf("digest:\n%s", content)
+	}
+
+	if _, err := registry.Create("billing"); err == nil || !strings.Contains(err.Error(), "unsupported log source type") {
+		t.Errorf("Create(billing) error = %v", err)
+	}
+}
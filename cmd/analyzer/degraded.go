@@ -0,0 +1,125 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/olegiv/logwatch-ai-go/internal/ai"
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+	"github.com/olegiv/logwatch-ai-go/internal/config"
+	"github.com/olegiv/logwatch-ai-go/internal/logging"
+	"github.com/olegiv/logwatch-ai-go/internal/notification"
+	"github.com/olegiv/logwatch-ai-go/internal/rules"
+	"github.com/olegiv/logwatch-ai-go/internal/storage"
+)
+
+// sendDegradedReport stores and sends a report built without LLM analysis
+// from the reader's own statistics and the deterministic rule matches, and
+// returns its status.
+func sendDegradedReport(
+	cfg *config.Config,
+	store *storage.Storage,
+	telegramClient *notification.TelegramClient,
+	logSource *analyzer.LogSource,
+	ruleMatches []rules.Match,
+	startTime time.Time,
+	log *logging.SecureLogger,
+) (string, error) {
+	var readStats *analyzer.ReadStats
+	if reporter, ok := logSource.Reader.(analyzer.StatsReporter); ok {
+		readStats = reporter.ReadStats()
+	}
+
+	analysis := buildDegradedAnalysis(providerLabel(cfg), readStats, ruleMatches)
+	applyReaderMetrics(analysis, logSource, log)
+	log.Warn().
+		Str("status", analysis.SystemStatus).
+		Int("rule_matches", len(ruleMatches)).
+		Bool("reader_stats", readStats != nil).
+		Msg("Degraded report built without AI analysis")
+
+	if store != nil {
+		summary := &storage.Summary{
+			Timestamp:       startTime, // See runAnalyzer
+			LogSourceType:   cfg.LogSourceType,
+			SiteName:        cfg.SelectedSiteName(),
+			Host:            cfg.SourceHost,
+			SystemStatus:    analysis.SystemStatus,
+			Summary:         analysis.Summary,
+			CriticalIssues:  analysis.CriticalIssues,
+			Warnings:        analysis.Warnings,
+			Recommendations: analysis.Recommendations,
+			Metrics:         analysis.Metrics,
+			Degraded:        true,
+		}
+		if err := store.SaveSummary(summary); err != nil {
+			log.Warn().Err(err).Msg("Failed to save degraded summary to database")
+		} else {
+			log.Info().Int64("id", summary.ID).Msg("Degraded summary saved to database")
+		}
+	}
+
+	log.Info().Msg("Sending degraded Telegram report...")
+	if err := telegramClient.SendDegradedReport(analysis, readStats, cfg.LogSourceType, cfg.SelectedSiteName()); err != nil {
+		return "", fmt.Errorf("failed to send degraded Telegram report: %w", err)
+	}
+
+	log.Info().
+		Float64("total_duration_s", time.Since(startTime).Seconds()).
+		Msg("Degraded report sent")
+
+	return analysis.SystemStatus, nil
+}
+
+// buildDegradedAnalysis builds the analysis of a run without LLM. The status
+// is at least "Satisfactory" so the report also reaches the alerts channel;
+// rule matches are applied on top like in a regular run, and a critical
+// match without a status floor reports "Bad".
+func buildDegradedAnalysis(provider string, readStats *analyzer.ReadStats, ruleMatches []rules.Match) *ai.Analysis {
+	summary := fmt.Sprintf("AI analysis unavailable: the %s provider could not produce an analysis for this run. "+
+		"This report contains only statistics computed by the log reader", provider)
+	if len(ruleMatches) > 0 {
+		summary += fmt.Sprintf(" and %d deterministic rule match(es)", len(ruleMatches))
+	}
+
+	analysis := &ai.Analysis{
+		SystemStatus:   "Satisfactory",
+		Summary:        summary + ".",
+		CriticalIssues: []string{},
+		Warnings:       []string{},
+		Recommendations: []string{
+			"Check LLM provider connectivity and the analyzer log so the next run includes a full analysis",
+		},
+		Metrics: map[string]any{},
+	}
+
+	if readStats != nil {
+		for _, item := range readStats.Totals {
+			analysis.Metrics[item.Name] = item.Count
+		}
+	}
+	if len(ruleMatches) > 0 {
+		analysis.Metrics["Rule matches"] = len(ruleMatches)
+	}
+
+	for _, m := range ruleMatches {
+		if m.Severity == rules.SeverityCritical {
+			analysis.SystemStatus = "Bad"
+			break
+		}
+	}
+	rules.Apply(analysis, ruleMatches)
+
+	return analysis
+}
+
+// providerLabel names the configured provider for the degraded report
+func providerLabel(cfg *config.Config) string {
+	if cfg.IsEnsemble() {
+		return "ensemble (" + cfg.EnsembleProviders + ")"
+	}
+	return cfg.LLMProvider
+}
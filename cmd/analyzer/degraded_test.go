@@ -0,0 +1,142 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/olegiv/go-logger"
+	"github.com/olegiv/logwatch-ai-go/internal/ai"
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+	"github.com/olegiv/logwatch-ai-go/internal/config"
+	"github.com/olegiv/logwatch-ai-go/internal/logging"
+	"github.com/olegiv/logwatch-ai-go/internal/logwatch"
+	"github.com/olegiv/logwatch-ai-go/internal/rules"
+)
+
+func TestBuildDegradedAnalysis(t *testing.T) {
+	readStats := &analyzer.ReadStats{
+		Totals: []analyzer.StatsItem{{Name: "Entries", Count: 120}, {Name: "Warning entries", Count: 7}},
+	}
+
+	t.Run("statistics only", func(t *testing.T) {
+		analysis := buildDegradedAnalysis("ollama", readStats, nil)
+		if analysis.SystemStatus != "Satisfactory" {
+			t.Errorf("SystemStatus = %q, want Satisfactory", analysis.SystemStatus)
+		}
+		if !strings.Contains(analysis.Summary, "AI analysis unavailable") || !strings.Contains(analysis.Summary, "ollama") {
+			t.Errorf("Summary = %q", analysis.Summary)
+		}
+		if analysis.Metrics["Entries"] != 120 || len(analysis.CriticalIssues) != 0 {
+			t.Errorf("unexpected analysis: %+v", analysis)
+		}
+	})
+
+	t.Run("critical rule match", func(t *testing.T) {
+		matches := []rules.Match{
+			{Rule: "oom", Severity: rules.SeverityCritical, Count: 1, Finding: "OOM (count: 1, rule: oom)"},
+		}
+		analysis := buildDegradedAnalysis("anthropic", readStats, matches)
+		if analysis.SystemStatus != "Bad" {
+			t.Errorf("SystemStatus = %q, want Bad", analysis.SystemStatus)
+		}
+		if len(analysis.CriticalIssues) != 1 || analysis.Metrics["Rule matches"] != 1 {
+			t.Errorf("unexpected analysis: %+v", analysis)
+		}
+	})
+
+	t.Run("status floor", func(t *testing.T) {
+		matches := []rules.Match{
+			{Rule: "ssh", Severity: rules.SeverityWarning, StatusFloor: "Awful", Count: 600, Finding: "SSH"},
+		}
+		if analysis := buildDegradedAnalysis("anthropic", nil, matches); analysis.SystemStatus != "Awful" {
+			t.Errorf("SystemStatus = %q, want Awful", analysis.SystemStatus)
+		}
+	})
+}
+
+func TestApplyReaderMetrics(t *testing.T) {
+	log := logging.NewSecure(logger.New(logger.Config{Level: "error", LogDir: t.TempDir(), Filename: "metrics.log", Console: false}))
+
+	reader := logwatch.NewReader(10, false, 150000)
+	report := " ################### Logwatch 7.11 (07/22/24) ####################\n\n" +
+		" --------------------- SSHD Begin ------------------------ \n\n" +
+		" Failed logins from:\n    203.0.113.5: 1843 times\n\n" +
+		" ---------------------- SSHD End ------------------------- \n"
+	if _, err := reader.ReadContent(report); err != nil {
+		t.Fatalf("ReadContent() error = %v", err)
+	}
+
+	analysis := &ai.Analysis{Metrics: map[string]any{"failedLogins": 1800, "errorCount": 3}}
+	applyReaderMetrics(analysis, &analyzer.LogSource{Reader: reader}, log)
+	if analysis.Metrics["failedLogins"] != 1843 || analysis.Metrics["errorCount"] != 3 {
+		t.Errorf("Metrics = %v, want the parsed failedLogins and the LLM errorCount", analysis.Metrics)
+	}
+}
+
+func TestProviderLabel(t *testing.T) {
+	if got := providerLabel(&config.Config{LLMProvider: "ollama"}); got != "ollama" {
+		t.Errorf("providerLabel() = %q", got)
+	}
+	cfg := &config.Config{LLMProvider: "anthropic", EnsembleProviders: "anthropic,ollama"}
+	if got := providerLabel(cfg); got != "ensemble (anthropic,ollama)" {
+		t.Errorf("providerLabel() = %q", got)
+	}
+}
+
+func TestCreateEnsembleClient_UnavailableMembers(t *testing.T) {
+	log := logging.NewSecure(logger.New(logger.Config{Level: "error", LogDir: t.TempDir(), Filename: "ensemble.log", Console: false}))
+	base := config.Config{
+		AnthropicAPIKey:  "sk-ant-test",
+		ClaudeModel:      "claude-haiku-4-5-20251001",
+		OllamaBaseURL:    "http://127.0.0.1:1", // Nothing listens: the connection check fails
+		OllamaModel:      "llama3.3:latest",
+		AITimeoutSeconds: 5,
+		AIMaxTokens:      1000,
+	}
+
+	tests := []struct {
+		name      string
+		providers string
+		check     func(ai.Provider) bool
+		wantErr   bool
+	}{
+		{
+			name:      "unavailable member left out",
+			providers: "anthropic,anthropic:claude-sonnet-4-6,ollama",
+			check:     func(p ai.Provider) bool { _, ok := p.(*ai.EnsembleClient); return ok },
+		},
+		{
+			name:      "single member left used alone",
+			providers: "anthropic,ollama",
+			check:     func(p ai.Provider) bool { _, ok := p.(*ai.Client); return ok },
+		},
+		{
+			name:      "no member left",
+			providers: "ollama,ollama:qwen3:8b",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := base
+			cfg.EnsembleProviders = tt.providers
+			provider, err := createEnsembleClient(t.Context(), &cfg, log)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "no ensemble provider available") {
+					t.Errorf("createEnsembleClient() error = %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("createEnsembleClient() error = %v", err)
+			}
+			if !tt.check(provider) {
+				t.Errorf("createEnsembleClient() = %T", provider)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+	"github.com/olegiv/logwatch-ai-go/internal/config"
+	"github.com/olegiv/logwatch-ai-go/internal/drupal"
+	"github.com/olegiv/logwatch-ai-go/internal/logging"
+	"github.com/olegiv/logwatch-ai-go/internal/storage"
+)
+
+// drupalDatabaseTimeout bounds connecting to and querying the Drupal database.
+const drupalDatabaseTimeout = 2 * time.Minute
+
+// readDrupalDatabase queries the watchdog table of the selected Drupal
+// site for the entries since the last stored run of the site (or the last
+// drupal.DefaultDatabaseWindow without one), replacing the export file.
+// The window ends at startTime, the timestamp the run is stored with, so
+// consecutive windows neither overlap nor leave gaps.
+func readDrupalDatabase(
+	ctx context.Context,
+	cfg *config.Config,
+	store *storage.Storage,
+	logSource *analyzer.LogSource,
+	startTime time.Time,
+	log *logging.SecureLogger,
+) (string, error) {
+	drupalReader, ok := logSource.Reader.(*drupal.Reader)
+	if !ok {
+		return "", fmt.Errorf("drupal database read requires Drupal reader")
+	}
+
+	dbURL, err := cfg.DrupalDatabaseURL()
+	if err != nil {
+		return "", err
+	}
+
+	query := drupal.DatabaseQuery{
+		Since:       startTime.Add(-drupal.DefaultDatabaseWindow),
+		Until:       startTime,
+		MinSeverity: cfg.DrupalMinSeverity,
+		Limit:       cfg.DrupalWatchdogLimit,
+	}
+	if store != nil {
+		lastRun, err := store.GetLastRunTime(&storage.SourceFilter{
+			LogSourceType: cfg.LogSourceType,
+			SiteName:      cfg.SelectedSiteName(),
+		})
+		if err != nil {
+			log.Warn().Err(err).Msg("Could not get last run time, using the default window")
+		} else if !lastRun.IsZero() {
+			query.Since = lastRun
+		}
+	}
+
+	log.Info().
+		Str("database", dbURL.String()).
+		Str("since", query.Since.Format(time.RFC3339)).
+		Int("min_severity", query.MinSeverity).
+		Int("limit", query.Limit).
+		Msg("Querying Drupal watchdog table...")
+
+	ctx, cancel := context.WithTimeout(ctx, drupalDatabaseTimeout)
+	defer cancel()
+
+	db, err := drupal.OpenDatabase(ctx, dbURL)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Warn().Err(err).Msg("Failed to close Drupal database")
+		}
+	}()
+
+	logContent, err := drupalReader.ReadDatabase(ctx, db, query)
+	if err != nil {
+		return "", fmt.Errorf("failed to read log content: %w", err)
+	}
+
+	if notAnalyzed := drupalReader.NotAnalyzed(); notAnalyzed > 0 {
+		log.Warn().
+			Int("limit", query.Limit).
+			Int("not_analyzed", notAnalyzed).
+			Msg("Watchdog row limit reached, older entries of the window are not analyzed")
+	}
+	log.Info().
+		Int("entries", drupalReader.EntryCount()).
+		Msg("Watchdog entries read successfully")
+	return logContent, nil
+}
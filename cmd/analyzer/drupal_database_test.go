@@ -0,0 +1,95 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/olegiv/go-logger"
+	"github.com/olegiv/logwatch-ai-go/internal/config"
+	"github.com/olegiv/logwatch-ai-go/internal/drupal"
+	"github.com/olegiv/logwatch-ai-go/internal/logging"
+	"github.com/olegiv/logwatch-ai-go/internal/storage"
+)
+
+func TestReadDrupalDatabase(t *testing.T) {
+	dir := t.TempDir()
+	log := logging.NewSecure(logger.New(logger.Config{Level: "error", LogDir: dir, Filename: "drupal.log", Console: false}))
+	defer func() { _ = log.Close() }()
+
+	dbPath := filepath.Join(dir, "drupal.sqlite")
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(`CREATE TABLE watchdog (
+		wid INTEGER PRIMARY KEY AUTOINCREMENT, uid INTEGER NOT NULL DEFAULT 0,
+		type VARCHAR(64) NOT NULL DEFAULT '', message TEXT NOT NULL, variables BLOB NOT NULL,
+		severity INTEGER NOT NULL DEFAULT 0, link TEXT NULL DEFAULT '', location TEXT NOT NULL,
+		referer TEXT NULL, hostname VARCHAR(128) NOT NULL DEFAULT '', timestamp INTEGER NOT NULL DEFAULT 0)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	for _, e := range []struct {
+		message string
+		age     time.Duration
+	}{
+		{"Before the last run", 3 * time.Hour},
+		{"SQLSTATE[HY000] [2002] Connection refused after the last run", time.Hour},
+		{"Logged after the run started", -time.Second},
+	} {
+		if _, err := db.Exec(`INSERT INTO watchdog (type, message, variables, severity, location, timestamp)
+			VALUES ('php', ?, 'a:0:{}', 3, 'https://example.com/', ?)`, e.message, now.Add(-e.age).Unix()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_ = db.Close()
+
+	store, err := storage.New(filepath.Join(dir, "summaries.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = store.Close() }()
+	lastRun := &storage.Summary{Timestamp: now.Add(-2 * time.Hour), LogSourceType: "drupal_watchdog", SiteName: "Production", SystemStatus: "Good"}
+	if err := store.SaveSummary(lastRun); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{
+		LogSourceType:          "drupal_watchdog",
+		DrupalWatchdogFormat:   "json",
+		DrupalWatchdogDSN:      "sqlite://" + dbPath,
+		DrupalSiteID:           "production",
+		DrupalSiteName:         "Production",
+		SiteName:               "Production",
+		DrupalMinSeverity:      drupal.SeverityError,
+		DrupalWatchdogLimit:    100,
+		MaxLogSizeMB:           10,
+		MaxPreprocessingTokens: 1000,
+	}
+	logSource, err := createLogSource(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := readDrupalDatabase(context.Background(), cfg, store, logSource, now, log)
+	if err != nil {
+		t.Fatalf("readDrupalDatabase() error = %v", err)
+	}
+	if !strings.Contains(content, "after the last run") || strings.Contains(content, "Before the last run") ||
+		strings.Contains(content, "after the run started") {
+		t.Errorf("content does not cover exactly the entries since the last run:\n%s", content)
+	}
+
+	// Without a database, the default window applies
+	if content, err = readDrupalDatabase(context.Background(), cfg, nil, logSource, now, log); err != nil || !strings.Contains(content, "Before the last run") {
+		t.Errorf("readDrupalDatabase(no store) = %v, content:\n%s", err, content)
+	}
+}
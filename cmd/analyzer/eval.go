@@ -0,0 +1,324 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/olegiv/go-logger"
+	"github.com/olegiv/logwatch-ai-go/internal/ai"
+	"github.com/olegiv/logwatch-ai-go/internal/config"
+	"github.com/olegiv/logwatch-ai-go/internal/eval"
+	"github.com/olegiv/logwatch-ai-go/internal/logging"
+)
+
+// evalOptions holds flags for the eval subcommand
+type evalOptions struct {
+	CasesDir  string
+	Providers string
+	RecordDir string
+	ReplayDir string
+	Format    string
+	Output    string
+}
+
+// evalMode returns "live", "record", or "replay"
+func (o *evalOptions) evalMode() string {
+	switch {
+	case o.ReplayDir != "":
+		return "replay"
+	case o.RecordDir != "":
+		return "record"
+	default:
+		return "live"
+	}
+}
+
+func parseEvalFlags(args []string) (*evalOptions, error) {
+	opts := &evalOptions{}
+	fs := flag.NewFlagSet("eval", flag.ContinueOnError)
+	fs.StringVar(&opts.CasesDir, "cases", "./testdata/eval", "Directory of eval case subdirectories (each with case.json)")
+	fs.StringVar(&opts.Providers, "providers", "", "Comma-separated provider[:model] targets (default: LLM_PROVIDER and its model; in replay mode: all recorded targets)")
+	fs.StringVar(&opts.RecordDir, "record", "", "Record raw provider responses under this directory for later replay")
+	fs.StringVar(&opts.ReplayDir, "replay", "", "Re-score recorded responses from this directory without calling providers")
+	fs.StringVar(&opts.Format, "format", "text", "Report format: text or json")
+	fs.StringVar(&opts.Output, "output", "", "Write the report to this file instead of stdout")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(fs.Output(), "Usage: %s eval [options]\n\n", os.Args[0])
+		_, _ = fmt.Fprintf(fs.Output(), "Runs golden log fixtures through the real readers, preprocessors, and\n")
+		_, _ = fmt.Fprintf(fs.Output(), "prompt builders and scores each provider's analysis.\n\nOptions:\n")
+		fs.PrintDefaults()
+		_, _ = fmt.Fprintf(fs.Output(), "\nExamples:\n")
+		_, _ = fmt.Fprintf(fs.Output(), "  %s eval -providers anthropic:claude-haiku-4-5-20251001,anthropic:claude-sonnet-4-6\n", os.Args[0])
+		_, _ = fmt.Fprintf(fs.Output(), "  %s eval -providers ollama:llama3.3:latest -record ./testdata/eval-recordings\n", os.Args[0])
+		_, _ = fmt.Fprintf(fs.Output(), "  %s eval -replay ./testdata/eval-recordings\n", os.Args[0])
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if opts.RecordDir != "" && opts.ReplayDir != "" {
+		return nil, fmt.Errorf("-record and -replay are mutually exclusive")
+	}
+	if opts.Format != "text" && opts.Format != "json" {
+		return nil, fmt.Errorf("-format must be 'text' or 'json' (got: %s)", opts.Format)
+	}
+
+	return opts, nil
+}
+
+// runEvalCommand implements the eval subcommand
+func runEvalCommand(args []string) int {
+	opts, err := parseEvalFlags(args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitSuccess
+		}
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	cfg, err := config.LoadLLMConfig()
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+		return exitFailure
+	}
+
+	// Keep the console free for the report; details go to the log file.
+	baseLog := logger.New(logger.Config{
+		Level:      cfg.LogLevel,
+		LogDir:     "./logs",
+		Filename:   "eval.log",
+		MaxSizeMB:  10,
+		MaxBackups: 5,
+		Console:    false,
+	})
+	log := logging.NewSecure(baseLog)
+	defer func() { _ = log.Close() }()
+
+	if err := runEval(ctx, cfg, opts, os.Stdout, log); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	return exitSuccess
+}
+
+func runEval(ctx context.Context, cfg *config.Config, opts *evalOptions, stdout io.Writer, log *logging.SecureLogger) error {
+	cases, err := eval.LoadCases(opts.CasesDir)
+	if err != nil {
+		return err
+	}
+
+	targets, err := resolveEvalTargets(cfg, opts)
+	if err != nil {
+		return err
+	}
+
+	providers := make(map[string]ai.Provider, len(targets))
+	for _, target := range targets {
+		provider, err := createEvalProvider(ctx, cfg, opts, target, log)
+		if err != nil {
+			return fmt.Errorf("target %s: %w", target.Label(), err)
+		}
+		providers[target.Label()] = provider
+	}
+
+	_, _ = fmt.Fprintf(os.Stderr, "Evaluating %d case(s) against %d target(s) in %s mode...\n",
+		len(cases), len(targets), opts.evalMode())
+
+	results, err := eval.Run(ctx, cases, targets, func(ctx context.Context, target eval.Target, c *eval.Case) (*ai.Analysis, *ai.Stats, error) {
+		return analyzeEvalCase(ctx, cfg, providers[target.Label()], c, log)
+	})
+	if err != nil {
+		return fmt.Errorf("eval interrupted: %w", err)
+	}
+
+	report := eval.NewReport(opts.evalMode(), results)
+
+	out := stdout
+	if opts.Output != "" {
+		f, err := os.Create(opts.Output)
+		if err != nil {
+			return fmt.Errorf("failed to create report file: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+		out = f
+	}
+
+	if opts.Format == "json" {
+		return report.WriteJSON(out)
+	}
+	return report.WriteText(out)
+}
+
+// resolveEvalTargets returns the explicit -providers list, the recorded
+// target directories in replay mode, or the configured LLM provider.
+func resolveEvalTargets(cfg *config.Config, opts *evalOptions) ([]eval.Target, error) {
+	if opts.Providers != "" {
+		return eval.ParseTargets(opts.Providers)
+	}
+
+	if opts.ReplayDir != "" {
+		entries, err := os.ReadDir(opts.ReplayDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read replay directory: %w", err)
+		}
+		var targets []eval.Target
+		for _, entry := range entries {
+			if entry.IsDir() {
+				targets = append(targets, eval.Target{Provider: entry.Name()})
+			}
+		}
+		if len(targets) == 0 {
+			return nil, fmt.Errorf("no recorded targets found in %s", opts.ReplayDir)
+		}
+		return targets, nil
+	}
+
+	return []eval.Target{{Provider: cfg.LLMProvider, Model: cfg.GetLLMModel()}}, nil
+}
+
+// createEvalProvider builds the provider for one target. Replay mode never
+// creates a live client, so it needs no credentials.
+func createEvalProvider(ctx context.Context, cfg *config.Config, opts *evalOptions, target eval.Target, log *logging.SecureLogger) (ai.Provider, error) {
+	if opts.ReplayDir != "" {
+		return ai.NewMockClient(ai.MockConfig{
+			Dir:        filepath.Join(opts.ReplayDir, target.Slug()),
+			Mode:       ai.MockModeReplay,
+			SourceType: "eval",
+			MaxTokens:  cfg.AIMaxTokens,
+		})
+	}
+
+	if target.Provider == "mock" {
+		return ai.NewMockClient(ai.MockConfig{
+			Dir:        cfg.MockDir,
+			Mode:       ai.MockModeFixture,
+			SourceType: "eval",
+			MaxTokens:  cfg.AIMaxTokens,
+		})
+	}
+
+	provider, err := createSpecClient(ctx, cfg, config.ProviderSpec{Provider: target.Provider, Model: target.Model}, log)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.RecordDir == "" {
+		return provider, nil
+	}
+
+	return ai.NewMockClient(ai.MockConfig{
+		Dir:        filepath.Join(opts.RecordDir, target.Slug()),
+		Mode:       ai.MockModeRecord,
+		SourceType: "eval",
+		MaxTokens:  cfg.AIMaxTokens,
+		Upstream:   provider,
+	})
+}
+
+// analyzeEvalCase runs one case through the same read, prompt-fitting, and
+// analysis path as a normal run, without history or exclusions so results
+// depend only on the fixture and the model.
+func analyzeEvalCase(ctx context.Context, cfg *config.Config, provider ai.Provider, c *eval.Case, log *logging.SecureLogger) (*ai.Analysis, *ai.Stats, error) {
+	mock, isMock := provider.(*ai.MockClient)
+	if isMock {
+		// Key fixtures and recordings per case: <dir>/<source_type>/<case>.*
+		mock = mock.WithKey(c.SourceType, c.Name)
+		provider = mock
+	}
+
+	caseCfg := *cfg
+	caseCfg.LogSourceType = c.SourceType
+	caseCfg.SiteName = c.SiteName
+	caseCfg.DrupalWatchdogFormat = "json"
+	if c.DrupalFormat != "" {
+		caseCfg.DrupalWatchdogFormat = c.DrupalFormat
+	}
+
+	logSource, err := createLogSource(&caseCfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	inputPath, cleanup, err := stageEvalInput(c.InputPath())
+	if err != nil {
+		return nil, nil, err
+	}
+	defer cleanup()
+
+	logContent, err := logSource.Reader.Read(inputPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read log content: %w", err)
+	}
+
+	systemPrompt := logSource.PromptBuilder.GetSystemPrompt(nil)
+	promptResult, err := preparePromptForAnalysis(ctx, &caseCfg, provider, logSource, systemPrompt, logContent, "", nil, log)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	analysis, stats, err := provider.Analyze(ctx, systemPrompt, promptResult.UserPrompt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Replays report the cost and latency of the original recorded call.
+	if isMock && stats != nil && stats.Provider == "Mock" {
+		if rec, recErr := mock.LoadRecording(); recErr == nil {
+			stats.CostUSD = rec.CostUSD
+			stats.DurationSeconds = rec.DurationSeconds
+		}
+	}
+
+	return analysis, stats, nil
+}
+
+// stageEvalInput copies a fixture into a temp directory so the readers'
+// freshness guard (files older than 24h are rejected as stale) applies to
+// the copy rather than to the committed fixture's modification time.
+func stageEvalInput(path string) (string, func(), error) {
+	src, err := os.Open(path) // #nosec G304 -- path comes from a validated eval case
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to open eval input: %w", err)
+	}
+	defer func() { _ = src.Close() }()
+
+	dir, err := os.MkdirTemp("", "logwatch-eval-")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	cleanup := func() { _ = os.RemoveAll(dir) }
+
+	stagedPath := filepath.Join(dir, filepath.Base(path))
+	dst, err := os.OpenFile(stagedPath, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o600)
+	if err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("failed to stage eval input: %w", err)
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		_ = dst.Close()
+		cleanup()
+		return "", nil, fmt.Errorf("failed to stage eval input: %w", err)
+	}
+	if err := dst.Close(); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("failed to stage eval input: %w", err)
+	}
+
+	return stagedPath, cleanup, nil
+}
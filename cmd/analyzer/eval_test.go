@@ -0,0 +1,102 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/olegiv/logwatch-ai-go/internal/config"
+	"github.com/olegiv/logwatch-ai-go/internal/eval"
+)
+
+func evalTestConfig() *config.Config {
+	return &config.Config{
+		LLMProvider:            "anthropic",
+		ClaudeModel:            "claude-haiku-4-5-20251001",
+		MaxLogSizeMB:           10,
+		EnablePreprocessing:    true,
+		MaxPreprocessingTokens: 150000,
+		AITimeoutSeconds:       120,
+		AIMaxTokens:            8000,
+	}
+}
+
+func TestParseEvalFlags(t *testing.T) {
+	opts, err := parseEvalFlags([]string{"-replay", "rec", "-format", "json"})
+	if err != nil {
+		t.Fatalf("parseEvalFlags() error = %v", err)
+	}
+	if opts.evalMode() != "replay" || opts.CasesDir != "./testdata/eval" {
+		t.Errorf("unexpected options: %+v", opts)
+	}
+
+	if _, err := parseEvalFlags([]string{"-replay", "a", "-record", "b"}); err == nil {
+		t.Error("expected error for -record with -replay")
+	}
+	if _, err := parseEvalFlags([]string{"-format", "xml"}); err == nil {
+		t.Error("expected error for invalid format")
+	}
+}
+
+func TestRunEval_ReplayGoldenFixtures(t *testing.T) {
+	opts := &evalOptions{
+		CasesDir:  filepath.Join("..", "..", "testdata", "eval"),
+		ReplayDir: filepath.Join("..", "..", "testdata", "eval-recordings"),
+		Format:    "json",
+	}
+
+	var out bytes.Buffer
+	if err := runEval(context.Background(), evalTestConfig(), opts, &out, nil); err != nil {
+		t.Fatalf("runEval() error = %v", err)
+	}
+
+	var report eval.Report
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("report is not valid JSON: %v\n%s", err, out.String())
+	}
+
+	if report.Mode != "replay" || len(report.Summaries) != 1 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	summary := report.Summaries[0]
+	if summary.Target != "baseline" || summary.Errors != 0 || summary.Cases < 5 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+	if summary.StatusAccuracy != 1 {
+		t.Errorf("baseline status accuracy = %v, want 1", summary.StatusAccuracy)
+	}
+	for _, r := range report.Results {
+		if r.Error != "" {
+			t.Errorf("case %s failed: %s", r.Case, r.Error)
+		}
+	}
+}
+
+func TestRunEval_MockFixtureTarget(t *testing.T) {
+	mockDir := t.TempDir()
+	fixture := `{"systemStatus":"Bad","summary":"s","criticalIssues":["SQL injection attempt"],"warnings":["failed login"],"recommendations":[],"metrics":{}}`
+	if err := os.WriteFile(filepath.Join(mockDir, "default.analysis.json"), []byte(fixture), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := evalTestConfig()
+	cfg.MockDir = mockDir
+	casesDir := filepath.Join("..", "..", "testdata", "eval")
+
+	var live bytes.Buffer
+	liveOpts := &evalOptions{CasesDir: casesDir, Providers: "mock", Format: "text"}
+	if err := runEval(context.Background(), cfg, liveOpts, &live, nil); err != nil {
+		t.Fatalf("runEval(live) error = %v", err)
+	}
+	for _, want := range []string{"live mode", "mock", "forbidden sql injection"} {
+		if !bytes.Contains(live.Bytes(), []byte(want)) {
+			t.Errorf("live report missing %q:\n%s", want, live.String())
+		}
+	}
+}
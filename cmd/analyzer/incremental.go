@@ -0,0 +1,120 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+	"github.com/olegiv/logwatch-ai-go/internal/config"
+	"github.com/olegiv/logwatch-ai-go/internal/logging"
+	"github.com/olegiv/logwatch-ai-go/internal/ocms"
+	"github.com/olegiv/logwatch-ai-go/internal/storage"
+	"github.com/olegiv/logwatch-ai-go/internal/tail"
+)
+
+// readIncremental reads the lines appended to the source files since the
+// checkpoints of the previous successful run (INCREMENTAL_READ). The new
+// checkpoints are returned instead of saved: saveCheckpoints stores them
+// once the run has succeeded, so the lines of a failed run are read again.
+func readIncremental(
+	cfg *config.Config,
+	store *storage.Storage,
+	logSource *analyzer.LogSource,
+	log *logging.SecureLogger,
+) (string, []tail.Checkpoint, error) {
+	if store == nil {
+		return "", nil, fmt.Errorf("incremental read requires the database")
+	}
+
+	files := []ocms.LogFile{{Path: cfg.GetLogSourcePath()}}
+	if cfg.IsOCMS() {
+		files = files[:0]
+		for _, logPath := range cfg.GetOCMSLogPaths() {
+			files = append(files, ocms.LogFile{Kind: logPath.Kind, Path: logPath.Path})
+		}
+	}
+
+	maxBytes := int64(cfg.MaxLogSizeMB) * 1024 * 1024
+	appended := make([]ocms.AppendedLog, 0, len(files))
+	checkpoints := make([]tail.Checkpoint, 0, len(files))
+	for _, file := range files {
+		from, err := store.GetFileCheckpoint(file.Path)
+		if err != nil {
+			return "", nil, err
+		}
+
+		result, err := tail.Read(file.Path, from, maxBytes)
+		if err != nil {
+			// Like ReadFiles, a missing OCMS error log is normal when
+			// several logs are read
+			if len(files) > 1 && errors.Is(err, fs.ErrNotExist) {
+				log.Info().Str("path", file.Path).Msg("Log file not found, skipping")
+				continue
+			}
+			return "", nil, fmt.Errorf("failed to read log content: %w", err)
+		}
+
+		if result.Reset && from != nil {
+			log.Warn().
+				Str("path", file.Path).
+				Msg("Log file was truncated or replaced, reading it from the start")
+		}
+		if result.Skipped > 0 {
+			log.Warn().
+				Str("path", file.Path).
+				Int64("skipped_bytes", result.Skipped).
+				Int("max_log_size_mb", cfg.MaxLogSizeMB).
+				Msg("New lines exceed MAX_LOG_SIZE_MB, oldest lines skipped")
+		}
+		log.Info().
+			Str("path", file.Path).
+			Int("bytes", len(result.Content)).
+			Int64("offset", result.Checkpoint.Offset).
+			Bool("rotated", result.Rotated).
+			Msg("Read new log lines")
+
+		appended = append(appended, ocms.AppendedLog{LogFile: file, Content: result.Content})
+		checkpoints = append(checkpoints, result.Checkpoint)
+	}
+	if len(checkpoints) == 0 {
+		return "", nil, fmt.Errorf("no readable log files (all missing)")
+	}
+
+	var logContent string
+	var err error
+	if cfg.IsOCMS() {
+		ocmsReader, ok := logSource.Reader.(*ocms.Reader)
+		if !ok {
+			return "", nil, fmt.Errorf("OCMS incremental read requires OCMS reader")
+		}
+		logContent, err = ocmsReader.ReadAppended(appended)
+	} else {
+		contentReader, ok := logSource.Reader.(analyzer.ContentReader)
+		if !ok {
+			return "", nil, fmt.Errorf("log source %s does not support incremental reads", logSource.Type)
+		}
+		logContent, err = contentReader.ReadContent(appended[0].Content)
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read log content: %w", err)
+	}
+
+	return logContent, checkpoints, nil
+}
+
+// saveCheckpoints stores the checkpoints of a successful incremental read,
+// so the next run continues after the lines analyzed by this one.
+func saveCheckpoints(store *storage.Storage, checkpoints []tail.Checkpoint, log *logging.SecureLogger) {
+	if store == nil || len(checkpoints) == 0 {
+		return
+	}
+	if err := store.SaveFileCheckpoints(checkpoints); err != nil {
+		log.Warn().Err(err).Msg("Failed to save file checkpoints, the next run reads these lines again")
+		return
+	}
+	log.Info().Int("files", len(checkpoints)).Msg("File checkpoints saved")
+}
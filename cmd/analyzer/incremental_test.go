@@ -0,0 +1,127 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/olegiv/go-logger"
+	"github.com/olegiv/logwatch-ai-go/internal/config"
+	"github.com/olegiv/logwatch-ai-go/internal/logging"
+	"github.com/olegiv/logwatch-ai-go/internal/ocms"
+	"github.com/olegiv/logwatch-ai-go/internal/storage"
+	"github.com/olegiv/logwatch-ai-go/internal/syslog"
+)
+
+func newIncrementalTestStore(t *testing.T, dir string) (*storage.Storage, *logging.SecureLogger) {
+	t.Helper()
+	log := logging.NewSecure(logger.New(logger.Config{Level: "error", LogDir: dir, Filename: "incremental.log", Console: false}))
+	t.Cleanup(func() { _ = log.Close() })
+
+	store, err := storage.New(filepath.Join(dir, "summaries.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	return store, log
+}
+
+func TestReadIncremental_Syslog(t *testing.T) {
+	dir := t.TempDir()
+	store, log := newIncrementalTestStore(t, dir)
+
+	path := filepath.Join(dir, "messages")
+	if err := os.WriteFile(path, []byte("Mar 10 08:00:01 web1 sshd[100]: Accepted publickey for deploy from 192.0.2.10 port 50000 ssh2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{
+		LogSourceType:          "syslog",
+		SyslogPath:             path,
+		IncrementalRead:        true,
+		EnableDatabase:         true,
+		MaxLogSizeMB:           10,
+		MaxPreprocessingTokens: 1000,
+	}
+	logSource, err := createLogSource(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, checkpoints, err := readIncremental(cfg, store, logSource, log)
+	if err != nil {
+		t.Fatalf("readIncremental() error = %v", err)
+	}
+	if !strings.Contains(content, "deploy") || len(checkpoints) != 1 {
+		t.Fatalf("first readIncremental() = %d checkpoints, content:\n%s", len(checkpoints), content)
+	}
+
+	// Without saved checkpoints (a failed run), the same lines are read again
+	if content, _, err = readIncremental(cfg, store, logSource, log); err != nil || !strings.Contains(content, "deploy") {
+		t.Fatalf("readIncremental(unsaved) = %v, content:\n%s", err, content)
+	}
+	saveCheckpoints(store, checkpoints, log)
+
+	if content, _, err = readIncremental(cfg, store, logSource, log); err != nil || !syslog.IsNoEntriesContent(content) {
+		t.Fatalf("readIncremental(nothing new) = %v, content:\n%s", err, content)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("Mar 10 09:00:01 web1 sshd[200]: Failed password for root from 203.0.113.5 port 40000 ssh2\n")
+	_ = f.Close()
+
+	content, _, err = readIncremental(cfg, store, logSource, log)
+	if err != nil {
+		t.Fatalf("readIncremental(appended) error = %v", err)
+	}
+	if !strings.Contains(content, "203.0.113.5") || strings.Contains(content, "deploy") {
+		t.Errorf("readIncremental(appended) does not cover exactly the new lines:\n%s", content)
+	}
+}
+
+func TestReadIncremental_OCMS(t *testing.T) {
+	dir := t.TempDir()
+	store, log := newIncrementalTestStore(t, dir)
+
+	mainLog := filepath.Join(dir, "ocms.log")
+	if err := os.WriteFile(mainLog, []byte("2026-04-26T02:15:00Z INFO main log event\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{
+		LogSourceType: "ocms",
+		OCMSLogPaths: []config.OCMSLogPath{
+			{Kind: config.OCMSLogKindMain, Path: mainLog},
+			{Kind: config.OCMSLogKindError, Path: filepath.Join(dir, "error.log")},
+		},
+		IncrementalRead:        true,
+		EnableDatabase:         true,
+		MaxLogSizeMB:           10,
+		MaxPreprocessingTokens: 1000,
+	}
+	logSource, err := createLogSource(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The missing error log is skipped
+	content, checkpoints, err := readIncremental(cfg, store, logSource, log)
+	if err != nil {
+		t.Fatalf("readIncremental() error = %v", err)
+	}
+	if !strings.Contains(content, "main log event") || len(checkpoints) != 1 {
+		t.Fatalf("readIncremental() = %d checkpoints, content:\n%s", len(checkpoints), content)
+	}
+	saveCheckpoints(store, checkpoints, log)
+
+	if content, _, err = readIncremental(cfg, store, logSource, log); err != nil || !ocms.IsNoEntriesContent(content) {
+		t.Errorf("readIncremental(nothing new) = %v, content:\n%s", err, content)
+	}
+}
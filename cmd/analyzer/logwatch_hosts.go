@@ -0,0 +1,120 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/olegiv/logwatch-ai-go/internal/config"
+	"github.com/olegiv/logwatch-ai-go/internal/logging"
+	"github.com/olegiv/logwatch-ai-go/internal/notification"
+)
+
+// runLogwatchHosts analyzes the collected report of each host in
+// logwatch-hosts.json with its own database row and Telegram report, then
+// sends the optional fleet roll-up. A failed host does not stop the other
+// hosts but fails the run.
+func runLogwatchHosts(ctx context.Context, cfg *config.Config, clients *runClients, log *logging.SecureLogger) error {
+	startTime := time.Now()
+	hosts := cfg.LogwatchHosts
+	log.Info().
+		Int("hosts", len(hosts)).
+		Str("config", cfg.LogwatchHostsConfigPath).
+		Msg("Analyzing logwatch hosts...")
+
+	outcomes := make([]notification.FleetHost, 0, len(hosts))
+	failed := 0
+	for _, host := range hosts {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("multi-host run interrupted: %w", err)
+		}
+
+		log.Info().
+			Str("host", host.Name).
+			Str("path", host.ReportPath).
+			Msg("Analyzing logwatch host...")
+
+		clients.telegram.SetHostname(host.Name)
+		status, err := analyzeSource(ctx, cfg.ForLogwatchHost(host), clients, log)
+		outcome := notification.FleetHost{Name: host.Name, Status: status}
+		if err != nil {
+			failed++
+			outcome.Failed = true
+			log.Error().Err(err).Str("host", host.Name).Msg("Logwatch host analysis failed")
+		}
+		outcomes = append(outcomes, outcome)
+	}
+	clients.telegram.SetHostname("")
+
+	if cfg.LogwatchHostsConfig.Rollup && len(hosts) > 1 {
+		log.Info().Msg("Sending fleet roll-up...")
+		if err := clients.telegram.SendFleetReport(cfg.LogSourceType, outcomes); err != nil {
+			return fmt.Errorf("failed to send fleet roll-up: %w", err)
+		}
+	}
+
+	log.Info().
+		Int("hosts", len(hosts)).
+		Int("failed", failed).
+		Float64("total_duration_s", time.Since(startTime).Seconds()).
+		Msg("Logwatch hosts analyzed")
+
+	if failed > 0 {
+		return fmt.Errorf("analysis failed for %d of %d logwatch hosts", failed, len(hosts))
+	}
+	return nil
+}
+
+// handleListLogwatchHosts prints the hosts of logwatch-hosts.json with the
+// reports they resolve to.
+func handleListLogwatchHosts(cli *config.CLIOptions) int {
+	hostsConfig, configPath, err := config.LoadLogwatchHostsConfig(cli.LogwatchHostsConfig)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	if hostsConfig == nil {
+		_, _ = fmt.Fprintf(os.Stderr, "No logwatch-hosts.json configuration file found.\n")
+		_, _ = fmt.Fprintf(os.Stderr, "\nSearch locations:\n")
+		_, _ = fmt.Fprintf(os.Stderr, "  - ./logwatch-hosts.json\n")
+		_, _ = fmt.Fprintf(os.Stderr, "  - ./configs/logwatch-hosts.json\n")
+		_, _ = fmt.Fprintf(os.Stderr, "  - /opt/logwatch-ai/logwatch-hosts.json\n")
+		_, _ = fmt.Fprintf(os.Stderr, "  - ~/.config/logwatch-ai/logwatch-hosts.json\n")
+		_, _ = fmt.Fprintf(os.Stderr, "\nUse -logwatch-hosts-config to specify a custom path.\n")
+		return exitFailure
+	}
+
+	fmt.Printf("Logwatch hosts configuration: %s\n", configPath)
+	fmt.Printf("Version: %s\n", hostsConfig.Version)
+	if hostsConfig.ReportGlob != "" {
+		hostFrom := hostsConfig.HostFrom
+		if hostFrom == "" {
+			hostFrom = config.LogwatchHostFromFile
+		}
+		fmt.Printf("Report glob: %s (host from %s name)\n", hostsConfig.ReportGlob, hostFrom)
+	}
+	fmt.Printf("Fleet roll-up: %t\n\n", hostsConfig.Rollup)
+
+	hosts, err := hostsConfig.ResolveHosts()
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	fmt.Printf("Hosts:\n")
+	for _, host := range hosts {
+		source := "configured"
+		if host.Globbed {
+			source = "report_glob"
+		}
+		fmt.Printf("  %-20s %s\n", host.Name, host.ReportPath)
+		fmt.Printf("    Source:  %s\n", source)
+	}
+
+	return exitSuccess
+}
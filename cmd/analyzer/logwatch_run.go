@@ -0,0 +1,55 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+	"github.com/olegiv/logwatch-ai-go/internal/config"
+	"github.com/olegiv/logwatch-ai-go/internal/logging"
+)
+
+// runLogwatch runs logwatch and feeds its report to the logwatch reader,
+// replacing scripts/generate-logwatch.sh and the staleness check of its
+// output file.
+func runLogwatch(
+	ctx context.Context,
+	cfg *config.Config,
+	logSource *analyzer.LogSource,
+	log *logging.SecureLogger,
+) (string, error) {
+	contentReader, ok := logSource.Reader.(analyzer.ContentReader)
+	if !ok {
+		return "", fmt.Errorf("log source %s does not support running logwatch", logSource.Type)
+	}
+
+	runner, err := cfg.LogwatchRunner()
+	if err != nil {
+		return "", err
+	}
+
+	log.Info().
+		Str("commands", strings.Join(runner.Commands(), "; ")).
+		Msg("Running logwatch...")
+
+	result, err := runner.Run(ctx, int64(cfg.MaxLogSizeMB)*1024*1024)
+	if err != nil {
+		return "", fmt.Errorf("failed to run logwatch: %w", err)
+	}
+
+	sourceInfo := result.SourceInfo()
+	log.Info().
+		Float64("size_mb", sourceInfo["size_mb"].(float64)).
+		Float64("duration_seconds", sourceInfo["duration_seconds"].(float64)).
+		Msg("Logwatch completed")
+
+	logContent, err := contentReader.ReadContent(result.Output)
+	if err != nil {
+		return "", fmt.Errorf("failed to read log content: %w", err)
+	}
+	return logContent, nil
+}
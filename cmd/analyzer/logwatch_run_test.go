@@ -0,0 +1,53 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/olegiv/go-logger"
+	"github.com/olegiv/logwatch-ai-go/internal/config"
+	"github.com/olegiv/logwatch-ai-go/internal/logging"
+)
+
+func TestRunLogwatch(t *testing.T) {
+	dir := t.TempDir()
+	log := logging.NewSecure(logger.New(logger.Config{Level: "error", LogDir: dir, Filename: "logwatch.log", Console: false}))
+	defer func() { _ = log.Close() }()
+
+	// The stub reports the detail level it was run with
+	stub := filepath.Join(dir, "logwatch")
+	script := "#!/bin/sh\necho '################### Logwatch ###################'\n" +
+		"for i in 1 2 3 4 5 6 7 8; do echo \"sshd: Failed password for root ($*)\"; done\n"
+	if err := os.WriteFile(stub, []byte(script), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{
+		LogSourceType:          "logwatch",
+		MaxLogSizeMB:           1,
+		MaxPreprocessingTokens: 1000,
+		LogwatchRun:            true,
+		LogwatchBinary:         stub,
+		LogwatchDetail:         "med",
+		LogwatchRange:          "today",
+		LogwatchTimeoutSeconds: 10,
+	}
+	logSource, err := createLogSource(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := runLogwatch(context.Background(), cfg, logSource, log)
+	if err != nil {
+		t.Fatalf("runLogwatch() error = %v", err)
+	}
+	if !strings.Contains(content, "--detail med --range today") {
+		t.Errorf("content = %q, want the stub report", content)
+	}
+}
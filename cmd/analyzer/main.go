@@ -5,22 +5,33 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
 	"github.com/olegiv/go-logger"
+	"github.com/olegiv/logwatch-ai-go/internal/accesslog"
 	"github.com/olegiv/logwatch-ai-go/internal/ai"
 	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
 	"github.com/olegiv/logwatch-ai-go/internal/config"
+	"github.com/olegiv/logwatch-ai-go/internal/customlog"
+	"github.com/olegiv/logwatch-ai-go/internal/docker"
 	"github.com/olegiv/logwatch-ai-go/internal/drupal"
+	"github.com/olegiv/logwatch-ai-go/internal/journald"
 	"github.com/olegiv/logwatch-ai-go/internal/logging"
 	"github.com/olegiv/logwatch-ai-go/internal/logwatch"
 	"github.com/olegiv/logwatch-ai-go/internal/notification"
 	"github.com/olegiv/logwatch-ai-go/internal/ocms"
+	"github.com/olegiv/logwatch-ai-go/internal/remote"
+	"github.com/olegiv/logwatch-ai-go/internal/rules"
 	"github.com/olegiv/logwatch-ai-go/internal/storage"
+	"github.com/olegiv/logwatch-ai-go/internal/syslog"
+	"github.com/olegiv/logwatch-ai-go/internal/tail"
 )
 
 const (
@@ -40,6 +51,16 @@ func main() {
 }
 
 func run() int {
+	// Subcommands take their own flags
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "eval":
+			return runEvalCommand(os.Args[2:])
+		case "ask":
+			return runAskCommand(os.Args[2:])
+		}
+	}
+
 	// Parse CLI arguments first
 	cli := config.ParseCLI()
 
@@ -66,6 +87,15 @@ func run() int {
 	if cli.ListOCMSSites {
 		return handleListOCMSSites(cli)
 	}
+	if cli.ListAccessLogSites {
+		return handleListAccessLogSites(cli)
+	}
+	if cli.ListLogwatchHosts {
+		return handleListLogwatchHosts(cli)
+	}
+	if cli.ListCustomSources {
+		return handleListCustomSources(cli)
+	}
 
 	// Setup signal handling for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
@@ -109,6 +139,12 @@ func run() int {
 	if cfg.SelectedSiteName() != "" && cfg.SelectedSiteName() != cfg.SelectedSiteID() {
 		logEvent = logEvent.Str("site_name", cfg.SelectedSiteName())
 	}
+	if cfg.HasLogwatchHosts() {
+		logEvent = logEvent.Int("logwatch_hosts", len(cfg.LogwatchHosts))
+	}
+	if cfg.SyslogReceiver {
+		logEvent = logEvent.Bool("syslog_receiver", true)
+	}
 	logEvent.Msg("Starting Log AI Analyzer")
 	log.Info().
 		Str("provider", cfg.LLMProvider).
@@ -121,6 +157,24 @@ func run() int {
 			Int("sites", len(cfg.Exclusions.Sites)).
 			Msg("Loaded finding exclusions")
 	}
+	if cfg.CustomSourcesConfig != nil {
+		log.Info().
+			Str("path", cfg.CustomSourcesConfigPath).
+			Int("sources", len(cfg.CustomSourcesConfig.Sources)).
+			Msg("Loaded custom sources")
+	}
+	if cfg.HasRemoteHosts() {
+		log.Info().
+			Str("path", cfg.RemoteHostsConfigPath).
+			Int("hosts", len(cfg.RemoteHostsConfig.Hosts)).
+			Msg("Loaded remote hosts")
+	}
+	if cfg.Rules != nil {
+		log.Info().
+			Str("path", cfg.RulesConfigPath).
+			Int("rules", len(cfg.Rules.Rules)).
+			Msg("Loaded deterministic alert rules")
+	}
 
 	// Run the analyzer
 	if err := runAnalyzer(ctx, cfg, log); err != nil {
@@ -133,8 +187,6 @@ func run() int {
 }
 
 func runAnalyzer(ctx context.Context, cfg *config.Config, log *logging.SecureLogger) error {
-	startTime := time.Now()
-
 	// Initialize components
 	log.Info().Msg("Initializing components...")
 
@@ -156,6 +208,19 @@ func runAnalyzer(ctx context.Context, cfg *config.Config, log *logging.SecureLog
 		log.Info().Str("path", cfg.DatabasePath).Msg("Database initialized")
 	}
 
+	// Remote hosts: sftp:// source paths are read over one SSH connection
+	// per host, opened on first use and closed with the run
+	if cfg.HasRemoteHosts() {
+		remoteFS := remote.NewFS(cfg.RemoteHostsConfig.Hosts)
+		analyzer.RegisterSourceFS(remote.Scheme, remoteFS)
+		defer func() {
+			analyzer.RegisterSourceFS(remote.Scheme, nil)
+			if err := remoteFS.Close(); err != nil {
+				log.Warn().Err(err).Msg("Failed to close remote connections")
+			}
+		}()
+	}
+
 	// 2. Initialize Telegram client
 	telegramClient, err := notification.NewTelegramClient(
 		cfg.TelegramBotToken,
@@ -172,16 +237,55 @@ func runAnalyzer(ctx context.Context, cfg *config.Config, log *logging.SecureLog
 		}
 	}(telegramClient)
 
+	if cfg.IsCustomSource() {
+		telegramClient.SetSourceName(cfg.LogSourceType, cfg.CustomSource.Name)
+	}
+
 	botInfo := telegramClient.GetBotInfo()
 	log.Info().
 		Str("username", botInfo["username"].(string)).
 		Msg("Telegram bot initialized")
 
-	// 3. Initialize LLM client based on provider
-	llmClient, err := createLLMClient(ctx, cfg, log)
+	// 3. Initialize LLM client based on provider. An unreachable provider
+	// does not abort the run; the report degrades to reader statistics.
+	clients := &runClients{store: store, telegram: telegramClient}
+	clients.connectLLM(ctx, cfg, log)
+	if cfg.HasLogwatchHosts() {
+		return runLogwatchHosts(ctx, cfg, clients, log)
+	}
+	if cfg.SyslogReceiver {
+		return runSyslogReceiver(ctx, cfg, clients, log)
+	}
+	_, err = analyzeSource(ctx, cfg, clients, log)
+	return err
+}
+
+// runClients holds the clients shared by the analyses of a run
+type runClients struct {
+	store    *storage.Storage // nil when the database is disabled
+	telegram *notification.TelegramClient
+	llm      ai.Provider
+	llmErr   error // why llm is unavailable; the reports degrade to reader statistics
+
+	// newLLM creates the LLM client; nil uses createLLMClient
+	newLLM func(context.Context, *config.Config, *logging.SecureLogger) (ai.Provider, error)
+}
+
+// connectLLM creates the LLM client of the run. On failure, llmErr is set
+// and the reports degrade to reader statistics.
+func (c *runClients) connectLLM(ctx context.Context, cfg *config.Config, log *logging.SecureLogger) {
+	newLLM := c.newLLM
+	if newLLM == nil {
+		newLLM = createLLMClient
+	}
+
+	llmClient, err := newLLM(ctx, cfg, log)
 	if err != nil {
-		return fmt.Errorf("failed to initialize LLM client: %w", err)
+		c.llm, c.llmErr = nil, fmt.Errorf("failed to initialize LLM client: %w", err)
+		log.Error().Err(c.llmErr).Msg("LLM client unavailable, continuing without AI analysis")
+		return
 	}
+	c.llm, c.llmErr = llmClient, nil
 
 	modelInfo := llmClient.GetModelInfo()
 	log.Info().
@@ -189,22 +293,63 @@ func runAnalyzer(ctx context.Context, cfg *config.Config, log *logging.SecureLog
 		Str("model", modelInfo["model"].(string)).
 		Int("max_tokens", modelInfo["max_tokens"].(int)).
 		Msg("LLM client initialized")
+}
 
-	// 4. Initialize log source based on configuration
+// retryLLM creates the LLM client again if it was unavailable, so a
+// long-running mode recovers once the provider is reachable.
+func (c *runClients) retryLLM(ctx context.Context, cfg *config.Config, log *logging.SecureLogger) {
+	if c.llmErr == nil {
+		return
+	}
+	log.Info().Msg("Retrying unavailable LLM client...")
+	c.connectLLM(ctx, cfg, log)
+}
+
+// analyzeSource reads, analyzes, stores, and reports the configured log
+// source. It returns the reported system status, empty when the source had
+// no entries for the period.
+func analyzeSource(ctx context.Context, cfg *config.Config, clients *runClients, log *logging.SecureLogger) (string, error) {
+	startTime := time.Now()
+	store, telegramClient, llmClient, llmErr := clients.store, clients.telegram, clients.llm, clients.llmErr
+
+	// Initialize log source based on configuration
 	logSource, err := createLogSource(cfg)
 	if err != nil {
-		return fmt.Errorf("failed to create log source: %w", err)
+		return "", fmt.Errorf("failed to create log source: %w", err)
 	}
+	drupalTally := drupalRuleTally(cfg, logSource)
 
 	// Get source path
 	sourcePath := cfg.GetLogSourcePath()
 
-	// Read log content
+	// Read log content. Checkpoints of an incremental read are saved once
+	// the run has succeeded.
 	var logContent string
-	if cfg.IsOCMS() && len(cfg.GetOCMSLogPaths()) > 1 {
+	var checkpoints []tail.Checkpoint
+	if cfg.HasSourceCommand() {
+		logContent, err = readSourceCommand(ctx, cfg, logSource, log)
+		if err != nil {
+			return "", err
+		}
+	} else if cfg.RunsLogwatch() {
+		logContent, err = runLogwatch(ctx, cfg, logSource, log)
+		if err != nil {
+			return "", err
+		}
+	} else if cfg.HasDrupalDatabase() {
+		logContent, err = readDrupalDatabase(ctx, cfg, store, logSource, startTime, log)
+		if err != nil {
+			return "", err
+		}
+	} else if cfg.IncrementalRead {
+		logContent, checkpoints, err = readIncremental(cfg, store, logSource, log)
+		if err != nil {
+			return "", err
+		}
+	} else if cfg.IsOCMS() && cfg.HasLabeledOCMSLogs() {
 		ocmsReader, ok := logSource.Reader.(*ocms.Reader)
 		if !ok {
-			return fmt.Errorf("OCMS multi-log read requires OCMS reader")
+			return "", fmt.Errorf("OCMS multi-log read requires OCMS reader")
 		}
 
 		ocmsPaths := cfg.GetOCMSLogPaths()
@@ -214,16 +359,17 @@ func runAnalyzer(ctx context.Context, cfg *config.Config, log *logging.SecureLog
 			Str("type", cfg.LogSourceType).
 			Msg("Reading OCMS log content...")
 		for _, logPath := range ocmsPaths {
-			files = append(files, ocms.LogFile{Kind: logPath.Kind, Path: logPath.Path})
+			files = append(files, ocms.LogFile{Kind: logPath.Kind, Path: logPath.Path, Day: logPath.Day})
 			log.Info().
 				Str("path", logPath.Path).
 				Str("log_kind", logPath.Kind).
+				Str("day", logPath.Day).
 				Msg("Reading OCMS log file...")
 		}
 
 		logContent, err = ocmsReader.ReadFiles(files)
 		if err != nil {
-			return fmt.Errorf("failed to read log content: %w", err)
+			return "", fmt.Errorf("failed to read log content: %w", err)
 		}
 
 		for _, logPath := range ocmsPaths {
@@ -247,7 +393,7 @@ func runAnalyzer(ctx context.Context, cfg *config.Config, log *logging.SecureLog
 
 		logContent, err = logSource.Reader.Read(sourcePath)
 		if err != nil {
-			return fmt.Errorf("failed to read log content: %w", err)
+			return "", fmt.Errorf("failed to read log content: %w", err)
 		}
 
 		sourceInfo, err := logSource.Reader.GetSourceInfo(sourcePath)
@@ -262,27 +408,39 @@ func runAnalyzer(ctx context.Context, cfg *config.Config, log *logging.SecureLog
 		}
 	}
 
-	// Check for no entries (Drupal watchdog specific)
+	// Check for no entries (Drupal watchdog, journal exports, access logs, syslog, Docker, custom sources, and incremental OCMS reads)
 	// When there are no log entries for the time period, skip AI analysis
 	// and send an informational notification instead
-	if cfg.LogSourceType == "drupal_watchdog" && drupal.IsNoEntriesContent(logContent) {
-		log.Info().Msg("No watchdog entries found for the time period - skipping AI analysis")
+	if (cfg.IsDrupalWatchdog() && drupal.IsNoEntriesContent(logContent)) ||
+		(cfg.IsJournald() && journald.IsNoEntriesContent(logContent)) ||
+		(cfg.IsAccessLog() && accesslog.IsNoEntriesContent(logContent)) ||
+		(cfg.IsSyslog() && syslog.IsNoEntriesContent(logContent)) ||
+		(cfg.IsDocker() && docker.IsNoEntriesContent(logContent)) ||
+		(cfg.IsCustomSource() && customlog.IsNoEntriesContent(logContent)) ||
+		(cfg.IsOCMS() && ocms.IsNoEntriesContent(logContent)) {
+		log.Info().Msg("No log entries found for the time period - skipping AI analysis")
 
 		// Send informational Telegram notification
 		if err := telegramClient.SendNoEntriesReport(cfg.LogSourceType, cfg.SelectedSiteName()); err != nil {
-			return fmt.Errorf("failed to send no-entries notification: %w", err)
+			return "", fmt.Errorf("failed to send no-entries notification: %w", err)
 		}
 
 		log.Info().Msg("No-entries notification sent to Telegram")
-		return nil
+		saveCheckpoints(store, checkpoints, log)
+		return "", nil
 	}
 
+	// Evaluate deterministic rules on the raw reader output before the LLM
+	// call, so their findings survive whatever the model reports
+	ruleMatches := evaluateRules(cfg, logContent, drupalTally, log)
+
 	// Get historical context (if database enabled)
 	// Filter by source type and site to get relevant historical data only
 	var historicalContext string
 	sourceFilter := &storage.SourceFilter{
 		LogSourceType: cfg.LogSourceType,
 		SiteName:      cfg.SelectedSiteName(), // Empty for single-site logwatch/OCMS
+		Host:          cfg.SourceHost,         // Empty unless a multi-host run
 	}
 	if store != nil {
 		log.Info().Msg("Retrieving historical context...")
@@ -294,53 +452,20 @@ func runAnalyzer(ctx context.Context, cfg *config.Config, log *logging.SecureLog
 		}
 	}
 
-	// Resolve operator-defined exclusions (optional feature). Patterns are
-	// injected into the prompts below so the LLM avoids matching findings
-	// and their influence on systemStatus, summary, and metrics. Pattern
-	// text is deliberately not logged; only counts are reported.
-	var globalExclusions, contextualExclusions []string
-	if cfg.Exclusions != nil {
-		globalExclusions = cfg.Exclusions.GlobalPatterns()
-		logType, err := analyzer.ParseSourceType(logSource.PromptBuilder.GetLogType())
-		if err == nil {
-			contextualExclusions = cfg.Exclusions.ContextualPatterns(logType, cfg.SelectedSiteID())
-		}
-		if len(globalExclusions)+len(contextualExclusions) > 0 {
-			log.Info().
-				Int("patterns_global", len(globalExclusions)).
-				Int("patterns_contextual", len(contextualExclusions)).
-				Msg("Injecting operator-defined exclusion patterns into prompt")
-		}
-	}
-
-	// Build prompts using the log source's prompt builder
-	systemPrompt := logSource.PromptBuilder.GetSystemPrompt(globalExclusions)
-
-	promptResult, err := preparePromptForAnalysis(
-		ctx,
-		cfg,
-		llmClient,
-		logSource,
-		systemPrompt,
-		logContent,
-		historicalContext,
-		contextualExclusions,
-		log,
-	)
-	if err != nil {
-		return err
+	var result *llmResult
+	if llmErr == nil {
+		result, llmErr = analyzeWithLLM(ctx, cfg, llmClient, logSource, logContent, historicalContext, log)
 	}
-	userPrompt := promptResult.UserPrompt
-
-	// Analyze with LLM
-	log.Info().
-		Str("log_type", logSource.PromptBuilder.GetLogType()).
-		Str("provider", llmClient.GetProviderName()).
-		Msg("Analyzing logs...")
-	analysis, stats, err := llmClient.Analyze(ctx, systemPrompt, userPrompt)
-	if err != nil {
-		return fmt.Errorf("LLM analysis failed: %w", err)
+	if llmErr != nil {
+		// No provider produced an analysis: report what the reader and the
+		// rules found instead of leaving the run silent
+		log.Error().Err(llmErr).Msg("AI analysis unavailable, sending degraded report")
+		return sendDegradedReport(cfg, store, telegramClient, logSource, ruleMatches, startTime, log)
 	}
+	systemPrompt, userPrompt := result.SystemPrompt, result.UserPrompt
+	analysis, stats := result.Analysis, result.Stats
+	rules.Apply(analysis, ruleMatches)
+	applyReaderMetrics(analysis, logSource, log)
 
 	log.Info().
 		Str("status", analysis.SystemStatus).
@@ -351,6 +476,14 @@ func runAnalyzer(ctx context.Context, cfg *config.Config, log *logging.SecureLog
 		Float64("duration_s", stats.DurationSeconds).
 		Msg("Analysis completed")
 
+	if analysis.Ensemble != nil {
+		log.Info().
+			Int("providers_succeeded", analysis.Ensemble.Succeeded()).
+			Int("disagreements", len(analysis.Ensemble.Disagreements())).
+			Bool("statuses_agree", analysis.Ensemble.StatusesAgree()).
+			Msg("Ensemble analyses reconciled")
+	}
+
 	// Log token usage
 	log.Debug().
 		Int("input_tokens", stats.InputTokens).
@@ -363,9 +496,12 @@ func runAnalyzer(ctx context.Context, cfg *config.Config, log *logging.SecureLog
 	if store != nil {
 		log.Info().Msg("Saving analysis to database...")
 		summary := &storage.Summary{
-			Timestamp:       time.Now(),
+			// The start of the run, before the logs were read, so the next
+			// Drupal database query continues without a gap
+			Timestamp:       startTime,
 			LogSourceType:   cfg.LogSourceType,
 			SiteName:        cfg.SelectedSiteName(), // Empty for single-site logwatch/OCMS
+			Host:            cfg.SourceHost,
 			SystemStatus:    analysis.SystemStatus,
 			Summary:         analysis.Summary,
 			CriticalIssues:  analysis.CriticalIssues,
@@ -376,11 +512,19 @@ func runAnalyzer(ctx context.Context, cfg *config.Config, log *logging.SecureLog
 			OutputTokens:    stats.OutputTokens,
 			CostUSD:         stats.CostUSD,
 		}
+		if analysis.Ensemble != nil {
+			ensembleJSON, err := json.Marshal(analysis.Ensemble)
+			if err != nil {
+				log.Warn().Err(err).Msg("Failed to encode ensemble details")
+			}
+			summary.Ensemble = ensembleJSON
+		}
 
 		if err := store.SaveSummary(summary); err != nil {
 			log.Warn().Err(err).Msg("Failed to save summary to database")
 		} else {
 			log.Info().Int64("id", summary.ID).Msg("Summary saved to database")
+			archivePrompts(store, cfg, summary.ID, systemPrompt, userPrompt, log)
 		}
 
 		// Cleanup old summaries (>90 days)
@@ -391,30 +535,273 @@ func runAnalyzer(ctx context.Context, cfg *config.Config, log *logging.SecureLog
 		} else if deleted > 0 {
 			log.Info().Int64("deleted", deleted).Msg("Old summaries cleaned up")
 		}
+
+		cleanupPromptArchives(store, cfg, log)
 	}
 
 	// Send Telegram notifications
 	log.Info().Msg("Sending Telegram notifications...")
 	if err := telegramClient.SendAnalysisReport(analysis, stats, cfg.LogSourceType, cfg.SelectedSiteName()); err != nil {
-		return fmt.Errorf("failed to send Telegram notification: %w", err)
+		return "", fmt.Errorf("failed to send Telegram notification: %w", err)
 	}
 
 	if cfg.HasAlertsChannel() && ai.ShouldTriggerAlert(analysis.SystemStatus) {
 		log.Info().Msg("Alert notification sent (status warrants attention)")
 	}
 
+	saveCheckpoints(store, checkpoints, log)
+
 	// Final summary
 	totalDuration := time.Since(startTime)
 	log.Info().
 		Float64("total_duration_s", totalDuration.Seconds()).
 		Msg("All operations completed successfully")
 
-	return nil
+	return analysis.SystemStatus, nil
+}
+
+// llmResult is the outcome of a successful LLM analysis together with the
+// prompts that produced it
+type llmResult struct {
+	SystemPrompt string
+	UserPrompt   string
+	Analysis     *ai.Analysis
+	Stats        *ai.Stats
+}
+
+// analyzeWithLLM builds the prompts for the log content and runs the LLM
+// analysis. Any error means no analysis is available for this run.
+func analyzeWithLLM(
+	ctx context.Context,
+	cfg *config.Config,
+	llmClient ai.Provider,
+	logSource *analyzer.LogSource,
+	logContent string,
+	historicalContext string,
+	log *logging.SecureLogger,
+) (*llmResult, error) {
+	// Resolve operator-defined exclusions (optional feature). Patterns are
+	// injected into the prompts below so the LLM avoids matching findings
+	// and their influence on systemStatus, summary, and metrics. Pattern
+	// text is deliberately not logged; only counts are reported.
+	var globalExclusions, contextualExclusions []string
+	if cfg.Exclusions != nil {
+		globalExclusions = cfg.Exclusions.GlobalPatterns()
+		logType, err := analyzer.ParseSourceType(logSource.PromptBuilder.GetLogType())
+		if err == nil {
+			contextualExclusions = cfg.Exclusions.ContextualPatterns(logType, cfg.SelectedSiteID())
+		}
+		if len(globalExclusions)+len(contextualExclusions) > 0 {
+			log.Info().
+				Int("patterns_global", len(globalExclusions)).
+				Int("patterns_contextual", len(contextualExclusions)).
+				Msg("Injecting operator-defined exclusion patterns into prompt")
+		}
+	}
+
+	// Build prompts using the log source's prompt builder
+	systemPrompt := logSource.PromptBuilder.GetSystemPrompt(globalExclusions)
+
+	promptResult, err := preparePromptForAnalysis(
+		ctx,
+		cfg,
+		llmClient,
+		logSource,
+		systemPrompt,
+		logContent,
+		historicalContext,
+		contextualExclusions,
+		log,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Analyze with LLM
+	log.Info().
+		Str("log_type", logSource.PromptBuilder.GetLogType()).
+		Str("provider", llmClient.GetProviderName()).
+		Msg("Analyzing logs...")
+	analysis, stats, err := llmClient.Analyze(ctx, systemPrompt, promptResult.UserPrompt)
+	if err != nil {
+		return nil, fmt.Errorf("LLM analysis failed: %w", err)
+	}
+
+	return &llmResult{
+		SystemPrompt: systemPrompt,
+		UserPrompt:   promptResult.UserPrompt,
+		Analysis:     analysis,
+		Stats:        stats,
+	}, nil
+}
+
+// drupalRuleTally counts the Drupal watchdog entries matching the rules
+// while the reader parses them; the reader does not retain its entries.
+// Returns nil when no rules are configured or the source is not Drupal.
+func drupalRuleTally(cfg *config.Config, logSource *analyzer.LogSource) *rules.DrupalTally {
+	drupalReader, ok := logSource.Reader.(*drupal.Reader)
+	if !ok || cfg.Rules == nil {
+		return nil
+	}
+	tally := cfg.Rules.NewDrupalTally()
+	drupalReader.SetEntryObserver(tally.Add)
+	return tally
+}
+
+// evaluateRules runs the operator-defined rules against the reader output
+// and, for Drupal watchdog, the tally of the parsed entries. Returns nil
+// when no rules are configured.
+func evaluateRules(cfg *config.Config, logContent string, drupalTally *rules.DrupalTally, log *logging.SecureLogger) []rules.Match {
+	if cfg.Rules == nil {
+		return nil
+	}
+
+	input := rules.Input{
+		SourceType:  cfg.LogSourceType,
+		SiteID:      cfg.SelectedSiteID(),
+		Content:     logContent,
+		DrupalTally: drupalTally,
+	}
+
+	matches := cfg.Rules.Evaluate(input)
+	for _, m := range matches {
+		log.Info().
+			Str("rule", m.Rule).
+			Str("severity", m.Severity).
+			Int("count", m.Count).
+			Msg("Deterministic rule matched")
+	}
+	return matches
+}
+
+// applyReaderMetrics overwrites the metrics of the analysis with the exact
+// metrics the reader parsed, such as logwatch failed logins, replacing
+// values the LLM counted itself.
+func applyReaderMetrics(analysis *ai.Analysis, logSource *analyzer.LogSource, log *logging.SecureLogger) {
+	reporter, ok := logSource.Reader.(analyzer.MetricsReporter)
+	if !ok {
+		return
+	}
+	metrics := reporter.ReadMetrics()
+	if len(metrics) == 0 {
+		return
+	}
+
+	if analysis.Metrics == nil {
+		analysis.Metrics = make(map[string]any, len(metrics))
+	}
+	maps.Copy(analysis.Metrics, metrics)
+	log.Debug().Int("metrics", len(metrics)).Msg("Applied metrics parsed by the reader")
 }
 
 // createLLMClient creates the appropriate LLM client based on configuration
 func createLLMClient(ctx context.Context, cfg *config.Config, log *logging.SecureLogger) (ai.Provider, error) {
-	switch cfg.LLMProvider {
+	if cfg.IsEnsemble() {
+		return createEnsembleClient(ctx, cfg, log)
+	}
+	if cfg.LLMProvider == "mock" {
+		return createMockClient(ctx, cfg, log)
+	}
+	return createProviderClient(ctx, cfg, cfg.LLMProvider, log)
+}
+
+// createEnsembleClient creates one client per ENSEMBLE_PROVIDERS entry and
+// wraps them in an ensemble that reconciles their analyses. A member that
+// cannot be created, such as an unreachable Ollama server, is logged and
+// left out; with a single member left, it is used on its own. Fails only
+// when no member is available.
+func createEnsembleClient(ctx context.Context, cfg *config.Config, log *logging.SecureLogger) (ai.Provider, error) {
+	specs, err := cfg.EnsembleSpecs()
+	if err != nil {
+		return nil, fmt.Errorf("invalid ENSEMBLE_PROVIDERS: %w", err)
+	}
+
+	members := make([]ai.EnsembleMember, 0, len(specs))
+	var errs []error
+	for _, spec := range specs {
+		provider, err := createSpecClient(ctx, cfg, spec, log)
+		if err != nil {
+			log.Warn().Err(err).Str("provider", spec.Label()).Msg("Ensemble provider unavailable, leaving it out")
+			errs = append(errs, fmt.Errorf("ensemble provider %s: %w", spec.Label(), err))
+			continue
+		}
+		members = append(members, ai.EnsembleMember{
+			Name:     spec.Provider + ":" + cfg.ForProvider(spec).GetLLMModel(),
+			Provider: provider,
+		})
+	}
+
+	switch len(members) {
+	case 0:
+		return nil, fmt.Errorf("no ensemble provider available: %w", errors.Join(errs...))
+	case 1:
+		log.Warn().
+			Str("provider", members[0].Name).
+			Msg("Only one ensemble provider available, analyzing without ensemble")
+		return members[0].Provider, nil
+	}
+
+	client, err := ai.NewEnsembleClient(ai.EnsembleConfig{
+		Members:    members,
+		StatusRule: ai.EnsembleStatusRule(cfg.EnsembleStatusRule),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ensemble client: %w", err)
+	}
+
+	log.Info().
+		Int("providers", len(members)).
+		Str("status_rule", cfg.EnsembleStatusRule).
+		Msg("Using provider ensemble")
+
+	return client, nil
+}
+
+// createSpecClient creates a live LLM client for a "provider[:model]" spec,
+// validating the provider's settings with the model override applied.
+func createSpecClient(ctx context.Context, cfg *config.Config, spec config.ProviderSpec, log *logging.SecureLogger) (ai.Provider, error) {
+	specCfg := cfg.ForProvider(spec)
+	if err := specCfg.ValidateProvider(spec.Provider); err != nil {
+		return nil, err
+	}
+	return createProviderClient(ctx, specCfg, spec.Provider, log)
+}
+
+// createMockClient creates the offline mock provider. In record mode the
+// configured upstream provider is created as well and its responses saved.
+func createMockClient(ctx context.Context, cfg *config.Config, log *logging.SecureLogger) (ai.Provider, error) {
+	var upstream ai.Provider
+	if cfg.MockMode == config.MockModeRecord {
+		var err error
+		upstream, err = createProviderClient(ctx, cfg, cfg.MockUpstreamProvider, log)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	client, err := ai.NewMockClient(ai.MockConfig{
+		Dir:        cfg.MockDir,
+		Mode:       ai.MockMode(cfg.MockMode),
+		SourceType: cfg.LogSourceType,
+		SiteID:     cfg.SelectedSiteID(),
+		MaxTokens:  cfg.AIMaxTokens,
+		Upstream:   upstream,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mock client: %w", err)
+	}
+
+	log.Info().
+		Str("mode", cfg.MockMode).
+		Str("dir", cfg.MockDir).
+		Msg("Using mock LLM provider")
+
+	return client, nil
+}
+
+// createProviderClient creates a live LLM client by provider name
+func createProviderClient(ctx context.Context, cfg *config.Config, provider string, log *logging.SecureLogger) (ai.Provider, error) {
+	switch provider {
 	case "anthropic":
 		proxyURL := cfg.GetProxyURL(true) // HTTPS proxy for API calls
 		client, err := ai.NewClient(cfg.AnthropicAPIKey, cfg.ClaudeModel, proxyURL, cfg.AITimeoutSeconds, cfg.AIMaxTokens)
@@ -470,60 +857,172 @@ func createLLMClient(ctx context.Context, cfg *config.Config, log *logging.Secur
 		return client, nil
 
 	default:
-		return nil, fmt.Errorf("unsupported LLM provider: %s", cfg.LLMProvider)
+		return nil, fmt.Errorf("unsupported LLM provider: %s", provider)
 	}
 }
 
-// createLogSource creates the appropriate log source based on configuration
+// createLogSource creates the log source selected by configuration from
+// the source registry
 func createLogSource(cfg *config.Config) (*analyzer.LogSource, error) {
-	switch cfg.LogSourceType {
-	case "logwatch":
-		return &analyzer.LogSource{
-			Type: analyzer.LogSourceLogwatch,
-			Reader: logwatch.NewReader(
-				cfg.MaxLogSizeMB,
-				false, // Reader preprocessing disabled — handled by preparePromptForAnalysis
-				cfg.MaxPreprocessingTokens,
-			),
-			Preprocessor:  logwatch.NewPreprocessor(cfg.MaxPreprocessingTokens),
-			PromptBuilder: logwatch.NewPromptBuilder(),
-		}, nil
-
-	case "drupal_watchdog":
-		promptBuilder := drupal.NewPromptBuilder()
-		if cfg.SelectedSiteName() != "" {
-			promptBuilder.SetSiteName(cfg.SelectedSiteName())
-		}
-		return &analyzer.LogSource{
-			Type: analyzer.LogSourceDrupalWatchdog,
-			Reader: drupal.NewReader(
-				cfg.MaxLogSizeMB,
-				false, // Reader preprocessing disabled — handled by preparePromptForAnalysis
-				cfg.MaxPreprocessingTokens,
-				drupal.InputFormat(cfg.DrupalWatchdogFormat),
-			),
-			Preprocessor:  drupal.NewPreprocessor(cfg.MaxPreprocessingTokens),
-			PromptBuilder: promptBuilder,
-		}, nil
-	case "ocms":
-		promptBuilder := ocms.NewPromptBuilder()
-		if cfg.SelectedSiteName() != "" {
-			promptBuilder.SetSiteName(cfg.SelectedSiteName())
-		}
-		return &analyzer.LogSource{
-			Type: analyzer.LogSourceOCMS,
-			Reader: ocms.NewReader(
-				cfg.MaxLogSizeMB,
-				false, // Reader preprocessing disabled — handled by preparePromptForAnalysis
-				cfg.MaxPreprocessingTokens,
-			),
-			Preprocessor:  ocms.NewPreprocessor(cfg.MaxPreprocessingTokens),
-			PromptBuilder: promptBuilder,
-		}, nil
+	registry, err := newSourceRegistry(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return registry.Create(analyzer.LogSourceType(cfg.LogSourceType))
+}
 
-	default:
-		return nil, fmt.Errorf("unsupported log source type: %s", cfg.LogSourceType)
+// newSourceRegistry registers the built-in log sources and the custom
+// sources of custom-sources.json. Sources are built by factories, so only
+// the settings of the selected source need to be valid.
+func newSourceRegistry(cfg *config.Config) (*analyzer.Registry, error) {
+	registry := analyzer.NewRegistry()
+	factories := map[analyzer.LogSourceType]analyzer.SourceFactory{
+		analyzer.LogSourceLogwatch: func() (*analyzer.LogSource, error) {
+			return &analyzer.LogSource{
+				Type: analyzer.LogSourceLogwatch,
+				Reader: logwatch.NewReader(
+					cfg.MaxLogSizeMB,
+					false, // Reader preprocessing disabled — handled by preparePromptForAnalysis
+					cfg.MaxPreprocessingTokens,
+				),
+				Preprocessor:  logwatch.NewPreprocessor(cfg.MaxPreprocessingTokens),
+				PromptBuilder: logwatch.NewPromptBuilder(),
+			}, nil
+		},
+
+		analyzer.LogSourceDrupalWatchdog: func() (*analyzer.LogSource, error) {
+			promptBuilder := drupal.NewPromptBuilder()
+			if cfg.SelectedSiteName() != "" {
+				promptBuilder.SetSiteName(cfg.SelectedSiteName())
+			}
+			return &analyzer.LogSource{
+				Type: analyzer.LogSourceDrupalWatchdog,
+				Reader: drupal.NewReader(
+					cfg.StreamSizeLimitMB(), // Streamed line by line, not read into memory
+					false,                   // Reader preprocessing disabled — handled by preparePromptForAnalysis
+					cfg.MaxPreprocessingTokens,
+					drupal.InputFormat(cfg.DrupalWatchdogFormat),
+				),
+				Preprocessor:  drupal.NewPreprocessor(cfg.MaxPreprocessingTokens),
+				PromptBuilder: promptBuilder,
+			}, nil
+		},
+
+		analyzer.LogSourceOCMS: func() (*analyzer.LogSource, error) {
+			promptBuilder := ocms.NewPromptBuilder()
+			if cfg.SelectedSiteName() != "" {
+				promptBuilder.SetSiteName(cfg.SelectedSiteName())
+			}
+			return &analyzer.LogSource{
+				Type: analyzer.LogSourceOCMS,
+				Reader: ocms.NewReader(
+					cfg.StreamSizeLimitMB(), // Streamed line by line, not read into memory
+					false,                   // Reader preprocessing disabled — handled by preparePromptForAnalysis
+					cfg.MaxPreprocessingTokens,
+				),
+				Preprocessor:  ocms.NewPreprocessor(cfg.MaxPreprocessingTokens),
+				PromptBuilder: promptBuilder,
+			}, nil
+		},
+
+		analyzer.LogSourceJournald: func() (*analyzer.LogSource, error) {
+			return &analyzer.LogSource{
+				Type: analyzer.LogSourceJournald,
+				Reader: journald.NewReader(
+					cfg.MaxLogSizeMB,
+					false, // Reader preprocessing disabled — handled by preparePromptForAnalysis
+					cfg.MaxPreprocessingTokens,
+				),
+				Preprocessor:  journald.NewPreprocessor(cfg.MaxPreprocessingTokens),
+				PromptBuilder: journald.NewPromptBuilder(),
+			}, nil
+		},
+
+		analyzer.LogSourceAccessLog: func() (*analyzer.LogSource, error) {
+			format, err := accesslog.ParseFormat(cfg.AccessLogFormat)
+			if err != nil {
+				return nil, err
+			}
+			promptBuilder := accesslog.NewPromptBuilder()
+			if cfg.SelectedSiteName() != "" {
+				promptBuilder.SetSiteName(cfg.SelectedSiteName())
+			}
+			return &analyzer.LogSource{
+				Type: analyzer.LogSourceAccessLog,
+				Reader: accesslog.NewReader(
+					cfg.MaxLogSizeMB,
+					false, // Reader preprocessing disabled — handled by preparePromptForAnalysis
+					cfg.MaxPreprocessingTokens,
+					format,
+					time.Duration(cfg.AccessLogSlowRequestMS)*time.Millisecond,
+				),
+				Preprocessor:  accesslog.NewPreprocessor(cfg.MaxPreprocessingTokens),
+				PromptBuilder: promptBuilder,
+			}, nil
+		},
+
+		analyzer.LogSourceSyslog: func() (*analyzer.LogSource, error) {
+			return &analyzer.LogSource{
+				Type: analyzer.LogSourceSyslog,
+				Reader: syslog.NewReader(
+					cfg.MaxLogSizeMB,
+					false, // Reader preprocessing disabled — handled by preparePromptForAnalysis
+					cfg.MaxPreprocessingTokens,
+				),
+				// The digest uses logwatch section headers, so logwatch section priorities apply
+				Preprocessor:  logwatch.NewPreprocessor(cfg.MaxPreprocessingTokens),
+				PromptBuilder: syslog.NewPromptBuilder(),
+			}, nil
+		},
+
+		analyzer.LogSourceDocker: func() (*analyzer.LogSource, error) {
+			selector, err := cfg.DockerSelector()
+			if err != nil {
+				return nil, err
+			}
+			return &analyzer.LogSource{
+				Type: analyzer.LogSourceDocker,
+				Reader: docker.NewReader(
+					cfg.MaxLogSizeMB,
+					false, // Reader preprocessing disabled — handled by preparePromptForAnalysis
+					cfg.MaxPreprocessingTokens,
+					selector,
+					time.Duration(cfg.DockerWindowHours)*time.Hour,
+				),
+				Preprocessor:  docker.NewPreprocessor(cfg.MaxPreprocessingTokens),
+				PromptBuilder: docker.NewPromptBuilder(),
+			}, nil
+		},
+	}
+
+	if cfg.CustomSourcesConfig != nil {
+		for _, sourceType := range cfg.CustomSourcesConfig.ListSources() {
+			factories[analyzer.LogSourceType(sourceType)] = func() (*analyzer.LogSource, error) {
+				source, err := cfg.CustomSourcesConfig.GetSource(sourceType)
+				if err != nil {
+					return nil, err
+				}
+				return &analyzer.LogSource{
+					Type: analyzer.LogSourceType(sourceType),
+					Reader: customlog.NewReader(
+						source,
+						cfg.MaxLogSizeMB,
+						false, // Reader preprocessing disabled — handled by preparePromptForAnalysis
+						cfg.MaxPreprocessingTokens,
+					),
+					Preprocessor:  customlog.NewPreprocessor(cfg.MaxPreprocessingTokens),
+					PromptBuilder: customlog.NewPromptBuilder(source),
+				}, nil
+			}
+		}
+	}
+
+	for sourceType, factory := range factories {
+		if err := registry.RegisterFactory(sourceType, factory); err != nil {
+			return nil, err
+		}
 	}
+	return registry, nil
 }
 
 // handleListDrupalSites lists available Drupal sites from drupal-sites.json
@@ -564,6 +1063,15 @@ func handleListDrupalSites(cli *config.CLIOptions) int {
 		fmt.Printf("  %-20s %s%s\n", siteID, displayName, defaultMarker)
 		fmt.Printf("    Drupal root:    %s\n", site.DrupalRoot)
 		fmt.Printf("    Watchdog path:  %s\n", site.WatchdogPath)
+		if site.WatchdogCommand != "" {
+			fmt.Printf("    Command:        %s\n", site.WatchdogCommand)
+		}
+		if site.WatchdogDSN != "" {
+			// Validated when the configuration was loaded; print without the password
+			if dbURL, err := drupal.ParseDatabaseURL(site.WatchdogDSN, site.DrupalRoot); err == nil {
+				fmt.Printf("    Database:       %s\n", dbURL)
+			}
+		}
 		fmt.Printf("    Format:         %s\n", getFormatOrDefault(site.WatchdogFormat))
 		fmt.Printf("    Min severity:   %d\n", site.MinSeverity)
 		fmt.Println()
@@ -613,6 +1121,12 @@ func handleListOCMSSites(cli *config.CLIOptions) int {
 	fmt.Printf("Default site: %s\n", sitesConfig.DefaultSite)
 	fmt.Printf("Default log kind: %s\n", getOCMSLogKindOrDefault(sitesConfig.DefaultLogKind))
 	fmt.Printf("OCMS sites registry: %s\n", registryFoundPath)
+	if sitesConfig.DiscoveryEnabled() {
+		fmt.Printf("Site discovery: enabled (include: %v, exclude: %v)\n",
+			sitesConfig.Discovery.Include, sitesConfig.Discovery.Exclude)
+	} else {
+		fmt.Printf("Site discovery: disabled\n")
+	}
 
 	logRange, err := config.NormalizeOCMSLogRange(cli.OCMSLogRange)
 	if err != nil {
@@ -622,8 +1136,8 @@ func handleListOCMSSites(cli *config.CLIOptions) int {
 	fmt.Printf("Log range: %s\n\n", logRange)
 	fmt.Printf("Available sites:\n")
 
-	for _, siteID := range sitesConfig.ListSites() {
-		siteConfig := sitesConfig.Sites[siteID]
+	for _, site := range sitesConfig.EligibleSites(registry) {
+		siteID, siteConfig := site.ID, site.Config
 		registrySite, err := registry.GetSite(siteID)
 		if err != nil {
 			_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -651,11 +1165,8 @@ func handleListOCMSSites(cli *config.CLIOptions) int {
 			_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			return exitFailure
 		}
-		selectedLogs, err := registrySite.LogPaths(logKind, logRange)
-		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			return exitFailure
-		}
+		// A range that matches no files is reported per site
+		selectedLogs, selectErr := registrySite.LogPaths(logKind, logRange)
 
 		displayName := siteConfig.Name
 		if displayName == "" {
@@ -666,17 +1177,30 @@ func handleListOCMSSites(cli *config.CLIOptions) int {
 			defaultMarker = " (default)"
 		}
 
+		source := "configured"
+		if site.Discovered {
+			source = "discovered"
+		}
+
 		fmt.Printf("  %-20s %s%s\n", siteID, displayName, defaultMarker)
+		fmt.Printf("    Source:        %s\n", source)
 		fmt.Printf("    Instance dir:  %s\n", registrySite.InstanceDir)
 		fmt.Printf("    System user:   %s\n", registrySite.SystemUser)
 		fmt.Printf("    Port:          %d\n", registrySite.Port)
 		fmt.Printf("    Log kind:      %s\n", logKind)
-		if len(selectedLogs) == 1 {
+		switch {
+		case selectErr != nil:
+			fmt.Printf("    Selected logs: none (%v)\n", selectErr)
+		case len(selectedLogs) == 1 && selectedLogs[0].Day == "":
 			fmt.Printf("    Selected log:  %s\n", selectedLogs[0].Path)
-		} else {
+		default:
 			fmt.Printf("    Selected logs:\n")
 			for _, selectedLog := range selectedLogs {
-				fmt.Printf("      %-5s %s\n", selectedLog.Kind, selectedLog.Path)
+				if selectedLog.Day != "" {
+					fmt.Printf("      %-5s %s  %s\n", selectedLog.Kind, selectedLog.Day, selectedLog.Path)
+				} else {
+					fmt.Printf("      %-5s %s\n", selectedLog.Kind, selectedLog.Path)
+				}
 			}
 		}
 		fmt.Printf("    Main log:      %s\n", mainLog)
@@ -687,6 +1211,64 @@ func handleListOCMSSites(cli *config.CLIOptions) int {
 	return exitSuccess
 }
 
+// handleListAccessLogSites lists available access log sites from access-log-sites.json.
+func handleListAccessLogSites(cli *config.CLIOptions) int {
+	sitesConfig, configPath, err := config.LoadAccessLogSitesConfig(cli.AccessLogSitesConfig)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	if sitesConfig == nil {
+		_, _ = fmt.Fprintf(os.Stderr, "No access-log-sites.json configuration file found.\n")
+		_, _ = fmt.Fprintf(os.Stderr, "\nSearch locations:\n")
+		_, _ = fmt.Fprintf(os.Stderr, "  - ./access-log-sites.json\n")
+		_, _ = fmt.Fprintf(os.Stderr, "  - ./configs/access-log-sites.json\n")
+		_, _ = fmt.Fprintf(os.Stderr, "  - /opt/logwatch-ai/access-log-sites.json\n")
+		_, _ = fmt.Fprintf(os.Stderr, "  - ~/.config/logwatch-ai/access-log-sites.json\n")
+		_, _ = fmt.Fprintf(os.Stderr, "\nUse -access-log-sites-config to specify a custom path.\n")
+		return exitFailure
+	}
+
+	fmt.Printf("Access log sites configuration: %s\n", configPath)
+	fmt.Printf("Version: %s\n\n", sitesConfig.Version)
+	fmt.Printf("Available sites:\n")
+
+	for _, siteID := range sitesConfig.ListSites() {
+		site := sitesConfig.Sites[siteID]
+		defaultMarker := ""
+		if siteID == sitesConfig.DefaultSite {
+			defaultMarker = " (default)"
+		}
+
+		displayName := site.Name
+		if displayName == "" {
+			displayName = siteID
+		}
+
+		fmt.Printf("  %-20s %s%s\n", siteID, displayName, defaultMarker)
+		fmt.Printf("    Log path:       %s\n", site.LogPath)
+		fmt.Printf("    Format:         %s\n", valueOrEnvDefault(site.Format, "ACCESS_LOG_FORMAT"))
+		slowThreshold := ""
+		if site.SlowRequestMS > 0 {
+			slowThreshold = fmt.Sprintf("%dms", site.SlowRequestMS)
+		}
+		fmt.Printf("    Slow requests:  %s\n", valueOrEnvDefault(slowThreshold, "ACCESS_LOG_SLOW_REQUEST_MS"))
+		fmt.Println()
+	}
+
+	return exitSuccess
+}
+
+// valueOrEnvDefault returns the value or a note naming the environment
+// variable that provides the default.
+func valueOrEnvDefault(value, envName string) string {
+	if value == "" {
+		return "(" + envName + ")"
+	}
+	return value
+}
+
 // getFormatOrDefault returns the format or "json" if empty
 func getFormatOrDefault(format string) string {
 	if format == "" {
@@ -701,3 +1283,34 @@ func getOCMSLogKindOrDefault(logKind string) string {
 	}
 	return logKind
 }
+
+// archivePrompts stores the exact prompts of a run so the ask command can
+// send follow-up questions about the same log content. Failures are logged
+// and do not affect the run.
+func archivePrompts(store *storage.Storage, cfg *config.Config, summaryID int64, systemPrompt, userPrompt string, log *logging.SecureLogger) {
+	if cfg.PromptArchiveRetentionDays <= 0 {
+		return
+	}
+
+	archive := &storage.PromptArchive{
+		SummaryID:    summaryID,
+		SystemPrompt: systemPrompt,
+		UserPrompt:   userPrompt,
+	}
+	if err := store.SavePromptArchive(archive); err != nil {
+		log.Warn().Err(err).Msg("Failed to archive prompts for follow-up questions")
+		return
+	}
+	log.Debug().Int64("id", summaryID).Msg("Prompts archived for follow-up questions")
+}
+
+// cleanupPromptArchives deletes archives past PROMPT_ARCHIVE_RETENTION_DAYS.
+// With archiving disabled, every remaining archive is removed.
+func cleanupPromptArchives(store *storage.Storage, cfg *config.Config, log *logging.SecureLogger) {
+	deleted, err := store.CleanupOldPromptArchives(cfg.PromptArchiveRetentionDays)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to cleanup old prompt archives")
+	} else if deleted > 0 {
+		log.Info().Int64("deleted", deleted).Msg("Old prompt archives cleaned up")
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+	"github.com/olegiv/logwatch-ai-go/internal/config"
+	"github.com/olegiv/logwatch-ai-go/internal/logging"
+	"github.com/olegiv/logwatch-ai-go/internal/sourcecmd"
+)
+
+// readSourceCommand runs SOURCE_COMMAND and feeds its stdout to the reader
+// of the log source, replacing the export file and its staleness check.
+func readSourceCommand(
+	ctx context.Context,
+	cfg *config.Config,
+	logSource *analyzer.LogSource,
+	log *logging.SecureLogger,
+) (string, error) {
+	contentReader, ok := logSource.Reader.(analyzer.ContentReader)
+	if !ok {
+		return "", fmt.Errorf("log source %s does not support source commands", logSource.Type)
+	}
+
+	command, err := cfg.SourceCommandSpec()
+	if err != nil {
+		return "", err
+	}
+
+	log.Info().
+		Str("command", command.String()).
+		Str("dir", command.Dir).
+		Str("user", command.User).
+		Str("type", cfg.LogSourceType).
+		Msg("Running source command...")
+
+	result, err := sourcecmd.Run(ctx, *command, int64(cfg.MaxLogSizeMB)*1024*1024)
+	if err != nil {
+		return "", fmt.Errorf("failed to run source command: %w", err)
+	}
+
+	sourceInfo := result.SourceInfo()
+	log.Info().
+		Float64("size_mb", sourceInfo["size_mb"].(float64)).
+		Float64("duration_seconds", sourceInfo["duration_seconds"].(float64)).
+		Msg("Source command completed")
+
+	logContent, err := contentReader.ReadContent(result.Output)
+	if err != nil {
+		return "", fmt.Errorf("failed to read log content: %w", err)
+	}
+	return logContent, nil
+}
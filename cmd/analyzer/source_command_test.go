@@ -0,0 +1,72 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/olegiv/go-logger"
+	"github.com/olegiv/logwatch-ai-go/internal/config"
+	"github.com/olegiv/logwatch-ai-go/internal/logging"
+	"github.com/olegiv/logwatch-ai-go/internal/sourcecmd"
+)
+
+func TestReadSourceCommand(t *testing.T) {
+	dir := t.TempDir()
+	log := logging.NewSecure(logger.New(logger.Config{Level: "error", LogDir: dir, Filename: "source.log", Console: false}))
+	defer func() { _ = log.Close() }()
+
+	report := "################### Logwatch ###################\n" + strings.Repeat("sshd: Failed password for root\n", 10)
+	newConfig := func(command string) *config.Config {
+		return &config.Config{
+			LogSourceType:               "logwatch",
+			MaxLogSizeMB:                1,
+			MaxPreprocessingTokens:      1000,
+			SourceCommand:               command,
+			SourceCommandTimeoutSeconds: 10,
+			SourceCommandEnv:            strings.Join(sourcecmd.DefaultEnv, ","),
+		}
+	}
+
+	t.Run("stdout is read", func(t *testing.T) {
+		cfg := newConfig("printf '%s' '" + report + "'")
+		logSource, err := createLogSource(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := readSourceCommand(context.Background(), cfg, logSource, log)
+		if err != nil {
+			t.Fatalf("readSourceCommand() error = %v", err)
+		}
+		if content != report {
+			t.Errorf("content = %q, want %q", content, report)
+		}
+	})
+
+	t.Run("content is validated", func(t *testing.T) {
+		cfg := newConfig("echo short")
+		logSource, err := createLogSource(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = readSourceCommand(context.Background(), cfg, logSource, log)
+		if err == nil || !strings.Contains(err.Error(), "failed to read log content") {
+			t.Errorf("readSourceCommand() error = %v", err)
+		}
+	})
+
+	t.Run("command failure", func(t *testing.T) {
+		cfg := newConfig("sh -c 'echo broken >&2; exit 3'")
+		logSource, err := createLogSource(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = readSourceCommand(context.Background(), cfg, logSource, log)
+		if err == nil || !strings.Contains(err.Error(), "failed to run source command") || !strings.Contains(err.Error(), "broken") {
+			t.Errorf("readSourceCommand() error = %v", err)
+		}
+	})
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/olegiv/logwatch-ai-go/internal/config"
+	"github.com/olegiv/logwatch-ai-go/internal/logging"
+	"github.com/olegiv/logwatch-ai-go/internal/syslogd"
+)
+
+// runSyslogReceiver receives syslog messages from other hosts until the
+// run is interrupted. Every interval, the messages spooled for each host
+// are analyzed like a syslog file of that host, with its own database row
+// and Telegram report. Spooled messages survive a restart and are analyzed
+// at the next interval.
+func runSyslogReceiver(ctx context.Context, cfg *config.Config, clients *runClients, log *logging.SecureLogger) error {
+	receiverConfig, err := cfg.SyslogReceiverConfig()
+	if err != nil {
+		return err
+	}
+	spool, err := syslogd.OpenSpool(cfg.SyslogReceiverSpoolDir, cfg.SyslogSpoolMaxBytes(), cfg.SyslogReceiverMaxHosts)
+	if err != nil {
+		return fmt.Errorf("failed to open syslog spool: %w", err)
+	}
+	defer func() {
+		if err := spool.Close(); err != nil {
+			log.Warn().Err(err).Msg("Failed to close syslog spool")
+		}
+	}()
+
+	receiver, err := syslogd.NewReceiver(*receiverConfig, spool)
+	if err != nil {
+		return fmt.Errorf("failed to create syslog receiver: %w", err)
+	}
+	receiverCtx, stop := context.WithCancel(ctx)
+	defer stop()
+	if err := receiver.Start(receiverCtx); err != nil {
+		return fmt.Errorf("failed to start syslog receiver: %w", err)
+	}
+
+	logEvent := log.Info().
+		Str("spool", spool.Dir()).
+		Int("interval_minutes", cfg.SyslogReceiverIntervalMinutes).
+		Bool("tls", receiverConfig.HasTLS())
+	if addr := receiver.UDPAddr(); addr != nil {
+		logEvent = logEvent.Str("udp", addr.String())
+	}
+	if addr := receiver.TCPAddr(); addr != nil {
+		logEvent = logEvent.Str("tcp", addr.String())
+	}
+	logEvent.Msg("Syslog receiver listening")
+
+	ticker := time.NewTicker(cfg.SyslogReceiverInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			stop()
+			receiver.Wait()
+			stats := receiver.Stats()
+			log.Info().
+				Int64("received", stats.Received).
+				Int64("dropped", stats.Dropped).
+				Int64("denied", stats.Denied).
+				Msg("Syslog receiver stopped, spooled messages are analyzed after the next start")
+			return nil
+		case <-ticker.C:
+			analyzeSyslogWindows(ctx, cfg, spool, clients, log)
+		}
+	}
+}
+
+// analyzeSyslogWindows rotates the spool and analyzes the window of each
+// host. A failed host does not stop the other hosts; its window is
+// discarded unless the run was interrupted, so a window that cannot be
+// analyzed is not retried forever. An LLM client that was unavailable is
+// created again first, so the receiver does not keep sending degraded
+// reports after the provider is back.
+func analyzeSyslogWindows(ctx context.Context, cfg *config.Config, spool *syslogd.Spool, clients *runClients, log *logging.SecureLogger) {
+	clients.retryLLM(ctx, cfg, log)
+
+	windows, rejected, err := spool.Rotate()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to rotate syslog spool")
+	}
+	if rejected > 0 {
+		log.Warn().
+			Int("messages", rejected).
+			Int("max_hosts", cfg.SyslogReceiverMaxHosts).
+			Msg("Dropped syslog messages of hosts beyond SYSLOG_RECEIVER_MAX_HOSTS")
+	}
+	if len(windows) == 0 {
+		log.Info().Msg("No syslog messages received in this interval")
+		return
+	}
+
+	log.Info().Int("hosts", len(windows)).Msg("Analyzing syslog receiver hosts...")
+	defer clients.telegram.SetHostname("")
+	for _, window := range windows {
+		if ctx.Err() != nil {
+			return
+		}
+		if window.Dropped > 0 {
+			log.Warn().
+				Str("host", window.Host).
+				Int("messages", window.Dropped).
+				Int("max_log_size_mb", cfg.MaxLogSizeMB).
+				Msg("Dropped syslog messages beyond MAX_LOG_SIZE_MB")
+		}
+
+		clients.telegram.SetHostname(window.Host)
+		if _, err := analyzeSource(ctx, cfg.ForSyslogHost(window.Host, window.Path), clients, log); err != nil {
+			log.Error().Err(err).Str("host", window.Host).Msg("Syslog host analysis failed")
+			if ctx.Err() != nil {
+				return
+			}
+		}
+		if err := spool.Remove(window); err != nil {
+			log.Warn().Err(err).Str("host", window.Host).Msg("Failed to remove syslog window")
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/olegiv/go-logger"
+	"github.com/olegiv/logwatch-ai-go/internal/ai"
+	"github.com/olegiv/logwatch-ai-go/internal/config"
+	"github.com/olegiv/logwatch-ai-go/internal/logging"
+	"github.com/olegiv/logwatch-ai-go/internal/syslogd"
+)
+
+func TestAnalyzeSyslogWindows_RetriesLLM(t *testing.T) {
+	dir := t.TempDir()
+	log := logging.NewSecure(logger.New(logger.Config{Level: "error", LogDir: dir, Filename: "receiver.log", Console: false}))
+	cfg := &config.Config{SyslogReceiverMaxHosts: 10}
+
+	spool, err := syslogd.OpenSpool(t.TempDir(), 1<<20, cfg.SyslogReceiverMaxHosts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = spool.Close() }()
+
+	mock, err := ai.NewMockClient(ai.MockConfig{Dir: t.TempDir(), SourceType: "syslog"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The provider is unreachable at startup and back by the next interval
+	calls := 0
+	clients := &runClients{
+		newLLM: func(context.Context, *config.Config, *logging.SecureLogger) (ai.Provider, error) {
+			calls++
+			if calls == 1 {
+				return nil, errors.New("connection refused")
+			}
+			return mock, nil
+		},
+	}
+
+	clients.connectLLM(t.Context(), cfg, log)
+	if clients.llm != nil || clients.llmErr == nil {
+		t.Fatalf("connectLLM() llm = %v, llmErr = %v, want the startup failure", clients.llm, clients.llmErr)
+	}
+
+	analyzeSyslogWindows(t.Context(), cfg, spool, clients, log)
+	if clients.llm != mock || clients.llmErr != nil {
+		t.Errorf("after an interval llm = %v, llmErr = %v, want the recovered client", clients.llm, clients.llmErr)
+	}
+
+	// A working client is kept, not created again every interval
+	analyzeSyslogWindows(t.Context(), cfg, spool, clients, log)
+	if calls != 2 {
+		t.Errorf("LLM client created %d times, want 2", calls)
+	}
+}
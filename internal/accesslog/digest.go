@@ -0,0 +1,495 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package accesslog
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+)
+
+// timeFormatDateTime is the date-time format used in the digest.
+const timeFormatDateTime = "2006-01-02 15:04:05"
+
+// timeFormatMinute is the format of request rate buckets.
+const timeFormatMinute = "2006-01-02 15:04"
+
+// Limits of the digest sections.
+const (
+	maxErrorPaths  = 25
+	maxSlowPaths   = 20
+	maxTopPaths    = 15
+	maxClients     = 20
+	maxScanners    = 20
+	maxPeakMinutes = 10
+	maxPathLen     = 120
+)
+
+// emptyUserAgent labels requests sent without a User-Agent header.
+const emptyUserAgent = "(empty user agent)"
+
+// scannerSignatures maps lowercase User-Agent substrings of vulnerability
+// scanners, fuzzers, and internet-wide scanning projects to display names.
+var scannerSignatures = []struct {
+	signature string
+	name      string
+}{
+	{"sqlmap", "sqlmap"},
+	{"nikto", "Nikto"},
+	{"nmap", "Nmap"},
+	{"masscan", "masscan"},
+	{"zgrab", "ZGrab"},
+	{"nuclei", "Nuclei"},
+	{"wpscan", "WPScan"},
+	{"dirbuster", "DirBuster"},
+	{"gobuster", "gobuster"},
+	{"feroxbuster", "feroxbuster"},
+	{"ffuf", "ffuf"},
+	{"wfuzz", "Wfuzz"},
+	{"acunetix", "Acunetix"},
+	{"nessus", "Nessus"},
+	{"openvas", "OpenVAS"},
+	{"qualys", "Qualys"},
+	{"netsparker", "Netsparker"},
+	{"w3af", "w3af"},
+	{"whatweb", "WhatWeb"},
+	{"jorgee", "Jorgee"},
+	{"censysinspect", "Censys"},
+	{"expanse", "Expanse"},
+	{"l9explore", "LeakIX"},
+	{"internetmeasurement", "InternetMeasurement"},
+}
+
+// detectScanner returns the scanner name for a User-Agent, emptyUserAgent
+// for a missing one, or "" for regular clients.
+func detectScanner(userAgent string) string {
+	if userAgent == "" {
+		return emptyUserAgent
+	}
+	ua := strings.ToLower(userAgent)
+	for _, s := range scannerSignatures {
+		if strings.Contains(ua, s.signature) {
+			return s.name
+		}
+	}
+	return ""
+}
+
+type clientStats struct {
+	requests     int
+	clientErrors int
+	serverErrors int
+	scanner      string
+}
+
+type errorPathStats struct {
+	status  int
+	method  string
+	path    string
+	count   int
+	clients map[string]struct{}
+}
+
+type scannerStats struct {
+	requests int
+	clients  map[string]struct{}
+}
+
+type slowPathStats struct {
+	count int
+	max   time.Duration
+	total time.Duration
+}
+
+// digest aggregates parsed requests into the traffic statistics sent to
+// the LLM instead of the raw lines.
+type digest struct {
+	slowThreshold time.Duration
+
+	requests int
+	unparsed int
+	bytes    int64
+	first    time.Time
+	last     time.Time
+
+	status     map[int]int
+	clients    map[string]*clientStats
+	errorPaths map[string]*errorPathStats // keyed by "status method path"
+	paths      map[string]int             // keyed by "method path"
+	minutes    map[time.Time]int
+	scanners   map[string]*scannerStats
+	slowPaths  map[string]*slowPathStats // keyed by "method path"
+	timed      int
+	slow       int
+}
+
+func newDigest(slowThreshold time.Duration) *digest {
+	return &digest{
+		slowThreshold: slowThreshold,
+		status:        make(map[int]int),
+		clients:       make(map[string]*clientStats),
+		errorPaths:    make(map[string]*errorPathStats),
+		paths:         make(map[string]int),
+		minutes:       make(map[time.Time]int),
+		scanners:      make(map[string]*scannerStats),
+		slowPaths:     make(map[string]*slowPathStats),
+	}
+}
+
+// add records a parsed request.
+func (d *digest) add(req Request) {
+	d.requests++
+	d.bytes += req.Bytes
+	d.status[req.Status]++
+
+	if !req.Time.IsZero() {
+		if d.first.IsZero() || req.Time.Before(d.first) {
+			d.first = req.Time
+		}
+		if req.Time.After(d.last) {
+			d.last = req.Time
+		}
+		d.minutes[req.Time.Truncate(time.Minute)]++
+	}
+
+	client := req.Client
+	if client == "" {
+		client = "unknown"
+	}
+	cs := d.clients[client]
+	if cs == nil {
+		cs = &clientStats{}
+		d.clients[client] = cs
+	}
+	cs.requests++
+
+	path := truncatePath(req.Path)
+	request := strings.TrimSpace(req.Method + " " + path)
+	d.paths[request]++
+
+	if req.Status >= 400 && req.Status <= 599 {
+		if req.Status >= 500 {
+			cs.serverErrors++
+		} else {
+			cs.clientErrors++
+		}
+		key := strconv.Itoa(req.Status) + " " + request
+		ps := d.errorPaths[key]
+		if ps == nil {
+			ps = &errorPathStats{status: req.Status, method: req.Method, path: path, clients: make(map[string]struct{})}
+			d.errorPaths[key] = ps
+		}
+		ps.count++
+		ps.clients[client] = struct{}{}
+	}
+
+	if scanner := detectScanner(req.UserAgent); scanner != "" {
+		ss := d.scanners[scanner]
+		if ss == nil {
+			ss = &scannerStats{clients: make(map[string]struct{})}
+			d.scanners[scanner] = ss
+		}
+		ss.requests++
+		ss.clients[client] = struct{}{}
+		if cs.scanner == "" || cs.scanner == emptyUserAgent {
+			cs.scanner = scanner
+		}
+	}
+
+	if req.HasDuration {
+		d.timed++
+		if req.Duration >= d.slowThreshold {
+			d.slow++
+			sp := d.slowPaths[request]
+			if sp == nil {
+				sp = &slowPathStats{}
+				d.slowPaths[request] = sp
+			}
+			sp.count++
+			sp.total += req.Duration
+			if req.Duration > sp.max {
+				sp.max = req.Duration
+			}
+		}
+	}
+}
+
+// statusClassCount returns the number of requests with status class
+// (2 for 2xx, 4 for 4xx, ...).
+func (d *digest) statusClassCount(class int) int {
+	total := 0
+	for status, count := range d.status {
+		if status/100 == class {
+			total += count
+		}
+	}
+	return total
+}
+
+// scannerRequests returns the number of requests from known scanners,
+// excluding requests that only lack a User-Agent.
+func (d *digest) scannerRequests() int {
+	total := 0
+	for name, s := range d.scanners {
+		if name != emptyUserAgent {
+			total += s.requests
+		}
+	}
+	return total
+}
+
+// averagePerMinute returns the average request rate over the logged time
+// span, counting minutes without requests.
+func (d *digest) averagePerMinute() float64 {
+	if d.first.IsZero() {
+		return 0
+	}
+	minutes := d.last.Truncate(time.Minute).Sub(d.first.Truncate(time.Minute)).Minutes() + 1
+	timedRequests := 0
+	for _, count := range d.minutes {
+		timedRequests += count
+	}
+	return float64(timedRequests) / minutes
+}
+
+// format renders the digest as the text sent to the LLM.
+func (d *digest) format() string {
+	var sb strings.Builder
+
+	sb.WriteString("=== ACCESS LOG TRAFFIC DIGEST ===\n\n")
+
+	d.writeSummary(&sb)
+	d.writeStatusCodes(&sb)
+	d.writeErrorPaths(&sb, "Server Errors (5xx) by Path", 5)
+	d.writeErrorPaths(&sb, "Client Errors (4xx) by Path", 4)
+	d.writeClients(&sb)
+	d.writeScanners(&sb)
+	d.writeRatePeaks(&sb)
+	d.writeSlowRequests(&sb)
+	d.writeTopPaths(&sb)
+
+	return sb.String()
+}
+
+func (d *digest) writeSummary(sb *strings.Builder) {
+	sb.WriteString("## Summary Statistics\n")
+	fmt.Fprintf(sb, "Requests: %d\n", d.requests)
+	if d.unparsed > 0 {
+		fmt.Fprintf(sb, "Unparsed lines: %d\n", d.unparsed)
+	}
+	if !d.first.IsZero() {
+		fmt.Fprintf(sb, "Time range: %s to %s\n", d.first.Format(timeFormatDateTime), d.last.Format(timeFormatDateTime))
+	}
+	fmt.Fprintf(sb, "Unique clients: %d\n", len(d.clients))
+	fmt.Fprintf(sb, "Data sent: %s\n", formatBytes(d.bytes))
+	if peak, count := d.peakMinute(); count > 0 {
+		fmt.Fprintf(sb, "Request rate: %.1f/min average, peak %d/min at %s\n",
+			d.averagePerMinute(), count, peak.Format(timeFormatMinute))
+	}
+	fmt.Fprintf(sb, "Client errors (4xx): %d (%s)\n", d.statusClassCount(4), d.percent(d.statusClassCount(4)))
+	fmt.Fprintf(sb, "Server errors (5xx): %d (%s)\n", d.statusClassCount(5), d.percent(d.statusClassCount(5)))
+	fmt.Fprintf(sb, "Scanner requests: %d\n", d.scannerRequests())
+	if d.timed > 0 {
+		fmt.Fprintf(sb, "Slow requests (>= %s): %d of %d timed\n", d.slowThreshold, d.slow, d.timed)
+	}
+	sb.WriteString("\n")
+}
+
+func (d *digest) writeStatusCodes(sb *strings.Builder) {
+	codes := make([]int, 0, len(d.status))
+	for status := range d.status {
+		codes = append(codes, status)
+	}
+	sort.Ints(codes)
+
+	sb.WriteString("## Status Codes\n")
+	for _, status := range codes {
+		fmt.Fprintf(sb, "- %d: %d (%s)\n", status, d.status[status], d.percent(d.status[status]))
+	}
+	sb.WriteString("\n")
+}
+
+func (d *digest) writeErrorPaths(sb *strings.Builder, title string, class int) {
+	var paths []*errorPathStats
+	for _, ps := range d.errorPaths {
+		if ps.status/100 == class {
+			paths = append(paths, ps)
+		}
+	}
+	if len(paths) == 0 {
+		return
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if paths[i].count != paths[j].count {
+			return paths[i].count > paths[j].count
+		}
+		if paths[i].path != paths[j].path {
+			return paths[i].path < paths[j].path
+		}
+		return paths[i].status < paths[j].status
+	})
+
+	fmt.Fprintf(sb, "## %s\n", title)
+	for i, ps := range paths {
+		if i >= maxErrorPaths {
+			fmt.Fprintf(sb, "[... %d more paths ...]\n", len(paths)-maxErrorPaths)
+			break
+		}
+		request := strings.TrimSpace(ps.method + " " + ps.path)
+		fmt.Fprintf(sb, "- [%dx] %d %s (%s)\n", ps.count, ps.status, request, pluralize(len(ps.clients), "client"))
+	}
+	sb.WriteString("\n")
+}
+
+func (d *digest) writeClients(sb *strings.Builder) {
+	counts := make(map[string]int, len(d.clients))
+	for client, cs := range d.clients {
+		counts[client] = cs.requests
+	}
+
+	sb.WriteString("## Top Clients\n")
+	for _, item := range analyzer.TopCounts(counts, maxClients) {
+		cs := d.clients[item.Name]
+		fmt.Fprintf(sb, "- %s: %s (4xx: %d, 5xx: %d)", item.Name, pluralize(cs.requests, "request"), cs.clientErrors, cs.serverErrors)
+		switch cs.scanner {
+		case "":
+		case emptyUserAgent:
+			sb.WriteString(" [no user agent]")
+		default:
+			fmt.Fprintf(sb, " [scanner: %s]", cs.scanner)
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+}
+
+func (d *digest) writeScanners(sb *strings.Builder) {
+	if len(d.scanners) == 0 {
+		return
+	}
+	counts := make(map[string]int, len(d.scanners))
+	for name, ss := range d.scanners {
+		counts[name] = ss.requests
+	}
+
+	sb.WriteString("## Scanner User Agents\n")
+	for _, item := range analyzer.TopCounts(counts, maxScanners) {
+		fmt.Fprintf(sb, "- %s: %s from %s\n", item.Name, pluralize(item.Count, "request"), pluralize(len(d.scanners[item.Name].clients), "client"))
+	}
+	sb.WriteString("\n")
+}
+
+func (d *digest) writeRatePeaks(sb *strings.Builder) {
+	if len(d.minutes) == 0 {
+		return
+	}
+	minutes := make([]time.Time, 0, len(d.minutes))
+	for minute := range d.minutes {
+		minutes = append(minutes, minute)
+	}
+	sort.Slice(minutes, func(i, j int) bool {
+		if d.minutes[minutes[i]] != d.minutes[minutes[j]] {
+			return d.minutes[minutes[i]] > d.minutes[minutes[j]]
+		}
+		return minutes[i].Before(minutes[j])
+	})
+	if len(minutes) > maxPeakMinutes {
+		minutes = minutes[:maxPeakMinutes]
+	}
+
+	average := d.averagePerMinute()
+	sb.WriteString("## Request Rate Peaks\n")
+	for _, minute := range minutes {
+		count := d.minutes[minute]
+		fmt.Fprintf(sb, "- %s: %d requests", minute.Format(timeFormatMinute), count)
+		if average > 0 {
+			fmt.Fprintf(sb, " (%.1fx average)", float64(count)/average)
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+}
+
+func (d *digest) writeSlowRequests(sb *strings.Builder) {
+	if len(d.slowPaths) == 0 {
+		return
+	}
+	counts := make(map[string]int, len(d.slowPaths))
+	for request, sp := range d.slowPaths {
+		counts[request] = sp.count
+	}
+
+	fmt.Fprintf(sb, "## Slow Requests (>= %s)\n", d.slowThreshold)
+	for _, item := range analyzer.TopCounts(counts, maxSlowPaths) {
+		sp := d.slowPaths[item.Name]
+		average := sp.total / time.Duration(sp.count)
+		fmt.Fprintf(sb, "- [%dx, max %s, avg %s] %s\n", sp.count, roundDuration(sp.max), roundDuration(average), item.Name)
+	}
+	sb.WriteString("\n")
+}
+
+func (d *digest) writeTopPaths(sb *strings.Builder) {
+	sb.WriteString("## Top Requested Paths\n")
+	for _, item := range analyzer.TopCounts(d.paths, maxTopPaths) {
+		fmt.Fprintf(sb, "- [%dx] %s\n", item.Count, item.Name)
+	}
+	sb.WriteString("\n")
+}
+
+// peakMinute returns the busiest minute and its request count.
+func (d *digest) peakMinute() (time.Time, int) {
+	var peak time.Time
+	best := 0
+	for minute, count := range d.minutes {
+		if count > best || (count == best && minute.Before(peak)) {
+			peak, best = minute, count
+		}
+	}
+	return peak, best
+}
+
+func (d *digest) percent(count int) string {
+	if d.requests == 0 {
+		return "0.0%"
+	}
+	return fmt.Sprintf("%.1f%%", float64(count)*100/float64(d.requests))
+}
+
+func truncatePath(path string) string {
+	if len(path) <= maxPathLen {
+		return path
+	}
+	return path[:maxPathLen] + "..."
+}
+
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+func roundDuration(d time.Duration) time.Duration {
+	if d >= time.Second {
+		return d.Round(100 * time.Millisecond)
+	}
+	return d.Round(time.Millisecond)
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package accesslog
+
+import (
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+)
+
+// Compile-time interface check
+var (
+	_ analyzer.Preprocessor       = (*Preprocessor)(nil)
+	_ analyzer.BudgetPreprocessor = (*Preprocessor)(nil)
+)
+
+// sectionPriority returns the priority of a digest section. Unknown
+// sections (rate peaks, slow requests, top paths) are low priority.
+func sectionPriority(name string) int {
+	switch name {
+	case "Summary Statistics", "Status Codes", "Server Errors (5xx) by Path":
+		return analyzer.SectionPriorityHigh
+	case "Client Errors (4xx) by Path", "Top Clients", "Scanner User Agents":
+		return analyzer.SectionPriorityMedium
+	default:
+		return analyzer.SectionPriorityLow
+	}
+}
+
+// Preprocessor handles digest preprocessing for small token budgets. The
+// digest is bounded by the per-section limits, so it only needs shortening
+// when the budget is far below the default. It keeps the statistics,
+// status codes, and server errors and shortens the client, scanner, rate,
+// and path lists first.
+type Preprocessor struct {
+	*analyzer.SectionPreprocessor
+}
+
+// NewPreprocessor creates a new access log preprocessor.
+func NewPreprocessor(maxTokens int) *Preprocessor {
+	return &Preprocessor{analyzer.NewSectionPreprocessor(maxTokens, sectionPriority)}
+}
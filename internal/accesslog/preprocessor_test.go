@@ -0,0 +1,29 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package accesslog
+
+import (
+	"testing"
+
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+)
+
+func TestSectionPriority(t *testing.T) {
+	tests := map[string]int{
+		"Summary Statistics":          analyzer.SectionPriorityHigh,
+		"Status Codes":                analyzer.SectionPriorityHigh,
+		"Server Errors (5xx) by Path": analyzer.SectionPriorityHigh,
+		"Client Errors (4xx) by Path": analyzer.SectionPriorityMedium,
+		"Top Clients":                 analyzer.SectionPriorityMedium,
+		"Scanner User Agents":         analyzer.SectionPriorityMedium,
+		"Request Rate Peaks":          analyzer.SectionPriorityLow,
+		"Slow Requests (>= 1s)":       analyzer.SectionPriorityLow,
+		"Top Requested Paths":         analyzer.SectionPriorityLow,
+	}
+	for name, want := range tests {
+		if got := sectionPriority(name); got != want {
+			t.Errorf("sectionPriority(%q) = %d, want %d", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package accesslog
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPromptBuilder_SiteName(t *testing.T) {
+	pb := NewPromptBuilder()
+	pb.SetSiteName("Shop Frontend")
+	if pb.GetSiteName() != "Shop Frontend" {
+		t.Errorf("GetSiteName() = %q", pb.GetSiteName())
+	}
+
+	if prompt := pb.GetUserPrompt("## Status Codes\n- 502: 12", "", nil); !strings.HasPrefix(prompt, "WEB SITE: Shop Frontend\n") {
+		t.Errorf("user prompt does not start with the site name:\n%s", prompt)
+	}
+	if strings.Contains(NewPromptBuilder().GetUserPrompt("content", "", nil), "WEB SITE") {
+		t.Error("user prompt should omit an empty site name")
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package accesslog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+)
+
+// NoEntriesContent is returned when the access log contains no requests.
+// This is a valid state for low-traffic sites on a rotated log.
+// Use IsNoEntriesContent() to check for this condition.
+const NoEntriesContent = "=== NO ACCESS LOG REQUESTS ===\n\nNo requests were found in the access log for the analyzed time period.\nThis typically means the site received no traffic or the log was rotated empty."
+
+// DefaultSlowThreshold is the request duration from which a request is
+// reported as slow when no threshold is configured.
+const DefaultSlowThreshold = time.Second
+
+// maxStatsItems limits the breakdowns of ReadStats.
+const maxStatsItems = 10
+
+// IsNoEntriesContent checks if the content indicates no requests were found.
+func IsNoEntriesContent(content string) bool {
+	return strings.HasPrefix(content, "=== NO ACCESS LOG REQUESTS ===")
+}
+
+// Compile-time interface checks
+var (
+	_ analyzer.LogReader     = (*Reader)(nil)
+	_ analyzer.StatsReporter = (*Reader)(nil)
+	_ analyzer.ContentReader = (*Reader)(nil)
+)
+
+// Reader handles reading nginx and Apache access logs.
+// Implements analyzer.LogReader interface.
+type Reader struct {
+	maxSizeMB           int
+	enablePreprocessing bool
+	maxTokens           int
+	format              *Format
+	slowThreshold       time.Duration
+	preprocessor        *Preprocessor
+	digest              *digest
+}
+
+// NewReader creates a new access log reader. A nil format parses the
+// combined log format; a non-positive slowThreshold uses
+// DefaultSlowThreshold.
+func NewReader(maxSizeMB int, enablePreprocessing bool, maxTokens int, format *Format, slowThreshold time.Duration) *Reader {
+	if format == nil {
+		format, _ = ParseFormat(FormatCombined)
+	}
+	if slowThreshold <= 0 {
+		slowThreshold = DefaultSlowThreshold
+	}
+	return &Reader{
+		maxSizeMB:           maxSizeMB,
+		enablePreprocessing: enablePreprocessing,
+		maxTokens:           maxTokens,
+		format:              format,
+		slowThreshold:       slowThreshold,
+		preprocessor:        NewPreprocessor(maxTokens),
+	}
+}
+
+// Read implements analyzer.LogReader.Read.
+// Parses the access log and returns a statistical traffic digest instead
+// of the raw lines.
+func (r *Reader) Read(sourcePath string) (string, error) {
+	r.digest = nil
+
+	content, err := analyzer.ReadSourceFileWithGuards(
+		sourcePath,
+		analyzer.FileReadOptions{
+			SourceLabel: "access log",
+			MaxSizeMB:   r.maxSizeMB,
+			MaxAge:      24 * time.Hour,
+		},
+		func(string) error { return nil }, // an empty log is a valid no-traffic period
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return r.ReadContent(content)
+}
+
+// ReadContent implements analyzer.ContentReader.
+// Digests access log lines that were not read from a file.
+func (r *Reader) ReadContent(content string) (string, error) {
+	r.digest = nil
+
+	if strings.TrimSpace(content) == "" {
+		r.digest = newDigest(r.slowThreshold)
+		return NoEntriesContent, nil
+	}
+
+	d, err := r.parse(content)
+	if err != nil {
+		return "", err
+	}
+	r.digest = d
+
+	formattedContent := d.format()
+
+	if err := r.Validate(formattedContent); err != nil {
+		return "", fmt.Errorf("access log content validation failed: %w", err)
+	}
+
+	if r.enablePreprocessing && r.preprocessor.ShouldProcess(formattedContent, r.maxTokens) {
+		processedContent, err := r.preprocessor.Process(formattedContent)
+		if err != nil {
+			return "", fmt.Errorf("preprocessing failed: %w", err)
+		}
+		return processedContent, nil
+	}
+
+	return formattedContent, nil
+}
+
+// parse aggregates the log lines. A log in which no line matches the
+// format is an error: the format is most likely misconfigured.
+func (r *Reader) parse(content string) (*digest, error) {
+	d := newDigest(r.slowThreshold)
+	for line := range strings.Lines(content) {
+		line = strings.TrimRight(line, "\r\n")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		req, ok := r.format.ParseLine(line)
+		if !ok {
+			d.unparsed++
+			continue
+		}
+		d.add(req)
+	}
+
+	if d.requests == 0 {
+		return nil, fmt.Errorf("no access log lines match the %q format (%d lines skipped)", r.format, d.unparsed)
+	}
+	return d, nil
+}
+
+// ReadStats implements analyzer.StatsReporter.
+// Summarizes the requests of the last Read by status code, client, error
+// path, and scanner.
+func (r *Reader) ReadStats() *analyzer.ReadStats {
+	d := r.digest
+	if d == nil {
+		return nil
+	}
+
+	stats := &analyzer.ReadStats{
+		Totals: []analyzer.StatsItem{
+			{Name: "Requests", Count: d.requests},
+			{Name: "Client errors (4xx)", Count: d.statusClassCount(4)},
+			{Name: "Server errors (5xx)", Count: d.statusClassCount(5)},
+			{Name: "Unique clients", Count: len(d.clients)},
+			{Name: "Scanner requests", Count: d.scannerRequests()},
+		},
+	}
+	if d.timed > 0 {
+		stats.Totals = append(stats.Totals, analyzer.StatsItem{Name: "Slow requests", Count: d.slow})
+	}
+
+	statusCounts := make(map[string]int, len(d.status))
+	for status, count := range d.status {
+		statusCounts[strconv.Itoa(status)] = count
+	}
+	stats.AddBreakdown("Status codes", analyzer.TopCounts(statusCounts, maxStatsItems))
+
+	clientCounts := make(map[string]int, len(d.clients))
+	for client, cs := range d.clients {
+		clientCounts[client] = cs.requests
+	}
+	stats.AddBreakdown("Top clients", analyzer.TopCounts(clientCounts, maxStatsItems))
+
+	errorPathCounts := make(map[string]int, len(d.errorPaths))
+	for key, ps := range d.errorPaths {
+		errorPathCounts[key] = ps.count
+	}
+	stats.AddBreakdown("Top error paths", analyzer.TopCounts(errorPathCounts, maxStatsItems))
+
+	scannerCounts := make(map[string]int, len(d.scanners))
+	for name, ss := range d.scanners {
+		scannerCounts[name] = ss.requests
+	}
+	stats.AddBreakdown("Scanner user agents", analyzer.TopCounts(scannerCounts, maxStatsItems))
+
+	return stats
+}
+
+// Validate implements analyzer.LogReader.Validate.
+// Performs basic validation on the formatted digest.
+func (r *Reader) Validate(content string) error {
+	if len(content) == 0 {
+		return fmt.Errorf("access log content is empty")
+	}
+
+	// NoEntriesContent is a valid state - no traffic for the time period
+	if IsNoEntriesContent(content) {
+		return nil
+	}
+
+	if len(content) < 50 {
+		return fmt.Errorf("access log content seems too small to be valid (only %d bytes)", len(content))
+	}
+
+	return nil
+}
+
+// GetSourceInfo implements analyzer.LogReader.GetSourceInfo.
+// Returns metadata about the access log file.
+func (r *Reader) GetSourceInfo(sourcePath string) (map[string]any, error) {
+	return analyzer.GetSourceFileInfo(sourcePath)
+}
@@ -0,0 +1,225 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package accesslog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// accessLine builds one combined-format line with a trailing request time.
+func accessLine(client string, minute, second int, request string, status int, userAgent string, requestTime float64) string {
+	return fmt.Sprintf(`%s - - [01/Jan/2026:02:%02d:%02d +0000] "%s" %d 512 "-" "%s" %.3f`,
+		client, minute, second, request, status, userAgent, requestTime)
+}
+
+func writeLog(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "access.log")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write temp file: %v", err)
+	}
+	return path
+}
+
+func sampleLog() string {
+	var lines []string
+	for i := range 6 {
+		lines = append(lines, accessLine("192.0.2.10", 0, i, "GET / HTTP/1.1", 200, "Mozilla/5.0", 0.05))
+	}
+	for i := range 4 {
+		lines = append(lines, accessLine("203.0.113.5", 1, i, "GET /.env HTTP/1.1", 404, "sqlmap/1.7", 0.01))
+	}
+	lines = append(lines,
+		accessLine("198.51.100.7", 1, 10, "POST /api/orders HTTP/1.1", 502, "Mozilla/5.0", 2.5),
+		accessLine("198.51.100.8", 2, 0, "POST /api/orders HTTP/1.1", 502, "Mozilla/5.0", 3.5),
+		accessLine("198.51.100.9", 2, 5, "GET /health HTTP/1.1", 200, "-", 0.001),
+		"this line does not match",
+	)
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func newTestReader(t *testing.T) *Reader {
+	t.Helper()
+	format, err := ParseFormat(combinedFormat + " $request_time")
+	if err != nil {
+		t.Fatalf("ParseFormat() error = %v", err)
+	}
+	return NewReader(10, false, 150000, format, 0)
+}
+
+func TestReader_Read(t *testing.T) {
+	r := newTestReader(t)
+
+	result, err := r.Read(writeLog(t, sampleLog()))
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"=== ACCESS LOG TRAFFIC DIGEST ===",
+		"Requests: 13",
+		"Unparsed lines: 1",
+		"Time range: 2026-01-01 02:00:00 to 2026-01-01 02:02:05",
+		"Unique clients: 5",
+		"Client errors (4xx): 4 (30.8%)",
+		"Server errors (5xx): 2 (15.4%)",
+		"Scanner requests: 4",
+		"Slow requests (>= 1s): 2 of 13 timed",
+		"Request rate: 4.3/min average, peak 6/min at 2026-01-01 02:00",
+		"- 502: 2 (15.4%)",
+		"- [2x] 502 POST /api/orders (2 clients)",
+		"- [4x] 404 GET /.env (1 client)",
+		"- 203.0.113.5: 4 requests (4xx: 4, 5xx: 0) [scanner: sqlmap]",
+		"- sqlmap: 4 requests from 1 client",
+		"- (empty user agent): 1 request from 1 client",
+		"- 198.51.100.9: 1 request (4xx: 0, 5xx: 0) [no user agent]",
+		"- [2x, max 3.5s, avg 3s] POST /api/orders",
+		"- [6x] GET /",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Read() result missing %q\n%s", want, result)
+		}
+	}
+
+	if strings.Contains(result, "Mozilla/5.0") {
+		t.Error("Read() result should not contain raw log lines")
+	}
+
+	// Sections are ordered by importance
+	if strings.Index(result, "## Server Errors") > strings.Index(result, "## Client Errors") {
+		t.Error("server errors should come before client errors")
+	}
+}
+
+func TestReader_Read_Empty(t *testing.T) {
+	r := newTestReader(t)
+
+	result, err := r.Read(writeLog(t, "\n"))
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if !IsNoEntriesContent(result) {
+		t.Errorf("Read() = %q, want NoEntriesContent", result)
+	}
+	if err := r.Validate(result); err != nil {
+		t.Errorf("Validate(NoEntriesContent) error = %v", err)
+	}
+}
+
+func TestReader_Read_FormatMismatch(t *testing.T) {
+	r := newTestReader(t)
+
+	_, err := r.Read(writeLog(t, "first garbage line\nsecond garbage line\n"))
+	if err == nil || !strings.Contains(err.Error(), "2 lines skipped") {
+		t.Errorf("Read() error = %v, want format mismatch error", err)
+	}
+}
+
+func TestReader_Read_Errors(t *testing.T) {
+	r := newTestReader(t)
+
+	if _, err := r.Read(filepath.Join(t.TempDir(), "missing.log")); err == nil || !strings.Contains(err.Error(), "access log file not found") {
+		t.Errorf("Read() error = %v, want not found error", err)
+	}
+
+	old := writeLog(t, sampleLog())
+	past := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(old, past, past); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+	if _, err := r.Read(old); err == nil || !strings.Contains(err.Error(), "too old") {
+		t.Errorf("Read() error = %v, want stale file error", err)
+	}
+}
+
+func TestReader_SlowThreshold(t *testing.T) {
+	format, _ := ParseFormat(combinedFormat + " $request_time")
+	r := NewReader(10, false, 150000, format, 3*time.Second)
+
+	result, err := r.Read(writeLog(t, sampleLog()))
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if !strings.Contains(result, "Slow requests (>= 3s): 1 of 13 timed") {
+		t.Errorf("Read() result missing custom slow threshold\n%s", result)
+	}
+
+	// Without request times there is no slow request section
+	r = NewReader(10, false, 150000, nil, 0)
+	result, err = r.Read(writeLog(t, sampleLog()))
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if strings.Contains(result, "Slow Requests") {
+		t.Error("Read() result should omit slow requests without request times")
+	}
+}
+
+func TestReader_ReadStats(t *testing.T) {
+	r := newTestReader(t)
+	if r.ReadStats() != nil {
+		t.Error("ReadStats() before Read should be nil")
+	}
+
+	if _, err := r.Read(writeLog(t, sampleLog())); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	stats := r.ReadStats()
+	if stats == nil {
+		t.Fatal("ReadStats() = nil")
+	}
+
+	totals := make(map[string]int)
+	for _, item := range stats.Totals {
+		totals[item.Name] = item.Count
+	}
+	want := map[string]int{
+		"Requests":            13,
+		"Client errors (4xx)": 4,
+		"Server errors (5xx)": 2,
+		"Unique clients":      5,
+		"Scanner requests":    4,
+		"Slow requests":       2,
+	}
+	for name, count := range want {
+		if totals[name] != count {
+			t.Errorf("Totals[%q] = %d, want %d", name, totals[name], count)
+		}
+	}
+
+	var titles []string
+	for _, b := range stats.Breakdowns {
+		titles = append(titles, b.Title)
+	}
+	if got := strings.Join(titles, ", "); got != "Status codes, Top clients, Top error paths, Scanner user agents" {
+		t.Errorf("Breakdowns = %s", got)
+	}
+	if top := stats.Breakdowns[2].Items[0]; top.Name != "404 GET /.env" || top.Count != 4 {
+		t.Errorf("top error path = %+v", top)
+	}
+}
+
+func TestDetectScanner(t *testing.T) {
+	tests := []struct {
+		userAgent string
+		want      string
+	}{
+		{"", emptyUserAgent},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64)", ""},
+		{"sqlmap/1.7.2#stable (https://sqlmap.org)", "sqlmap"},
+		{"Mozilla/5.0 (compatible; Nmap Scripting Engine; https://nmap.org/book/nse.html)", "Nmap"},
+		{"Mozilla/5.0 zgrab/0.x", "ZGrab"},
+	}
+	for _, tt := range tests {
+		if got := detectScanner(tt.userAgent); got != tt.want {
+			t.Errorf("detectScanner(%q) = %q, want %q", tt.userAgent, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,377 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package accesslog provides log analysis for nginx and Apache access logs.
+// It parses the combined and common log formats, or a custom nginx
+// log_format / Apache LogFormat string, aggregates the requests into a
+// compact traffic digest, and implements the analyzer interfaces to enable
+// access log analysis alongside other log sources like logwatch.
+package accesslog
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Named formats accepted in place of a format string.
+const (
+	FormatCombined = "combined"
+	FormatCommon   = "common"
+)
+
+// Format strings of the named formats, in nginx log_format syntax.
+const (
+	combinedFormat = `$remote_addr - $remote_user [$time_local] "$request" $status $body_bytes_sent "$http_referer" "$http_user_agent"`
+	commonFormat   = `$remote_addr - $remote_user [$time_local] "$request" $status $body_bytes_sent`
+)
+
+// timeLayoutCLF is the timestamp layout of $time_local and Apache %t.
+const timeLayoutCLF = "02/Jan/2006:15:04:05 -0700"
+
+// field identifies the request attribute a format variable maps to.
+type field int
+
+const (
+	fieldIgnored field = iota
+	fieldClient
+	fieldTimeCLF
+	fieldTimeISO8601
+	fieldRequest
+	fieldMethod
+	fieldPath
+	fieldStatus
+	fieldBytes
+	fieldReferer
+	fieldUserAgent
+	fieldHost
+	fieldRequestSeconds  // $request_time, %T, %{s}T
+	fieldUpstreamSeconds // $upstream_response_time, used without a request time
+	fieldRequestMillis   // %{ms}T
+	fieldRequestMicros   // %D, %{us}T
+)
+
+// nginxFields maps nginx log_format variables to request attributes.
+// Variables not listed here are matched but ignored.
+var nginxFields = map[string]field{
+	"remote_addr":            fieldClient,
+	"time_local":             fieldTimeCLF,
+	"time_iso8601":           fieldTimeISO8601,
+	"request":                fieldRequest,
+	"request_method":         fieldMethod,
+	"request_uri":            fieldPath,
+	"uri":                    fieldPath,
+	"status":                 fieldStatus,
+	"body_bytes_sent":        fieldBytes,
+	"bytes_sent":             fieldBytes,
+	"http_referer":           fieldReferer,
+	"http_user_agent":        fieldUserAgent,
+	"host":                   fieldHost,
+	"server_name":            fieldHost,
+	"request_time":           fieldRequestSeconds,
+	"upstream_response_time": fieldUpstreamSeconds,
+}
+
+// apacheFields maps Apache LogFormat directives (without the leading %
+// and the > / < modifiers) to request attributes. Header directives are
+// matched case-insensitively.
+var apacheFields = map[string]field{
+	"h":             fieldClient,
+	"a":             fieldClient,
+	"t":             fieldTimeCLF,
+	"r":             fieldRequest,
+	"m":             fieldMethod,
+	"U":             fieldPath,
+	"s":             fieldStatus,
+	"b":             fieldBytes,
+	"B":             fieldBytes,
+	"O":             fieldBytes,
+	"{referer}i":    fieldReferer,
+	"{user-agent}i": fieldUserAgent,
+	"v":             fieldHost,
+	"V":             fieldHost,
+	"T":             fieldRequestSeconds,
+	"{s}T":          fieldRequestSeconds,
+	"{ms}T":         fieldRequestMillis,
+	"D":             fieldRequestMicros,
+	"{us}T":         fieldRequestMicros,
+}
+
+// Variable syntax of the two format dialects.
+var (
+	nginxVarRegex     = regexp.MustCompile(`\$(\w+)`)
+	apacheDirectRegex = regexp.MustCompile(`%[<>]?(\{[^}]*\})?([a-zA-Z])`)
+)
+
+// Request is a single parsed access log line, reduced to the attributes
+// used for analysis.
+type Request struct {
+	Time        time.Time
+	Client      string
+	Method      string
+	Path        string // request path without the query string
+	Status      int
+	Bytes       int64
+	Referer     string
+	UserAgent   string
+	Host        string
+	Duration    time.Duration
+	HasDuration bool
+}
+
+// formatPart is a literal or a variable of a compiled format.
+type formatPart struct {
+	literal string
+	field   field
+	isVar   bool
+	apacheT bool // Apache %t, which renders its own brackets
+}
+
+// Format is a compiled access log format.
+type Format struct {
+	spec   string
+	regex  *regexp.Regexp
+	fields []field // field of each capture group
+}
+
+// ParseFormat compiles an access log format. The spec is "combined"
+// (default when empty), "common", or a custom format string using nginx
+// log_format variables ($remote_addr, $status, ...) or Apache LogFormat
+// directives (%h, %>s, ...). The format must capture at least the status.
+func ParseFormat(spec string) (*Format, error) {
+	format := strings.TrimSpace(spec)
+	switch strings.ToLower(format) {
+	case "", FormatCombined:
+		format = combinedFormat
+	case FormatCommon:
+		format = commonFormat
+	}
+
+	var parts []formatPart
+	switch {
+	case strings.Contains(format, "$"):
+		parts = splitFormat(format, nginxVarRegex, func(m []string) (field, bool) {
+			return nginxFields[m[1]], false
+		})
+	case strings.Contains(format, "%"):
+		parts = splitFormat(format, apacheDirectRegex, func(m []string) (field, bool) {
+			key := m[2]
+			if m[1] != "" {
+				key = strings.ToLower(m[1]) + m[2]
+			}
+			return apacheFields[key], key == "t"
+		})
+	default:
+		return nil, fmt.Errorf("access log format %q contains no $variables or %%directives", spec)
+	}
+
+	return compileFormat(spec, parts)
+}
+
+// String returns the format spec the Format was compiled from.
+func (f *Format) String() string {
+	if f.spec == "" {
+		return FormatCombined
+	}
+	return f.spec
+}
+
+// splitFormat splits a format string into literals and variables.
+func splitFormat(format string, varRegex *regexp.Regexp, lookup func(m []string) (field, bool)) []formatPart {
+	var parts []formatPart
+	last := 0
+	for _, loc := range varRegex.FindAllStringSubmatchIndex(format, -1) {
+		if loc[0] > last {
+			parts = append(parts, formatPart{literal: format[last:loc[0]]})
+		}
+		match := make([]string, len(loc)/2)
+		for i := range match {
+			if loc[2*i] >= 0 {
+				match[i] = format[loc[2*i]:loc[2*i+1]]
+			}
+		}
+		f, apacheT := lookup(match)
+		parts = append(parts, formatPart{field: f, isVar: true, apacheT: apacheT})
+		last = loc[1]
+	}
+	if last < len(format) {
+		parts = append(parts, formatPart{literal: format[last:]})
+	}
+	return parts
+}
+
+// compileFormat builds the line regex. A variable matches up to the first
+// character of the literal that follows it; variables between quotes also
+// accept escaped quotes. The regex is not anchored at the end, so a format
+// also parses lines that carry extra trailing fields (common vs. combined).
+func compileFormat(spec string, parts []formatPart) (*Format, error) {
+	var pattern strings.Builder
+	pattern.WriteString("^")
+
+	f := &Format{spec: strings.TrimSpace(spec)}
+	hasStatus := false
+	for i, part := range parts {
+		if !part.isVar {
+			pattern.WriteString(regexp.QuoteMeta(part.literal))
+			continue
+		}
+
+		var next, prev string
+		if i+1 < len(parts) && !parts[i+1].isVar {
+			next = parts[i+1].literal
+		}
+		if i > 0 && !parts[i-1].isVar {
+			prev = parts[i-1].literal
+		}
+
+		switch {
+		case part.apacheT:
+			pattern.WriteString(`\[([^\]]*)\]`)
+		case next == "":
+			pattern.WriteString(`(\S*)`)
+		case strings.HasPrefix(next, `"`) && strings.HasSuffix(prev, `"`):
+			pattern.WriteString(`((?:[^"\\]|\\.)*)`)
+		default:
+			stop, _ := utf8.DecodeRuneInString(next)
+			pattern.WriteString(`([^` + regexp.QuoteMeta(string(stop)) + `]*)`)
+		}
+		f.fields = append(f.fields, part.field)
+		if part.field == fieldStatus {
+			hasStatus = true
+		}
+	}
+
+	if !hasStatus {
+		return nil, fmt.Errorf("access log format %q has no status field ($status or %%>s)", spec)
+	}
+
+	regex, err := regexp.Compile(pattern.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile access log format %q: %w", spec, err)
+	}
+	f.regex = regex
+	return f, nil
+}
+
+// ParseLine parses a single log line. Lines that do not match the format
+// or carry no valid status are reported as not ok.
+func (f *Format) ParseLine(line string) (Request, bool) {
+	match := f.regex.FindStringSubmatch(line)
+	if match == nil {
+		return Request{}, false
+	}
+
+	var req Request
+	var upstream time.Duration
+	hasUpstream := false
+	statusOK := false
+	for i, fld := range f.fields {
+		value := match[i+1]
+		if value == "-" {
+			value = ""
+		}
+		switch fld {
+		case fieldClient:
+			req.Client = value
+		case fieldTimeCLF:
+			if t, err := time.Parse(timeLayoutCLF, value); err == nil {
+				req.Time = t
+			}
+		case fieldTimeISO8601:
+			if t, err := time.Parse(time.RFC3339, value); err == nil {
+				req.Time = t
+			}
+		case fieldRequest:
+			req.Method, req.Path = parseRequestLine(value)
+		case fieldMethod:
+			req.Method = value
+		case fieldPath:
+			req.Path = stripQuery(value)
+		case fieldStatus:
+			if status, err := strconv.Atoi(value); err == nil && status >= 100 && status <= 999 {
+				req.Status = status
+				statusOK = true
+			}
+		case fieldBytes:
+			req.Bytes, _ = strconv.ParseInt(value, 10, 64)
+		case fieldReferer:
+			req.Referer = value
+		case fieldUserAgent:
+			req.UserAgent = value
+		case fieldHost:
+			req.Host = value
+		case fieldRequestSeconds:
+			req.Duration, req.HasDuration = parseDuration(value, time.Second)
+		case fieldRequestMillis:
+			req.Duration, req.HasDuration = parseDuration(value, time.Millisecond)
+		case fieldRequestMicros:
+			req.Duration, req.HasDuration = parseDuration(value, time.Microsecond)
+		case fieldUpstreamSeconds:
+			upstream, hasUpstream = parseDuration(value, time.Second)
+		}
+	}
+	if !statusOK {
+		return Request{}, false
+	}
+	if !req.HasDuration && hasUpstream {
+		req.Duration, req.HasDuration = upstream, true
+	}
+
+	return req, true
+}
+
+// parseRequestLine splits "GET /path?query HTTP/1.1". Malformed request
+// lines (TLS handshakes sent to a plain HTTP port, scanner garbage) keep
+// the raw text as path with an empty method.
+func parseRequestLine(request string) (method, path string) {
+	fields := strings.Fields(request)
+	if len(fields) >= 2 && isMethod(fields[0]) {
+		return fields[0], stripQuery(fields[1])
+	}
+	return "", request
+}
+
+// isMethod reports whether s looks like an HTTP method token.
+func isMethod(s string) bool {
+	if s == "" || len(s) > 16 {
+		return false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func stripQuery(path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		return path[:i]
+	}
+	return path
+}
+
+// parseDuration parses a duration value in the given unit. nginx writes
+// several comma-separated upstream times when a request was retried; the
+// values are summed.
+func parseDuration(value string, unit time.Duration) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	var total float64
+	for _, v := range strings.Split(value, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil || n < 0 {
+			return 0, false
+		}
+		total += n
+	}
+	return time.Duration(total * float64(unit)), true
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package accesslog
+
+import (
+	"testing"
+	"time"
+)
+
+const combinedLine = `203.0.113.5 - - [01/Jan/2026:02:13:45 +0100] "GET /wp-login.php?redirect=1 HTTP/1.1" 404 162 "-" "Mozilla/5.0 (compatible; Nuclei)"`
+
+func TestParseFormat_Combined(t *testing.T) {
+	f, err := ParseFormat("")
+	if err != nil {
+		t.Fatalf("ParseFormat() error = %v", err)
+	}
+	if f.String() != FormatCombined {
+		t.Errorf("String() = %q, want %q", f.String(), FormatCombined)
+	}
+
+	req, ok := f.ParseLine(combinedLine)
+	if !ok {
+		t.Fatal("ParseLine() failed for combined line")
+	}
+
+	want := Request{
+		Time:      time.Date(2026, 1, 1, 1, 13, 45, 0, time.UTC),
+		Client:    "203.0.113.5",
+		Method:    "GET",
+		Path:      "/wp-login.php",
+		Status:    404,
+		Bytes:     162,
+		UserAgent: "Mozilla/5.0 (compatible; Nuclei)",
+	}
+	if !req.Time.Equal(want.Time) {
+		t.Errorf("Time = %v, want %v", req.Time, want.Time)
+	}
+	req.Time = want.Time
+	if req != want {
+		t.Errorf("ParseLine() = %+v, want %+v", req, want)
+	}
+}
+
+func TestParseFormat_CommonParsesCombinedLines(t *testing.T) {
+	f, err := ParseFormat("common")
+	if err != nil {
+		t.Fatalf("ParseFormat() error = %v", err)
+	}
+	req, ok := f.ParseLine(combinedLine)
+	if !ok || req.Status != 404 || req.UserAgent != "" {
+		t.Errorf("ParseLine() = %+v, %v; want status 404 without user agent", req, ok)
+	}
+}
+
+func TestParseFormat_CustomNginx(t *testing.T) {
+	f, err := ParseFormat(`$remote_addr [$time_iso8601] "$request" $status $body_bytes_sent "$http_user_agent" rt=$request_time urt=$upstream_response_time`)
+	if err != nil {
+		t.Fatalf("ParseFormat() error = %v", err)
+	}
+
+	req, ok := f.ParseLine(`198.51.100.7 [2026-01-01T02:00:00+00:00] "POST /api/orders HTTP/2.0" 502 0 "curl/8.5.0" rt=2.503 urt=2.500`)
+	if !ok {
+		t.Fatal("ParseLine() failed for custom nginx line")
+	}
+	if req.Method != "POST" || req.Path != "/api/orders" || req.Status != 502 {
+		t.Errorf("ParseLine() = %+v", req)
+	}
+	if !req.HasDuration || req.Duration != 2503*time.Millisecond {
+		t.Errorf("Duration = %v (%v), want 2.503s from $request_time", req.Duration, req.HasDuration)
+	}
+	if req.Time.IsZero() {
+		t.Error("Time not parsed from $time_iso8601")
+	}
+}
+
+func TestParseFormat_UpstreamTimeFallback(t *testing.T) {
+	f, err := ParseFormat(`$remote_addr "$request" $status $upstream_response_time`)
+	if err != nil {
+		t.Fatalf("ParseFormat() error = %v", err)
+	}
+
+	req, ok := f.ParseLine(`192.0.2.1 "GET / HTTP/1.1" 200 0.120`)
+	if !ok || !req.HasDuration || req.Duration != 120*time.Millisecond {
+		t.Errorf("ParseLine() = %+v, %v; want 120ms upstream time", req, ok)
+	}
+
+	req, ok = f.ParseLine(`192.0.2.1 "GET / HTTP/1.1" 200 -`)
+	if !ok || req.HasDuration {
+		t.Errorf("ParseLine() = %+v, %v; want no duration for '-'", req, ok)
+	}
+}
+
+func TestParseFormat_CustomApache(t *testing.T) {
+	f, err := ParseFormat(`%h %l %u %t "%r" %>s %b "%{Referer}i" "%{User-Agent}i" %D`)
+	if err != nil {
+		t.Fatalf("ParseFormat() error = %v", err)
+	}
+
+	req, ok := f.ParseLine(`192.0.2.10 - admin [01/Jan/2026:02:13:45 +0000] "GET /search?q=x HTTP/1.1" 200 5120 "https://example.com/" "Mozilla/5.0 \"quoted\"" 1500000`)
+	if !ok {
+		t.Fatal("ParseLine() failed for Apache line")
+	}
+	if req.Client != "192.0.2.10" || req.Path != "/search" || req.Status != 200 || req.Bytes != 5120 {
+		t.Errorf("ParseLine() = %+v", req)
+	}
+	if req.Referer != "https://example.com/" || req.UserAgent != `Mozilla/5.0 \"quoted\"` {
+		t.Errorf("Referer/UserAgent = %q / %q", req.Referer, req.UserAgent)
+	}
+	if req.Duration != 1500*time.Millisecond {
+		t.Errorf("Duration = %v, want 1.5s from %%D", req.Duration)
+	}
+	if req.Time.IsZero() {
+		t.Errorf("Time not parsed from Apache %%t")
+	}
+}
+
+func TestParseFormat_Errors(t *testing.T) {
+	tests := []string{
+		"no variables here",
+		`$remote_addr $request`,
+		`%h %r`,
+	}
+	for _, spec := range tests {
+		if _, err := ParseFormat(spec); err == nil {
+			t.Errorf("ParseFormat(%q) expected error", spec)
+		}
+	}
+}
+
+func TestParseLine_Malformed(t *testing.T) {
+	f, _ := ParseFormat(FormatCombined)
+
+	if _, ok := f.ParseLine("garbage line"); ok {
+		t.Error("ParseLine() should reject lines that do not match")
+	}
+
+	req, ok := f.ParseLine(`192.0.2.3 - - [01/Jan/2026:02:13:45 +0000] "\x16\x03\x01\x00" 400 157 "-" "-"`)
+	if !ok {
+		t.Fatal("ParseLine() failed for malformed request line")
+	}
+	if req.Method != "" || req.Path != `\x16\x03\x01\x00` || req.UserAgent != "" {
+		t.Errorf("ParseLine() = %+v, want raw request as path", req)
+	}
+}
@@ -98,6 +98,22 @@ func NewClient(apiKey, model, proxyURL string, timeoutSeconds, maxTokens int) (*
 // Analyze performs log analysis using provided prompts.
 // This is the generic analysis method that accepts custom system and user prompts.
 func (c *Client) Analyze(ctx context.Context, systemPrompt, userPrompt string) (*Analysis, *Stats, error) {
+	responseText, stats, err := c.AnalyzeRaw(ctx, systemPrompt, userPrompt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Parse analysis
+	analysis, err := ParseAnalysis(responseText)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse analysis: %w", err)
+	}
+
+	return analysis, stats, nil
+}
+
+// AnalyzeRaw sends the prompts to Claude and returns the unparsed response text.
+func (c *Client) AnalyzeRaw(ctx context.Context, systemPrompt, userPrompt string) (string, *Stats, error) {
 	startTime := time.Now()
 
 	// Create request with retry logic
@@ -105,31 +121,70 @@ func (c *Client) Analyze(ctx context.Context, systemPrompt, userPrompt string) (
 		return c.callAPI(ctx, systemPrompt, userPrompt)
 	})
 	if err != nil {
-		return nil, nil, err
+		return "", nil, err
 	}
 
 	// Extract response content
 	if len(response.Content) == 0 {
-		return nil, nil, fmt.Errorf("empty response from Claude")
+		return "", nil, fmt.Errorf("empty response from Claude")
 	}
 
-	var responseText strings.Builder
-	for _, content := range response.Content {
-		if content.Type == "text" && content.Text != nil {
-			responseText.WriteString(*content.Text)
+	// Calculate statistics
+	stats := c.calculateStats(response, time.Since(startTime).Seconds())
+
+	return responseText(response), stats, nil
+}
+
+// FollowUp continues a conversation about a stored analysis and returns
+// Claude's plain-text answer.
+func (c *Client) FollowUp(ctx context.Context, systemPrompt string, messages []Message) (string, *Stats, error) {
+	startTime := time.Now()
+
+	request := anthropic.MessagesRequest{
+		Model:     anthropic.Model(c.model),
+		Messages:  make([]anthropic.Message, 0, len(messages)),
+		System:    systemPrompt,
+		MaxTokens: c.maxTokens,
+	}
+	for _, msg := range messages {
+		role := anthropic.RoleUser
+		if msg.Role == RoleAssistant {
+			role = anthropic.RoleAssistant
 		}
+		request.Messages = append(request.Messages, anthropic.Message{
+			Role:    role,
+			Content: []anthropic.MessageContent{anthropic.NewTextMessageContent(msg.Content)},
+		})
 	}
 
-	// Parse analysis
-	analysis, err := ParseAnalysis(responseText.String())
+	response, err := retryWithBackoff(defaultMaxRetries, func() (anthropic.MessagesResponse, error) {
+		resp, retryErr := c.client.CreateMessages(ctx, request)
+		if retryErr != nil {
+			return resp, internalerrors.Wrapf(retryErr, "API call failed")
+		}
+		return resp, nil
+	})
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to parse analysis: %w", err)
+		return "", nil, err
 	}
 
-	// Calculate statistics
-	stats := c.calculateStats(response, time.Since(startTime).Seconds())
+	answer := responseText(response)
+	if answer == "" {
+		return "", nil, fmt.Errorf("empty response from Claude")
+	}
 
-	return analysis, stats, nil
+	return answer, c.calculateStats(response, time.Since(startTime).Seconds()), nil
+}
+
+// responseText concatenates the text blocks of a Messages API response.
+func responseText(response anthropic.MessagesResponse) string {
+	var text strings.Builder
+	for _, content := range response.Content {
+		if content.Type == "text" && content.Text != nil {
+			text.WriteString(*content.Text)
+		}
+	}
+	return text.String()
 }
 
 // callAPI makes the actual API call to Claude
@@ -219,6 +274,8 @@ func (c *Client) GetProviderName() string {
 
 // Ensure Client implements Provider interface
 var (
-	_ Provider           = (*Client)(nil)
-	_ PromptTokenCounter = (*Client)(nil)
+	_ Provider            = (*Client)(nil)
+	_ PromptTokenCounter  = (*Client)(nil)
+	_ RawResponseProvider = (*Client)(nil)
+	_ FollowUpProvider    = (*Client)(nil)
 )
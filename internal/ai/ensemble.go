@@ -0,0 +1,441 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// EnsembleStatusRule selects how member statuses are reconciled.
+type EnsembleStatusRule string
+
+const (
+	// EnsembleStatusWorst takes the most severe status reported by any model.
+	EnsembleStatusWorst EnsembleStatusRule = "worst"
+	// EnsembleStatusMajority takes the most common status; ties go to the worse one.
+	EnsembleStatusMajority EnsembleStatusRule = "majority"
+	// EnsembleStatusPrimary takes the status of the first model that succeeded.
+	EnsembleStatusPrimary EnsembleStatusRule = "primary"
+)
+
+const (
+	ensembleProviderName = "Ensemble"
+
+	// ensembleSimilarityThreshold is the minimum Dice coefficient between
+	// the word sets of two findings for them to be treated as the same issue.
+	ensembleSimilarityThreshold = 0.5
+
+	severityCritical = "critical"
+	severityWarning  = "warning"
+)
+
+// ensembleStopWords are ignored when comparing findings.
+var ensembleStopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "for": true, "from": true, "has": true, "have": true,
+	"in": true, "is": true, "of": true, "on": true, "or": true, "the": true,
+	"to": true, "was": true, "were": true, "with": true,
+}
+
+// EnsembleMember is one provider in an ensemble, named by its
+// "provider[:model]" label.
+type EnsembleMember struct {
+	Name     string
+	Provider Provider
+}
+
+// EnsembleConfig holds ensemble configuration
+type EnsembleConfig struct {
+	Members    []EnsembleMember
+	StatusRule EnsembleStatusRule
+}
+
+// EnsembleClient runs the same prompt through several providers in parallel
+// and reconciles their analyses into one. Findings are merged by word
+// similarity and suffixed with how many models reported them, e.g.
+// "(2/3 models)". A finding counts as critical if any model reported it as
+// critical. The run fails only if every member fails.
+type EnsembleClient struct {
+	members    []EnsembleMember
+	statusRule EnsembleStatusRule
+}
+
+// EnsembleVote is one member's outcome.
+type EnsembleVote struct {
+	Model  string `json:"model"`
+	Status string `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// EnsembleFinding is a merged finding with the models that reported it.
+type EnsembleFinding struct {
+	Text      string   `json:"text"`
+	Severity  string   `json:"severity"` // "critical" or "warning"
+	Models    []string `json:"models"`
+	Agreement int      `json:"agreement"`
+}
+
+// EnsembleResult records how an ensemble analysis was reconciled, for the
+// report and for later review.
+type EnsembleResult struct {
+	StatusRule string            `json:"statusRule"`
+	Votes      []EnsembleVote    `json:"votes"`
+	Findings   []EnsembleFinding `json:"findings"`
+}
+
+// Succeeded returns the number of members that produced an analysis.
+func (r *EnsembleResult) Succeeded() int {
+	n := 0
+	for _, v := range r.Votes {
+		if v.Error == "" {
+			n++
+		}
+	}
+	return n
+}
+
+// Disagreements returns the findings not reported by every successful model.
+func (r *EnsembleResult) Disagreements() []EnsembleFinding {
+	succeeded := r.Succeeded()
+	var out []EnsembleFinding
+	for _, f := range r.Findings {
+		if f.Agreement < succeeded {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// StatusesAgree reports whether every successful model returned the same status.
+func (r *EnsembleResult) StatusesAgree() bool {
+	status := ""
+	for _, v := range r.Votes {
+		if v.Error != "" {
+			continue
+		}
+		if status != "" && v.Status != status {
+			return false
+		}
+		status = v.Status
+	}
+	return true
+}
+
+// NewEnsembleClient creates a new ensemble provider
+func NewEnsembleClient(cfg EnsembleConfig) (*EnsembleClient, error) {
+	if len(cfg.Members) < 2 {
+		return nil, fmt.Errorf("ensemble requires at least two providers")
+	}
+	for _, m := range cfg.Members {
+		if m.Provider == nil || m.Name == "" {
+			return nil, fmt.Errorf("ensemble member must have a name and provider")
+		}
+	}
+
+	rule := cfg.StatusRule
+	switch rule {
+	case "":
+		rule = EnsembleStatusWorst
+	case EnsembleStatusWorst, EnsembleStatusMajority, EnsembleStatusPrimary:
+	default:
+		return nil, fmt.Errorf("invalid ensemble status rule: %s", rule)
+	}
+
+	return &EnsembleClient{
+		members:    slices.Clone(cfg.Members),
+		statusRule: rule,
+	}, nil
+}
+
+type memberResult struct {
+	analysis *Analysis
+	stats    *Stats
+	err      error
+}
+
+// Analyze runs every member concurrently and merges their analyses. The
+// returned Analysis carries the reconciliation details in Ensemble.
+func (c *EnsembleClient) Analyze(ctx context.Context, systemPrompt, userPrompt string) (*Analysis, *Stats, error) {
+	results := make([]memberResult, len(c.members))
+
+	var wg sync.WaitGroup
+	for i, m := range c.members {
+		wg.Go(func() {
+			analysis, stats, err := m.Provider.Analyze(ctx, systemPrompt, userPrompt)
+			results[i] = memberResult{analysis: analysis, stats: stats, err: err}
+		})
+	}
+	wg.Wait()
+
+	var errs []error
+	for i, r := range results {
+		if r.err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", c.members[i].Name, r.err))
+		}
+	}
+	if len(errs) == len(results) {
+		return nil, nil, fmt.Errorf("all ensemble providers failed: %w", errors.Join(errs...))
+	}
+
+	return c.merge(results), c.combineStats(results), nil
+}
+
+// merge reconciles the successful member analyses.
+func (c *EnsembleClient) merge(results []memberResult) *Analysis {
+	ensemble := &EnsembleResult{StatusRule: string(c.statusRule)}
+	var primary *Analysis
+	var statuses []string
+	var clusters []*findingCluster
+	var recommendations []string
+
+	for i, r := range results {
+		name := c.members[i].Name
+		if r.err != nil {
+			ensemble.Votes = append(ensemble.Votes, EnsembleVote{Model: name, Error: r.err.Error()})
+			continue
+		}
+		ensemble.Votes = append(ensemble.Votes, EnsembleVote{Model: name, Status: r.analysis.SystemStatus})
+		statuses = append(statuses, r.analysis.SystemStatus)
+		if primary == nil {
+			primary = r.analysis
+		}
+
+		clusters = addFindings(clusters, name, severityCritical, r.analysis.CriticalIssues)
+		clusters = addFindings(clusters, name, severityWarning, r.analysis.Warnings)
+		recommendations = appendDistinct(recommendations, r.analysis.Recommendations)
+	}
+
+	succeeded := len(statuses)
+	// Stable sort keeps first-seen order among findings with equal agreement.
+	slices.SortStableFunc(clusters, func(a, b *findingCluster) int {
+		return len(b.models) - len(a.models)
+	})
+
+	merged := &Analysis{
+		SystemStatus:    reconcileStatus(c.statusRule, statuses),
+		Summary:         primary.Summary,
+		CriticalIssues:  []string{},
+		Warnings:        []string{},
+		Recommendations: recommendations,
+		Metrics:         primary.Metrics,
+		Ensemble:        ensemble,
+	}
+	for _, cl := range clusters {
+		text := fmt.Sprintf("%s (%d/%d models)", cl.texts[0], len(cl.models), succeeded)
+		if cl.severity == severityCritical {
+			merged.CriticalIssues = append(merged.CriticalIssues, text)
+		} else {
+			merged.Warnings = append(merged.Warnings, text)
+		}
+		ensemble.Findings = append(ensemble.Findings, EnsembleFinding{
+			Text:      cl.texts[0],
+			Severity:  cl.severity,
+			Models:    cl.models,
+			Agreement: len(cl.models),
+		})
+	}
+	if merged.Recommendations == nil {
+		merged.Recommendations = []string{}
+	}
+
+	return merged
+}
+
+// combineStats sums tokens and cost across members. Duration is the slowest
+// member since they run concurrently.
+func (c *EnsembleClient) combineStats(results []memberResult) *Stats {
+	names := make([]string, 0, len(c.members))
+	combined := &Stats{Provider: ensembleProviderName}
+	for i, r := range results {
+		names = append(names, c.members[i].Name)
+		if r.stats == nil {
+			continue
+		}
+		combined.InputTokens += r.stats.InputTokens
+		combined.OutputTokens += r.stats.OutputTokens
+		combined.CacheCreationTokens += r.stats.CacheCreationTokens
+		combined.CacheReadTokens += r.stats.CacheReadTokens
+		combined.CostUSD += r.stats.CostUSD
+		combined.DurationSeconds = max(combined.DurationSeconds, r.stats.DurationSeconds)
+	}
+	combined.Model = strings.Join(names, " + ")
+	return combined
+}
+
+// FollowUp delegates to the first member that supports follow-up questions.
+func (c *EnsembleClient) FollowUp(ctx context.Context, systemPrompt string, messages []Message) (string, *Stats, error) {
+	for _, m := range c.members {
+		if fp, ok := m.Provider.(FollowUpProvider); ok {
+			return fp.FollowUp(ctx, systemPrompt, messages)
+		}
+	}
+	return "", nil, fmt.Errorf("no ensemble provider supports follow-up questions")
+}
+
+// GetModelInfo returns the member names with the smallest context and
+// response limits, so a prompt fitted for the ensemble fits every member.
+func (c *EnsembleClient) GetModelInfo() map[string]any {
+	names := make([]string, 0, len(c.members))
+	contextLimit, maxTokens := 0, 0
+	for _, m := range c.members {
+		names = append(names, m.Name)
+		info := m.Provider.GetModelInfo()
+		if v, ok := info["context_limit"].(int); ok && v > 0 && (contextLimit == 0 || v < contextLimit) {
+			contextLimit = v
+		}
+		if v, ok := info["max_tokens"].(int); ok && v > 0 && (maxTokens == 0 || v < maxTokens) {
+			maxTokens = v
+		}
+	}
+
+	info := map[string]any{
+		"model":       strings.Join(names, " + "),
+		"provider":    ensembleProviderName,
+		"members":     names,
+		"status_rule": string(c.statusRule),
+		"max_tokens":  maxTokens,
+	}
+	if contextLimit > 0 {
+		info["context_limit"] = contextLimit
+	}
+	return info
+}
+
+// GetProviderName returns the name of the provider
+func (c *EnsembleClient) GetProviderName() string {
+	return ensembleProviderName
+}
+
+// reconcileStatus applies the status rule to the successful members'
+// statuses, given in member order.
+func reconcileStatus(rule EnsembleStatusRule, statuses []string) string {
+	switch rule {
+	case EnsembleStatusPrimary:
+		return statuses[0]
+	case EnsembleStatusMajority:
+		counts := make(map[string]int)
+		best := statuses[0]
+		for _, s := range statuses {
+			counts[s]++
+		}
+		for _, s := range statuses {
+			if counts[s] > counts[best] || (counts[s] == counts[best] && StatusRank(s) > StatusRank(best)) {
+				best = s
+			}
+		}
+		return best
+	default:
+		worst := statuses[0]
+		for _, s := range statuses[1:] {
+			if StatusRank(s) > StatusRank(worst) {
+				worst = s
+			}
+		}
+		return worst
+	}
+}
+
+// findingCluster groups similar findings reported by different models.
+type findingCluster struct {
+	texts    []string
+	words    []map[string]bool
+	models   []string
+	severity string
+}
+
+// addFindings assigns each finding from one model to the most similar
+// cluster that model has not contributed to yet, or starts a new cluster.
+func addFindings(clusters []*findingCluster, model, severity string, findings []string) []*findingCluster {
+	for _, text := range findings {
+		words := findingWords(text)
+
+		var best *findingCluster
+		bestScore := 0.0
+		for _, cl := range clusters {
+			if slices.Contains(cl.models, model) {
+				continue
+			}
+			for _, w := range cl.words {
+				if score := diceSimilarity(words, w); score >= ensembleSimilarityThreshold && score > bestScore {
+					best, bestScore = cl, score
+				}
+			}
+		}
+
+		if best == nil {
+			clusters = append(clusters, &findingCluster{
+				texts:    []string{text},
+				words:    []map[string]bool{words},
+				models:   []string{model},
+				severity: severity,
+			})
+			continue
+		}
+
+		best.texts = append(best.texts, text)
+		best.words = append(best.words, words)
+		best.models = append(best.models, model)
+		if severity == severityCritical {
+			best.severity = severityCritical
+		}
+	}
+	return clusters
+}
+
+// appendDistinct appends items not similar to any already present.
+func appendDistinct(existing, items []string) []string {
+	for _, item := range items {
+		words := findingWords(item)
+		duplicate := slices.ContainsFunc(existing, func(e string) bool {
+			return diceSimilarity(words, findingWords(e)) >= ensembleSimilarityThreshold
+		})
+		if !duplicate {
+			existing = append(existing, item)
+		}
+	}
+	return existing
+}
+
+// findingWords returns the lower-cased significant words of a finding.
+// Dots and colons are kept inside tokens so IPs, versions, and ports stay whole.
+func findingWords(text string) map[string]bool {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '.' && r != ':'
+	})
+
+	words := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		f = strings.Trim(f, ".:")
+		if f != "" && !ensembleStopWords[f] {
+			words[f] = true
+		}
+	}
+	return words
+}
+
+// diceSimilarity returns 2|A∩B| / (|A|+|B|).
+func diceSimilarity(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	common := 0
+	for w := range a {
+		if b[w] {
+			common++
+		}
+	}
+	return 2 * float64(common) / float64(len(a)+len(b))
+}
+
+// Ensure EnsembleClient implements Provider and FollowUpProvider interfaces
+var (
+	_ Provider         = (*EnsembleClient)(nil)
+	_ FollowUpProvider = (*EnsembleClient)(nil)
+)
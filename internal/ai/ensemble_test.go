@@ -0,0 +1,165 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package ai
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// analysisStubProvider returns a fixed analysis or error.
+type analysisStubProvider struct {
+	analysis     *Analysis
+	err          error
+	contextLimit int
+}
+
+func (p *analysisStubProvider) Analyze(context.Context, string, string) (*Analysis, *Stats, error) {
+	if p.err != nil {
+		return nil, nil, p.err
+	}
+	return p.analysis, &Stats{InputTokens: 100, OutputTokens: 10, CostUSD: 0.01, DurationSeconds: 2}, nil
+}
+
+func (p *analysisStubProvider) GetModelInfo() map[string]any {
+	return map[string]any{"model": "stub", "max_tokens": 4000, "context_limit": p.contextLimit}
+}
+
+func (p *analysisStubProvider) GetProviderName() string { return "Stub" }
+
+func newTestEnsemble(t *testing.T, rule EnsembleStatusRule, providers ...*analysisStubProvider) *EnsembleClient {
+	t.Helper()
+	members := make([]EnsembleMember, 0, len(providers))
+	for i, p := range providers {
+		members = append(members, EnsembleMember{Name: string(rune('a' + i)), Provider: p})
+	}
+	client, err := NewEnsembleClient(EnsembleConfig{Members: members, StatusRule: rule})
+	if err != nil {
+		t.Fatalf("NewEnsembleClient() error = %v", err)
+	}
+	return client
+}
+
+func TestNewEnsembleClient_Invalid(t *testing.T) {
+	one := []EnsembleMember{{Name: "a", Provider: &analysisStubProvider{}}}
+	if _, err := NewEnsembleClient(EnsembleConfig{Members: one}); err == nil {
+		t.Error("expected error for a single member")
+	}
+
+	two := append(one, EnsembleMember{Name: "b", Provider: &analysisStubProvider{}})
+	if _, err := NewEnsembleClient(EnsembleConfig{Members: two, StatusRule: "loudest"}); err == nil {
+		t.Error("expected error for unknown status rule")
+	}
+}
+
+func TestEnsembleClient_MergesFindings(t *testing.T) {
+	haiku := &analysisStubProvider{contextLimit: 200000, analysis: &Analysis{
+		SystemStatus:    "Bad",
+		Summary:         "primary summary",
+		CriticalIssues:  []string{"SSH brute force from 203.0.113.5"},
+		Warnings:        []string{"Disk usage at 91% on /var"},
+		Recommendations: []string{"Block 203.0.113.5 in the firewall"},
+		Metrics:         map[string]any{"failedLogins": 523},
+	}}
+	ollama := &analysisStubProvider{contextLimit: 32000, analysis: &Analysis{
+		SystemStatus:    "Satisfactory",
+		Summary:         "secondary summary",
+		CriticalIssues:  []string{},
+		Warnings:        []string{"Brute-force SSH login attempts from 203.0.113.5", "Kernel OOM killer invoked"},
+		Recommendations: []string{"Block 203.0.113.5 in firewall", "Add swap"},
+	}}
+
+	client := newTestEnsemble(t, EnsembleStatusWorst, haiku, ollama)
+	analysis, stats, err := client.Analyze(context.Background(), "system", "user")
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	if analysis.SystemStatus != "Bad" || analysis.Summary != "primary summary" {
+		t.Errorf("status/summary = %s / %s", analysis.SystemStatus, analysis.Summary)
+	}
+	// The SSH finding is agreed by both models and stays critical.
+	if len(analysis.CriticalIssues) != 1 || analysis.CriticalIssues[0] != "SSH brute force from 203.0.113.5 (2/2 models)" {
+		t.Errorf("CriticalIssues = %v", analysis.CriticalIssues)
+	}
+	if len(analysis.Warnings) != 2 || !strings.HasSuffix(analysis.Warnings[0], "(1/2 models)") {
+		t.Errorf("Warnings = %v", analysis.Warnings)
+	}
+	if len(analysis.Recommendations) != 2 {
+		t.Errorf("Recommendations = %v", analysis.Recommendations)
+	}
+
+	ens := analysis.Ensemble
+	if ens == nil || ens.Succeeded() != 2 || ens.StatusesAgree() {
+		t.Fatalf("unexpected ensemble result: %+v", ens)
+	}
+	if d := ens.Disagreements(); len(d) != 2 {
+		t.Errorf("Disagreements() = %+v, want 2", d)
+	}
+
+	if stats.Provider != "Ensemble" || stats.InputTokens != 200 || stats.CostUSD != 0.02 || stats.DurationSeconds != 2 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+	if info := client.GetModelInfo(); info["context_limit"] != 32000 || info["model"] != "a + b" {
+		t.Errorf("GetModelInfo() = %v", info)
+	}
+}
+
+func TestEnsembleClient_PartialFailure(t *testing.T) {
+	ok := &analysisStubProvider{analysis: &Analysis{SystemStatus: "Good", Summary: "fine", Warnings: []string{"minor"}}}
+	down := &analysisStubProvider{err: errors.New("connection refused")}
+
+	analysis, _, err := newTestEnsemble(t, EnsembleStatusWorst, down, ok).Analyze(context.Background(), "s", "u")
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if analysis.Summary != "fine" || analysis.Warnings[0] != "minor (1/1 models)" {
+		t.Errorf("unexpected analysis: %+v", analysis)
+	}
+	if analysis.Ensemble.Votes[0].Error == "" {
+		t.Error("failed member should be recorded")
+	}
+
+	_, _, err = newTestEnsemble(t, EnsembleStatusWorst, down, down).Analyze(context.Background(), "s", "u")
+	if err == nil || !strings.Contains(err.Error(), "all ensemble providers failed") {
+		t.Errorf("expected all-failed error, got %v", err)
+	}
+}
+
+func TestReconcileStatus(t *testing.T) {
+	tests := []struct {
+		rule     EnsembleStatusRule
+		statuses []string
+		want     string
+	}{
+		{EnsembleStatusWorst, []string{"Good", "Awful", "Bad"}, "Awful"},
+		{EnsembleStatusMajority, []string{"Good", "Bad", "Good"}, "Good"},
+		{EnsembleStatusMajority, []string{"Good", "Bad"}, "Bad"},
+		{EnsembleStatusPrimary, []string{"Satisfactory", "Awful"}, "Satisfactory"},
+	}
+
+	for _, tt := range tests {
+		if got := reconcileStatus(tt.rule, tt.statuses); got != tt.want {
+			t.Errorf("reconcileStatus(%s, %v) = %s, want %s", tt.rule, tt.statuses, got, tt.want)
+		}
+	}
+}
+
+func TestDiceSimilarity(t *testing.T) {
+	a := findingWords("SSH brute force from 203.0.113.5")
+	b := findingWords("Brute-force SSH login attempts from 203.0.113.5 (523 failures)")
+	c := findingWords("Disk usage at 91% on /var")
+
+	if got := diceSimilarity(a, b); got < ensembleSimilarityThreshold {
+		t.Errorf("similar findings scored %v", got)
+	}
+	if got := diceSimilarity(a, c); got >= ensembleSimilarityThreshold {
+		t.Errorf("unrelated findings scored %v", got)
+	}
+	if !a["203.0.113.5"] {
+		t.Errorf("IP address should stay one token: %v", a)
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuildFollowUpMessages(t *testing.T) {
+	analysis := &Analysis{SystemStatus: "Bad", Summary: "SSH brute force"}
+
+	messages, err := BuildFollowUpMessages("LOG CONTENT", analysis, "  Which IPs?\x00 ")
+	if err != nil {
+		t.Fatalf("BuildFollowUpMessages() error = %v", err)
+	}
+	if len(messages) != 3 {
+		t.Fatalf("got %d messages, want 3", len(messages))
+	}
+	if messages[0].Role != RoleUser || messages[0].Content != "LOG CONTENT" {
+		t.Errorf("unexpected first message: %+v", messages[0])
+	}
+	if messages[1].Role != RoleAssistant || !strings.Contains(messages[1].Content, `"systemStatus": "Bad"`) {
+		t.Errorf("unexpected assistant message: %+v", messages[1])
+	}
+	if messages[2].Content != "Follow-up question: Which IPs?" {
+		t.Errorf("unexpected question message: %q", messages[2].Content)
+	}
+
+	if _, err := BuildFollowUpMessages("x", analysis, "   "); err == nil {
+		t.Error("expected error for empty question")
+	}
+	if _, err := BuildFollowUpMessages("x", analysis, strings.Repeat("a", maxFollowUpQuestionChars+1)); err == nil {
+		t.Error("expected error for oversized question")
+	}
+}
+
+func TestFollowUpSystemPrompt(t *testing.T) {
+	got := FollowUpSystemPrompt("BASE")
+	if !strings.HasPrefix(got, "BASE") || !strings.Contains(got, "FOLLOW-UP MODE") {
+		t.Errorf("FollowUpSystemPrompt() = %q", got)
+	}
+}
+
+var followUpTestMessages = []Message{
+	{Role: RoleUser, Content: "logs"},
+	{Role: RoleAssistant, Content: "{}"},
+	{Role: RoleUser, Content: "Follow-up question: why?"},
+}
+
+func TestOllamaClient_FollowUp(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ollamaChatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		if req.Format != "" {
+			t.Errorf("follow-up request must not force JSON format, got %q", req.Format)
+		}
+		if len(req.Messages) != 4 || req.Messages[0].Role != "system" || req.Messages[2].Role != RoleAssistant {
+			t.Errorf("unexpected messages: %+v", req.Messages)
+		}
+
+		_ = json.NewEncoder(w).Encode(ollamaChatResponse{
+			Model:           req.Model,
+			Message:         ollamaMessage{Role: "assistant", Content: "Because of the disk."},
+			Done:            true,
+			PromptEvalCount: 100,
+			EvalCount:       10,
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewOllamaClient(OllamaConfig{BaseURL: server.URL, Model: "llama3.3:latest"})
+	if err != nil {
+		t.Fatalf("NewOllamaClient() error = %v", err)
+	}
+
+	answer, stats, err := client.FollowUp(context.Background(), "system", followUpTestMessages)
+	if err != nil {
+		t.Fatalf("FollowUp() error = %v", err)
+	}
+	if answer != "Because of the disk." {
+		t.Errorf("answer = %q", answer)
+	}
+	if stats == nil || stats.InputTokens != 100 || stats.OutputTokens != 10 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestLMStudioClient_FollowUp(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req openAIChatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		if len(req.Messages) != 4 || req.Messages[0].Role != "system" {
+			t.Errorf("unexpected messages: %+v", req.Messages)
+		}
+
+		_, _ = w.Write([]byte(`{"choices":[{"index":0,"message":{"role":"assistant","content":"Restart nginx."}}],` +
+			`"usage":{"prompt_tokens":50,"completion_tokens":5,"total_tokens":55}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewLMStudioClient(LMStudioConfig{BaseURL: server.URL, Model: "local-model"})
+	if err != nil {
+		t.Fatalf("NewLMStudioClient() error = %v", err)
+	}
+
+	answer, stats, err := client.FollowUp(context.Background(), "system", followUpTestMessages)
+	if err != nil {
+		t.Fatalf("FollowUp() error = %v", err)
+	}
+	if answer != "Restart nginx." || stats == nil || stats.OutputTokens != 5 {
+		t.Errorf("FollowUp() = %q, %+v", answer, stats)
+	}
+}
+
+func TestMockClient_FollowUp(t *testing.T) {
+	dir := t.TempDir()
+	client, err := NewMockClient(MockConfig{Dir: dir, Mode: MockModeFixture, SourceType: "logwatch"})
+	if err != nil {
+		t.Fatalf("NewMockClient() error = %v", err)
+	}
+
+	answer, _, err := client.FollowUp(context.Background(), "system", followUpTestMessages)
+	if err != nil || !strings.Contains(answer, "canned") {
+		t.Errorf("FollowUp() without fixture = %q, %v", answer, err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "logwatch"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "logwatch", "default.answer.txt"), []byte("The SSH attempts came from 203.0.113.5.\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	answer, stats, err := client.FollowUp(context.Background(), "system", followUpTestMessages)
+	if err != nil {
+		t.Fatalf("FollowUp() error = %v", err)
+	}
+	if answer != "The SSH attempts came from 203.0.113.5." || stats.Provider != mockProviderName {
+		t.Errorf("FollowUp() = %q, %+v", answer, stats)
+	}
+}
@@ -122,6 +122,22 @@ func NewLMStudioClient(cfg LMStudioConfig) (*LMStudioClient, error) {
 
 // Analyze performs log analysis using LM Studio
 func (c *LMStudioClient) Analyze(ctx context.Context, systemPrompt, userPrompt string) (*Analysis, *Stats, error) {
+	responseText, stats, err := c.AnalyzeRaw(ctx, systemPrompt, userPrompt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Parse analysis
+	analysis, err := ParseAnalysis(responseText)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse analysis: %w", err)
+	}
+
+	return analysis, stats, nil
+}
+
+// AnalyzeRaw sends the prompts to LM Studio and returns the unparsed response text.
+func (c *LMStudioClient) AnalyzeRaw(ctx context.Context, systemPrompt, userPrompt string) (string, *Stats, error) {
 	startTime := time.Now()
 
 	// Create request with retry logic
@@ -129,29 +145,48 @@ func (c *LMStudioClient) Analyze(ctx context.Context, systemPrompt, userPrompt s
 		return c.callAPI(ctx, systemPrompt, userPrompt)
 	})
 	if err != nil {
-		return nil, nil, err
+		return "", nil, err
 	}
 
 	// Extract response content
 	if len(response.Choices) == 0 {
-		return nil, nil, fmt.Errorf("empty response from LM Studio (no choices)")
+		return "", nil, fmt.Errorf("empty response from LM Studio (no choices)")
 	}
 
 	responseText := response.Choices[0].Message.Content
 	if responseText == "" {
-		return nil, nil, fmt.Errorf("empty response from LM Studio")
+		return "", nil, fmt.Errorf("empty response from LM Studio")
 	}
 
-	// Parse analysis
-	analysis, err := ParseAnalysis(responseText)
+	// Calculate statistics
+	stats := c.calculateStats(response, time.Since(startTime).Seconds())
+
+	return responseText, stats, nil
+}
+
+// FollowUp continues a conversation about a stored analysis and returns the
+// model's plain-text answer.
+func (c *LMStudioClient) FollowUp(ctx context.Context, systemPrompt string, messages []Message) (string, *Stats, error) {
+	startTime := time.Now()
+
+	chat := make([]openAIMessage, 0, len(messages)+1)
+	chat = append(chat, openAIMessage{Role: "system", Content: systemPrompt})
+	for _, msg := range messages {
+		chat = append(chat, openAIMessage(msg))
+	}
+
+	response, err := retryWithBackoff(defaultMaxRetries, func() (*openAIChatResponse, error) {
+		return c.callChat(ctx, chat)
+	})
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to parse analysis: %w", err)
+		return "", nil, err
 	}
 
-	// Calculate statistics
-	stats := c.calculateStats(response, time.Since(startTime).Seconds())
+	if len(response.Choices) == 0 || response.Choices[0].Message.Content == "" {
+		return "", nil, fmt.Errorf("empty response from LM Studio")
+	}
 
-	return analysis, stats, nil
+	return response.Choices[0].Message.Content, c.calculateStats(response, time.Since(startTime).Seconds()), nil
 }
 
 // callAPI makes the actual API call to LM Studio using the OpenAI-compatible endpoint
@@ -159,12 +194,17 @@ func (c *LMStudioClient) callAPI(ctx context.Context, systemPrompt, userPrompt s
 	// Note: LM Studio doesn't support "json_object" response_format like OpenAI.
 	// It only accepts "json_schema" (requires full schema) or "text".
 	// We rely on the system prompt to request JSON output instead.
+	return c.callChat(ctx, []openAIMessage{
+		{Role: "system", Content: systemPrompt},
+		{Role: "user", Content: userPrompt},
+	})
+}
+
+// callChat sends a chat completion request with the given messages.
+func (c *LMStudioClient) callChat(ctx context.Context, messages []openAIMessage) (*openAIChatResponse, error) {
 	request := openAIChatRequest{
-		Model: c.model,
-		Messages: []openAIMessage{
-			{Role: "system", Content: systemPrompt},
-			{Role: "user", Content: userPrompt},
-		},
+		Model:       c.model,
+		Messages:    messages,
 		MaxTokens:   c.maxTokens,
 		Temperature: 0.1, // Low temperature for consistent, factual output
 		TopP:        0.9,
@@ -276,4 +316,8 @@ func (c *LMStudioClient) CheckConnection(ctx context.Context) error {
 }
 
 // Ensure LMStudioClient implements Provider interface
-var _ Provider = (*LMStudioClient)(nil)
+var (
+	_ Provider            = (*LMStudioClient)(nil)
+	_ RawResponseProvider = (*LMStudioClient)(nil)
+	_ FollowUpProvider    = (*LMStudioClient)(nil)
+)
@@ -0,0 +1,437 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// MockMode selects how the mock provider produces analyses.
+type MockMode string
+
+const (
+	// MockModeFixture returns canned Analysis JSON fixtures.
+	MockModeFixture MockMode = "fixture"
+	// MockModeReplay replays raw provider responses captured in record mode.
+	MockModeReplay MockMode = "replay"
+	// MockModeRecord forwards to an upstream provider and saves its raw response.
+	MockModeRecord MockMode = "record"
+)
+
+const (
+	mockProviderName       = "Mock"
+	mockDefaultKey         = "default"
+	mockFixtureSuffix      = ".analysis.json"
+	mockRecordingSuffix    = ".response.json"
+	mockAnswerSuffix       = ".answer.txt"
+	mockDefaultContextSize = 200000
+	maxMockFileBytes       = 10 * 1024 * 1024 // 10 MiB
+)
+
+// MockClient is an offline Provider that serves analyses from a directory of
+// fixtures or recorded responses. Files are keyed by log source type and site:
+//
+//	<dir>/<source_type>/<site_id>.analysis.json  (fixture mode)
+//	<dir>/<source_type>/<site_id>.response.json  (replay/record modes)
+//
+//	<dir>/<source_type>/<site_id>.answer.txt     (follow-up answers)
+//
+// Lookups fall back to "default" for the site and then to <dir>/default.*.
+type MockClient struct {
+	dir        string
+	mode       MockMode
+	sourceType string
+	siteID     string
+	maxTokens  int
+	upstream   Provider
+}
+
+// MockConfig holds mock provider configuration
+type MockConfig struct {
+	Dir        string   // Fixture/recording directory
+	Mode       MockMode // fixture, replay, or record
+	SourceType string   // Log source type used as the first key component
+	SiteID     string   // Optional site ID; empty means "default"
+	MaxTokens  int      // Reported in model info for prompt budgeting
+	Upstream   Provider // Live provider used in record mode
+}
+
+// MockRecording is the on-disk format of a recorded provider response.
+type MockRecording struct {
+	Provider        string    `json:"provider"`
+	Model           string    `json:"model"`
+	RecordedAt      time.Time `json:"recorded_at"`
+	Response        string    `json:"response"`
+	InputTokens     int       `json:"input_tokens"`
+	OutputTokens    int       `json:"output_tokens"`
+	CostUSD         float64   `json:"cost_usd"`
+	DurationSeconds float64   `json:"duration_seconds"`
+}
+
+// NewMockClient creates a new mock provider
+func NewMockClient(cfg MockConfig) (*MockClient, error) {
+	if cfg.Dir == "" {
+		return nil, fmt.Errorf("mock directory is required")
+	}
+
+	if cfg.Mode == "" {
+		cfg.Mode = MockModeFixture
+	}
+
+	switch cfg.Mode {
+	case MockModeFixture, MockModeReplay:
+	case MockModeRecord:
+		if cfg.Upstream == nil {
+			return nil, fmt.Errorf("mock record mode requires an upstream provider")
+		}
+	default:
+		return nil, fmt.Errorf("invalid mock mode: %s (must be fixture, replay, or record)", cfg.Mode)
+	}
+
+	if cfg.SourceType == "" {
+		return nil, fmt.Errorf("mock source type is required")
+	}
+
+	if cfg.MaxTokens <= 0 {
+		cfg.MaxTokens = 8000
+	}
+
+	return &MockClient{
+		dir:        cfg.Dir,
+		mode:       cfg.Mode,
+		sourceType: sanitizeMockKey(cfg.SourceType),
+		siteID:     sanitizeMockKey(cfg.SiteID),
+		maxTokens:  cfg.MaxTokens,
+		upstream:   cfg.Upstream,
+	}, nil
+}
+
+// WithKey returns a copy of the client that reads and records files for a
+// different source type and site. The eval harness uses it to key fixtures
+// and recordings per case.
+func (c *MockClient) WithKey(sourceType, siteID string) *MockClient {
+	clone := *c
+	clone.sourceType = sanitizeMockKey(sourceType)
+	clone.siteID = sanitizeMockKey(siteID)
+	return &clone
+}
+
+// Analyze returns a fixture, replays a recording, or records a live response
+// depending on the configured mode.
+func (c *MockClient) Analyze(ctx context.Context, systemPrompt, userPrompt string) (*Analysis, *Stats, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	switch c.mode {
+	case MockModeReplay:
+		return c.replay()
+	case MockModeRecord:
+		return c.record(ctx, systemPrompt, userPrompt)
+	default:
+		return c.fixture()
+	}
+}
+
+// fixture loads a canned Analysis and validates it through ParseAnalysis.
+func (c *MockClient) fixture() (*Analysis, *Stats, error) {
+	startTime := time.Now()
+
+	path, data, err := c.readKeyed(mockFixtureSuffix)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	analysis, err := ParseAnalysis(string(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse mock fixture %s: %w", path, err)
+	}
+
+	return analysis, &Stats{
+		Provider:        mockProviderName,
+		Model:           string(MockModeFixture),
+		DurationSeconds: time.Since(startTime).Seconds(),
+	}, nil
+}
+
+// replay loads a recorded raw response and parses it like a live response.
+func (c *MockClient) replay() (*Analysis, *Stats, error) {
+	startTime := time.Now()
+
+	rec, err := c.LoadRecording()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	analysis, err := ParseAnalysis(rec.Response)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse analysis: %w", err)
+	}
+
+	model := rec.Model
+	if model == "" {
+		model = string(MockModeReplay)
+	}
+
+	return analysis, &Stats{
+		Provider:        mockProviderName,
+		Model:           model,
+		InputTokens:     rec.InputTokens,
+		OutputTokens:    rec.OutputTokens,
+		DurationSeconds: time.Since(startTime).Seconds(),
+	}, nil
+}
+
+// LoadRecording reads the recorded response for the configured key, using
+// the same fallback order as replay.
+func (c *MockClient) LoadRecording() (*MockRecording, error) {
+	path, data, err := c.readKeyed(mockRecordingSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	var rec MockRecording
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("failed to decode mock recording %s: %w", path, err)
+	}
+
+	return &rec, nil
+}
+
+// record calls the upstream provider and saves its raw response for replay.
+// The upstream stats are returned unchanged since the call was billed.
+func (c *MockClient) record(ctx context.Context, systemPrompt, userPrompt string) (*Analysis, *Stats, error) {
+	var (
+		responseText string
+		stats        *Stats
+	)
+
+	if raw, ok := c.upstream.(RawResponseProvider); ok {
+		text, rawStats, err := raw.AnalyzeRaw(ctx, systemPrompt, userPrompt)
+		if err != nil {
+			return nil, nil, err
+		}
+		responseText, stats = text, rawStats
+	} else {
+		// Providers without raw access are recorded as their parsed analysis.
+		analysis, upstreamStats, err := c.upstream.Analyze(ctx, systemPrompt, userPrompt)
+		if err != nil {
+			return nil, nil, err
+		}
+		encoded, err := json.Marshal(analysis)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to encode upstream analysis: %w", err)
+		}
+		responseText, stats = string(encoded), upstreamStats
+	}
+
+	analysis, err := ParseAnalysis(responseText)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse analysis: %w", err)
+	}
+
+	rec := MockRecording{
+		Provider:   c.upstream.GetProviderName(),
+		RecordedAt: time.Now().UTC(),
+		Response:   responseText,
+	}
+	if stats != nil {
+		rec.Model = stats.Model
+		rec.InputTokens = stats.InputTokens
+		rec.OutputTokens = stats.OutputTokens
+		rec.CostUSD = stats.CostUSD
+		rec.DurationSeconds = stats.DurationSeconds
+	}
+
+	if err := c.writeRecording(rec); err != nil {
+		return nil, nil, err
+	}
+
+	return analysis, stats, nil
+}
+
+// RecordingPath returns the file record mode writes for the configured key.
+func (c *MockClient) RecordingPath() string {
+	return filepath.Join(c.dir, c.sourceType, c.siteKey()+mockRecordingSuffix)
+}
+
+func (c *MockClient) writeRecording(rec MockRecording) error {
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode mock recording: %w", err)
+	}
+
+	path := c.RecordingPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create mock directory: %w", err)
+	}
+
+	// Write to a temp file and rename so a crash never leaves a torn recording.
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".recording-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create mock recording: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer func() { _ = os.Remove(tmpName) }()
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write mock recording: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write mock recording: %w", err)
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		return fmt.Errorf("failed to save mock recording: %w", err)
+	}
+
+	return nil
+}
+
+// readKeyed reads the first existing file for the configured key, trying the
+// site-specific file, the source default, then the directory-wide default.
+func (c *MockClient) readKeyed(suffix string) (string, []byte, error) {
+	candidates := []string{
+		filepath.Join(c.dir, c.sourceType, c.siteKey()+suffix),
+		filepath.Join(c.dir, c.sourceType, mockDefaultKey+suffix),
+		filepath.Join(c.dir, mockDefaultKey+suffix),
+	}
+
+	for i, path := range candidates {
+		if i > 0 && path == candidates[i-1] {
+			continue
+		}
+
+		data, err := readMockFile(path)
+		if err == nil {
+			return path, data, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", nil, fmt.Errorf("failed to read mock file %s: %w", path, err)
+		}
+	}
+
+	return "", nil, fmt.Errorf("no mock %s file found for %s/%s in %s: %w",
+		mockFileKind(suffix), c.sourceType, c.siteKey(), c.dir, fs.ErrNotExist)
+}
+
+// mockFileKind turns a suffix such as ".analysis.json" into "analysis".
+func mockFileKind(suffix string) string {
+	kind, _, _ := strings.Cut(strings.TrimPrefix(suffix, "."), ".")
+	return kind
+}
+
+func readMockFile(path string) ([]byte, error) {
+	f, err := os.Open(path) // #nosec G304 -- path is built from configured mock dir and sanitized keys
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	return readResponseBodyLimited(f, maxMockFileBytes)
+}
+
+func (c *MockClient) siteKey() string {
+	if c.siteID == "" {
+		return mockDefaultKey
+	}
+	return c.siteID
+}
+
+// sanitizeMockKey maps a key component to a safe single path element.
+func sanitizeMockKey(key string) string {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, r := range key {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+
+	sanitized := b.String()
+	if strings.Trim(sanitized, ".") == "" {
+		return strings.Repeat("_", len(sanitized))
+	}
+	return sanitized
+}
+
+// FollowUp returns a canned answer from <site_id>.answer.txt, or a generic
+// answer when none exists. Record mode forwards to the upstream provider.
+func (c *MockClient) FollowUp(ctx context.Context, systemPrompt string, messages []Message) (string, *Stats, error) {
+	if err := ctx.Err(); err != nil {
+		return "", nil, err
+	}
+
+	if c.mode == MockModeRecord {
+		upstream, ok := c.upstream.(FollowUpProvider)
+		if !ok {
+			return "", nil, fmt.Errorf("upstream provider %s does not support follow-up questions", c.upstream.GetProviderName())
+		}
+		return upstream.FollowUp(ctx, systemPrompt, messages)
+	}
+
+	startTime := time.Now()
+	answer := "No mock answer fixture is configured; this is a canned follow-up response."
+	if _, data, err := c.readKeyed(mockAnswerSuffix); err == nil {
+		answer = strings.TrimSpace(string(data))
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return "", nil, err
+	}
+
+	return answer, &Stats{
+		Provider:        mockProviderName,
+		Model:           string(c.mode),
+		DurationSeconds: time.Since(startTime).Seconds(),
+	}, nil
+}
+
+// GetModelInfo returns information about the configured model. In record mode
+// the upstream's limits are reported so prompt sizing matches the live model.
+func (c *MockClient) GetModelInfo() map[string]any {
+	if c.mode == MockModeRecord {
+		info := make(map[string]any)
+		for k, v := range c.upstream.GetModelInfo() {
+			info[k] = v
+		}
+		info["provider"] = mockProviderName
+		info["mock_mode"] = string(c.mode)
+		info["upstream_provider"] = c.upstream.GetProviderName()
+		return info
+	}
+
+	return map[string]any{
+		"model":         "mock-" + string(c.mode),
+		"provider":      mockProviderName,
+		"mock_mode":     string(c.mode),
+		"mock_dir":      c.dir,
+		"max_tokens":    c.maxTokens,
+		"context_limit": mockDefaultContextSize,
+	}
+}
+
+// GetProviderName returns the name of the provider
+func (c *MockClient) GetProviderName() string {
+	return mockProviderName
+}
+
+// Ensure MockClient implements Provider and FollowUpProvider interfaces
+var (
+	_ Provider         = (*MockClient)(nil)
+	_ FollowUpProvider = (*MockClient)(nil)
+)
@@ -0,0 +1,225 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const mockTestAnalysis = `{"systemStatus":"Good","summary":"All quiet","criticalIssues":[],"warnings":["disk 80%"],"recommendations":[],"metrics":{}}`
+
+// rawStubProvider is a Provider that also exposes raw response text.
+type rawStubProvider struct {
+	response string
+	calls    int
+}
+
+func (p *rawStubProvider) Analyze(ctx context.Context, systemPrompt, userPrompt string) (*Analysis, *Stats, error) {
+	text, stats, err := p.AnalyzeRaw(ctx, systemPrompt, userPrompt)
+	if err != nil {
+		return nil, nil, err
+	}
+	analysis, err := ParseAnalysis(text)
+	return analysis, stats, err
+}
+
+func (p *rawStubProvider) AnalyzeRaw(_ context.Context, _, _ string) (string, *Stats, error) {
+	p.calls++
+	return p.response, &Stats{Provider: "Stub", Model: "stub-model", InputTokens: 100, OutputTokens: 20, CostUSD: 0.01}, nil
+}
+
+func (p *rawStubProvider) GetModelInfo() map[string]any {
+	return map[string]any{"model": "stub-model", "provider": "Stub", "max_tokens": 4000, "context_limit": 32000}
+}
+
+func (p *rawStubProvider) GetProviderName() string { return "Stub" }
+
+func writeMockFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+}
+
+func TestNewMockClient(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     MockConfig
+		wantErr bool
+	}{
+		{name: "fixture default mode", cfg: MockConfig{Dir: "x", SourceType: "logwatch"}},
+		{name: "replay mode", cfg: MockConfig{Dir: "x", Mode: MockModeReplay, SourceType: "logwatch"}},
+		{name: "missing dir", cfg: MockConfig{SourceType: "logwatch"}, wantErr: true},
+		{name: "missing source type", cfg: MockConfig{Dir: "x"}, wantErr: true},
+		{name: "invalid mode", cfg: MockConfig{Dir: "x", Mode: "live", SourceType: "logwatch"}, wantErr: true},
+		{name: "record without upstream", cfg: MockConfig{Dir: "x", Mode: MockModeRecord, SourceType: "logwatch"}, wantErr: true},
+		{name: "record with upstream", cfg: MockConfig{Dir: "x", Mode: MockModeRecord, SourceType: "logwatch", Upstream: &rawStubProvider{}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewMockClient(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewMockClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && client.GetProviderName() != "Mock" {
+				t.Errorf("GetProviderName() = %q, want Mock", client.GetProviderName())
+			}
+		})
+	}
+}
+
+func TestMockClient_FixtureLookup(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeMockFile(t, filepath.Join(dir, "drupal_watchdog", "prod.analysis.json"),
+		`{"systemStatus":"Bad","summary":"prod site","criticalIssues":["db down"],"warnings":[],"recommendations":[],"metrics":{}}`)
+	writeMockFile(t, filepath.Join(dir, "drupal_watchdog", "default.analysis.json"),
+		`{"systemStatus":"Good","summary":"drupal default","criticalIssues":[],"warnings":[],"recommendations":[],"metrics":{}}`)
+	writeMockFile(t, filepath.Join(dir, "default.analysis.json"),
+		`{"systemStatus":"Excellent","summary":"global default","criticalIssues":[],"warnings":[],"recommendations":[],"metrics":{}}`)
+
+	tests := []struct {
+		name       string
+		sourceType string
+		siteID     string
+		want       string
+	}{
+		{name: "site specific", sourceType: "drupal_watchdog", siteID: "prod", want: "prod site"},
+		{name: "source default", sourceType: "drupal_watchdog", siteID: "staging", want: "drupal default"},
+		{name: "source default without site", sourceType: "drupal_watchdog", want: "drupal default"},
+		{name: "global default", sourceType: "logwatch", want: "global default"},
+		{name: "traversal is sanitized", sourceType: "drupal_watchdog", siteID: "../prod", want: "drupal default"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewMockClient(MockConfig{Dir: dir, SourceType: tt.sourceType, SiteID: tt.siteID})
+			if err != nil {
+				t.Fatalf("NewMockClient() error = %v", err)
+			}
+
+			analysis, stats, err := client.Analyze(context.Background(), "sys", "user")
+			if err != nil {
+				t.Fatalf("Analyze() error = %v", err)
+			}
+			if analysis.Summary != tt.want {
+				t.Errorf("Summary = %q, want %q", analysis.Summary, tt.want)
+			}
+			if stats.Provider != "Mock" || stats.CostUSD != 0 {
+				t.Errorf("unexpected stats: %+v", stats)
+			}
+		})
+	}
+}
+
+func TestMockClient_FixtureMissing(t *testing.T) {
+	client, err := NewMockClient(MockConfig{Dir: t.TempDir(), SourceType: "logwatch"})
+	if err != nil {
+		t.Fatalf("NewMockClient() error = %v", err)
+	}
+
+	_, _, err = client.Analyze(context.Background(), "sys", "user")
+	if err == nil || !strings.Contains(err.Error(), "no mock analysis file found") {
+		t.Errorf("expected missing fixture error, got %v", err)
+	}
+}
+
+func TestMockClient_FixtureInvalid(t *testing.T) {
+	dir := t.TempDir()
+	writeMockFile(t, filepath.Join(dir, "logwatch", "default.analysis.json"), `{"systemStatus":"Weird","summary":"x"}`)
+
+	client, err := NewMockClient(MockConfig{Dir: dir, SourceType: "logwatch"})
+	if err != nil {
+		t.Fatalf("NewMockClient() error = %v", err)
+	}
+
+	if _, _, err := client.Analyze(context.Background(), "sys", "user"); err == nil {
+		t.Error("expected error for invalid fixture status")
+	}
+}
+
+func TestMockClient_RecordThenReplay(t *testing.T) {
+	dir := t.TempDir()
+	upstream := &rawStubProvider{response: "Here is the analysis:\n" + mockTestAnalysis}
+
+	recorder, err := NewMockClient(MockConfig{
+		Dir:        dir,
+		Mode:       MockModeRecord,
+		SourceType: "ocms",
+		SiteID:     "example.com",
+		Upstream:   upstream,
+	})
+	if err != nil {
+		t.Fatalf("NewMockClient() error = %v", err)
+	}
+
+	analysis, stats, err := recorder.Analyze(context.Background(), "sys", "user")
+	if err != nil {
+		t.Fatalf("record Analyze() error = %v", err)
+	}
+	if upstream.calls != 1 {
+		t.Errorf("upstream calls = %d, want 1", upstream.calls)
+	}
+	if analysis.SystemStatus != "Good" || stats.CostUSD != 0.01 {
+		t.Errorf("unexpected record result: %+v %+v", analysis, stats)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "ocms", "example.com.response.json"))
+	if err != nil {
+		t.Fatalf("recording not written: %v", err)
+	}
+	var rec MockRecording
+	if err := json.Unmarshal(data, &rec); err != nil {
+		t.Fatalf("recording is not valid JSON: %v", err)
+	}
+	if rec.Provider != "Stub" || rec.Model != "stub-model" || !strings.HasPrefix(rec.Response, "Here is the analysis") {
+		t.Errorf("unexpected recording: %+v", rec)
+	}
+
+	player, err := NewMockClient(MockConfig{Dir: dir, Mode: MockModeReplay, SourceType: "ocms", SiteID: "example.com"})
+	if err != nil {
+		t.Fatalf("NewMockClient() error = %v", err)
+	}
+
+	replayed, replayStats, err := player.Analyze(context.Background(), "sys", "user")
+	if err != nil {
+		t.Fatalf("replay Analyze() error = %v", err)
+	}
+	if replayed.Summary != analysis.Summary || len(replayed.Warnings) != 1 {
+		t.Errorf("replayed analysis differs: %+v", replayed)
+	}
+	if replayStats.Provider != "Mock" || replayStats.Model != "stub-model" || replayStats.CostUSD != 0 {
+		t.Errorf("unexpected replay stats: %+v", replayStats)
+	}
+	if upstream.calls != 1 {
+		t.Errorf("replay must not call upstream, calls = %d", upstream.calls)
+	}
+}
+
+func TestMockClient_GetModelInfo(t *testing.T) {
+	fixture, err := NewMockClient(MockConfig{Dir: "x", SourceType: "logwatch", MaxTokens: 1234})
+	if err != nil {
+		t.Fatalf("NewMockClient() error = %v", err)
+	}
+	info := fixture.GetModelInfo()
+	if info["max_tokens"] != 1234 || info["provider"] != "Mock" {
+		t.Errorf("unexpected fixture model info: %v", info)
+	}
+
+	recorder, err := NewMockClient(MockConfig{Dir: "x", Mode: MockModeRecord, SourceType: "logwatch", Upstream: &rawStubProvider{}})
+	if err != nil {
+		t.Fatalf("NewMockClient() error = %v", err)
+	}
+	info = recorder.GetModelInfo()
+	if info["context_limit"] != 32000 || info["upstream_provider"] != "Stub" {
+		t.Errorf("record mode should report upstream limits: %v", info)
+	}
+}
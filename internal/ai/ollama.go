@@ -98,6 +98,22 @@ func NewOllamaClient(cfg OllamaConfig) (*OllamaClient, error) {
 
 // Analyze performs log analysis using Ollama
 func (c *OllamaClient) Analyze(ctx context.Context, systemPrompt, userPrompt string) (*Analysis, *Stats, error) {
+	responseText, stats, err := c.AnalyzeRaw(ctx, systemPrompt, userPrompt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Parse analysis
+	analysis, err := ParseAnalysis(responseText)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse analysis: %w", err)
+	}
+
+	return analysis, stats, nil
+}
+
+// AnalyzeRaw sends the prompts to Ollama and returns the unparsed response text.
+func (c *OllamaClient) AnalyzeRaw(ctx context.Context, systemPrompt, userPrompt string) (string, *Stats, error) {
 	startTime := time.Now()
 
 	// Create request with retry logic
@@ -105,42 +121,66 @@ func (c *OllamaClient) Analyze(ctx context.Context, systemPrompt, userPrompt str
 		return c.callAPI(ctx, systemPrompt, userPrompt)
 	})
 	if err != nil {
-		return nil, nil, err
+		return "", nil, err
 	}
 
 	// Extract response content
 	responseText := response.Message.Content
 	if responseText == "" {
-		return nil, nil, fmt.Errorf("empty response from Ollama")
+		return "", nil, fmt.Errorf("empty response from Ollama")
 	}
 
-	// Parse analysis
-	analysis, err := ParseAnalysis(responseText)
+	// Calculate statistics
+	stats := c.calculateStats(response, time.Since(startTime).Seconds())
+
+	return responseText, stats, nil
+}
+
+// FollowUp continues a conversation about a stored analysis and returns the
+// model's plain-text answer.
+func (c *OllamaClient) FollowUp(ctx context.Context, systemPrompt string, messages []Message) (string, *Stats, error) {
+	startTime := time.Now()
+
+	chat := make([]ollamaMessage, 0, len(messages)+1)
+	chat = append(chat, ollamaMessage{Role: "system", Content: systemPrompt})
+	for _, msg := range messages {
+		chat = append(chat, ollamaMessage(msg))
+	}
+
+	response, err := retryWithBackoff(defaultMaxRetries, func() (*ollamaChatResponse, error) {
+		return c.callChat(ctx, chat, "")
+	})
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to parse analysis: %w", err)
+		return "", nil, err
 	}
 
-	// Calculate statistics
-	stats := c.calculateStats(response, time.Since(startTime).Seconds())
+	if response.Message.Content == "" {
+		return "", nil, fmt.Errorf("empty response from Ollama")
+	}
 
-	return analysis, stats, nil
+	return response.Message.Content, c.calculateStats(response, time.Since(startTime).Seconds()), nil
 }
 
 // callAPI makes the actual API call to Ollama using the chat endpoint
 func (c *OllamaClient) callAPI(ctx context.Context, systemPrompt, userPrompt string) (*ollamaChatResponse, error) {
+	return c.callChat(ctx, []ollamaMessage{
+		{Role: "system", Content: systemPrompt},
+		{Role: "user", Content: userPrompt},
+	}, "json") // Request JSON output format
+}
+
+// callChat sends a chat request; format is "json" or empty for free text.
+func (c *OllamaClient) callChat(ctx context.Context, messages []ollamaMessage, format string) (*ollamaChatResponse, error) {
 	request := ollamaChatRequest{
-		Model: c.model,
-		Messages: []ollamaMessage{
-			{Role: "system", Content: systemPrompt},
-			{Role: "user", Content: userPrompt},
-		},
-		Stream: false,
+		Model:    c.model,
+		Messages: messages,
+		Stream:   false,
 		Options: ollamaOptions{
 			NumPredict:  c.maxTokens,
 			Temperature: 0.1, // Low temperature for consistent, factual output
 			TopP:        0.9,
 		},
-		Format: "json", // Request JSON output format
+		Format: format,
 	}
 
 	url := c.baseURL + "/api/chat"
@@ -253,4 +293,8 @@ func (c *OllamaClient) CheckConnection(ctx context.Context) error {
 }
 
 // Ensure OllamaClient implements Provider interface
-var _ Provider = (*OllamaClient)(nil)
+var (
+	_ Provider            = (*OllamaClient)(nil)
+	_ RawResponseProvider = (*OllamaClient)(nil)
+	_ FollowUpProvider    = (*OllamaClient)(nil)
+)
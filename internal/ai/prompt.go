@@ -21,6 +21,10 @@ type Analysis struct {
 	Warnings        []string       `json:"warnings"`
 	Recommendations []string       `json:"recommendations"`
 	Metrics         map[string]any `json:"metrics"`
+
+	// Ensemble is set when the analysis was reconciled from several
+	// providers; it is never part of the model's JSON response.
+	Ensemble *EnsembleResult `json:"-"`
 }
 
 // StringArrayFormatReminder is appended verbatim to every PromptBuilder's
@@ -388,6 +392,25 @@ func ShouldTriggerAlert(status string) bool {
 	return alertStatuses[status]
 }
 
+// StatusRank returns the severity rank of a system status, from 0 for
+// "Excellent" to 4 for "Awful". Unknown statuses return -1.
+func StatusRank(status string) int {
+	switch status {
+	case "Excellent":
+		return 0
+	case "Good":
+		return 1
+	case "Satisfactory":
+		return 2
+	case "Bad":
+		return 3
+	case "Awful":
+		return 4
+	default:
+		return -1
+	}
+}
+
 // extractJSON extracts the first balanced JSON object from a response string.
 // This is more reliable than greedy regex matching (M-06 fix).
 func extractJSON(response string) string {
@@ -526,6 +526,18 @@ func TestShouldTriggerAlert(t *testing.T) {
 	}
 }
 
+func TestStatusRank(t *testing.T) {
+	statuses := []string{"Excellent", "Good", "Satisfactory", "Bad", "Awful"}
+	for want, status := range statuses {
+		if got := StatusRank(status); got != want {
+			t.Errorf("StatusRank(%q) = %d, want %d", status, got, want)
+		}
+	}
+	if got := StatusRank("Unknown"); got != -1 {
+		t.Errorf("StatusRank(Unknown) = %d, want -1", got)
+	}
+}
+
 func TestExtractJSON(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1,8 +1,8 @@
 // Copyright (c) 2025-2026 Oleg Ivanchenko
 // SPDX-License-Identifier: GPL-3.0-or-later
 
-// Package ai exposes LLM provider clients (Anthropic, Ollama, LM Studio)
-// and shared prompt, parsing, and token-counting helpers.
+// Package ai exposes LLM provider clients (Anthropic, Ollama, LM Studio,
+// and an offline mock) and shared prompt, parsing, and token-counting helpers.
 package ai
 
 import "context"
@@ -24,3 +24,10 @@ type Provider interface {
 type PromptTokenCounter interface {
 	CountPromptTokens(ctx context.Context, systemPrompt, userPrompt string) (int, error)
 }
+
+// RawResponseProvider is an optional capability for providers that can return
+// the model's unparsed response text. The mock provider uses it in record mode
+// so replays go through ParseAnalysis exactly like a live run.
+type RawResponseProvider interface {
+	AnalyzeRaw(ctx context.Context, systemPrompt, userPrompt string) (string, *Stats, error)
+}
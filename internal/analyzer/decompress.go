@@ -0,0 +1,145 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package analyzer
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+// Compression is the compression format of a log file.
+type Compression string
+
+// Compression formats recognized by OpenSourceFile.
+const (
+	CompressionNone  Compression = ""
+	CompressionGzip  Compression = "gzip"
+	CompressionBzip2 Compression = "bzip2"
+	CompressionXZ    Compression = "xz"
+	CompressionZstd  Compression = "zstd"
+)
+
+// zstdMaxWindow caps the memory of the zstd decoder; zstd's own default
+// accepts frames that need gigabytes.
+const zstdMaxWindow = 64 << 20
+
+// ErrContentTooLarge is returned by ReadLimited when the content exceeds
+// the size limit.
+var ErrContentTooLarge = errors.New("content exceeds the size limit")
+
+// compressionMagic maps the leading bytes of each format to it. Files are
+// recognized by content, not by name, so logrotate's .gz, .bz2, .xz, and
+// .zst extensions and renamed files are handled alike.
+var compressionMagic = []struct {
+	magic       []byte
+	compression Compression
+}{
+	{[]byte{0x1f, 0x8b}, CompressionGzip},
+	{[]byte("BZh"), CompressionBzip2},
+	{[]byte{0xfd, '7', 'z', 'X', 'Z', 0x00}, CompressionXZ},
+	{[]byte{0x28, 0xb5, 0x2f, 0xfd}, CompressionZstd},
+}
+
+// DetectCompression returns the compression format of content that starts
+// with header.
+func DetectCompression(header []byte) Compression {
+	for _, m := range compressionMagic {
+		if bytes.HasPrefix(header, m.magic) {
+			return m.compression
+		}
+	}
+	return CompressionNone
+}
+
+// sourceFile is an open log file whose reads return decompressed content.
+type sourceFile struct {
+	io.Reader
+	file  io.Closer
+	close func()
+}
+
+func (f *sourceFile) Close() error {
+	if f.close != nil {
+		f.close()
+	}
+	return f.file.Close()
+}
+
+// OpenSourceFile opens a log file. Reads return the decompressed content of
+// gzip, bzip2, xz, and zstd files and the content of other files as is.
+// The decompressed size is unbounded; read it with ReadLimited.
+func OpenSourceFile(path string) (io.ReadCloser, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return newSourceFile(file, path)
+}
+
+// newSourceFile wraps an open log file, local or remote, so reads return
+// its decompressed content. The file is closed if it is not a valid
+// compressed file.
+func newSourceFile(file io.ReadCloser, path string) (io.ReadCloser, error) {
+	buffered := bufio.NewReader(file)
+	header, err := buffered.Peek(6)
+	if err != nil && !errors.Is(err, io.EOF) {
+		_ = file.Close()
+		return nil, err
+	}
+
+	source := &sourceFile{Reader: buffered, file: file}
+	switch DetectCompression(header) {
+	case CompressionGzip:
+		reader, err := gzip.NewReader(buffered)
+		if err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("invalid gzip file %s: %w", path, err)
+		}
+		source.Reader = reader
+	case CompressionBzip2:
+		source.Reader = bzip2.NewReader(buffered)
+	case CompressionXZ:
+		reader, err := xz.NewReader(buffered)
+		if err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("invalid xz file %s: %w", path, err)
+		}
+		source.Reader = reader
+	case CompressionZstd:
+		decoder, err := zstd.NewReader(buffered,
+			zstd.WithDecoderConcurrency(1),
+			zstd.WithDecoderMaxWindow(zstdMaxWindow))
+		if err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("invalid zstd file %s: %w", path, err)
+		}
+		source.Reader = decoder
+		source.close = decoder.Close
+	}
+
+	return source, nil
+}
+
+// ReadLimited reads r to the end, failing with ErrContentTooLarge as soon as
+// more than maxBytes are read. With decompressing readers this bounds the
+// memory used by a small file that expands to gigabytes.
+func ReadLimited(r io.Reader, maxBytes int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, ErrContentTooLarge
+	}
+	return data, nil
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package analyzer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+// bzip2Sample is "bzip2 line one\nbzip2 line two\n" compressed with bzip2;
+// the standard library only decompresses the format.
+var bzip2Sample = []byte{
+	0x42, 0x5a, 0x68, 0x39, 0x31, 0x41, 0x59, 0x26, 0x53, 0x59, 0x03, 0x22, 0x77, 0x4f, 0x00, 0x00,
+	0x04, 0x59, 0x80, 0x00, 0x10, 0x40, 0x00, 0x10, 0x00, 0x12, 0x25, 0xc4, 0x90, 0x20, 0x00, 0x21,
+	0x2a, 0x68, 0xd0, 0xf4, 0x8f, 0x42, 0x01, 0xa0, 0x05, 0xb9, 0x20, 0xa2, 0x4a, 0xc5, 0x0c, 0x42,
+	0xf0, 0xf5, 0x92, 0x1b, 0x2c, 0x5d, 0xc9, 0x14, 0xe1, 0x42, 0x40, 0x0c, 0x89, 0xdd, 0x3c,
+}
+
+func gzipBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func xzBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := xz.NewWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func zstdBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+	enc, err := zstd.NewWriter(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = enc.Close() }()
+	return enc.EncodeAll(data, nil)
+}
+
+func TestOpenSourceFile(t *testing.T) {
+	t.Parallel()
+
+	plain := []byte("line one\nline two\n")
+	tests := []struct {
+		name        string
+		data        []byte
+		want        string
+		compression Compression
+	}{
+		{"plain", plain, string(plain), CompressionNone},
+		{"empty", nil, "", CompressionNone},
+		{"gzip", gzipBytes(t, plain), string(plain), CompressionGzip},
+		{"bzip2", bzip2Sample, "bzip2 line one\nbzip2 line two\n", CompressionBzip2},
+		{"xz", xzBytes(t, plain), string(plain), CompressionXZ},
+		{"zstd", zstdBytes(t, plain), string(plain), CompressionZstd},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := DetectCompression(tt.data); got != tt.compression {
+				t.Errorf("DetectCompression() = %q, want %q", got, tt.compression)
+			}
+
+			// No extension: the format is recognized by content
+			path := filepath.Join(t.TempDir(), "app.log.1")
+			if err := os.WriteFile(path, tt.data, 0o600); err != nil {
+				t.Fatal(err)
+			}
+			f, err := OpenSourceFile(path)
+			if err != nil {
+				t.Fatalf("OpenSourceFile() error = %v", err)
+			}
+			defer func() { _ = f.Close() }()
+
+			got, err := io.ReadAll(f)
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("content = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenSourceFile_InvalidGzip(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "broken.log.gz")
+	if err := os.WriteFile(path, []byte{0x1f, 0x8b, 0x00}, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenSourceFile(path); err == nil || !strings.Contains(err.Error(), "invalid gzip file") {
+		t.Errorf("OpenSourceFile() error = %v, want invalid gzip file", err)
+	}
+}
+
+func TestReadLimited(t *testing.T) {
+	t.Parallel()
+
+	if got, err := ReadLimited(strings.NewReader("12345"), 5); err != nil || string(got) != "12345" {
+		t.Errorf("ReadLimited(at limit) = %q, %v", got, err)
+	}
+	if _, err := ReadLimited(strings.NewReader("123456"), 5); !errors.Is(err, ErrContentTooLarge) {
+		t.Errorf("ReadLimited(over limit) error = %v, want ErrContentTooLarge", err)
+	}
+}
+
+func TestReadSourceFileWithGuards_DecompressionBomb(t *testing.T) {
+	t.Parallel()
+
+	// 4MB of zeros compress to a few KB, well within the 1MB on-disk limit
+	bomb := gzipBytes(t, make([]byte, 4*1024*1024))
+	path := filepath.Join(t.TempDir(), "app.log.1.gz")
+	if err := os.WriteFile(path, bomb, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := ReadSourceFileWithGuards(path, FileReadOptions{
+		SourceLabel: "sample",
+		MaxSizeMB:   1,
+		MaxAge:      24 * time.Hour,
+	}, func(string) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "exceeds maximum size of 1MB after decompression") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
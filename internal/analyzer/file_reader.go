@@ -4,21 +4,39 @@
 package analyzer
 
 import (
+	"errors"
 	"fmt"
-	"os"
+	"io/fs"
+	"strings"
 	"time"
 )
 
 // FileReadOptions controls common source-file read guards used by log readers.
 type FileReadOptions struct {
-	SourceLabel string
-	MaxSizeMB   int
-	MaxAge      time.Duration
+	SourceLabel  string
+	MaxSizeMB    int
+	MaxAge       time.Duration
+	MaxLineBytes int // OpenSourceLines only: longer lines are truncated (default: DefaultMaxLineBytes)
+}
+
+// maxBytes returns the size limit in bytes.
+func (o FileReadOptions) maxBytes() int64 {
+	return int64(o.MaxSizeMB) * 1024 * 1024
 }
 
 // ReadSourceFileWithGuards reads a text source file after common safety checks.
 // It standardizes not-found/readability/size/age errors and then delegates
 // content validation to validateContent.
+//
+// Compressed files (gzip, bzip2, xz, zstd) are decompressed transparently.
+// The source path may also be a pattern matching several files (see
+// ExpandSourcePath), such as rotated segments, which are concatenated in
+// chronological order. The size limit applies to the total decompressed
+// content and the age limit to the newest file.
+//
+// Remote source paths are read through the file system registered for
+// their scheme (see RegisterSourceFS); the guards apply to the remote
+// file information alike.
 func ReadSourceFileWithGuards(
 	sourcePath string,
 	opts FileReadOptions,
@@ -28,55 +46,134 @@ func ReadSourceFileWithGuards(
 		return "", fmt.Errorf("content validator is required")
 	}
 
-	fileInfo, err := os.Stat(sourcePath)
+	fsys, paths, err := guardSourceFiles(sourcePath, opts)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return "", fmt.Errorf("%s file not found: %s: %w", opts.SourceLabel, sourcePath, err)
+		return "", err
+	}
+
+	maxBytes := opts.maxBytes()
+	var content strings.Builder
+	for _, path := range paths {
+		data, err := readDecompressed(fsys, path, maxBytes-int64(content.Len()))
+		if errors.Is(err, ErrContentTooLarge) {
+			return "", fmt.Errorf("%s content exceeds maximum size of %dMB after decompression",
+				opts.SourceLabel, opts.MaxSizeMB)
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s file: %w", opts.SourceLabel, err)
 		}
-		return "", fmt.Errorf("failed to stat %s file: %w", opts.SourceLabel, err)
+		content.Write(data)
+		// Keep the last line of a segment apart from the next segment
+		if len(paths) > 1 && len(data) > 0 && data[len(data)-1] != '\n' {
+			content.WriteByte('\n')
+		}
+	}
+	contentStr := content.String()
+
+	if err := validateContent(contentStr); err != nil {
+		return "", fmt.Errorf("%s content validation failed: %w", opts.SourceLabel, err)
 	}
 
-	if fileInfo.Mode().Perm()&0o400 == 0 {
-		return "", fmt.Errorf("%s file is not readable: %s", opts.SourceLabel, sourcePath)
+	return contentStr, nil
+}
+
+// guardSourceFiles expands the source path and checks the files for
+// existence, readability, size, and age.
+func guardSourceFiles(sourcePath string, opts FileReadOptions) (SourceFS, []string, error) {
+	fsys, err := sourceFSFor(sourcePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	paths, err := expandSourcePath(fsys, sourcePath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil, fmt.Errorf("%s file not found: %s: %w", opts.SourceLabel, sourcePath, err)
+		}
+		return nil, nil, err
 	}
 
-	maxBytes := int64(opts.MaxSizeMB) * 1024 * 1024
-	if fileInfo.Size() > maxBytes {
-		return "", fmt.Errorf("%s file exceeds maximum size of %dMB (size: %.2fMB)",
-			opts.SourceLabel, opts.MaxSizeMB, float64(fileInfo.Size())/1024/1024)
+	maxBytes := opts.maxBytes()
+	var newest time.Time
+	for _, path := range paths {
+		fileInfo, err := fsys.Stat(path)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil, nil, fmt.Errorf("%s file not found: %s: %w", opts.SourceLabel, path, err)
+			}
+			return nil, nil, fmt.Errorf("failed to stat %s file: %w", opts.SourceLabel, err)
+		}
+
+		if fileInfo.Mode().Perm()&0o400 == 0 {
+			return nil, nil, fmt.Errorf("%s file is not readable: %s", opts.SourceLabel, path)
+		}
+
+		if fileInfo.Size() > maxBytes {
+			return nil, nil, fmt.Errorf("%s file exceeds maximum size of %dMB (size: %.2fMB)",
+				opts.SourceLabel, opts.MaxSizeMB, float64(fileInfo.Size())/1024/1024)
+		}
+
+		if fileInfo.ModTime().After(newest) {
+			newest = fileInfo.ModTime()
+		}
 	}
 
 	if opts.MaxAge > 0 {
-		fileAge := time.Since(fileInfo.ModTime())
+		fileAge := time.Since(newest)
 		if fileAge > opts.MaxAge {
-			return "", fmt.Errorf("%s file is too old (%.1f hours), may be stale", opts.SourceLabel, fileAge.Hours())
+			return nil, nil, fmt.Errorf("%s file is too old (%.1f hours), may be stale", opts.SourceLabel, fileAge.Hours())
 		}
 	}
 
-	content, err := os.ReadFile(sourcePath)
+	return fsys, paths, nil
+}
+
+// readDecompressed reads a possibly compressed file, up to maxBytes of
+// decompressed content.
+func readDecompressed(fsys SourceFS, path string, maxBytes int64) ([]byte, error) {
+	opened, err := fsys.Open(path)
 	if err != nil {
-		return "", fmt.Errorf("failed to read %s file: %w", opts.SourceLabel, err)
+		return nil, err
 	}
-	contentStr := string(content)
-
-	if err := validateContent(contentStr); err != nil {
-		return "", fmt.Errorf("%s content validation failed: %w", opts.SourceLabel, err)
+	file, err := newSourceFile(opened, path)
+	if err != nil {
+		return nil, err
 	}
+	defer func() { _ = file.Close() }()
 
-	return contentStr, nil
+	return ReadLimited(file, maxBytes)
 }
 
 // GetSourceFileInfo returns common file metadata used in log-source readers.
+// For a pattern matching several files, the size is their total size on
+// disk and the modification time that of the newest file.
 func GetSourceFileInfo(sourcePath string) (map[string]any, error) {
-	fileInfo, err := os.Stat(sourcePath)
+	fsys, err := sourceFSFor(sourcePath)
+	if err != nil {
+		return nil, err
+	}
+	paths, err := expandSourcePath(fsys, sourcePath)
 	if err != nil {
 		return nil, err
 	}
 
+	var size int64
+	var modified time.Time
+	for _, path := range paths {
+		fileInfo, err := fsys.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		size += fileInfo.Size()
+		if fileInfo.ModTime().After(modified) {
+			modified = fileInfo.ModTime()
+		}
+	}
+
 	return map[string]any{
-		"size_bytes": fileInfo.Size(),
-		"size_mb":    float64(fileInfo.Size()) / 1024 / 1024,
-		"modified":   fileInfo.ModTime(),
-		"age_hours":  time.Since(fileInfo.ModTime()).Hours(),
+		"size_bytes": size,
+		"size_mb":    float64(size) / 1024 / 1024,
+		"modified":   modified,
+		"age_hours":  time.Since(modified).Hours(),
+		"files":      len(paths),
 	}, nil
 }
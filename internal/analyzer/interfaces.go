@@ -3,7 +3,8 @@
 
 // Package analyzer provides common interfaces for log analysis.
 // This abstraction layer enables support for multiple log source types
-// (logwatch, drupal_watchdog, ocms) through a unified interface.
+// (logwatch, drupal_watchdog, ocms, journald, access_log, syslog, docker, and
+// regex-defined custom sources) through a unified interface.
 package analyzer
 
 import "strings"
@@ -40,6 +41,14 @@ type LogReader interface {
 	GetSourceInfo(sourcePath string) (map[string]any, error)
 }
 
+// ContentReader is implemented by readers that can process log content
+// that was not read from a file, such as the stdout of a source command.
+// ReadContent applies the same parsing, validation, and preprocessing as
+// Read, without the file guards (size, age).
+type ContentReader interface {
+	ReadContent(content string) (string, error)
+}
+
 // Preprocessor handles content preprocessing for large logs.
 // Reduces token count while preserving critical information.
 type Preprocessor interface {
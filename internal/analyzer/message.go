@@ -0,0 +1,54 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package analyzer
+
+import (
+	"regexp"
+	"sort"
+)
+
+// Patterns used by NormalizeMessage, compiled once: it runs for every entry.
+var (
+	uuidRegex   = regexp.MustCompile(`[a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[a-fA-F0-9]{4}-[a-fA-F0-9]{4}-[a-fA-F0-9]{12}`)
+	hexRegex    = regexp.MustCompile(`\b(?:0x)?[a-fA-F0-9]{12,}\b`)
+	ipRegex     = regexp.MustCompile(`\b\d{1,3}\.\d{1,3}\.\d{1,3}\.\d{1,3}\b`)
+	numberRegex = regexp.MustCompile(`\d+`)
+)
+
+// NormalizeMessage replaces the variable parts of a log message (UUIDs,
+// long hex IDs, IP addresses, and numbers) with placeholders, so repeats
+// of the same message group together in a digest.
+func NormalizeMessage(msg string) string {
+	// UUIDs and long hex IDs first, since they contain digits
+	msg = uuidRegex.ReplaceAllString(msg, "[UUID]")
+	msg = hexRegex.ReplaceAllString(msg, "[HEX]")
+	msg = ipRegex.ReplaceAllString(msg, "[IP]")
+	msg = numberRegex.ReplaceAllString(msg, "[N]")
+	return msg
+}
+
+// FindIPv4 returns the IPv4 addresses in a log message.
+func FindIPv4(msg string) []string {
+	return ipRegex.FindAllString(msg, -1)
+}
+
+// TruncateMessage truncates a message to maxLen runes, ending it with "..."
+// when it is shortened.
+func TruncateMessage(msg string, maxLen int) string {
+	runes := []rune(msg)
+	if len(runes) <= maxLen {
+		return msg
+	}
+	return string(runes[:maxLen-3]) + "..."
+}
+
+// SortedKeys returns the keys of a set in sorted order.
+func SortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
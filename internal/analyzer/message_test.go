@@ -0,0 +1,33 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package analyzer
+
+import "testing"
+
+func TestNormalizeMessage(t *testing.T) {
+	a := NormalizeMessage("Connection from 10.0.0.1 port 51234 session 4f1c2a9e7b3d5f60")
+	b := NormalizeMessage("Connection from 10.0.0.2 port 40000 session 0a1b2c3d4e5f6a7b")
+	if a != b {
+		t.Errorf("NormalizeMessage() = %q and %q, want equal", a, b)
+	}
+	if got := NormalizeMessage("job 550e8400-e29b-41d4-a716-446655440000 took 12ms"); got != "job [UUID] took [N]ms" {
+		t.Errorf("NormalizeMessage() = %q", got)
+	}
+}
+
+func TestFindIPv4(t *testing.T) {
+	got := FindIPv4("Failed password from 203.0.113.7 via 10.0.0.1 port 22")
+	if len(got) != 2 || got[0] != "203.0.113.7" || got[1] != "10.0.0.1" {
+		t.Errorf("FindIPv4() = %v", got)
+	}
+}
+
+func TestTruncateMessage(t *testing.T) {
+	if got := TruncateMessage("short", 10); got != "short" {
+		t.Errorf("TruncateMessage() = %q, want unchanged", got)
+	}
+	if got := TruncateMessage("ünïcödé message", 8); got != "ünïcö..." {
+		t.Errorf("TruncateMessage() = %q, want %q", got, "ünïcö...")
+	}
+}
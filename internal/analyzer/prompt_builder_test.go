@@ -0,0 +1,90 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package analyzer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/olegiv/logwatch-ai-go/internal/accesslog"
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+	"github.com/olegiv/logwatch-ai-go/internal/customlog"
+	"github.com/olegiv/logwatch-ai-go/internal/docker"
+	"github.com/olegiv/logwatch-ai-go/internal/drupal"
+	"github.com/olegiv/logwatch-ai-go/internal/journald"
+	"github.com/olegiv/logwatch-ai-go/internal/logwatch"
+	"github.com/olegiv/logwatch-ai-go/internal/ocms"
+	"github.com/olegiv/logwatch-ai-go/internal/syslog"
+)
+
+// TestPromptBuilders checks the contract every source's prompt builder
+// shares: the JSON response fields, global exclusions in the system
+// prompt, and the log content, history, and run-scoped exclusions in the
+// user prompt. Source-specific prompt content is tested in the packages.
+func TestPromptBuilders(t *testing.T) {
+	custom, err := (&customlog.Definition{Name: "Order Service", LineRegex: `^(?P<message>.*)$`}).Compile("orders")
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+
+	tests := []struct {
+		builder analyzer.PromptBuilder
+		logType string
+	}{
+		{logwatch.NewPromptBuilder(), "logwatch"},
+		{drupal.NewPromptBuilder(), "drupal_watchdog"},
+		{ocms.NewPromptBuilder(), "ocms"},
+		{journald.NewPromptBuilder(), "journald"},
+		{accesslog.NewPromptBuilder(), "access_log"},
+		{syslog.NewPromptBuilder(), "syslog"},
+		{docker.NewPromptBuilder(), "docker"},
+		{customlog.NewPromptBuilder(custom), "orders"},
+	}
+
+	const (
+		globalPattern     = "nightly backup job"
+		contextualPattern = "staging host"
+		logContent        = "sample log line 4711"
+		history           = "previous run: Good"
+	)
+
+	for _, tt := range tests {
+		t.Run(tt.logType, func(t *testing.T) {
+			t.Parallel()
+			pb := tt.builder
+
+			if got := pb.GetLogType(); got != tt.logType {
+				t.Errorf("GetLogType() = %q, want %q", got, tt.logType)
+			}
+
+			system := pb.GetSystemPrompt(nil)
+			for _, want := range []string{"systemStatus", "criticalIssues", "recommendations"} {
+				if !strings.Contains(system, want) {
+					t.Errorf("system prompt missing response field %q", want)
+				}
+			}
+			if strings.Contains(system, "EXCLUSIONS") {
+				t.Error("system prompt without exclusions has an exclusions block")
+			}
+			if !strings.Contains(pb.GetSystemPrompt([]string{globalPattern}), "- "+globalPattern+"\n") {
+				t.Error("system prompt missing global exclusion")
+			}
+
+			user := pb.GetUserPrompt(logContent, history, []string{contextualPattern})
+			for _, want := range []string{logContent, "HISTORICAL CONTEXT:\n" + history, "RUN-SCOPED EXCLUSIONS", "- " + contextualPattern + "\n"} {
+				if !strings.Contains(user, want) {
+					t.Errorf("user prompt missing %q", want)
+				}
+			}
+			if !strings.Contains(user, "JSON") {
+				t.Error("user prompt does not ask for the JSON response")
+			}
+
+			bare := pb.GetUserPrompt(logContent, "", nil)
+			if strings.Contains(bare, "HISTORICAL CONTEXT") || strings.Contains(bare, "EXCLUSIONS") {
+				t.Error("user prompt should omit empty historical context and exclusions")
+			}
+		})
+	}
+}
@@ -5,6 +5,8 @@ package analyzer
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
 	"sync"
 )
 
@@ -16,6 +18,10 @@ const (
 	LogSourceLogwatch       LogSourceType = "logwatch"
 	LogSourceDrupalWatchdog LogSourceType = "drupal_watchdog"
 	LogSourceOCMS           LogSourceType = "ocms"
+	LogSourceJournald       LogSourceType = "journald"
+	LogSourceAccessLog      LogSourceType = "access_log"
+	LogSourceSyslog         LogSourceType = "syslog"
+	LogSourceDocker         LogSourceType = "docker"
 )
 
 // LogSource bundles all components needed to analyze a specific log type.
@@ -26,17 +32,24 @@ type LogSource struct {
 	PromptBuilder PromptBuilder
 }
 
+// SourceFactory creates the log source of a source type for a run.
+// Factories defer building sources whose settings are only valid when the
+// source type is selected.
+type SourceFactory func() (*LogSource, error)
+
 // Registry holds all registered log sources.
 // It provides thread-safe access to log source configurations.
 type Registry struct {
-	mu      sync.RWMutex
-	sources map[LogSourceType]*LogSource
+	mu        sync.RWMutex
+	sources   map[LogSourceType]*LogSource
+	factories map[LogSourceType]SourceFactory
 }
 
 // NewRegistry creates a new empty registry.
 func NewRegistry() *Registry {
 	return &Registry{
-		sources: make(map[LogSourceType]*LogSource),
+		sources:   make(map[LogSourceType]*LogSource),
+		factories: make(map[LogSourceType]SourceFactory),
 	}
 }
 
@@ -63,9 +76,57 @@ func (r *Registry) Register(source *LogSource) error {
 	defer r.mu.Unlock()
 
 	r.sources[source.Type] = source
+	delete(r.factories, source.Type)
 	return nil
 }
 
+// RegisterFactory adds a log source type whose source is built by factory
+// on Create. It replaces a source or factory of the same type.
+func (r *Registry) RegisterFactory(sourceType LogSourceType, factory SourceFactory) error {
+	if sourceType == "" {
+		return fmt.Errorf("log source type cannot be empty")
+	}
+	if factory == nil {
+		return fmt.Errorf("log source factory cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.factories[sourceType] = factory
+	delete(r.sources, sourceType)
+	return nil
+}
+
+// Create returns the log source of a type: the registered source, or the
+// one built by its factory. Built sources are not cached, so each call
+// gets fresh components.
+func (r *Registry) Create(sourceType LogSourceType) (*LogSource, error) {
+	r.mu.RLock()
+	source, ok := r.sources[sourceType]
+	factory := r.factories[sourceType]
+	r.mu.RUnlock()
+
+	if ok {
+		return source, nil
+	}
+	if factory == nil {
+		return nil, fmt.Errorf("unsupported log source type: %s", sourceType)
+	}
+
+	source, err := factory()
+	if err != nil {
+		return nil, err
+	}
+	if source == nil || source.Reader == nil || source.Preprocessor == nil || source.PromptBuilder == nil {
+		return nil, fmt.Errorf("log source factory of %s returned an incomplete source", sourceType)
+	}
+	if source.Type != sourceType {
+		return nil, fmt.Errorf("log source factory of %s returned a %s source", sourceType, source.Type)
+	}
+	return source, nil
+}
+
 // Get retrieves a log source by type.
 // Returns nil and false if the source type is not registered.
 func (r *Registry) Get(sourceType LogSourceType) (*LogSource, bool) {
@@ -91,10 +152,13 @@ func (r *Registry) List() []LogSourceType {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	types := make([]LogSourceType, 0, len(r.sources))
+	types := make([]LogSourceType, 0, len(r.sources)+len(r.factories))
 	for t := range r.sources {
 		types = append(types, t)
 	}
+	for t := range r.factories {
+		types = append(types, t)
+	}
 	return types
 }
 
@@ -103,23 +167,96 @@ func (r *Registry) Has(sourceType LogSourceType) bool {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	_, ok := r.sources[sourceType]
+	if _, ok := r.sources[sourceType]; ok {
+		return true
+	}
+	_, ok := r.factories[sourceType]
 	return ok
 }
 
-// ValidSourceTypes returns a list of valid log source type strings.
-// Useful for configuration validation.
-func ValidSourceTypes() []string {
+// customSourceTypeRegex validates the names of custom source types.
+var customSourceTypeRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// customSourceTypes holds the source types declared by RegisterSourceType.
+var customSourceTypes = struct {
+	sync.RWMutex
+	types map[LogSourceType]struct{}
+}{types: make(map[LogSourceType]struct{})}
+
+// RegisterSourceType declares a custom source type defined in
+// configuration rather than code, so that ParseSourceType and
+// ValidSourceTypes accept it. Built-in type names cannot be redeclared;
+// declaring a custom type again is not an error.
+func RegisterSourceType(name string) (LogSourceType, error) {
+	if err := ValidateCustomSourceType(name); err != nil {
+		return "", err
+	}
+
+	customSourceTypes.Lock()
+	defer customSourceTypes.Unlock()
+
+	customSourceTypes.types[LogSourceType(name)] = struct{}{}
+	return LogSourceType(name), nil
+}
+
+// ValidateCustomSourceType checks the name of a custom source type.
+func ValidateCustomSourceType(name string) error {
+	if !customSourceTypeRegex.MatchString(name) {
+		return fmt.Errorf("invalid source type name %q (lowercase letters, digits, and underscores, starting with a letter)", name)
+	}
+	if slices.Contains(builtinSourceTypes(), name) {
+		return fmt.Errorf("source type %q is built in", name)
+	}
+	return nil
+}
+
+// IsCustomSourceType reports whether a source type was declared by
+// RegisterSourceType.
+func IsCustomSourceType(sourceType LogSourceType) bool {
+	customSourceTypes.RLock()
+	defer customSourceTypes.RUnlock()
+
+	_, ok := customSourceTypes.types[sourceType]
+	return ok
+}
+
+// builtinSourceTypes returns the source types implemented in code.
+func builtinSourceTypes() []string {
 	return []string{
 		string(LogSourceLogwatch),
 		string(LogSourceDrupalWatchdog),
 		string(LogSourceOCMS),
+		string(LogSourceJournald),
+		string(LogSourceAccessLog),
+		string(LogSourceSyslog),
+		string(LogSourceDocker),
 	}
 }
 
+// ValidSourceTypes returns a list of valid log source type strings: the
+// built-in types followed by the declared custom types, sorted.
+// Useful for configuration validation.
+func ValidSourceTypes() []string {
+	types := builtinSourceTypes()
+
+	customSourceTypes.RLock()
+	custom := make([]string, 0, len(customSourceTypes.types))
+	for t := range customSourceTypes.types {
+		custom = append(custom, string(t))
+	}
+	customSourceTypes.RUnlock()
+
+	slices.Sort(custom)
+	return append(types, custom...)
+}
+
 // ParseSourceType converts a string to LogSourceType.
 // Returns an error if the string is not a valid source type.
 func ParseSourceType(s string) (LogSourceType, error) {
+	if IsCustomSourceType(LogSourceType(s)) {
+		return LogSourceType(s), nil
+	}
+
 	switch s {
 	case string(LogSourceLogwatch):
 		return LogSourceLogwatch, nil
@@ -127,6 +264,14 @@ func ParseSourceType(s string) (LogSourceType, error) {
 		return LogSourceDrupalWatchdog, nil
 	case string(LogSourceOCMS):
 		return LogSourceOCMS, nil
+	case string(LogSourceJournald):
+		return LogSourceJournald, nil
+	case string(LogSourceAccessLog):
+		return LogSourceAccessLog, nil
+	case string(LogSourceSyslog):
+		return LogSourceSyslog, nil
+	case string(LogSourceDocker):
+		return LogSourceDocker, nil
 	default:
 		return "", fmt.Errorf("invalid log source type: %q (valid types: %v)", s, ValidSourceTypes())
 	}
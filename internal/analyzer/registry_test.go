@@ -246,14 +246,18 @@ func TestRegistry_Has(t *testing.T) {
 
 func TestValidSourceTypes(t *testing.T) {
 	types := ValidSourceTypes()
-	if len(types) != 3 {
-		t.Errorf("ValidSourceTypes() returned %d items, want 3", len(types))
+	if len(types) != 7 {
+		t.Errorf("ValidSourceTypes() returned %d items, want 7", len(types))
 	}
 
 	expected := map[string]bool{
 		"logwatch":        true,
 		"drupal_watchdog": true,
 		"ocms":            true,
+		"journald":        true,
+		"access_log":      true,
+		"syslog":          true,
+		"docker":          true,
 	}
 
 	for _, typ := range types {
@@ -272,6 +276,10 @@ func TestParseSourceType(t *testing.T) {
 		{"logwatch", LogSourceLogwatch, false},
 		{"drupal_watchdog", LogSourceDrupalWatchdog, false},
 		{"ocms", LogSourceOCMS, false},
+		{"journald", LogSourceJournald, false},
+		{"access_log", LogSourceAccessLog, false},
+		{"syslog", LogSourceSyslog, false},
+		{"docker", LogSourceDocker, false},
 		{"invalid", "", true},
 		{"", "", true},
 		{"LOGWATCH", "", true}, // case sensitive
@@ -290,3 +298,89 @@ func TestParseSourceType(t *testing.T) {
 		})
 	}
 }
+
+func TestRegistry_Create(t *testing.T) {
+	r := NewRegistry()
+	source := &LogSource{
+		Type:          LogSourceLogwatch,
+		Reader:        &mockReader{},
+		Preprocessor:  &mockPreprocessor{},
+		PromptBuilder: &mockPromptBuilder{logType: "logwatch"},
+	}
+	if err := r.Register(source); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	calls := 0
+	err := r.RegisterFactory(LogSourceSyslog, func() (*LogSource, error) {
+		calls++
+		return &LogSource{
+			Type:          LogSourceSyslog,
+			Reader:        &mockReader{},
+			Preprocessor:  &mockPreprocessor{},
+			PromptBuilder: &mockPromptBuilder{logType: "syslog"},
+		}, nil
+	})
+	if err != nil {
+		t.Fatalf("RegisterFactory() error = %v", err)
+	}
+	if !r.Has(LogSourceSyslog) || len(r.List()) != 2 {
+		t.Errorf("factory types not listed: %v", r.List())
+	}
+
+	if got, err := r.Create(LogSourceLogwatch); err != nil || got != source {
+		t.Errorf("Create(logwatch) = %v, %v, want the registered source", got, err)
+	}
+	first, err := r.Create(LogSourceSyslog)
+	if err != nil || first.Type != LogSourceSyslog {
+		t.Fatalf("Create(syslog) = %v, %v", first, err)
+	}
+	second, _ := r.Create(LogSourceSyslog)
+	if first == second || calls != 2 {
+		t.Errorf("Create() should build a fresh source per call (calls = %d)", calls)
+	}
+
+	if _, err := r.Create(LogSourceDocker); err == nil {
+		t.Error("Create() expected error for unregistered type")
+	}
+
+	_ = r.RegisterFactory(LogSourceDocker, func() (*LogSource, error) {
+		return &LogSource{Type: LogSourceJournald, Reader: &mockReader{}, Preprocessor: &mockPreprocessor{}, PromptBuilder: &mockPromptBuilder{}}, nil
+	})
+	if _, err := r.Create(LogSourceDocker); err == nil {
+		t.Error("Create() expected error for a factory returning another type")
+	}
+
+	if err := r.RegisterFactory(LogSourceOCMS, nil); err == nil {
+		t.Error("RegisterFactory() expected error for nil factory")
+	}
+}
+
+func TestRegisterSourceType(t *testing.T) {
+	t.Cleanup(func() {
+		customSourceTypes.Lock()
+		delete(customSourceTypes.types, "orders")
+		customSourceTypes.Unlock()
+	})
+
+	if _, err := ParseSourceType("orders"); err == nil {
+		t.Fatal("ParseSourceType() accepted an undeclared custom type")
+	}
+
+	sourceType, err := RegisterSourceType("orders")
+	if err != nil || sourceType != "orders" {
+		t.Fatalf("RegisterSourceType() = %q, %v", sourceType, err)
+	}
+	if got, err := ParseSourceType("orders"); err != nil || got != "orders" || !IsCustomSourceType(got) {
+		t.Errorf("ParseSourceType(orders) = %q, %v", got, err)
+	}
+	if types := ValidSourceTypes(); types[len(types)-1] != "orders" {
+		t.Errorf("ValidSourceTypes() = %v, want custom types last", types)
+	}
+
+	for _, name := range []string{"syslog", "Orders", "1orders", "order-service", ""} {
+		if _, err := RegisterSourceType(name); err == nil {
+			t.Errorf("RegisterSourceType(%q) expected error", name)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package analyzer
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// Compile-time interface check
+var (
+	_ Preprocessor       = (*SectionPreprocessor)(nil)
+	_ BudgetPreprocessor = (*SectionPreprocessor)(nil)
+)
+
+// Priority levels of digest sections, returned by a SectionPriorityFunc.
+const (
+	SectionPriorityHigh   = 1
+	SectionPriorityMedium = 2
+	SectionPriorityLow    = 3
+)
+
+// SectionPriorityFunc returns the priority of a digest section by name.
+type SectionPriorityFunc func(name string) int
+
+// SectionRenderFunc renders a section whose lines are not ranked by the
+// section alone, such as a list of entries of mixed severity. keep returns
+// the share of lines to keep for a priority. It returns false to leave the
+// section to the default rendering by its SectionPriorityFunc priority.
+type SectionRenderFunc func(sb *strings.Builder, name string, lines []string, keep func(priority int) float64) bool
+
+// sectionRegex matches the "## Name" headers of a digest.
+var sectionRegex = regexp.MustCompile(`(?m)^##\s*(.+?)\s*$`)
+
+// compressionProfile is the share of lines kept per section priority.
+type compressionProfile struct {
+	high, medium, low float64
+}
+
+// ratio returns the share of lines kept for a section priority. Unknown
+// priorities are treated as low.
+func (p compressionProfile) ratio(priority int) float64 {
+	switch priority {
+	case SectionPriorityHigh:
+		return p.high
+	case SectionPriorityMedium:
+		return p.medium
+	default:
+		return p.low
+	}
+}
+
+// compressionProfiles are tried in order until the content fits. Lines
+// within a section are expected to be sorted most important first, so the
+// head of each section is kept.
+var compressionProfiles = []compressionProfile{
+	{high: 1.0, medium: 0.5, low: 0.2},
+	{high: 1.0, medium: 0.25, low: 0.05},
+	{high: 1.0, medium: 0.1, low: 0},
+	{high: 0.5, medium: 0.1, low: 0},
+}
+
+// SectionPreprocessor shortens digests made of "## Name" sections to a
+// token budget. Sections are shortened by priority, low first, keeping
+// the head of each section; content still too large, or without
+// sections, is cut line by line. The sources whose readers write such
+// digests (journald, access_log, docker, custom sources, and OCMS) differ
+// only in their section priorities and, for OCMS, the rendering of one
+// section.
+type SectionPreprocessor struct {
+	maxTokens int
+	priority  SectionPriorityFunc
+	render    SectionRenderFunc
+}
+
+// NewSectionPreprocessor creates a preprocessor ranking the sections with
+// priority.
+func NewSectionPreprocessor(maxTokens int, priority SectionPriorityFunc) *SectionPreprocessor {
+	return &SectionPreprocessor{
+		maxTokens: maxTokens,
+		priority:  priority,
+	}
+}
+
+// WithSectionRenderer sets a renderer for the sections that need their
+// own shortening and returns the preprocessor.
+func (p *SectionPreprocessor) WithSectionRenderer(render SectionRenderFunc) *SectionPreprocessor {
+	p.render = render
+	return p
+}
+
+// EstimateTokens estimates the number of tokens in the content.
+// Delegates to the shared EstimateTokens function.
+func (p *SectionPreprocessor) EstimateTokens(content string) int {
+	return EstimateTokens(content)
+}
+
+// ShouldProcess determines if preprocessing is needed based on token count.
+func (p *SectionPreprocessor) ShouldProcess(content string, maxTokens int) bool {
+	return p.EstimateTokens(content) > maxTokens
+}
+
+// Process shortens the content to the configured token budget.
+func (p *SectionPreprocessor) Process(content string) (string, error) {
+	return p.processWithMaxTokens(content, p.maxTokens)
+}
+
+// ProcessWithBudget shortens the content to a dynamic token budget.
+func (p *SectionPreprocessor) ProcessWithBudget(content string, maxTokens int) (string, error) {
+	return p.processWithMaxTokens(content, maxTokens)
+}
+
+func (p *SectionPreprocessor) processWithMaxTokens(content string, maxTokens int) (string, error) {
+	if content == "" {
+		return "", nil
+	}
+	if maxTokens <= 0 {
+		maxTokens = p.maxTokens
+	}
+	if p.EstimateTokens(content) <= maxTokens {
+		return content, nil
+	}
+
+	header, sections := parseSections(content)
+	if len(sections) == 0 {
+		return p.trimToTokenBudget(content, maxTokens), nil
+	}
+
+	var candidate string
+	for _, profile := range compressionProfiles {
+		candidate = p.renderSections(header, sections, profile)
+		if p.EstimateTokens(candidate) <= maxTokens {
+			return candidate, nil
+		}
+	}
+
+	return p.trimToTokenBudget(candidate, maxTokens), nil
+}
+
+// section is a "## Name" block of a digest.
+type section struct {
+	name  string
+	lines []string
+}
+
+// parseSections splits a digest into the text before the first section
+// and the sections themselves.
+func parseSections(content string) (string, []section) {
+	matches := sectionRegex.FindAllStringSubmatchIndex(content, -1)
+	if len(matches) == 0 {
+		return content, nil
+	}
+
+	header := content[:matches[0][0]]
+	sections := make([]section, 0, len(matches))
+	for i, match := range matches {
+		end := len(content)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		body := strings.TrimSpace(content[match[1]:end])
+		var lines []string
+		if body != "" {
+			lines = strings.Split(body, "\n")
+		}
+		sections = append(sections, section{name: content[match[2]:match[3]], lines: lines})
+	}
+	return header, sections
+}
+
+// renderSections renders the sections keeping the head of each one
+// according to its priority.
+func (p *SectionPreprocessor) renderSections(header string, sections []section, profile compressionProfile) string {
+	var sb strings.Builder
+	sb.WriteString(header)
+
+	for _, s := range sections {
+		if p.render != nil && p.render(&sb, s.name, s.lines, profile.ratio) {
+			continue
+		}
+
+		keep := int(math.Ceil(float64(len(s.lines)) * profile.ratio(p.priority(s.name))))
+		if keep <= 0 {
+			fmt.Fprintf(&sb, "## %s\n[... %d lines omitted due to size limits ...]\n\n", s.name, len(s.lines))
+			continue
+		}
+
+		fmt.Fprintf(&sb, "## %s\n", s.name)
+		for _, line := range s.lines[:keep] {
+			sb.WriteString(line)
+			sb.WriteString("\n")
+		}
+		if omitted := len(s.lines) - keep; omitted > 0 {
+			fmt.Fprintf(&sb, "[... %d more lines omitted ...]\n", omitted)
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// trimToTokenBudget cuts the content line by line until it fits.
+func (p *SectionPreprocessor) trimToTokenBudget(content string, maxTokens int) string {
+	lines := strings.Split(content, "\n")
+	for len(lines) > 1 && p.EstimateTokens(strings.Join(lines, "\n")) > maxTokens {
+		lines = lines[:len(lines)*9/10]
+	}
+	return strings.Join(lines, "\n") + "\n[... truncated due to size limits ...]"
+}
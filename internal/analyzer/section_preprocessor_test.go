@@ -0,0 +1,134 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// testSectionPriority ranks the sections of sectionDigest.
+func testSectionPriority(name string) int {
+	switch name {
+	case "Summary Statistics", "Errors":
+		return SectionPriorityHigh
+	case "Warnings":
+		return SectionPriorityMedium
+	default:
+		return SectionPriorityLow
+	}
+}
+
+func sectionDigest(lines int) string {
+	var sb strings.Builder
+	sb.WriteString("=== SAMPLE DIGEST ===\n\n")
+	sb.WriteString("## Summary Statistics\nEntries: 99999\n\n")
+	sb.WriteString("## Errors\n")
+	for i := range 20 {
+		fmt.Fprintf(&sb, "- [%dx] disk failure on device %d\n", 100-i, i)
+	}
+	sb.WriteString("\n## Warnings\n")
+	for i := range lines / 4 {
+		fmt.Fprintf(&sb, "- [%dx] slow response from backend number %d with some padding\n", lines-i, i)
+	}
+	sb.WriteString("\n## Info\n")
+	for i := range lines {
+		fmt.Fprintf(&sb, "- [%dx] routine message number %d with some padding text\n", lines-i, i)
+	}
+	return sb.String()
+}
+
+func TestSectionPreprocessor_ProcessWithBudget(t *testing.T) {
+	t.Parallel()
+
+	p := NewSectionPreprocessor(150000, testSectionPriority)
+	content := sectionDigest(2000)
+
+	processed, err := p.ProcessWithBudget(content, 2000)
+	if err != nil {
+		t.Fatalf("ProcessWithBudget() error = %v", err)
+	}
+	if p.EstimateTokens(processed) > 2000 {
+		t.Errorf("processed content has %d tokens, want <= 2000", p.EstimateTokens(processed))
+	}
+	for _, want := range []string{
+		"=== SAMPLE DIGEST ===\n",
+		"disk failure on device 19\n",
+		"backend number 0 ",
+		"## Info\n[... 2000 lines omitted due to size limits ...]\n",
+	} {
+		if !strings.Contains(processed, want) {
+			t.Errorf("processed content missing %q", want)
+		}
+	}
+	// The head of a shortened section is kept
+	if !strings.Contains(processed, "more lines omitted ...]") || strings.Contains(processed, "backend number 499 ") {
+		t.Error("expected the warnings section to be shortened to its head")
+	}
+}
+
+func TestSectionPreprocessor_SmallContentUnchanged(t *testing.T) {
+	t.Parallel()
+
+	p := NewSectionPreprocessor(150000, testSectionPriority)
+	content := sectionDigest(5)
+
+	processed, err := p.Process(content)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if processed != content {
+		t.Error("Process() should return content within budget unchanged")
+	}
+	if p.ShouldProcess(content, 150000) {
+		t.Error("ShouldProcess() = true for small content")
+	}
+	if processed, _ := p.Process(""); processed != "" {
+		t.Errorf("Process(\"\") = %q", processed)
+	}
+}
+
+func TestSectionPreprocessor_TrimsUnsectionedContent(t *testing.T) {
+	t.Parallel()
+
+	p := NewSectionPreprocessor(150000, testSectionPriority)
+	content := strings.Repeat("unstructured line with several words\n", 2000)
+
+	processed, err := p.ProcessWithBudget(content, 500)
+	if err != nil {
+		t.Fatalf("ProcessWithBudget() error = %v", err)
+	}
+	if p.EstimateTokens(processed) > 500 || !strings.HasSuffix(processed, "[... truncated due to size limits ...]") {
+		t.Errorf("unexpected trimmed content (%d tokens)", p.EstimateTokens(processed))
+	}
+}
+
+func TestSectionPreprocessor_SectionRenderer(t *testing.T) {
+	t.Parallel()
+
+	// Keep the Info section in full but render it compactly, as a source
+	// with per-line priorities would.
+	var rendered int
+	p := NewSectionPreprocessor(150000, testSectionPriority).WithSectionRenderer(
+		func(sb *strings.Builder, name string, lines []string, keep func(int) float64) bool {
+			if name != "Info" {
+				return false
+			}
+			rendered++
+			fmt.Fprintf(sb, "## %s\n%d lines, keeping %.0f%% of high priority\n\n", name, len(lines), keep(SectionPriorityHigh)*100)
+			return true
+		})
+
+	processed, err := p.ProcessWithBudget(sectionDigest(2000), 2000)
+	if err != nil {
+		t.Fatalf("ProcessWithBudget() error = %v", err)
+	}
+	if rendered == 0 || !strings.Contains(processed, "## Info\n2000 lines, keeping 100% of high priority\n") {
+		t.Errorf("Info section not rendered by the hook:\n%s", processed)
+	}
+	if !strings.Contains(processed, "disk failure on device 19\n") {
+		t.Error("other sections should be rendered by priority")
+	}
+}
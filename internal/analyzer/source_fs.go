@@ -0,0 +1,92 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package analyzer
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+)
+
+// SourceFS is a file system that source files are read from. Names are
+// complete source paths: remote file systems receive and return URLs such
+// as sftp://app01/var/log/syslog, so errors and logs name the remote file.
+type SourceFS interface {
+	Stat(name string) (fs.FileInfo, error)
+	Open(name string) (io.ReadCloser, error)
+	Glob(pattern string) ([]string, error)
+}
+
+// sourceURLRegex matches the scheme of a remote source path.
+var sourceURLRegex = regexp.MustCompile(`^([a-z][a-z0-9+.-]*)://`)
+
+// sourceFileSystems holds the file systems of remote source paths by URL
+// scheme. Paths without a scheme are local.
+var sourceFileSystems = struct {
+	sync.RWMutex
+	schemes map[string]SourceFS
+}{schemes: make(map[string]SourceFS)}
+
+// RegisterSourceFS sets the file system of source paths with the URL
+// scheme, so the readers of all log sources read such paths through it.
+// A nil fsys removes the scheme.
+func RegisterSourceFS(scheme string, fsys SourceFS) {
+	sourceFileSystems.Lock()
+	defer sourceFileSystems.Unlock()
+	if fsys == nil {
+		delete(sourceFileSystems.schemes, scheme)
+		return
+	}
+	sourceFileSystems.schemes[scheme] = fsys
+}
+
+// SourcePathScheme returns the URL scheme of a remote source path, or ""
+// for a local path.
+func SourcePathScheme(sourcePath string) string {
+	if m := sourceURLRegex.FindStringSubmatch(sourcePath); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// IsRemoteSourcePath returns true if the source path is a URL, such as
+// sftp://host/path, rather than a local path.
+func IsRemoteSourcePath(sourcePath string) bool {
+	return SourcePathScheme(sourcePath) != ""
+}
+
+// sourceFSFor returns the file system of a source path.
+func sourceFSFor(sourcePath string) (SourceFS, error) {
+	scheme := SourcePathScheme(sourcePath)
+	if scheme == "" {
+		return localFS{}, nil
+	}
+
+	sourceFileSystems.RLock()
+	defer sourceFileSystems.RUnlock()
+	fsys, ok := sourceFileSystems.schemes[scheme]
+	if !ok {
+		return nil, fmt.Errorf("unsupported source path scheme %s:// (%s)", scheme, sourcePath)
+	}
+	return fsys, nil
+}
+
+// localFS reads source files from the local file system.
+type localFS struct{}
+
+func (localFS) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
+
+func (localFS) Open(name string) (io.ReadCloser, error) {
+	return os.Open(name) // #nosec G304 -- operator-configured log source path
+}
+
+func (localFS) Glob(pattern string) ([]string, error) {
+	return filepath.Glob(pattern)
+}
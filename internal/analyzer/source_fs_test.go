@@ -0,0 +1,98 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package analyzer
+
+import (
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+// mapSourceFS serves test:// source paths from a map file system.
+type mapSourceFS struct {
+	files fstest.MapFS
+}
+
+func (m mapSourceFS) name(sourcePath string) string {
+	return strings.TrimPrefix(sourcePath, "test:///")
+}
+
+func (m mapSourceFS) Stat(name string) (fs.FileInfo, error) {
+	return m.files.Stat(m.name(name))
+}
+
+func (m mapSourceFS) Open(name string) (io.ReadCloser, error) {
+	return m.files.Open(m.name(name))
+}
+
+func (m mapSourceFS) Glob(pattern string) ([]string, error) {
+	matches, err := fs.Glob(m.files, m.name(pattern))
+	for i, match := range matches {
+		matches[i] = "test:///" + match
+	}
+	return matches, err
+}
+
+func TestSourcePathScheme(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"/var/log/syslog":              "",
+		"logs/app.log":                 "",
+		"sftp://app01/var/log/syslog":  "sftp",
+		"SFTP://app01/var/log/syslog":  "",
+		"/srv/backup/sftp://not-a-url": "",
+	}
+	for sourcePath, want := range tests {
+		if got := SourcePathScheme(sourcePath); got != want {
+			t.Errorf("SourcePathScheme(%q) = %q, want %q", sourcePath, got, want)
+		}
+		if IsRemoteSourcePath(sourcePath) != (want != "") {
+			t.Errorf("IsRemoteSourcePath(%q) = %v", sourcePath, want == "")
+		}
+	}
+}
+
+func TestReadSourceFileWithGuards_SourceFS(t *testing.T) {
+	// Registers the test scheme, so not parallel
+	now := time.Now()
+	RegisterSourceFS("test", mapSourceFS{files: fstest.MapFS{
+		"logs/app.log.1": {Data: []byte("older line\n"), ModTime: now.Add(-time.Hour), Mode: 0o644},
+		"logs/app.log":   {Data: []byte("newer line\n"), ModTime: now, Mode: 0o644},
+		"logs/old.log":   {Data: []byte("old line\n"), ModTime: now.Add(-48 * time.Hour), Mode: 0o644},
+		"logs/secret":    {Data: []byte("secret\n"), ModTime: now, Mode: 0o200},
+	}})
+	t.Cleanup(func() { RegisterSourceFS("test", nil) })
+
+	opts := FileReadOptions{SourceLabel: "app", MaxSizeMB: 1, MaxAge: 24 * time.Hour}
+	accept := func(string) error { return nil }
+
+	got, err := ReadSourceFileWithGuards("test:///logs/app.log*", opts, accept)
+	if err != nil {
+		t.Fatalf("ReadSourceFileWithGuards() error = %v", err)
+	}
+	if got != "older line\nnewer line\n" {
+		t.Errorf("ReadSourceFileWithGuards() = %q", got)
+	}
+
+	for name, want := range map[string]string{
+		"old.log": "too old",
+		"secret":  "not readable",
+		"missing": "app file not found: test:///logs/missing",
+	} {
+		_, err := ReadSourceFileWithGuards("test:///"+path.Join("logs", name), opts, accept)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ReadSourceFileWithGuards(%s) error = %v, want %q", name, err, want)
+		}
+	}
+
+	_, err = ReadSourceFileWithGuards("ftp://host/var/log/syslog", opts, accept)
+	if err == nil || !strings.Contains(err.Error(), "unsupported source path scheme ftp://") {
+		t.Errorf("ReadSourceFileWithGuards(ftp) error = %v, want unsupported scheme", err)
+	}
+}
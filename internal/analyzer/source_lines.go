@@ -0,0 +1,254 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package analyzer
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// DefaultMaxLineBytes is the line length beyond which OpenSourceLines
+// truncates lines unless FileReadOptions.MaxLineBytes is set.
+const DefaultMaxLineBytes = 1 << 20
+
+// lineReaderSize is the read buffer of a LineScanner.
+const lineReaderSize = 64 << 10
+
+// OpenSourceReader checks a source file like ReadSourceFileWithGuards and
+// returns a reader over its decompressed content, with the files of a
+// pattern concatenated in chronological order. Unlike
+// ReadSourceFileWithGuards it does not hold the content in memory; the
+// size limit applies to the content read, and exceeding it fails the
+// read.
+func OpenSourceReader(sourcePath string, opts FileReadOptions) (io.ReadCloser, error) {
+	fsys, paths, err := guardSourceFiles(sourcePath, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &sourceReader{fsys: fsys, paths: paths, opts: opts}, nil
+}
+
+// sourceReader reads the files of a source one after the other.
+type sourceReader struct {
+	fsys  SourceFS
+	paths []string
+	opts  FileReadOptions
+
+	file io.ReadCloser
+	// last is the last byte read from the current file
+	last           byte
+	pendingNewline bool
+	read           int64
+	err            error
+}
+
+func (s *sourceReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	for {
+		if s.err != nil {
+			return 0, s.err
+		}
+		// Keep the last line of a segment apart from the next segment
+		if s.pendingNewline {
+			s.pendingNewline = false
+			p[0] = '\n'
+			return s.count(1)
+		}
+		if s.file == nil {
+			if len(s.paths) == 0 {
+				return 0, io.EOF
+			}
+			if err := s.openNext(); err != nil {
+				s.err = err
+				return 0, err
+			}
+		}
+
+		n, err := s.file.Read(p)
+		if n > 0 {
+			s.last = p[n-1]
+			return s.count(n)
+		}
+		if errors.Is(err, io.EOF) {
+			s.closeFile()
+			if s.last != 0 && s.last != '\n' && len(s.paths) > 0 {
+				s.pendingNewline = true
+			}
+			s.last = 0
+			continue
+		}
+		if err != nil {
+			s.err = fmt.Errorf("failed to read %s file: %w", s.opts.SourceLabel, err)
+			return 0, s.err
+		}
+	}
+}
+
+// count adds n bytes to the content read and enforces the size limit.
+func (s *sourceReader) count(n int) (int, error) {
+	s.read += int64(n)
+	if s.read > s.opts.maxBytes() {
+		s.err = fmt.Errorf("%s content exceeds maximum size of %dMB after decompression",
+			s.opts.SourceLabel, s.opts.MaxSizeMB)
+		return 0, s.err
+	}
+	return n, nil
+}
+
+// openNext opens the next file of the source.
+func (s *sourceReader) openNext() error {
+	path := s.paths[0]
+	s.paths = s.paths[1:]
+
+	opened, err := s.fsys.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s file: %w", s.opts.SourceLabel, err)
+	}
+	file, err := newSourceFile(opened, path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s file: %w", s.opts.SourceLabel, err)
+	}
+	s.file = file
+	return nil
+}
+
+// closeFile closes the current file.
+func (s *sourceReader) closeFile() {
+	if s.file != nil {
+		_ = s.file.Close()
+	}
+	s.file = nil
+}
+
+// Close closes the file being read.
+func (s *sourceReader) Close() error {
+	s.closeFile()
+	s.paths = nil
+	return nil
+}
+
+// LineScanner streams the lines of a source file, decompressed and with
+// the files of a pattern in chronological order, so a reader can process
+// logs far larger than memory. Lines are returned without the line ending.
+type LineScanner struct {
+	source       *sourceReader
+	reader       *bufio.Reader
+	maxLineBytes int
+
+	line      []byte
+	long      []byte
+	truncated int
+	err       error
+}
+
+// OpenSourceLines checks a source file like ReadSourceFileWithGuards and
+// returns a scanner over its lines. The size limit applies to the
+// decompressed content read; exceeding it fails the scan. Close the
+// scanner when done.
+func OpenSourceLines(sourcePath string, opts FileReadOptions) (*LineScanner, error) {
+	fsys, paths, err := guardSourceFiles(sourcePath, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.MaxLineBytes <= 0 {
+		opts.MaxLineBytes = DefaultMaxLineBytes
+	}
+	source := &sourceReader{fsys: fsys, paths: paths, opts: opts}
+	return &LineScanner{
+		source:       source,
+		reader:       bufio.NewReaderSize(source, lineReaderSize),
+		maxLineBytes: opts.MaxLineBytes,
+	}, nil
+}
+
+// Scan advances to the next line. It returns false at the end of the last
+// file or on an error, which Err reports.
+func (s *LineScanner) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+
+	line, err := s.readLine()
+	if err != nil && !errors.Is(err, io.EOF) {
+		s.err = err
+		return false
+	}
+	if errors.Is(err, io.EOF) && len(line) == 0 {
+		return false
+	}
+
+	s.line = trimLineEnding(line)
+	return true
+}
+
+// readLine reads one line. Lines longer than maxLineBytes are truncated
+// and the rest is discarded.
+func (s *LineScanner) readLine() ([]byte, error) {
+	s.long = s.long[:0]
+	for {
+		chunk, err := s.reader.ReadSlice('\n')
+		if !errors.Is(err, bufio.ErrBufferFull) {
+			if len(s.long) == 0 {
+				return chunk, err
+			}
+			s.long = appendCapped(s.long, chunk, s.maxLineBytes)
+			return s.long, err
+		}
+		wasFull := len(s.long) >= s.maxLineBytes
+		s.long = appendCapped(s.long, chunk, s.maxLineBytes)
+		if !wasFull && len(s.long) >= s.maxLineBytes {
+			s.truncated++
+		}
+	}
+}
+
+// appendCapped appends data to buf up to limit bytes.
+func appendCapped(buf, data []byte, limit int) []byte {
+	if room := limit - len(buf); room < len(data) {
+		data = data[:max(room, 0)]
+	}
+	return append(buf, data...)
+}
+
+// trimLineEnding removes a trailing LF or CRLF.
+func trimLineEnding(line []byte) []byte {
+	line = bytes.TrimSuffix(line, []byte("\n"))
+	return bytes.TrimSuffix(line, []byte("\r"))
+}
+
+// Bytes returns the current line. The slice is only valid until the next
+// call to Scan.
+func (s *LineScanner) Bytes() []byte {
+	return s.line
+}
+
+// Text returns the current line as a string.
+func (s *LineScanner) Text() string {
+	return string(s.line)
+}
+
+// Err returns the first error of the scan.
+func (s *LineScanner) Err() error {
+	return s.err
+}
+
+// BytesRead returns the decompressed bytes read so far.
+func (s *LineScanner) BytesRead() int64 {
+	return s.source.read
+}
+
+// Truncated returns the number of lines truncated to MaxLineBytes.
+func (s *LineScanner) Truncated() int {
+	return s.truncated
+}
+
+// Close closes the file being read.
+func (s *LineScanner) Close() error {
+	return s.source.Close()
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package analyzer
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// scanAll returns the lines of a scanner.
+func scanAll(t *testing.T, scanner *LineScanner) ([]string, error) {
+	t.Helper()
+	defer func() { _ = scanner.Close() }()
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
+
+func TestOpenSourceLines(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeRotatedLogs(t, dir)
+	// A segment without a final line ending and with CRLF endings
+	if err := os.WriteFile(filepath.Join(dir, "app.log"), []byte("day 4 line\r\nday 4 tail"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	scanner, err := OpenSourceLines(filepath.Join(dir, "app.log*"), FileReadOptions{
+		SourceLabel: "sample",
+		MaxSizeMB:   10,
+		MaxAge:      24 * time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("OpenSourceLines() error = %v", err)
+	}
+	lines, err := scanAll(t, scanner)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	want := []string{"day 1 line", "day 2 line", "day 3 line", "day 4 line", "day 4 tail"}
+	if strings.Join(lines, "|") != strings.Join(want, "|") {
+		t.Errorf("lines = %q, want %q", lines, want)
+	}
+	if scanner.BytesRead() == 0 {
+		t.Error("BytesRead() = 0")
+	}
+}
+
+func TestOpenSourceLines_Compressed(t *testing.T) {
+	t.Parallel()
+
+	f := filepath.Join(t.TempDir(), "sample.log.gz")
+	if err := os.WriteFile(f, gzipBytes(t, []byte("first\nsecond\n")), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	scanner, err := OpenSourceLines(f, FileReadOptions{SourceLabel: "sample", MaxSizeMB: 1})
+	if err != nil {
+		t.Fatalf("OpenSourceLines() error = %v", err)
+	}
+	lines, err := scanAll(t, scanner)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if len(lines) != 2 || lines[0] != "first" || lines[1] != "second" {
+		t.Errorf("lines = %q", lines)
+	}
+}
+
+func TestOpenSourceLines_LongLines(t *testing.T) {
+	t.Parallel()
+
+	f := filepath.Join(t.TempDir(), "sample.log")
+	long := strings.Repeat("x", 3*lineReaderSize)
+	if err := os.WriteFile(f, []byte("short\n"+long+"\nafter\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	scanner, err := OpenSourceLines(f, FileReadOptions{SourceLabel: "sample", MaxSizeMB: 1, MaxLineBytes: 1000})
+	if err != nil {
+		t.Fatalf("OpenSourceLines() error = %v", err)
+	}
+	lines, err := scanAll(t, scanner)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if len(lines) != 3 || lines[0] != "short" || lines[1] != long[:1000] || lines[2] != "after" {
+		t.Errorf("got %d lines, want the long line truncated to 1000 bytes", len(lines))
+	}
+	if scanner.Truncated() != 1 {
+		t.Errorf("Truncated() = %d, want 1", scanner.Truncated())
+	}
+}
+
+func TestOpenSourceLines_SizeLimit(t *testing.T) {
+	t.Parallel()
+
+	// 2MB of lines compress to a file far below the 1MB limit
+	f := filepath.Join(t.TempDir(), "bomb.log.gz")
+	data := bytes.Repeat([]byte("repeated line\n"), 2<<20/14)
+	if err := os.WriteFile(f, gzipBytes(t, data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	scanner, err := OpenSourceLines(f, FileReadOptions{SourceLabel: "sample", MaxSizeMB: 1})
+	if err != nil {
+		t.Fatalf("OpenSourceLines() error = %v", err)
+	}
+	if _, err := scanAll(t, scanner); err == nil || !strings.Contains(err.Error(), "exceeds maximum size of 1MB after decompression") {
+		t.Errorf("Scan() error = %v, want the decompressed size limit", err)
+	}
+}
+
+func TestOpenSourceLines_NotFound(t *testing.T) {
+	t.Parallel()
+
+	_, err := OpenSourceLines(filepath.Join(t.TempDir(), "missing.log"), FileReadOptions{SourceLabel: "sample", MaxSizeMB: 1})
+	if err == nil || !strings.Contains(err.Error(), "sample file not found") {
+		t.Errorf("OpenSourceLines() error = %v", err)
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package analyzer
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSourcePaths caps the number of files a source path expands to.
+const maxSourcePaths = 1000
+
+// braceRangePattern matches a numeric brace range such as {1..7}.
+var braceRangePattern = regexp.MustCompile(`^(\d+)\.\.(\d+)$`)
+
+// compressedExtensions are stripped before reading the rotation number of
+// a file name, so ocms.log.2.gz is rotation 2.
+var compressedExtensions = []string{".gz", ".bz2", ".xz", ".zst"}
+
+// maxRotationDigits is the longest numeric suffix read as a rotation
+// number; longer ones, such as dateext dates, are not rotation numbers.
+const maxRotationDigits = 5
+
+// dateSuffixPattern matches the date of a dateext rotation, as in
+// app.log-20261017, app.log.20261017, app.log-2026-10-17, or the hourly
+// app.log-2026101702.
+var dateSuffixPattern = regexp.MustCompile(`[.-](\d{4}-\d{2}-\d{2}|\d{8}(?:\d{2})?)$`)
+
+// ExpandSourcePath returns the files of a source path in chronological
+// order. Besides a single file, the path may be a glob pattern (*, ?, [...])
+// and contain brace lists and numeric ranges like a shell would expand them:
+//
+//	/var/log/syslog*
+//	/srv/ocms/logs/ocms.log.{7..1}.gz
+//	/srv/ocms/logs/{ocms,error}.log.1
+//
+// Files that do not exist are left out; if none exist, the error wraps
+// fs.ErrNotExist. A path that names an existing file is never expanded.
+//
+// Rotated files are ordered oldest first: by rotation number, highest
+// first (syslog.3.gz, syslog.2.gz, syslog.1, syslog), then dateext
+// rotations by their date (syslog-20261016, syslog-20261017), then other
+// names, such as the live file, by modification time.
+//
+// Remote source paths (see RegisterSourceFS) are expanded on the remote
+// host.
+func ExpandSourcePath(sourcePath string) ([]string, error) {
+	fsys, err := sourceFSFor(sourcePath)
+	if err != nil {
+		return nil, err
+	}
+	return expandSourcePath(fsys, sourcePath)
+}
+
+// expandSourcePath implements ExpandSourcePath on a file system.
+func expandSourcePath(fsys SourceFS, sourcePath string) ([]string, error) {
+	if !strings.ContainsAny(sourcePath, "*?[{") {
+		return []string{sourcePath}, nil
+	}
+	if _, err := fsys.Stat(sourcePath); err == nil {
+		return []string{sourcePath}, nil
+	}
+
+	patterns, err := expandBraces(sourcePath)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var files []sourcePathFile
+	for _, pattern := range patterns {
+		matches, err := fsys.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid source path pattern %s: %w", sourcePath, err)
+		}
+		for _, match := range matches {
+			info, err := fsys.Stat(match)
+			if err != nil || info.IsDir() || seen[match] {
+				continue
+			}
+			seen[match] = true
+			files = append(files, sourcePathFile{path: match, modified: info.ModTime()})
+		}
+		if len(files) > maxSourcePaths {
+			return nil, fmt.Errorf("source path %s matches more than %d files", sourcePath, maxSourcePaths)
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files match %s: %w", sourcePath, fs.ErrNotExist)
+	}
+
+	for i := range files {
+		files[i].rotation = rotationNumber(files[i].path)
+		files[i].rotated, files[i].dated = rotationDate(files[i].path)
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		a, b := files[i], files[j]
+		if a.rotation != b.rotation {
+			return a.rotation > b.rotation
+		}
+		if a.dated != b.dated {
+			return a.dated
+		}
+		if !a.rotated.Equal(b.rotated) {
+			return a.rotated.Before(b.rotated)
+		}
+		if !a.modified.Equal(b.modified) {
+			return a.modified.Before(b.modified)
+		}
+		return a.path < b.path
+	})
+
+	paths := make([]string, len(files))
+	for i, f := range files {
+		paths[i] = f.path
+	}
+	return paths, nil
+}
+
+// sourcePathFile is a file matched by a source path pattern.
+type sourcePathFile struct {
+	path     string
+	modified time.Time
+	rotation int
+	rotated  time.Time // date of a dateext rotation
+	dated    bool
+}
+
+// rotationNumber returns N of a logrotate file name like app.log.N or
+// app.log.N.gz, or 0 for the live file and other names.
+func rotationNumber(path string) int {
+	name := rotationName(path)
+	dot := strings.LastIndexByte(name, '.')
+	if dot < 0 {
+		return 0
+	}
+	suffix := name[dot+1:]
+	if len(suffix) > maxRotationDigits {
+		return 0
+	}
+	n, err := strconv.Atoi(suffix)
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+// rotationDate returns the date of a dateext rotation like
+// app.log-20261017.gz, and false for other names.
+func rotationDate(path string) (time.Time, bool) {
+	m := dateSuffixPattern.FindStringSubmatch(rotationName(path))
+	if m == nil {
+		return time.Time{}, false
+	}
+	layout := "2006-01-02"
+	switch len(m[1]) {
+	case 8:
+		layout = "20060102"
+	case 10:
+		if !strings.Contains(m[1], "-") {
+			layout = "2006010215"
+		}
+	}
+	date, err := time.Parse(layout, m[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// rotationName returns the file name of path without a compression
+// extension.
+func rotationName(path string) string {
+	name := filepath.Base(path)
+	for _, ext := range compressedExtensions {
+		name = strings.TrimSuffix(name, ext)
+	}
+	return name
+}
+
+// expandBraces expands the first brace expression of s, {a,b} or {1..7},
+// and recursively the rest. Text without a closing brace is kept as is.
+func expandBraces(s string) ([]string, error) {
+	open := strings.IndexByte(s, '{')
+	if open < 0 {
+		return []string{s}, nil
+	}
+	closing := strings.IndexByte(s[open:], '}')
+	if closing < 0 {
+		return []string{s}, nil
+	}
+	closing += open
+	prefix, body, suffix := s[:open], s[open+1:closing], s[closing+1:]
+
+	var alternatives []string
+	if m := braceRangePattern.FindStringSubmatch(body); m != nil {
+		from, _ := strconv.Atoi(m[1])
+		to, _ := strconv.Atoi(m[2])
+		step := 1
+		if to < from {
+			step = -1
+		}
+		if (to-from)*step >= maxSourcePaths {
+			return nil, fmt.Errorf("brace range {%s} expands to more than %d paths", body, maxSourcePaths)
+		}
+		for n := from; ; n += step {
+			alternatives = append(alternatives, strconv.Itoa(n))
+			if n == to {
+				break
+			}
+		}
+	} else {
+		alternatives = strings.Split(body, ",")
+	}
+
+	rest, err := expandBraces(suffix)
+	if err != nil {
+		return nil, err
+	}
+	if len(alternatives)*len(rest) > maxSourcePaths {
+		return nil, fmt.Errorf("source path expands to more than %d paths", maxSourcePaths)
+	}
+
+	expanded := make([]string, 0, len(alternatives)*len(rest))
+	for _, alternative := range alternatives {
+		for _, r := range rest {
+			expanded = append(expanded, prefix+alternative+r)
+		}
+	}
+	return expanded, nil
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package analyzer
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeRotatedLogs writes the live log and rotated segments of app.log,
+// each segment older than the next.
+func writeRotatedLogs(t *testing.T, dir string) {
+	t.Helper()
+	segments := []struct {
+		name    string
+		data    []byte
+		ageDays int
+	}{
+		{"app.log.3.gz", gzipBytes(t, []byte("day 1 line\n")), 3},
+		{"app.log.2.gz", gzipBytes(t, []byte("day 2 line\n")), 2},
+		{"app.log.1", []byte("day 3 line\n"), 1},
+		{"app.log", []byte("day 4 line"), 0},
+	}
+	for _, s := range segments {
+		path := filepath.Join(dir, s.name)
+		if err := os.WriteFile(path, s.data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		modified := time.Now().Add(-time.Duration(s.ageDays) * 24 * time.Hour)
+		if err := os.Chtimes(path, modified, modified); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestExpandSourcePath(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeRotatedLogs(t, dir)
+	// Dateext rotations are ordered by their date, not their modification
+	// time (here the reverse, as after copying), and before the live file
+	for i, name := range []string{"web.log", "web.log-2026-04-19", "web.log.20260412", "web.log-20260405.gz"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("x\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		modified := time.Now().Add(-time.Duration(i+1) * time.Hour)
+		if err := os.Chtimes(path, modified, modified); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{"single file", "app.log", []string{"app.log"}},
+		{"glob", "app.log*", []string{"app.log.3.gz", "app.log.2.gz", "app.log.1", "app.log"}},
+		{"ascending range", "app.log.{1..3}*", []string{"app.log.3.gz", "app.log.2.gz", "app.log.1"}},
+		{"range skips missing files", "app.log.{1..7}.gz", []string{"app.log.3.gz", "app.log.2.gz"}},
+		{"brace list", "app.log{.1,}", []string{"app.log.1", "app.log"}},
+		{"dateext by date", "web.log*", []string{"web.log-20260405.gz", "web.log.20260412", "web.log-2026-04-19", "web.log"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ExpandSourcePath(filepath.Join(dir, tt.path))
+			if err != nil {
+				t.Fatalf("ExpandSourcePath() error = %v", err)
+			}
+			for i := range got {
+				got[i] = filepath.Base(got[i])
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExpandSourcePath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRotationNumberAndDate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		rotation int
+		date     string
+	}{
+		{"app.log", 0, ""},
+		{"app.log.1", 1, ""},
+		{"app.log.12.gz", 12, ""},
+		{"app.log.20261017", 0, "2026-10-17 00"},
+		{"app.log-20261017.xz", 0, "2026-10-17 00"},
+		{"app.log-2026-10-17", 0, "2026-10-17 00"},
+		{"app.log-2026101702", 0, "2026-10-17 02"},
+		{"app.log-20261399", 0, ""},
+	}
+	for _, tt := range tests {
+		if got := rotationNumber(tt.name); got != tt.rotation {
+			t.Errorf("rotationNumber(%q) = %d, want %d", tt.name, got, tt.rotation)
+		}
+		date, ok := rotationDate(tt.name)
+		if got := date.Format("2006-01-02 15"); ok != (tt.date != "") || (ok && got != tt.date) {
+			t.Errorf("rotationDate(%q) = %s, %v, want %q", tt.name, got, ok, tt.date)
+		}
+	}
+}
+
+func TestExpandSourcePath_NoMatch(t *testing.T) {
+	t.Parallel()
+
+	_, err := ExpandSourcePath(filepath.Join(t.TempDir(), "app.log.{1..7}.gz"))
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ExpandSourcePath() error = %v, want fs.ErrNotExist", err)
+	}
+}
+
+func TestExpandSourcePath_LiteralName(t *testing.T) {
+	t.Parallel()
+
+	// An existing file is not treated as a pattern
+	path := filepath.Join(t.TempDir(), "app[1].log")
+	if err := os.WriteFile(path, []byte("x\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ExpandSourcePath(path)
+	if err != nil || !reflect.DeepEqual(got, []string{path}) {
+		t.Errorf("ExpandSourcePath() = %v, %v", got, err)
+	}
+}
+
+func TestExpandBraces(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"app.log", []string{"app.log"}},
+		{"app.log.{3..1}.gz", []string{"app.log.3.gz", "app.log.2.gz", "app.log.1.gz"}},
+		{"{a,b}.log.{1,2}", []string{"a.log.1", "a.log.2", "b.log.1", "b.log.2"}},
+		{"app.log{", []string{"app.log{"}},
+	}
+	for _, tt := range tests {
+		got, err := expandBraces(tt.in)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("expandBraces(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+
+	if _, err := expandBraces("app.log.{1..5000}"); err == nil || !strings.Contains(err.Error(), "more than") {
+		t.Errorf("expandBraces(large range) error = %v", err)
+	}
+}
+
+func TestReadSourceFileWithGuards_RotatedSegments(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeRotatedLogs(t, dir)
+
+	got, err := ReadSourceFileWithGuards(filepath.Join(dir, "app.log*"), FileReadOptions{
+		SourceLabel: "sample",
+		MaxSizeMB:   10,
+		MaxAge:      24 * time.Hour, // applies to the newest segment only
+	}, func(string) error { return nil })
+	if err != nil {
+		t.Fatalf("ReadSourceFileWithGuards() error = %v", err)
+	}
+
+	want := "day 1 line\nday 2 line\nday 3 line\nday 4 line\n"
+	if got != want {
+		t.Errorf("content = %q, want %q", got, want)
+	}
+
+	info, err := GetSourceFileInfo(filepath.Join(dir, "app.log*"))
+	if err != nil {
+		t.Fatalf("GetSourceFileInfo() error = %v", err)
+	}
+	if info["files"] != 4 {
+		t.Errorf("GetSourceFileInfo() files = %v, want 4", info["files"])
+	}
+}
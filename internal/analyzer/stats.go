@@ -0,0 +1,72 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package analyzer
+
+import "sort"
+
+// StatsReporter is an optional LogReader extension for readers that can
+// summarize their last read without an LLM. The statistics are the body of
+// the degraded report sent when no LLM provider is reachable.
+type StatsReporter interface {
+	// ReadStats returns statistics of the content returned by the last
+	// successful Read, or nil if nothing was read yet.
+	ReadStats() *ReadStats
+}
+
+// MetricsReporter is an optional LogReader extension for readers that
+// extract exact metrics from their last read. The metrics override the
+// values of the same keys in the LLM analysis.
+type MetricsReporter interface {
+	// ReadMetrics returns the metrics of the content returned by the last
+	// successful Read, or nil if there are none.
+	ReadMetrics() map[string]any
+}
+
+// ReadStats holds reader-computed statistics of a log source.
+type ReadStats struct {
+	// Totals are headline counts, in display order
+	Totals []StatsItem
+
+	// Breakdowns are titled count lists (severities, sections, ...)
+	Breakdowns []StatsBreakdown
+}
+
+// StatsBreakdown is a titled list of counts.
+type StatsBreakdown struct {
+	Title string
+	Items []StatsItem
+}
+
+// StatsItem is a single named count.
+type StatsItem struct {
+	Name  string
+	Count int
+}
+
+// AddBreakdown appends a breakdown, skipping empty ones.
+func (s *ReadStats) AddBreakdown(title string, items []StatsItem) {
+	if len(items) == 0 {
+		return
+	}
+	s.Breakdowns = append(s.Breakdowns, StatsBreakdown{Title: title, Items: items})
+}
+
+// TopCounts converts a count map into items sorted by count (descending,
+// ties by name), keeping at most limit items. A limit <= 0 keeps all.
+func TopCounts(counts map[string]int, limit int) []StatsItem {
+	items := make([]StatsItem, 0, len(counts))
+	for name, count := range counts {
+		items = append(items, StatsItem{Name: name, Count: count})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Count != items[j].Count {
+			return items[i].Count > items[j].Count
+		}
+		return items[i].Name < items[j].Name
+	})
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	return items
+}
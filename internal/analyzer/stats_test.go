@@ -0,0 +1,33 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTopCounts(t *testing.T) {
+	counts := map[string]int{"php": 5, "cron": 2, "access": 5, "user": 1}
+
+	got := TopCounts(counts, 3)
+	want := []StatsItem{{"access", 5}, {"php", 5}, {"cron", 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TopCounts() = %v, want %v", got, want)
+	}
+
+	if got := TopCounts(counts, 0); len(got) != 4 {
+		t.Errorf("TopCounts(limit 0) returned %d items, want 4", len(got))
+	}
+}
+
+func TestReadStats_AddBreakdown(t *testing.T) {
+	var stats ReadStats
+	stats.AddBreakdown("Empty", nil)
+	stats.AddBreakdown("Types", []StatsItem{{"php", 1}})
+
+	if len(stats.Breakdowns) != 1 || stats.Breakdowns[0].Title != "Types" {
+		t.Errorf("Breakdowns = %+v", stats.Breakdowns)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/olegiv/logwatch-ai-go/internal/accesslog"
+)
+
+// AccessLogSite represents configuration for a single web site's access log
+type AccessLogSite struct {
+	Name          string `json:"name"`            // Human-readable site name for reports
+	LogPath       string `json:"log_path"`        // Path to the nginx/Apache access log
+	Format        string `json:"format"`          // "combined", "common", or a custom format string (default: ACCESS_LOG_FORMAT)
+	SlowRequestMS int    `json:"slow_request_ms"` // Slow request threshold in ms (default: ACCESS_LOG_SLOW_REQUEST_MS)
+}
+
+// AccessLogSitesConfig represents the multi-site access log configuration file
+type AccessLogSitesConfig struct {
+	Version     string                   `json:"version"`      // Config file version
+	DefaultSite string                   `json:"default_site"` // Default site ID if -access-log-site not specified
+	Sites       map[string]AccessLogSite `json:"sites"`        // Site configurations keyed by site ID
+}
+
+// Validate checks the configuration for errors
+func (c *AccessLogSitesConfig) Validate() error {
+	if len(c.Sites) == 0 {
+		return fmt.Errorf("no sites defined in configuration")
+	}
+
+	// Validate default_site references an existing site
+	if c.DefaultSite != "" {
+		if _, exists := c.Sites[c.DefaultSite]; !exists {
+			return fmt.Errorf("default_site '%s' does not exist in sites", c.DefaultSite)
+		}
+	}
+
+	// Validate each site
+	for siteID, site := range c.Sites {
+		if site.LogPath == "" {
+			return fmt.Errorf("site '%s': log_path is required", siteID)
+		}
+		if site.Format != "" {
+			if _, err := accesslog.ParseFormat(site.Format); err != nil {
+				return fmt.Errorf("site '%s': %w", siteID, err)
+			}
+		}
+		if site.SlowRequestMS < 0 {
+			return fmt.Errorf("site '%s': slow_request_ms must not be negative (got: %d)", siteID, site.SlowRequestMS)
+		}
+	}
+
+	return nil
+}
+
+// GetSite returns a site by ID, falling back to default_site if siteID is empty
+func (c *AccessLogSitesConfig) GetSite(siteID string) (*AccessLogSite, error) {
+	resolvedSiteID, err := resolveSiteID("access log", "-access-log-site", siteID, c.DefaultSite, "-list-access-log-sites")
+	if err != nil {
+		return nil, err
+	}
+
+	site, exists := c.Sites[resolvedSiteID]
+	if !exists {
+		available := c.ListSites()
+		return nil, fmt.Errorf("site '%s' not found (available: %v)", resolvedSiteID, available)
+	}
+
+	return &site, nil
+}
+
+// ListSites returns all available site IDs in sorted order
+func (c *AccessLogSitesConfig) ListSites() []string {
+	return sortedSiteIDs(c.Sites)
+}
+
+// LoadAccessLogSitesConfig loads and parses the access-log-sites.json file
+// If configPath is empty, it searches standard locations.
+// Returns nil, nil if no config file is found (not an error - single-site mode).
+func LoadAccessLogSitesConfig(configPath string) (*AccessLogSitesConfig, string, error) {
+	data, foundPath, err := loadFirstExistingFile(
+		configPath,
+		"access log sites config",
+		standardAccessLogSitesConfigPaths(),
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	if data == nil {
+		return nil, "", nil
+	}
+
+	var config AccessLogSitesConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, "", fmt.Errorf("failed to parse %s: %w", foundPath, err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, "", fmt.Errorf("invalid config in %s: %w", foundPath, err)
+	}
+
+	return &config, foundPath, nil
+}
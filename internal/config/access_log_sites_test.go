@@ -0,0 +1,207 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAccessLogSitesConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  AccessLogSitesConfig
+		wantErr string
+	}{
+		{
+			name: "valid config",
+			config: AccessLogSitesConfig{
+				Version:     "1.0",
+				DefaultSite: "shop",
+				Sites: map[string]AccessLogSite{
+					"shop": {Name: "Shop", LogPath: "/var/log/nginx/shop-access.log.1"},
+					"api": {
+						LogPath:       "/var/log/apache2/api-access.log.1",
+						Format:        `%h %l %u %t "%r" %>s %b %D`,
+						SlowRequestMS: 500,
+					},
+				},
+			},
+		},
+		{
+			name:    "empty sites",
+			config:  AccessLogSitesConfig{Version: "1.0"},
+			wantErr: "no sites defined",
+		},
+		{
+			name: "default_site references non-existent site",
+			config: AccessLogSitesConfig{
+				DefaultSite: "missing",
+				Sites:       map[string]AccessLogSite{"shop": {LogPath: "/var/log/nginx/access.log"}},
+			},
+			wantErr: "default_site 'missing' does not exist",
+		},
+		{
+			name: "missing log_path",
+			config: AccessLogSitesConfig{
+				Sites: map[string]AccessLogSite{"shop": {Name: "Shop"}},
+			},
+			wantErr: "log_path is required",
+		},
+		{
+			name: "invalid format",
+			config: AccessLogSitesConfig{
+				Sites: map[string]AccessLogSite{"shop": {LogPath: "/var/log/nginx/access.log", Format: "plain text"}},
+			},
+			wantErr: "site 'shop': access log format",
+		},
+		{
+			name: "negative slow_request_ms",
+			config: AccessLogSitesConfig{
+				Sites: map[string]AccessLogSite{"shop": {LogPath: "/var/log/nginx/access.log", SlowRequestMS: -1}},
+			},
+			wantErr: "slow_request_ms must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// writeAccessLogSitesConfig writes an access-log-sites.json fixture and
+// returns its path.
+func writeAccessLogSitesConfig(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "access-log-sites.json")
+	content := `{
+  "version": "1.0",
+  "default_site": "shop",
+  "sites": {
+    "shop": {
+      "name": "Shop Frontend",
+      "log_path": "/var/log/nginx/shop-access.log.1",
+      "slow_request_ms": 2000
+    },
+    "api": {
+      "log_path": "/var/log/nginx/api-access.log.1",
+      "format": "common"
+    }
+  }
+}`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write access log sites config: %v", err)
+	}
+	return path
+}
+
+func accessLogTestConfig() *Config {
+	return &Config{
+		LogSourceType:          "access_log",
+		AccessLogPath:          "/var/log/nginx/access.log.1",
+		AccessLogFormat:        "combined",
+		AccessLogSlowRequestMS: 1000,
+	}
+}
+
+func TestLoadAccessLogSitesConfig(t *testing.T) {
+	configPath := writeAccessLogSitesConfig(t)
+
+	config, foundPath, err := LoadAccessLogSitesConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadAccessLogSitesConfig() error = %v", err)
+	}
+	if foundPath != configPath {
+		t.Errorf("foundPath = %q, want %q", foundPath, configPath)
+	}
+	if got := strings.Join(config.ListSites(), ","); got != "api,shop" {
+		t.Errorf("ListSites() = %s, want api,shop", got)
+	}
+
+	if _, _, err := LoadAccessLogSitesConfig("/nonexistent/access-log-sites.json"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("LoadAccessLogSitesConfig() error = %v, want not found error", err)
+	}
+}
+
+func TestApplyAccessLogMultiSiteConfig_UsesDefaultSite(t *testing.T) {
+	cfg := accessLogTestConfig()
+	err := cfg.applyAccessLogMultiSiteConfig(&CLIOptions{AccessLogSitesConfig: writeAccessLogSitesConfig(t)})
+	if err != nil {
+		t.Fatalf("applyAccessLogMultiSiteConfig() error = %v", err)
+	}
+
+	if cfg.AccessLogPath != "/var/log/nginx/shop-access.log.1" {
+		t.Errorf("AccessLogPath = %q", cfg.AccessLogPath)
+	}
+	if cfg.AccessLogFormat != "combined" {
+		t.Errorf("AccessLogFormat = %q, want env default", cfg.AccessLogFormat)
+	}
+	if cfg.AccessLogSlowRequestMS != 2000 {
+		t.Errorf("AccessLogSlowRequestMS = %d, want 2000", cfg.AccessLogSlowRequestMS)
+	}
+	if cfg.SelectedSiteID() != "shop" || cfg.SelectedSiteName() != "Shop Frontend" {
+		t.Errorf("selected site = %q/%q", cfg.SelectedSiteID(), cfg.SelectedSiteName())
+	}
+}
+
+func TestApplyAccessLogMultiSiteConfig_SelectedSite(t *testing.T) {
+	cfg := accessLogTestConfig()
+	err := cfg.applyAccessLogMultiSiteConfig(&CLIOptions{
+		AccessLogSitesConfig: writeAccessLogSitesConfig(t),
+		AccessLogSite:        "api",
+		SourcePath:           "/tmp/api-access.log",
+	})
+	if err != nil {
+		t.Fatalf("applyAccessLogMultiSiteConfig() error = %v", err)
+	}
+
+	if cfg.AccessLogPath != "/var/log/nginx/access.log.1" {
+		t.Errorf("AccessLogPath = %q, want -source-path to take precedence", cfg.AccessLogPath)
+	}
+	if cfg.AccessLogFormat != "common" || cfg.AccessLogSlowRequestMS != 1000 {
+		t.Errorf("format/threshold = %q/%d", cfg.AccessLogFormat, cfg.AccessLogSlowRequestMS)
+	}
+	if cfg.SelectedSiteID() != "api" || cfg.SelectedSiteName() != "api" {
+		t.Errorf("selected site = %q/%q", cfg.SelectedSiteID(), cfg.SelectedSiteName())
+	}
+
+	err = accessLogTestConfig().applyAccessLogMultiSiteConfig(&CLIOptions{
+		AccessLogSitesConfig: writeAccessLogSitesConfig(t),
+		AccessLogSite:        "blog",
+	})
+	if err == nil || !strings.Contains(err.Error(), "site 'blog' not found") {
+		t.Errorf("applyAccessLogMultiSiteConfig() error = %v, want unknown site error", err)
+	}
+}
+
+func TestApplyAccessLogMultiSiteConfig_SingleSiteMode(t *testing.T) {
+	t.Setenv("HOME", "/nonexistent-home-for-test")
+	t.Chdir(t.TempDir())
+
+	cfg := accessLogTestConfig()
+	if err := cfg.applyAccessLogMultiSiteConfig(&CLIOptions{}); err != nil {
+		t.Fatalf("applyAccessLogMultiSiteConfig() error = %v", err)
+	}
+	if cfg.AccessLogPath != "/var/log/nginx/access.log.1" || cfg.SelectedSiteID() != "" {
+		t.Errorf("single-site config changed: path=%q site=%q", cfg.AccessLogPath, cfg.SelectedSiteID())
+	}
+
+	err := accessLogTestConfig().applyAccessLogMultiSiteConfig(&CLIOptions{AccessLogSite: "shop"})
+	if err == nil || !strings.Contains(err.Error(), "access-log-sites.json is required") {
+		t.Errorf("applyAccessLogMultiSiteConfig() error = %v, want missing config error", err)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+	"github.com/olegiv/logwatch-ai-go/internal/customlog"
+)
+
+// CustomSourcesConfig represents the custom source configuration file
+// (custom-sources.json). Each source declares a line-oriented log format,
+// analyzed by the generic customlog reader without new code.
+type CustomSourcesConfig struct {
+	Version string                          `json:"version"` // Config file version
+	Sources map[string]customlog.Definition `json:"sources"` // Definitions keyed by source type
+}
+
+// Validate checks the configuration for errors
+func (c *CustomSourcesConfig) Validate() error {
+	if len(c.Sources) == 0 {
+		return fmt.Errorf("no sources defined in configuration")
+	}
+
+	for _, sourceType := range c.ListSources() {
+		if err := analyzer.ValidateCustomSourceType(sourceType); err != nil {
+			return err
+		}
+		definition := c.Sources[sourceType]
+		if _, err := definition.Compile(sourceType); err != nil {
+			return fmt.Errorf("source '%s': %w", sourceType, err)
+		}
+	}
+
+	return nil
+}
+
+// ListSources returns all source types sorted alphabetically
+func (c *CustomSourcesConfig) ListSources() []string {
+	return sortedSiteIDs(c.Sources)
+}
+
+// GetSource returns the compiled source of a source type
+func (c *CustomSourcesConfig) GetSource(sourceType string) (*customlog.Source, error) {
+	definition, exists := c.Sources[sourceType]
+	if !exists {
+		return nil, fmt.Errorf("source '%s' not found (available: %v)", sourceType, c.ListSources())
+	}
+	return definition.Compile(sourceType)
+}
+
+// LoadCustomSourcesConfig loads and parses the custom-sources.json file
+// If configPath is empty, it searches standard locations.
+// Returns nil, nil if no config file is found (not an error - no custom sources).
+func LoadCustomSourcesConfig(configPath string) (*CustomSourcesConfig, string, error) {
+	data, foundPath, err := loadFirstExistingFile(
+		configPath,
+		"custom sources config",
+		standardCustomSourcesConfigPaths(),
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	if data == nil {
+		return nil, "", nil
+	}
+
+	var config CustomSourcesConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, "", fmt.Errorf("failed to parse %s: %w", foundPath, err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, "", fmt.Errorf("invalid config in %s: %w", foundPath, err)
+	}
+
+	return &config, foundPath, nil
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/olegiv/logwatch-ai-go/internal/customlog"
+)
+
+func TestCustomSourcesConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	valid := customlog.Definition{LogPath: "/var/log/orders.log", LineRegex: `^(?P<level>\w+) (?P<message>.*)$`}
+	tests := []struct {
+		name    string
+		sources map[string]customlog.Definition
+		want    string
+	}{
+		{"no sources", nil, "no sources defined"},
+		{"built-in name", map[string]customlog.Definition{"syslog": valid}, "built in"},
+		{"invalid name", map[string]customlog.Definition{"Order-Service": valid}, "invalid source type name"},
+		{"invalid definition", map[string]customlog.Definition{"orders": {LineRegex: `^(?P<level>\w+)$`}}, "source 'orders': line_regex must have a named group"},
+	}
+
+	for _, tt := range tests {
+		config := &CustomSourcesConfig{Sources: tt.sources}
+		if err := config.Validate(); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: Validate() error = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+
+	config := &CustomSourcesConfig{Sources: map[string]customlog.Definition{"orders": valid}}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if _, err := config.GetSource("billing"); err == nil || !strings.Contains(err.Error(), "not found (available: [orders])") {
+		t.Errorf("GetSource(billing) error = %v", err)
+	}
+}
+
+func TestLoadWithCLI_CustomSource(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "sk-ant-test-key-1234567890")
+	t.Setenv("TELEGRAM_BOT_TOKEN", "123456789:ABCdefGHIjklMNOpqrsTUVwxyz")
+	t.Setenv("TELEGRAM_CHANNEL_ARCHIVE_ID", "-1001234567890")
+	t.Setenv("ENABLE_DATABASE", "true")
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "custom-sources.json")
+	content := `{
+  "version": "1.0",
+  "sources": {
+    "orders": {
+      "name": "Order Service",
+      "log_path": "/var/log/orders/app.log",
+      "line_regex": "^(?P<timestamp>\\S+ \\S+) (?P<level>[A-Z]+) (?P<component>\\S+) - (?P<message>.*)$",
+      "priority_keywords": ["payment"]
+    }
+  }
+}`
+	if err := os.WriteFile(configPath, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	rulesPath := filepath.Join(dir, "rules.json")
+	rulesContent := `{"version": "1.0", "rules": [{"name": "payment-errors", "sources": ["orders"], "pattern": "payment", "severity": "critical", "status_floor": "Bad", "finding": "Payment errors"}]}`
+	if err := os.WriteFile(rulesPath, []byte(rulesContent), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadWithCLI(&CLIOptions{SourceType: "orders", CustomSourcesConfig: configPath, RulesConfig: rulesPath, Incremental: true})
+	if err != nil {
+		t.Fatalf("LoadWithCLI() error = %v", err)
+	}
+	if !config.IsCustomSource() || config.CustomSource.Name != "Order Service" {
+		t.Fatalf("CustomSource = %+v", config.CustomSource)
+	}
+	if got := config.GetLogSourcePath(); got != "/var/log/orders/app.log" {
+		t.Errorf("GetLogSourcePath() = %q, want log_path", got)
+	}
+	if config.CustomSourcesConfigPath != configPath || config.Rules == nil {
+		t.Errorf("CustomSourcesConfigPath = %q, rules loaded = %t", config.CustomSourcesConfigPath, config.Rules != nil)
+	}
+
+	config, err = LoadWithCLI(&CLIOptions{SourceType: "orders", CustomSourcesConfig: configPath, SourcePath: "/tmp/orders.log"})
+	if err != nil {
+		t.Fatalf("LoadWithCLI(-source-path) error = %v", err)
+	}
+	if got := config.GetLogSourcePath(); got != "/tmp/orders.log" {
+		t.Errorf("GetLogSourcePath() = %q, want -source-path", got)
+	}
+
+	// Declared sources do not change built-in source types
+	config, err = LoadWithCLI(&CLIOptions{SourceType: "syslog", CustomSourcesConfig: configPath, SourcePath: "/var/log/syslog"})
+	if err != nil || config.IsCustomSource() {
+		t.Errorf("LoadWithCLI(syslog) = custom %t, %v", config != nil && config.IsCustomSource(), err)
+	}
+
+	if _, err := LoadWithCLI(&CLIOptions{SourceType: "billing", CustomSourcesConfig: configPath}); err == nil || !strings.Contains(err.Error(), "custom-sources.json") {
+		t.Errorf("LoadWithCLI(billing) error = %v", err)
+	}
+
+	if _, err := LoadWithCLI(&CLIOptions{SourceType: "orders", CustomSourcesConfig: filepath.Join(dir, "missing.json")}); err == nil {
+		t.Error("LoadWithCLI() expected error for a missing -custom-sources-config")
+	}
+}
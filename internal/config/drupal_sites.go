@@ -6,16 +6,36 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+
+	"github.com/olegiv/logwatch-ai-go/internal/drupal"
 )
 
+// defaultDrupalMinSeverity is the min_severity of a site that does not set
+// it: emergency to error.
+const defaultDrupalMinSeverity = drupal.SeverityError
+
 // DrupalSite represents configuration for a single Drupal site
 type DrupalSite struct {
-	Name           string `json:"name"`            // Human-readable site name for reports
-	DrupalRoot     string `json:"drupal_root"`     // Path to Drupal installation root
-	WatchdogPath   string `json:"watchdog_path"`   // Path to watchdog export file
-	WatchdogFormat string `json:"watchdog_format"` // "json" or "drush" (default: "json")
-	MinSeverity    int    `json:"min_severity"`    // RFC 5424 severity level (default: 3)
-	WatchdogLimit  int    `json:"watchdog_limit"`  // Max entries in output (default: 100)
+	Name            string `json:"name"`             // Human-readable site name for reports
+	DrupalRoot      string `json:"drupal_root"`      // Path to Drupal installation root
+	WatchdogPath    string `json:"watchdog_path"`    // Path to watchdog export file
+	WatchdogCommand string `json:"watchdog_command"` // Command printing the watchdog export, run in drupal_root (replaces watchdog_path)
+	WatchdogDSN     string `json:"watchdog_dsn"`     // Drupal database URL to query the watchdog table directly (replaces watchdog_path)
+	WatchdogFormat  string `json:"watchdog_format"`  // "json" or "drush" (default: "json")
+	MinSeverity     int    `json:"min_severity"`     // RFC 5424 severity level (default: 3)
+	WatchdogLimit   int    `json:"watchdog_limit"`   // Max entries in output (default: 100)
+}
+
+// UnmarshalJSON applies the defaults of min_severity and watchdog_limit to
+// sites that do not set them.
+func (s *DrupalSite) UnmarshalJSON(data []byte) error {
+	type plainSite DrupalSite
+	site := plainSite{MinSeverity: defaultDrupalMinSeverity, WatchdogLimit: drupal.DefaultWatchdogLimit}
+	if err := json.Unmarshal(data, &site); err != nil {
+		return err
+	}
+	*s = DrupalSite(site)
+	return nil
 }
 
 // DrupalSitesConfig represents the multi-site configuration file
@@ -43,8 +63,13 @@ func (c *DrupalSitesConfig) Validate() error {
 		if site.DrupalRoot == "" {
 			return fmt.Errorf("site '%s': drupal_root is required", siteID)
 		}
-		if site.WatchdogPath == "" {
-			return fmt.Errorf("site '%s': watchdog_path is required", siteID)
+		if site.WatchdogPath == "" && site.WatchdogCommand == "" && site.WatchdogDSN == "" {
+			return fmt.Errorf("site '%s': watchdog_path, watchdog_command, or watchdog_dsn is required", siteID)
+		}
+		if site.WatchdogDSN != "" {
+			if _, err := drupal.ParseDatabaseURL(site.WatchdogDSN, site.DrupalRoot); err != nil {
+				return fmt.Errorf("site '%s': invalid watchdog_dsn: %w", siteID, err)
+			}
 		}
 		if site.WatchdogFormat != "" && site.WatchdogFormat != "json" && site.WatchdogFormat != "drush" {
 			return fmt.Errorf("site '%s': watchdog_format must be 'json' or 'drush' (got: %s)", siteID, site.WatchdogFormat)
@@ -52,6 +77,9 @@ func (c *DrupalSitesConfig) Validate() error {
 		if site.MinSeverity < 0 || site.MinSeverity > 7 {
 			return fmt.Errorf("site '%s': min_severity must be 0-7 (got: %d)", siteID, site.MinSeverity)
 		}
+		if site.WatchdogLimit < 0 {
+			return fmt.Errorf("site '%s': watchdog_limit must not be negative (got: %d)", siteID, site.WatchdogLimit)
+		}
 	}
 
 	return nil
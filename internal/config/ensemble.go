@@ -0,0 +1,116 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Ensemble status rules (ENSEMBLE_STATUS_RULE)
+const (
+	EnsembleStatusWorst    = "worst"    // Most severe status reported by any model
+	EnsembleStatusMajority = "majority" // Most common status; ties go to the worse one
+	EnsembleStatusPrimary  = "primary"  // Status of the first model that succeeded
+)
+
+// ProviderSpec identifies a provider and optional model override, written
+// as "provider" or "provider:model". Only the first colon separates the two,
+// so Ollama tags like "llama3.3:latest" are preserved.
+type ProviderSpec struct {
+	Provider string
+	Model    string
+}
+
+// Label returns the "provider[:model]" form of the spec.
+func (s ProviderSpec) Label() string {
+	if s.Model == "" {
+		return s.Provider
+	}
+	return s.Provider + ":" + s.Model
+}
+
+// ParseProviderSpecs parses a comma-separated list of "provider[:model]"
+// specs, rejecting empty providers and duplicates.
+func ParseProviderSpecs(spec string) ([]ProviderSpec, error) {
+	var specs []ProviderSpec
+	seen := make(map[string]bool)
+
+	for part := range strings.SplitSeq(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		provider, model, _ := strings.Cut(part, ":")
+		ps := ProviderSpec{Provider: strings.TrimSpace(provider), Model: strings.TrimSpace(model)}
+		if ps.Provider == "" {
+			return nil, fmt.Errorf("invalid provider %q: provider is required", part)
+		}
+		if seen[ps.Label()] {
+			return nil, fmt.Errorf("duplicate provider %q", ps.Label())
+		}
+		seen[ps.Label()] = true
+		specs = append(specs, ps)
+	}
+
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("no providers specified")
+	}
+
+	return specs, nil
+}
+
+// ForProvider returns a copy of the configuration with LLMProvider set to
+// the spec's provider and its model overridden when the spec names one.
+func (c *Config) ForProvider(spec ProviderSpec) *Config {
+	out := *c
+	out.LLMProvider = spec.Provider
+	if spec.Model != "" {
+		switch spec.Provider {
+		case "anthropic":
+			out.ClaudeModel = spec.Model
+		case "ollama":
+			out.OllamaModel = spec.Model
+		case "lmstudio":
+			out.LMStudioModel = spec.Model
+		}
+	}
+	return &out
+}
+
+// IsEnsemble returns true if analyses run through several providers
+func (c *Config) IsEnsemble() bool {
+	return strings.TrimSpace(c.EnsembleProviders) != ""
+}
+
+// EnsembleSpecs returns the parsed ENSEMBLE_PROVIDERS list
+func (c *Config) EnsembleSpecs() ([]ProviderSpec, error) {
+	return ParseProviderSpecs(c.EnsembleProviders)
+}
+
+// validateEnsemble validates ENSEMBLE_PROVIDERS and ENSEMBLE_STATUS_RULE.
+// Each member is validated like LLM_PROVIDER, with its model override.
+func (c *Config) validateEnsemble() error {
+	specs, err := c.EnsembleSpecs()
+	if err != nil {
+		return fmt.Errorf("ENSEMBLE_PROVIDERS: %w", err)
+	}
+	if len(specs) < 2 {
+		return fmt.Errorf("ENSEMBLE_PROVIDERS must list at least two providers")
+	}
+
+	for _, spec := range specs {
+		if err := c.ForProvider(spec).validateProviderSettings(spec.Provider, "ENSEMBLE_PROVIDERS"); err != nil {
+			return err
+		}
+	}
+
+	switch c.EnsembleStatusRule {
+	case EnsembleStatusWorst, EnsembleStatusMajority, EnsembleStatusPrimary:
+		return nil
+	default:
+		return fmt.Errorf("ENSEMBLE_STATUS_RULE must be 'worst', 'majority', or 'primary' (got: %s)", c.EnsembleStatusRule)
+	}
+}
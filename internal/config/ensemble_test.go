@@ -0,0 +1,89 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package config
+
+import "testing"
+
+func TestParseProviderSpecs(t *testing.T) {
+	specs, err := ParseProviderSpecs("anthropic:claude-haiku-4-5-20251001, ollama:llama3.3:latest,lmstudio")
+	if err != nil {
+		t.Fatalf("ParseProviderSpecs() error = %v", err)
+	}
+
+	want := []ProviderSpec{
+		{Provider: "anthropic", Model: "claude-haiku-4-5-20251001"},
+		{Provider: "ollama", Model: "llama3.3:latest"},
+		{Provider: "lmstudio"},
+	}
+	if len(specs) != len(want) {
+		t.Fatalf("got %d specs, want %d", len(specs), len(want))
+	}
+	for i := range want {
+		if specs[i] != want[i] {
+			t.Errorf("spec %d = %+v, want %+v", i, specs[i], want[i])
+		}
+	}
+
+	for _, spec := range []string{"", " , ", ":model", "ollama,ollama"} {
+		if _, err := ParseProviderSpecs(spec); err == nil {
+			t.Errorf("ParseProviderSpecs(%q) expected error", spec)
+		}
+	}
+}
+
+func TestForProvider(t *testing.T) {
+	cfg := &Config{LLMProvider: "anthropic", ClaudeModel: "claude-haiku-4-5-20251001", OllamaModel: "llama3.3:latest"}
+
+	ollama := cfg.ForProvider(ProviderSpec{Provider: "ollama", Model: "qwen2.5:14b"})
+	if ollama.LLMProvider != "ollama" || ollama.GetLLMModel() != "qwen2.5:14b" {
+		t.Errorf("ForProvider(ollama) = %s/%s", ollama.LLMProvider, ollama.GetLLMModel())
+	}
+	if cfg.OllamaModel != "llama3.3:latest" || cfg.LLMProvider != "anthropic" {
+		t.Error("ForProvider must not modify the original config")
+	}
+}
+
+func TestValidateEnsemble(t *testing.T) {
+	base := Config{
+		LLMProvider:        "anthropic",
+		AnthropicAPIKey:    "sk-ant-test-key-1234567890",
+		ClaudeModel:        "claude-haiku-4-5-20251001",
+		OllamaBaseURL:      "http://localhost:11434",
+		OllamaModel:        "llama3.3:latest",
+		EnsembleStatusRule: EnsembleStatusWorst,
+	}
+
+	tests := []struct {
+		name          string
+		providers     string
+		rule          string
+		apiKey        string
+		errorContains string
+	}{
+		{name: "valid", providers: "anthropic,ollama:qwen2.5:14b"},
+		{name: "single provider", providers: "anthropic", errorContains: "at least two providers"},
+		{name: "mock member", providers: "anthropic,mock", errorContains: "ENSEMBLE_PROVIDERS must be"},
+		{name: "missing member credentials", providers: "anthropic,ollama", apiKey: "-", errorContains: "ANTHROPIC_API_KEY is required when ENSEMBLE_PROVIDERS=anthropic"},
+		{name: "invalid member model", providers: "anthropic:gpt-4,ollama", errorContains: "CLAUDE_MODEL has invalid format"},
+		{name: "invalid rule", providers: "anthropic,ollama", rule: "loudest", errorContains: "ENSEMBLE_STATUS_RULE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := base
+			cfg.EnsembleProviders = tt.providers
+			if tt.rule != "" {
+				cfg.EnsembleStatusRule = tt.rule
+			}
+			if tt.apiKey == "-" {
+				cfg.AnthropicAPIKey = ""
+			}
+			if !cfg.IsEnsemble() {
+				t.Fatal("IsEnsemble() = false")
+			}
+			err := cfg.ValidateLLMProvider()
+			checkError(t, err, tt.errorContains != "", tt.errorContains)
+		})
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Path elements naming the host of a globbed logwatch report
+const (
+	LogwatchHostFromFile = "file" // web01.txt or web01.example.com.txt.gz
+	LogwatchHostFromDir  = "dir"  // web01/logwatch.txt
+)
+
+var (
+	// logwatchHostRegex validates host names, which appear in reports and
+	// the database
+	logwatchHostRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+	// logwatchReportSuffixes are stripped from report file names to get the
+	// host name, so host names may contain dots
+	logwatchReportSuffixes = []string{".gz", ".bz2", ".xz", ".zst", ".txt", ".log"}
+)
+
+// LogwatchHostEntry represents a host of the logwatch host inventory
+type LogwatchHostEntry struct {
+	ReportPath string `json:"report_path"` // logwatch report collected from the host
+}
+
+// LogwatchHostsConfig represents the host inventory of multi-host logwatch
+// runs (logwatch-hosts.json). Each host is analyzed and reported on its
+// own; Rollup adds a fleet message ranking the hosts by status.
+type LogwatchHostsConfig struct {
+	Version    string                       `json:"version"`     // Config file version
+	Hosts      map[string]LogwatchHostEntry `json:"hosts"`       // Reports keyed by host name
+	ReportGlob string                       `json:"report_glob"` // Optional glob of further reports, e.g. /srv/logwatch/*.txt
+	HostFrom   string                       `json:"host_from"`   // "file" (default) or "dir": the path element naming a globbed report's host
+	Rollup     bool                         `json:"rollup"`      // Send a fleet roll-up message after the hosts
+}
+
+// LogwatchHost is a host of a multi-host logwatch run
+type LogwatchHost struct {
+	Name       string
+	ReportPath string
+	Globbed    bool // Found by report_glob rather than listed in hosts
+}
+
+// Validate checks the configuration for errors
+func (c *LogwatchHostsConfig) Validate() error {
+	if len(c.Hosts) == 0 && c.ReportGlob == "" {
+		return fmt.Errorf("no hosts or report_glob defined in configuration")
+	}
+
+	for name, host := range c.Hosts {
+		if !logwatchHostRegex.MatchString(name) {
+			return fmt.Errorf("invalid host name '%s'", name)
+		}
+		if host.ReportPath == "" {
+			return fmt.Errorf("host '%s': report_path is required", name)
+		}
+	}
+
+	if c.ReportGlob != "" {
+		if _, err := filepath.Match(c.ReportGlob, ""); err != nil {
+			return fmt.Errorf("invalid report_glob %q: %w", c.ReportGlob, err)
+		}
+	}
+
+	switch c.HostFrom {
+	case "", LogwatchHostFromFile, LogwatchHostFromDir:
+	default:
+		return fmt.Errorf("host_from must be '%s' or '%s' (got: %s)", LogwatchHostFromFile, LogwatchHostFromDir, c.HostFrom)
+	}
+
+	return nil
+}
+
+// ResolveHosts returns the configured hosts and the hosts of the reports
+// matching report_glob, sorted by name. A configured host takes precedence
+// over a globbed report of the same name; two globbed reports of one host
+// are an error.
+func (c *LogwatchHostsConfig) ResolveHosts() ([]LogwatchHost, error) {
+	hosts := make([]LogwatchHost, 0, len(c.Hosts))
+	for _, name := range sortedSiteIDs(c.Hosts) {
+		hosts = append(hosts, LogwatchHost{Name: name, ReportPath: c.Hosts[name].ReportPath})
+	}
+
+	if c.ReportGlob != "" {
+		matches, err := filepath.Glob(c.ReportGlob)
+		if err != nil {
+			return nil, fmt.Errorf("invalid report_glob %q: %w", c.ReportGlob, err)
+		}
+
+		globbed := make(map[string]string)
+		for _, path := range matches {
+			name := logwatchHostFromPath(path, c.HostFrom)
+			if !logwatchHostRegex.MatchString(name) {
+				return nil, fmt.Errorf("report %s: invalid host name '%s'", path, name)
+			}
+			if _, configured := c.Hosts[name]; configured {
+				continue
+			}
+			if other, exists := globbed[name]; exists {
+				return nil, fmt.Errorf("host '%s' has more than one report: %s and %s", name, other, path)
+			}
+			globbed[name] = path
+			hosts = append(hosts, LogwatchHost{Name: name, ReportPath: path, Globbed: true})
+		}
+	}
+
+	if len(hosts) == 0 {
+		return nil, fmt.Errorf("no hosts defined and no reports match report_glob %s", c.ReportGlob)
+	}
+
+	slices.SortFunc(hosts, func(a, b LogwatchHost) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return hosts, nil
+}
+
+// logwatchHostFromPath returns the host name of a globbed report: its
+// file name without report and compression suffixes, or the name of its
+// directory.
+func logwatchHostFromPath(path, hostFrom string) string {
+	if hostFrom == LogwatchHostFromDir {
+		return filepath.Base(filepath.Dir(path))
+	}
+
+	name := filepath.Base(path)
+	for {
+		trimmed := name
+		for _, suffix := range logwatchReportSuffixes {
+			trimmed = strings.TrimSuffix(trimmed, suffix)
+		}
+		if trimmed == name || trimmed == "" {
+			return name
+		}
+		name = trimmed
+	}
+}
+
+// LoadLogwatchHostsConfig loads and parses the logwatch-hosts.json file
+// If configPath is empty, it searches standard locations.
+// Returns nil, nil if no config file is found (not an error - single-host mode).
+func LoadLogwatchHostsConfig(configPath string) (*LogwatchHostsConfig, string, error) {
+	data, foundPath, err := loadFirstExistingFile(
+		configPath,
+		"logwatch hosts config",
+		standardLogwatchHostsConfigPaths(),
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	if data == nil {
+		return nil, "", nil
+	}
+
+	var config LogwatchHostsConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, "", fmt.Errorf("failed to parse %s: %w", foundPath, err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, "", fmt.Errorf("invalid config in %s: %w", foundPath, err)
+	}
+
+	return &config, foundPath, nil
+}
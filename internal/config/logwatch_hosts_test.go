@@ -0,0 +1,147 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeReports creates empty report files below dir.
+func writeReports(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestLogwatchHostsConfig_ResolveHosts(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeReports(t, dir, "web01.txt", "web02.example.com.txt.gz", "db01.log", "db01/logwatch.txt", "db02/logwatch.txt")
+
+	config := &LogwatchHostsConfig{
+		Hosts:      map[string]LogwatchHostEntry{"db01": {ReportPath: "/srv/db01/logwatch.txt"}},
+		ReportGlob: filepath.Join(dir, "*.*"),
+	}
+	hosts, err := config.ResolveHosts()
+	if err != nil {
+		t.Fatalf("ResolveHosts() error = %v", err)
+	}
+	want := []LogwatchHost{
+		{Name: "db01", ReportPath: "/srv/db01/logwatch.txt"},
+		{Name: "web01", ReportPath: filepath.Join(dir, "web01.txt"), Globbed: true},
+		{Name: "web02.example.com", ReportPath: filepath.Join(dir, "web02.example.com.txt.gz"), Globbed: true},
+	}
+	if !reflect.DeepEqual(hosts, want) {
+		t.Errorf("ResolveHosts() =\n%+v\nwant\n%+v", hosts, want)
+	}
+
+	config = &LogwatchHostsConfig{ReportGlob: filepath.Join(dir, "*", "logwatch.txt"), HostFrom: LogwatchHostFromDir}
+	hosts, err = config.ResolveHosts()
+	if err != nil {
+		t.Fatalf("ResolveHosts(dir) error = %v", err)
+	}
+	if len(hosts) != 2 || hosts[0].Name != "db01" || hosts[1].Name != "db02" {
+		t.Errorf("ResolveHosts(dir) = %+v, want db01 and db02", hosts)
+	}
+
+	// db01.log and db01/logwatch.txt named by file
+	config = &LogwatchHostsConfig{ReportGlob: filepath.Join(dir, "db01*")}
+	writeReports(t, dir, "db01.txt")
+	if _, err := config.ResolveHosts(); err == nil || !strings.Contains(err.Error(), "more than one report") {
+		t.Errorf("ResolveHosts() error = %v, want duplicate host", err)
+	}
+
+	config = &LogwatchHostsConfig{ReportGlob: filepath.Join(dir, "*.missing")}
+	if _, err := config.ResolveHosts(); err == nil {
+		t.Error("ResolveHosts() expected error without hosts")
+	}
+}
+
+func TestLogwatchHostsConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		config LogwatchHostsConfig
+		want   string
+	}{
+		{"empty", LogwatchHostsConfig{}, "no hosts or report_glob"},
+		{"invalid host name", LogwatchHostsConfig{Hosts: map[string]LogwatchHostEntry{"web 01": {ReportPath: "/r"}}}, "invalid host name"},
+		{"missing report path", LogwatchHostsConfig{Hosts: map[string]LogwatchHostEntry{"web01": {}}}, "report_path is required"},
+		{"invalid glob", LogwatchHostsConfig{ReportGlob: "/srv/[logwatch"}, "invalid report_glob"},
+		{"invalid host_from", LogwatchHostsConfig{ReportGlob: "/srv/*.txt", HostFrom: "path"}, "host_from"},
+	}
+
+	for _, tt := range tests {
+		if err := tt.config.Validate(); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: Validate() error = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestLoadWithCLI_LogwatchHosts(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "sk-ant-test-key-1234567890")
+	t.Setenv("TELEGRAM_BOT_TOKEN", "123456789:ABCdefGHIjklMNOpqrsTUVwxyz")
+	t.Setenv("TELEGRAM_CHANNEL_ARCHIVE_ID", "-1001234567890")
+	t.Setenv("LOG_SOURCE_TYPE", "logwatch")
+
+	dir := t.TempDir()
+	writeReports(t, dir, "reports/web01.txt", "reports/web02.txt")
+	configPath := filepath.Join(dir, "logwatch-hosts.json")
+	content := `{
+  "version": "1.0",
+  "hosts": {"db01": {"report_path": "/srv/logwatch/db01.txt"}},
+  "report_glob": "` + filepath.Join(dir, "reports", "*.txt") + `",
+  "rollup": true
+}`
+	if err := os.WriteFile(configPath, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadWithCLI(&CLIOptions{LogwatchHostsConfig: configPath})
+	if err != nil {
+		t.Fatalf("LoadWithCLI() error = %v", err)
+	}
+	if !config.HasLogwatchHosts() || len(config.LogwatchHosts) != 3 || !config.LogwatchHostsConfig.Rollup {
+		t.Fatalf("LogwatchHosts = %+v", config.LogwatchHosts)
+	}
+
+	hostCfg := config.ForLogwatchHost(config.LogwatchHosts[1])
+	if hostCfg.SourceHost != "web01" || hostCfg.GetLogSourcePath() != filepath.Join(dir, "reports", "web01.txt") || hostCfg.HasLogwatchHosts() {
+		t.Errorf("ForLogwatchHost() = host %q, path %q", hostCfg.SourceHost, hostCfg.GetLogSourcePath())
+	}
+	if config.SourceHost != "" || !config.HasLogwatchHosts() {
+		t.Error("ForLogwatchHost() modified the run configuration")
+	}
+
+	config, err = LoadWithCLI(&CLIOptions{LogwatchHostsConfig: configPath, LogwatchHost: "web02"})
+	if err != nil {
+		t.Fatalf("LoadWithCLI(-logwatch-host) error = %v", err)
+	}
+	if len(config.LogwatchHosts) != 1 || config.LogwatchHosts[0].Name != "web02" {
+		t.Errorf("LogwatchHosts = %+v, want web02 only", config.LogwatchHosts)
+	}
+
+	_, err = LoadWithCLI(&CLIOptions{LogwatchHostsConfig: configPath, LogwatchHost: "web03"})
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("LoadWithCLI(unknown host) error = %v, want not found", err)
+	}
+
+	_, err = LoadWithCLI(&CLIOptions{LogwatchHostsConfig: configPath, SourcePath: "/tmp/logwatch.txt"})
+	if err == nil || !strings.Contains(err.Error(), "cannot be used with") {
+		t.Errorf("LoadWithCLI(-source-path) error = %v, want conflict", err)
+	}
+}
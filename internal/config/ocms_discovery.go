@@ -0,0 +1,129 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package config
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// OCMSSiteDiscovery makes the sites of the OCMS registry eligible without
+// an entry in ocms-sites.json. Include and Exclude are site ID glob
+// patterns (path.Match syntax); an empty Include matches every site.
+// Sites configured in ocms-sites.json are always eligible and take
+// precedence over the template.
+type OCMSSiteDiscovery struct {
+	Enabled  bool             `json:"enabled"`
+	Include  []string         `json:"include"`
+	Exclude  []string         `json:"exclude"`
+	Template OCMSSiteTemplate `json:"template"`
+}
+
+// OCMSSiteTemplate holds the settings of discovered sites. Name may refer
+// to the registry fields {site_id}, {system_user}, and {port}; an empty
+// Name is the site ID and an empty LogKind is default_log_kind.
+type OCMSSiteTemplate struct {
+	Name    string `json:"name"`
+	LogKind string `json:"log_kind"`
+}
+
+// OCMSSiteEntry is an OCMS site eligible for analysis, configured in
+// ocms-sites.json or discovered in the registry.
+type OCMSSiteEntry struct {
+	ID         string
+	Config     OCMSSiteConfig
+	Discovered bool
+}
+
+// DiscoveryEnabled reports whether registry sites are discovered.
+func (c *OCMSSitesConfig) DiscoveryEnabled() bool {
+	return c != nil && c.Discovery != nil && c.Discovery.Enabled
+}
+
+// validate checks the log kind and patterns of the discovery settings.
+func (d *OCMSSiteDiscovery) validate() error {
+	if _, err := NormalizeOCMSLogKind(d.Template.LogKind); err != nil {
+		return fmt.Errorf("template: log_kind: %w", err)
+	}
+	for _, pattern := range slices.Concat(d.Include, d.Exclude) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid site ID pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// matches reports whether a site ID passes the include and exclude filters.
+func (d *OCMSSiteDiscovery) matches(siteID string) bool {
+	match := func(pattern string) bool {
+		ok, _ := path.Match(pattern, siteID)
+		return ok
+	}
+	if len(d.Include) > 0 && !slices.ContainsFunc(d.Include, match) {
+		return false
+	}
+	return !slices.ContainsFunc(d.Exclude, match)
+}
+
+// siteConfig returns the settings of a discovered site.
+func (d *OCMSSiteDiscovery) siteConfig(site OCMSSite) OCMSSiteConfig {
+	name := strings.NewReplacer(
+		"{site_id}", site.ID,
+		"{system_user}", site.SystemUser,
+		"{port}", strconv.Itoa(site.Port),
+	).Replace(d.Template.Name)
+	return OCMSSiteConfig{Name: name, LogKind: d.Template.LogKind}
+}
+
+// ResolveSite returns the configured site with the ID or, with discovery
+// enabled, the registry site with the ID that passes the filters.
+func (c *OCMSSitesConfig) ResolveSite(siteID string, registry *OCMSSitesRegistry) (*OCMSSiteEntry, error) {
+	if site, exists := c.Sites[siteID]; exists {
+		return &OCMSSiteEntry{ID: siteID, Config: site}, nil
+	}
+	if c.DiscoveryEnabled() && c.Discovery.matches(siteID) {
+		if registrySite, exists := registry.Sites[siteID]; exists {
+			return &OCMSSiteEntry{ID: siteID, Config: c.Discovery.siteConfig(registrySite), Discovered: true}, nil
+		}
+	}
+
+	var available []string
+	for _, site := range c.EligibleSites(registry) {
+		available = append(available, site.ID)
+	}
+	return nil, fmt.Errorf("site '%s' not found (available: %v)", siteID, available)
+}
+
+// EligibleSites returns the configured sites and, with discovery enabled,
+// the discovered registry sites, sorted by ID.
+func (c *OCMSSitesConfig) EligibleSites(registry *OCMSSitesRegistry) []OCMSSiteEntry {
+	if c == nil {
+		return nil
+	}
+
+	sites := make([]OCMSSiteEntry, 0, len(c.Sites))
+	for _, siteID := range c.ListSites() {
+		sites = append(sites, OCMSSiteEntry{ID: siteID, Config: c.Sites[siteID]})
+	}
+	if c.DiscoveryEnabled() && registry != nil {
+		for _, siteID := range registry.ListSites() {
+			if _, configured := c.Sites[siteID]; configured || !c.Discovery.matches(siteID) {
+				continue
+			}
+			sites = append(sites, OCMSSiteEntry{
+				ID:         siteID,
+				Config:     c.Discovery.siteConfig(registry.Sites[siteID]),
+				Discovered: true,
+			})
+		}
+	}
+
+	slices.SortFunc(sites, func(a, b OCMSSiteEntry) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return sites
+}
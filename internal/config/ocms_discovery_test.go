@@ -0,0 +1,150 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func discoveryRegistry(t *testing.T) *OCMSSitesRegistry {
+	t.Helper()
+
+	registry, err := ParseOCMSSitesRegistry([]byte(`
+example_com /var/www/vhosts/example.com/ocms example_com 8081
+shop_example_com /var/www/vhosts/shop.example.com/ocms shop 8082
+staging_example_com /var/www/vhosts/staging.example.com/ocms staging 8083
+`))
+	if err != nil {
+		t.Fatalf("ParseOCMSSitesRegistry() error = %v", err)
+	}
+	return registry
+}
+
+func TestOCMSSitesConfig_EligibleSites(t *testing.T) {
+	t.Parallel()
+
+	config := &OCMSSitesConfig{
+		DefaultLogKind: OCMSLogKindMain,
+		Sites: map[string]OCMSSiteConfig{
+			"example_com": {Name: "Example Site"},
+		},
+		Discovery: &OCMSSiteDiscovery{
+			Enabled:  true,
+			Include:  []string{"*_com"},
+			Exclude:  []string{"staging_*"},
+			Template: OCMSSiteTemplate{Name: "OCMS {site_id} ({system_user}:{port})", LogKind: OCMSLogKindAll},
+		},
+	}
+
+	got := config.EligibleSites(discoveryRegistry(t))
+	want := []OCMSSiteEntry{
+		{ID: "example_com", Config: OCMSSiteConfig{Name: "Example Site"}},
+		{
+			ID:         "shop_example_com",
+			Config:     OCMSSiteConfig{Name: "OCMS shop_example_com (shop:8082)", LogKind: OCMSLogKindAll},
+			Discovered: true,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("EligibleSites() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	config.Discovery.Enabled = false
+	if got := config.EligibleSites(discoveryRegistry(t)); len(got) != 1 || got[0].Discovered {
+		t.Fatalf("EligibleSites() without discovery = %+v, want the configured site only", got)
+	}
+}
+
+func TestOCMSSitesConfig_ResolveSite(t *testing.T) {
+	t.Parallel()
+
+	registry := discoveryRegistry(t)
+	config := &OCMSSitesConfig{
+		Discovery: &OCMSSiteDiscovery{Enabled: true, Exclude: []string{"staging_*"}},
+	}
+
+	site, err := config.ResolveSite("shop_example_com", registry)
+	if err != nil {
+		t.Fatalf("ResolveSite() error = %v", err)
+	}
+	if !site.Discovered || site.Config.Name != "" {
+		t.Fatalf("ResolveSite() = %+v, want a discovered site named by its ID", site)
+	}
+
+	for _, siteID := range []string{"staging_example_com", "missing_com"} {
+		_, err := config.ResolveSite(siteID, registry)
+		if err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Errorf("ResolveSite(%q) error = %v, want not found", siteID, err)
+		}
+	}
+}
+
+func TestLoadOCMSSitesConfig_Discovery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name: "discovery without sites",
+			content: `{
+  "version": "1.0",
+  "default_site": "shop_example_com",
+  "discovery": {"enabled": true, "template": {"log_kind": "error"}}
+}`,
+		},
+		{
+			name: "disabled discovery still requires sites",
+			content: `{
+  "version": "1.0",
+  "discovery": {"enabled": false}
+}`,
+			wantErr: "no sites defined",
+		},
+		{
+			name: "invalid template log kind",
+			content: `{
+  "version": "1.0",
+  "discovery": {"enabled": true, "template": {"log_kind": "verbose"}}
+}`,
+			wantErr: "discovery: template: log_kind",
+		},
+		{
+			name: "invalid pattern",
+			content: `{
+  "version": "1.0",
+  "discovery": {"enabled": true, "include": ["[shop"]}
+}`,
+			wantErr: "invalid site ID pattern",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			configPath := filepath.Join(t.TempDir(), "ocms-sites.json")
+			if err := os.WriteFile(configPath, []byte(tt.content), 0o600); err != nil {
+				t.Fatalf("write config: %v", err)
+			}
+
+			_, _, err := LoadOCMSSitesConfig(configPath)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("LoadOCMSSitesConfig() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("LoadOCMSSitesConfig() error = %v, want substring %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+	"github.com/olegiv/logwatch-ai-go/internal/ocms"
+)
+
+// An OCMS log range is a comma-separated list of terms:
+//
+//	today                   the live log
+//	yesterday               the first rotation (.1), the default
+//	2026-04-25              the logs of a day
+//	2026-04-20..2026-04-26  the logs of a span of days
+//	last-7-days             the logs of the 7 days before today
+//	3, 1..7                 rotation indexes (0 is the live log)
+//
+// Rotated logs may be compressed (.N.gz, .N.bz2, .N.xz, .N.zst). A log file
+// covers the day of its first timestamped entry, or of its modification
+// time when no entry in its first lines has a timestamp.
+
+const (
+	ocmsDateLayout = "2006-01-02"
+
+	// maxOCMSRotations bounds the rotation indexes and the days of a range.
+	maxOCMSRotations = 366
+
+	// ocmsDateScanLines is the number of lines searched for the first
+	// timestamped entry of a log file.
+	ocmsDateScanLines = 100
+)
+
+var (
+	ocmsLastDaysRegex = regexp.MustCompile(`^last-(\d+)-days?$`)
+
+	// ocmsRotationSuffixes are tried in order after the rotation index.
+	ocmsRotationSuffixes = []string{"", ".gz", ".bz2", ".xz", ".zst"}
+)
+
+// ocmsRangeTerm is one term of an OCMS log range: a span of rotation
+// indexes, of days, or the last days before today.
+type ocmsRangeTerm struct {
+	byDate      bool
+	from, to    string // inclusive days, YYYY-MM-DD sorts chronologically
+	lastDays    int    // resolved against the current day
+	first, last int    // inclusive rotation indexes
+}
+
+// matches reports whether the log file with the rotation index and day is
+// selected by the term.
+func (t ocmsRangeTerm) matches(index int, day string, now time.Time) bool {
+	if !t.byDate {
+		return index >= t.first && index <= t.last
+	}
+	from, to := t.from, t.to
+	if t.lastDays > 0 {
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		from = today.AddDate(0, 0, -t.lastDays).Format(ocmsDateLayout)
+		to = today.AddDate(0, 0, -1).Format(ocmsDateLayout)
+	}
+	return day != "" && day >= from && day <= to
+}
+
+// NormalizeOCMSLogRange validates an OCMS log range and returns it
+// lowercased and without whitespace. An empty range is "yesterday".
+func NormalizeOCMSLogRange(logRange string) (string, error) {
+	normalized, _, err := parseOCMSLogRange(logRange)
+	return normalized, err
+}
+
+// parseOCMSLogRange returns the normalized range and its terms.
+func parseOCMSLogRange(logRange string) (string, []ocmsRangeTerm, error) {
+	normalized := strings.ToLower(strings.TrimSpace(logRange))
+	if normalized == "" {
+		normalized = OCMSLogRangeYesterday
+	}
+
+	parts := strings.Split(normalized, ",")
+	terms := make([]ocmsRangeTerm, 0, len(parts))
+	for i, part := range parts {
+		parts[i] = strings.TrimSpace(part)
+		term, err := parseOCMSRangeTerm(parts[i])
+		if err != nil {
+			return "", nil, fmt.Errorf("OCMS log range must be today, yesterday, a date (YYYY-MM-DD), "+
+				"a date span (YYYY-MM-DD..YYYY-MM-DD), last-N-days, or rotation indexes (N or N..M) (got: %s): %w", logRange, err)
+		}
+		terms = append(terms, term)
+	}
+	return strings.Join(parts, ","), terms, nil
+}
+
+func parseOCMSRangeTerm(term string) (ocmsRangeTerm, error) {
+	switch term {
+	case OCMSLogRangeToday:
+		return ocmsRangeTerm{first: 0, last: 0}, nil
+	case OCMSLogRangeYesterday:
+		return ocmsRangeTerm{first: 1, last: 1}, nil
+	}
+
+	if m := ocmsLastDaysRegex.FindStringSubmatch(term); m != nil {
+		days, err := strconv.Atoi(m[1])
+		if err != nil || days < 1 || days > maxOCMSRotations {
+			return ocmsRangeTerm{}, fmt.Errorf("last-N-days must be between 1 and %d days", maxOCMSRotations)
+		}
+		return ocmsRangeTerm{byDate: true, lastDays: days}, nil
+	}
+
+	from, to, isSpan := strings.Cut(term, "..")
+	if !isSpan {
+		to = from
+	}
+
+	if fromDay, err := time.Parse(ocmsDateLayout, from); err == nil {
+		toDay, err := time.Parse(ocmsDateLayout, to)
+		if err != nil {
+			return ocmsRangeTerm{}, fmt.Errorf("invalid date %q", to)
+		}
+		if toDay.Before(fromDay) {
+			fromDay, toDay = toDay, fromDay
+		}
+		return ocmsRangeTerm{
+			byDate: true,
+			from:   fromDay.Format(ocmsDateLayout),
+			to:     toDay.Format(ocmsDateLayout),
+		}, nil
+	}
+
+	first, errFirst := strconv.Atoi(from)
+	last, errLast := strconv.Atoi(to)
+	if errFirst != nil || errLast != nil {
+		return ocmsRangeTerm{}, fmt.Errorf("invalid term %q", term)
+	}
+	if last < first {
+		first, last = last, first
+	}
+	if first < 0 || last > maxOCMSRotations {
+		return ocmsRangeTerm{}, fmt.Errorf("rotation index must be between 0 and %d", maxOCMSRotations)
+	}
+	return ocmsRangeTerm{first: first, last: last}, nil
+}
+
+// isSingleOCMSLogRange reports whether the range is "today" or
+// "yesterday", which select one log file without labeling it by day.
+func isSingleOCMSLogRange(logRange string) bool {
+	return logRange == OCMSLogRangeToday || logRange == OCMSLogRangeYesterday
+}
+
+// ocmsRangeFile is a log file selected by an OCMS log range.
+type ocmsRangeFile struct {
+	path string
+	day  string
+}
+
+// resolveOCMSLogRange returns the log files of the live log at basePath and
+// its rotations that are selected by the terms, oldest first. Missing
+// rotations are skipped; a date scan ends at the first missing rotation.
+func resolveOCMSLogRange(basePath string, terms []ocmsRangeTerm, now time.Time) []ocmsRangeFile {
+	byDate := false
+	maxIndex := 0
+	for _, t := range terms {
+		byDate = byDate || t.byDate
+		maxIndex = max(maxIndex, t.last)
+	}
+	if byDate {
+		// Dates are found by scanning every rotation
+		maxIndex = maxOCMSRotations
+	}
+
+	var files []ocmsRangeFile
+	for index := 0; index <= maxIndex; index++ {
+		path, ok := findOCMSRotation(basePath, index)
+		if !ok {
+			if index > 0 && byDate {
+				break
+			}
+			continue
+		}
+		day := ocmsLogFileDay(path)
+		if slices.ContainsFunc(terms, func(t ocmsRangeTerm) bool { return t.matches(index, day, now) }) {
+			files = append(files, ocmsRangeFile{path: path, day: day})
+		}
+	}
+
+	slices.Reverse(files)
+	return files
+}
+
+// ocmsRotationPath returns the path of the rotation with the index, or the
+// live log for index 0.
+func ocmsRotationPath(basePath string, index int) string {
+	if index == 0 {
+		return basePath
+	}
+	return basePath + "." + strconv.Itoa(index)
+}
+
+// findOCMSRotation returns the existing, possibly compressed file of the
+// rotation with the index.
+func findOCMSRotation(basePath string, index int) (string, bool) {
+	path := ocmsRotationPath(basePath, index)
+	if index == 0 {
+		_, err := os.Stat(path)
+		return path, err == nil
+	}
+	for _, suffix := range ocmsRotationSuffixes {
+		if _, err := os.Stat(path + suffix); err == nil {
+			return path + suffix, true
+		}
+	}
+	return path, false
+}
+
+// ocmsLogFileDay returns the day covered by a log file: the day of its
+// first timestamped entry as written in the log, or of its modification
+// time.
+func ocmsLogFileDay(path string) string {
+	if day, ok := firstOCMSEntryDay(path); ok {
+		return day
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return ""
+	}
+	return info.ModTime().Format(ocmsDateLayout)
+}
+
+func firstOCMSEntryDay(path string) (string, bool) {
+	file, err := analyzer.OpenSourceFile(path)
+	if err != nil {
+		return "", false
+	}
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for lines := 0; lines < ocmsDateScanLines && scanner.Scan(); lines++ {
+		if entry, ok := ocms.ParseLine(scanner.Text()); ok && !entry.Time.IsZero() {
+			return entry.Time.Format(ocmsDateLayout), true
+		}
+	}
+	return "", false
+}
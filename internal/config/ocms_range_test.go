@@ -0,0 +1,154 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package config
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// writeOCMSRotations writes the live and rotated OCMS logs of a site for
+// 2026-04-23 to 2026-04-26 and returns the site.
+func writeOCMSRotations(t *testing.T) OCMSSite {
+	t.Helper()
+
+	instanceDir := t.TempDir()
+	logsDir := filepath.Join(instanceDir, "logs")
+	if err := os.Mkdir(logsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	if _, err := zw.Write([]byte("2026-04-24 08:00:00 [INFO] day 24\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string][]byte{
+		"ocms.log":    []byte("2026-04-26 00:10:00 [INFO] day 26\n"),
+		"ocms.log.1":  []byte("2026-04-25 00:10:00 [INFO] day 25\n"),
+		"ocms.log.2":  compressed.Bytes(),
+		"ocms.log.3":  []byte("no timestamp on day 23\n"),
+		"error.log.1": []byte("2026-04-25 13:00:00 [ERROR] boom\n"),
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(logsDir, name), data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// ocms.log.2 is named as logrotate's compress option names it
+	if err := os.Rename(filepath.Join(logsDir, "ocms.log.2"), filepath.Join(logsDir, "ocms.log.2.gz")); err != nil {
+		t.Fatal(err)
+	}
+	// ocms.log.3 is dated by its modification time
+	modified := time.Date(2026, 4, 23, 23, 59, 0, 0, time.Local)
+	if err := os.Chtimes(filepath.Join(logsDir, "ocms.log.3"), modified, modified); err != nil {
+		t.Fatal(err)
+	}
+
+	return OCMSSite{InstanceDir: instanceDir}
+}
+
+func TestOCMSSite_LogPaths_Ranges(t *testing.T) {
+	t.Parallel()
+
+	site := writeOCMSRotations(t)
+	now := time.Date(2026, 4, 26, 9, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name     string
+		logKind  string
+		logRange string
+		want     []string // kind day basename
+	}{
+		{
+			name:     "single day of both logs",
+			logKind:  OCMSLogKindAll,
+			logRange: "2026-04-25",
+			want:     []string{"main 2026-04-25 ocms.log.1", "error 2026-04-25 error.log.1"},
+		},
+		{
+			name:     "last days exclude today",
+			logKind:  OCMSLogKindMain,
+			logRange: "last-3-days",
+			want:     []string{"main 2026-04-23 ocms.log.3", "main 2026-04-24 ocms.log.2.gz", "main 2026-04-25 ocms.log.1"},
+		},
+		{
+			name:     "date span grouped by day",
+			logKind:  OCMSLogKindAll,
+			logRange: "2026-04-24..2026-04-26",
+			want: []string{
+				"main 2026-04-24 ocms.log.2.gz",
+				"main 2026-04-25 ocms.log.1",
+				"error 2026-04-25 error.log.1",
+				"main 2026-04-26 ocms.log",
+			},
+		},
+		{
+			name:     "rotation indexes",
+			logKind:  OCMSLogKindMain,
+			logRange: "0,2",
+			want:     []string{"main 2026-04-24 ocms.log.2.gz", "main 2026-04-26 ocms.log"},
+		},
+		{
+			name:     "missing rotations are skipped",
+			logKind:  OCMSLogKindError,
+			logRange: "0..9",
+			want:     []string{"error 2026-04-25 error.log.1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			paths, err := site.logPathsAt(tt.logKind, tt.logRange, now)
+			if err != nil {
+				t.Fatalf("LogPaths(%s, %s) error = %v", tt.logKind, tt.logRange, err)
+			}
+			var got []string
+			for _, p := range paths {
+				got = append(got, p.Kind+" "+p.Day+" "+filepath.Base(p.Path))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LogPaths(%s, %s) =\n%v\nwant\n%v", tt.logKind, tt.logRange, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOCMSSite_LogPaths_RangeWithoutFiles(t *testing.T) {
+	t.Parallel()
+
+	site := writeOCMSRotations(t)
+	if _, err := site.LogPaths(OCMSLogKindAll, "2026-01-01"); err == nil {
+		t.Fatal("LogPaths() expected error for a day without logs")
+	}
+}
+
+func TestOCMSSite_LogPath_CompressedYesterday(t *testing.T) {
+	t.Parallel()
+
+	site := writeOCMSRotations(t)
+	logsDir := filepath.Join(site.InstanceDir, "logs")
+	if err := os.Rename(filepath.Join(logsDir, "ocms.log.1"), filepath.Join(logsDir, "ocms.log.1.gz")); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := site.LogPath(OCMSLogKindMain, OCMSLogRangeYesterday)
+	if err != nil {
+		t.Fatalf("LogPath() error = %v", err)
+	}
+	if want := filepath.Join(logsDir, "ocms.log.1.gz"); got != want {
+		t.Errorf("LogPath() = %q, want %q", got, want)
+	}
+}
@@ -9,8 +9,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -23,14 +25,10 @@ const (
 	// OCMS log range — selects current vs rotated log files. yesterday
 	// is the default because the dominant use case is the daily cron
 	// running after midnight logrotate, where ocms.log.1 holds the full
-	// previous day's data.
+	// previous day's data. Dates, day spans, and rotation indexes are
+	// also accepted (see ocms_range.go).
 	OCMSLogRangeToday     = "today"
 	OCMSLogRangeYesterday = "yesterday"
-
-	// rotatedLogSuffix is appended to the derived log filename when the
-	// effective range is "yesterday". Plain numeric suffix only — gzipped
-	// older rotations (.2.gz etc.) are not supported in v1.
-	rotatedLogSuffix = ".1"
 )
 
 // OCMSSite represents one site entry from /etc/ocms/sites.conf.
@@ -46,10 +44,12 @@ type OCMSSitesRegistry struct {
 	Sites map[string]OCMSSite
 }
 
-// OCMSLogPath represents one derived OCMS log file.
+// OCMSLogPath represents one derived OCMS log file. Day is the day the file
+// covers when it was selected by a date or rotation range.
 type OCMSLogPath struct {
 	Kind string
 	Path string
+	Day  string
 }
 
 // OCMSSiteConfig represents logwatch-ai settings for a single OCMS site.
@@ -65,6 +65,7 @@ type OCMSSitesConfig struct {
 	RegistryPath   string                    `json:"registry_path"`
 	DefaultLogKind string                    `json:"default_log_kind"`
 	Sites          map[string]OCMSSiteConfig `json:"sites"`
+	Discovery      *OCMSSiteDiscovery        `json:"discovery,omitempty"`
 }
 
 // NormalizeOCMSLogKind validates and normalizes an OCMS log kind.
@@ -81,26 +82,15 @@ func NormalizeOCMSLogKind(logKind string) (string, error) {
 	}
 }
 
-// NormalizeOCMSLogRange validates and normalizes an OCMS log range.
-// Empty input maps to yesterday (the daily-cron default).
-func NormalizeOCMSLogRange(logRange string) (string, error) {
-	switch strings.ToLower(strings.TrimSpace(logRange)) {
-	case "", OCMSLogRangeYesterday:
-		return OCMSLogRangeYesterday, nil
-	case OCMSLogRangeToday:
-		return OCMSLogRangeToday, nil
-	default:
-		return "", fmt.Errorf("OCMS log range must be 'today' or 'yesterday' (got: %s)", logRange)
-	}
-}
-
-// Validate checks the logwatch-ai OCMS sites JSON configuration.
+// Validate checks the logwatch-ai OCMS sites JSON configuration. With
+// discovery enabled, sites may be empty and default_site may name a
+// discovered site.
 func (c *OCMSSitesConfig) Validate() error {
-	if c == nil || len(c.Sites) == 0 {
+	if c == nil || (len(c.Sites) == 0 && !c.DiscoveryEnabled()) {
 		return fmt.Errorf("no sites defined in configuration")
 	}
 
-	if c.DefaultSite != "" {
+	if c.DefaultSite != "" && !c.DiscoveryEnabled() {
 		if _, exists := c.Sites[c.DefaultSite]; !exists {
 			return fmt.Errorf("default_site '%s' does not exist in sites", c.DefaultSite)
 		}
@@ -116,6 +106,12 @@ func (c *OCMSSitesConfig) Validate() error {
 		}
 	}
 
+	if c.Discovery != nil {
+		if err := c.Discovery.validate(); err != nil {
+			return fmt.Errorf("discovery: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -181,8 +177,9 @@ func LoadOCMSSitesConfig(configPath string) (*OCMSSitesConfig, string, error) {
 }
 
 // LogPath returns the derived log path for the requested log kind and
-// range. Range "yesterday" appends `.1` to the filename (the rotated
-// log produced by the previous day's logrotate run).
+// range. Range "yesterday" selects the first rotation (`.1`, or its
+// compressed form when only that exists); other ranges return the live
+// log whose rotations they select (see LogPaths).
 func (s OCMSSite) LogPath(logKind, logRange string) (string, error) {
 	normalizedKind, err := NormalizeOCMSLogKind(logKind)
 	if err != nil {
@@ -197,51 +194,69 @@ func (s OCMSSite) LogPath(logKind, logRange string) (string, error) {
 		return "", err
 	}
 
-	var basename string
-	switch normalizedKind {
-	case OCMSLogKindError:
-		basename = "error.log"
-	default:
-		basename = "ocms.log"
-	}
+	basePath := s.baseLogPath(normalizedKind)
 	if normalizedRange == OCMSLogRangeYesterday {
-		basename += rotatedLogSuffix
+		path, _ := findOCMSRotation(basePath, 1)
+		return path, nil
 	}
-	return filepath.Join(s.InstanceDir, "logs", basename), nil
+	return basePath, nil
 }
 
 // LogPaths returns all derived log paths for the requested log kind and
-// range. Same `.1` rule as LogPath when range is "yesterday".
+// range. "today" and "yesterday" select one file per kind as LogPath does.
+// Other ranges select the matching live and rotated files, labeled with
+// their day and ordered by day; they fail when no file matches.
 func (s OCMSSite) LogPaths(logKind, logRange string) ([]OCMSLogPath, error) {
+	return s.logPathsAt(logKind, logRange, time.Now())
+}
+
+func (s OCMSSite) logPathsAt(logKind, logRange string, now time.Time) ([]OCMSLogPath, error) {
 	normalizedKind, err := NormalizeOCMSLogKind(logKind)
 	if err != nil {
 		return nil, err
 	}
-	normalizedRange, err := NormalizeOCMSLogRange(logRange)
+	normalizedRange, terms, err := parseOCMSLogRange(logRange)
 	if err != nil {
 		return nil, err
 	}
 
-	mainBase := "ocms.log"
-	errorBase := "error.log"
-	if normalizedRange == OCMSLogRangeYesterday {
-		mainBase += rotatedLogSuffix
-		errorBase += rotatedLogSuffix
+	kinds := []string{normalizedKind}
+	if normalizedKind == OCMSLogKindAll {
+		kinds = []string{OCMSLogKindMain, OCMSLogKindError}
 	}
-	mainPath := filepath.Join(s.InstanceDir, "logs", mainBase)
-	errorPath := filepath.Join(s.InstanceDir, "logs", errorBase)
 
-	switch normalizedKind {
-	case OCMSLogKindAll:
-		return []OCMSLogPath{
-			{Kind: OCMSLogKindMain, Path: mainPath},
-			{Kind: OCMSLogKindError, Path: errorPath},
-		}, nil
-	case OCMSLogKindError:
-		return []OCMSLogPath{{Kind: OCMSLogKindError, Path: errorPath}}, nil
-	default:
-		return []OCMSLogPath{{Kind: OCMSLogKindMain, Path: mainPath}}, nil
+	var paths []OCMSLogPath
+	for _, kind := range kinds {
+		if isSingleOCMSLogRange(normalizedRange) {
+			path, err := s.LogPath(kind, normalizedRange)
+			if err != nil {
+				return nil, err
+			}
+			paths = append(paths, OCMSLogPath{Kind: kind, Path: path})
+			continue
+		}
+		for _, file := range resolveOCMSLogRange(s.baseLogPath(kind), terms, now) {
+			paths = append(paths, OCMSLogPath{Kind: kind, Path: file.path, Day: file.day})
+		}
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("OCMS log range %s matches no log files in %s", normalizedRange, filepath.Join(s.InstanceDir, "logs"))
+	}
+
+	// Group the main and error logs of each day
+	slices.SortStableFunc(paths, func(a, b OCMSLogPath) int {
+		return strings.Compare(a.Day, b.Day)
+	})
+	return paths, nil
+}
+
+// baseLogPath returns the live log path of a log kind.
+func (s OCMSSite) baseLogPath(logKind string) string {
+	basename := "ocms.log"
+	if logKind == OCMSLogKindError {
+		basename = "error.log"
 	}
+	return filepath.Join(s.InstanceDir, "logs", basename)
 }
 
 // Validate checks the parsed OCMS sites registry for errors.
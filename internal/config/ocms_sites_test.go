@@ -147,7 +147,14 @@ func TestNormalizeOCMSLogRange(t *testing.T) {
 		{name: "today", input: "today", want: OCMSLogRangeToday},
 		{name: "uppercase today", input: "TODAY", want: OCMSLogRangeToday},
 		{name: "trim whitespace", input: " yesterday ", want: OCMSLogRangeYesterday},
+		{name: "date", input: "2026-04-25", want: "2026-04-25"},
+		{name: "date span and last days", input: "2026-04-20..2026-04-22, LAST-7-DAYS", want: "2026-04-20..2026-04-22,last-7-days"},
+		{name: "rotation indexes", input: "0,2..5", want: "0,2..5"},
 		{name: "invalid", input: "lastweek", wantErr: true},
+		{name: "invalid date", input: "2026-02-30", wantErr: true},
+		{name: "mixed span", input: "2026-04-20..3", wantErr: true},
+		{name: "zero days", input: "last-0-days", wantErr: true},
+		{name: "empty term", input: "today,", wantErr: true},
 	}
 
 	for _, tt := range tests {
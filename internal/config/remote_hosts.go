@@ -0,0 +1,71 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/olegiv/logwatch-ai-go/internal/remote"
+)
+
+// RemoteHostsConfig represents the remote host configuration file
+// (remote-hosts.json). Source paths like sftp://<host>/var/log/syslog
+// are read over SFTP with the SSH settings of the host.
+type RemoteHostsConfig struct {
+	Version string                 `json:"version"` // Config file version
+	Hosts   map[string]remote.Host `json:"hosts"`   // SSH settings keyed by the host name of sftp:// paths
+}
+
+// Validate checks the configuration for errors
+func (c *RemoteHostsConfig) Validate() error {
+	if len(c.Hosts) == 0 {
+		return fmt.Errorf("no hosts defined in configuration")
+	}
+
+	for _, name := range c.ListHosts() {
+		if err := remote.ValidateHostName(name); err != nil {
+			return err
+		}
+		host := c.Hosts[name]
+		if err := host.Validate(); err != nil {
+			return fmt.Errorf("host '%s': %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// ListHosts returns all host names sorted alphabetically
+func (c *RemoteHostsConfig) ListHosts() []string {
+	return sortedSiteIDs(c.Hosts)
+}
+
+// LoadRemoteHostsConfig loads and parses the remote-hosts.json file
+// If configPath is empty, it searches standard locations.
+// Returns nil, nil if no config file is found (not an error - no remote sources).
+func LoadRemoteHostsConfig(configPath string) (*RemoteHostsConfig, string, error) {
+	data, foundPath, err := loadFirstExistingFile(
+		configPath,
+		"remote hosts config",
+		standardRemoteHostsConfigPaths(),
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	if data == nil {
+		return nil, "", nil
+	}
+
+	var config RemoteHostsConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, "", fmt.Errorf("failed to parse %s: %w", foundPath, err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, "", fmt.Errorf("invalid config in %s: %w", foundPath, err)
+	}
+
+	return &config, foundPath, nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/olegiv/logwatch-ai-go/internal/remote"
+)
+
+func TestRemoteHostsConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	valid := remote.Host{User: "logreader", IdentityFile: "/opt/logwatch-ai/.ssh/id_ed25519"}
+	tests := []struct {
+		name  string
+		hosts map[string]remote.Host
+		want  string
+	}{
+		{"no hosts", nil, "no hosts defined"},
+		{"invalid name", map[string]remote.Host{"app 01": valid}, "invalid host name"},
+		{"missing key", map[string]remote.Host{"app01": {User: "logreader"}}, "host 'app01': identity_file is required"},
+	}
+
+	for _, tt := range tests {
+		config := &RemoteHostsConfig{Hosts: tt.hosts}
+		if err := config.Validate(); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: Validate() error = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+
+	config := &RemoteHostsConfig{Hosts: map[string]remote.Host{"app01": valid}}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestLoadWithCLI_RemoteSourcePath(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "sk-ant-test-key-1234567890")
+	t.Setenv("TELEGRAM_BOT_TOKEN", "123456789:ABCdefGHIjklMNOpqrsTUVwxyz")
+	t.Setenv("TELEGRAM_CHANNEL_ARCHIVE_ID", "-1001234567890")
+	t.Setenv("ENABLE_DATABASE", "true")
+
+	configPath := filepath.Join(t.TempDir(), "remote-hosts.json")
+	content := `{
+  "version": "1.0",
+  "hosts": {
+    "app01": {"address": "10.0.0.5", "user": "logreader", "identity_file": "/opt/logwatch-ai/.ssh/id_ed25519"}
+  }
+}`
+	if err := os.WriteFile(configPath, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadWithCLI(&CLIOptions{SourceType: "syslog", SourcePath: "sftp://app01/var/log/auth.log.1", RemoteHostsConfig: configPath})
+	if err != nil {
+		t.Fatalf("LoadWithCLI() error = %v", err)
+	}
+	if !config.HasRemoteHosts() || config.RemoteHostsConfigPath != configPath || config.GetLogSourcePath() != "sftp://app01/var/log/auth.log.1" {
+		t.Errorf("LoadWithCLI() remote hosts = %+v, path %q", config.RemoteHostsConfig, config.GetLogSourcePath())
+	}
+
+	tests := []struct {
+		name string
+		cli  CLIOptions
+		want string
+	}{
+		{"unknown host", CLIOptions{SourceType: "syslog", SourcePath: "sftp://web01/var/log/syslog", RemoteHostsConfig: configPath}, "remote host 'web01' not found (available: [app01])"},
+		{"without remote hosts", CLIOptions{SourceType: "syslog", SourcePath: "sftp://app01/var/log/syslog"}, "requires remote-hosts.json"},
+		{"incremental", CLIOptions{SourceType: "syslog", SourcePath: "sftp://app01/var/log/syslog", RemoteHostsConfig: configPath, Incremental: true}, "INCREMENTAL_READ requires a local log file"},
+		{"ocms", CLIOptions{SourceType: "ocms", SourcePath: "sftp://app01/srv/ocms/logs/ocms.log.1", RemoteHostsConfig: configPath}, "not supported when LOG_SOURCE_TYPE=ocms"},
+		{"other scheme", CLIOptions{SourceType: "syslog", SourcePath: "https://app01/var/log/syslog", RemoteHostsConfig: configPath}, "unsupported source path scheme https://"},
+		{"invalid URL", CLIOptions{SourceType: "syslog", SourcePath: "sftp://app01", RemoteHostsConfig: configPath}, "no file path"},
+	}
+	for _, tt := range tests {
+		if _, err := LoadWithCLI(&tt.cli); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: LoadWithCLI() error = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
@@ -89,3 +89,67 @@ func standardOCMSSitesConfigPaths() []string {
 
 	return searchPaths
 }
+
+func standardAccessLogSitesConfigPaths() []string {
+	searchPaths := []string{
+		"./access-log-sites.json",
+		"./configs/access-log-sites.json",
+		"/opt/logwatch-ai/access-log-sites.json",
+	}
+
+	if home := os.Getenv("HOME"); home != "" {
+		searchPaths = append(searchPaths,
+			filepath.Join(home, ".config", "logwatch-ai", "access-log-sites.json"),
+		)
+	}
+
+	return searchPaths
+}
+
+func standardLogwatchHostsConfigPaths() []string {
+	searchPaths := []string{
+		"./logwatch-hosts.json",
+		"./configs/logwatch-hosts.json",
+		"/opt/logwatch-ai/logwatch-hosts.json",
+	}
+
+	if home := os.Getenv("HOME"); home != "" {
+		searchPaths = append(searchPaths,
+			filepath.Join(home, ".config", "logwatch-ai", "logwatch-hosts.json"),
+		)
+	}
+
+	return searchPaths
+}
+
+func standardCustomSourcesConfigPaths() []string {
+	searchPaths := []string{
+		"./custom-sources.json",
+		"./configs/custom-sources.json",
+		"/opt/logwatch-ai/custom-sources.json",
+	}
+
+	if home := os.Getenv("HOME"); home != "" {
+		searchPaths = append(searchPaths,
+			filepath.Join(home, ".config", "logwatch-ai", "custom-sources.json"),
+		)
+	}
+
+	return searchPaths
+}
+
+func standardRemoteHostsConfigPaths() []string {
+	searchPaths := []string{
+		"./remote-hosts.json",
+		"./configs/remote-hosts.json",
+		"/opt/logwatch-ai/remote-hosts.json",
+	}
+
+	if home := os.Getenv("HOME"); home != "" {
+		searchPaths = append(searchPaths,
+			filepath.Join(home, ".config", "logwatch-ai", "remote-hosts.json"),
+		)
+	}
+
+	return searchPaths
+}
@@ -0,0 +1,258 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package customlog
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+)
+
+// timeFormatDateTime is the standard date-time format of the report.
+const timeFormatDateTime = "2006-01-02 15:04:05"
+
+// Limits of the formatted report. Repeats are collapsed before these apply.
+const (
+	maxGroupsPerLevel = 30
+	maxComponents     = 20
+	maxMessageLen     = 250
+)
+
+// ansiRegex matches terminal color codes, compiled once: it runs per message.
+var ansiRegex = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+// digest aggregates the entries of a custom log.
+type digest struct {
+	source       *Source
+	entries      int
+	unparsed     int // lines before the first entry or not matching the line regex
+	continuation int // indented lines following an entry, such as stack traces
+	first        time.Time
+	last         time.Time
+	levels       map[Level]int
+	components   map[string]*componentStats
+	keywords     map[string]int
+	groups       []*entryGroup
+	index        map[string]*entryGroup
+}
+
+// componentStats counts the entries of one component.
+type componentStats struct {
+	entries int
+	errors  int // critical and error entries
+}
+
+// entryGroup collapses repeated entries.
+type entryGroup struct {
+	level     Level
+	component string
+	example   string
+	keywords  []string
+	count     int
+	first     time.Time
+	last      time.Time
+}
+
+func newDigest(source *Source) *digest {
+	return &digest{
+		source:     source,
+		levels:     make(map[Level]int),
+		components: make(map[string]*componentStats),
+		keywords:   make(map[string]int),
+		index:      make(map[string]*entryGroup),
+	}
+}
+
+// add records one entry.
+func (d *digest) add(entry Entry) {
+	d.entries++
+	d.levels[entry.Level]++
+	for _, keyword := range entry.Keywords {
+		d.keywords[keyword]++
+	}
+	if !entry.Time.IsZero() {
+		if d.first.IsZero() || entry.Time.Before(d.first) {
+			d.first = entry.Time
+		}
+		if entry.Time.After(d.last) {
+			d.last = entry.Time
+		}
+	}
+
+	if entry.Component != "" {
+		cs, ok := d.components[entry.Component]
+		if !ok {
+			cs = &componentStats{}
+			d.components[entry.Component] = cs
+		}
+		cs.entries++
+		if entry.Level <= LevelError {
+			cs.errors++
+		}
+	}
+
+	g, ok := d.index[entry.key]
+	if !ok {
+		g = &entryGroup{
+			level:     entry.Level,
+			component: entry.Component,
+			example:   entry.Message,
+			keywords:  entry.Keywords,
+			first:     entry.Time,
+			last:      entry.Time,
+		}
+		d.index[entry.key] = g
+		d.groups = append(d.groups, g)
+	}
+	g.count++
+	if !entry.Time.IsZero() {
+		if g.first.IsZero() || entry.Time.Before(g.first) {
+			g.first = entry.Time
+		}
+		if entry.Time.After(g.last) {
+			g.last = entry.Time
+		}
+	}
+}
+
+// errorCount returns the number of critical and error entries.
+func (d *digest) errorCount() int {
+	return d.levels[LevelCritical] + d.levels[LevelError]
+}
+
+// format renders the report: summary, components, and one section per
+// level, most severe first.
+func (d *digest) format() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "=== %s LOG DIGEST ===\n\n", strings.ToUpper(d.source.Name))
+
+	sb.WriteString("## Summary Statistics\n")
+	if !d.first.IsZero() {
+		fmt.Fprintf(&sb, "Time range: %s to %s\n", d.first.Format(timeFormatDateTime), d.last.Format(timeFormatDateTime))
+	}
+	fmt.Fprintf(&sb, "Entries: %d\n", d.entries)
+	var levels []string
+	for level := LevelCritical; level <= LevelDebug; level++ {
+		if count := d.levels[level]; count > 0 {
+			levels = append(levels, fmt.Sprintf("%s: %d", level, count))
+		}
+	}
+	fmt.Fprintf(&sb, "Levels: %s\n", strings.Join(levels, ", "))
+	if len(d.keywords) > 0 {
+		var matches []string
+		for _, keyword := range d.source.PriorityKeywords {
+			if count := d.keywords[keyword]; count > 0 {
+				matches = append(matches, fmt.Sprintf("%s: %d", keyword, count))
+			}
+		}
+		fmt.Fprintf(&sb, "Priority keyword matches: %s\n", strings.Join(matches, ", "))
+	}
+	if d.continuation > 0 {
+		fmt.Fprintf(&sb, "Continuation lines (e.g. stack traces): %d\n", d.continuation)
+	}
+	if d.unparsed > 0 {
+		fmt.Fprintf(&sb, "Unparsed lines: %d\n", d.unparsed)
+	}
+
+	if len(d.components) > 0 {
+		sb.WriteString("\n## Components\n")
+		names := make([]string, 0, len(d.components))
+		for name := range d.components {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			a, b := d.components[names[i]], d.components[names[j]]
+			if a.errors != b.errors {
+				return a.errors > b.errors
+			}
+			if a.entries != b.entries {
+				return a.entries > b.entries
+			}
+			return names[i] < names[j]
+		})
+		for i, name := range names {
+			if i >= maxComponents {
+				fmt.Fprintf(&sb, "... and %d more components\n", len(names)-maxComponents)
+				break
+			}
+			cs := d.components[name]
+			fmt.Fprintf(&sb, "- %s: %d entries (critical/error: %d)\n", name, cs.entries, cs.errors)
+		}
+	}
+
+	for level := LevelCritical; level <= LevelDebug; level++ {
+		var groups []*entryGroup
+		for _, g := range d.groups {
+			if g.level == level {
+				groups = append(groups, g)
+			}
+		}
+		if len(groups) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n## %s\n", levelSectionName(level))
+		writeGroups(&sb, sortGroups(groups))
+	}
+
+	return sb.String()
+}
+
+// levelSectionName returns the section header of a level.
+func levelSectionName(level Level) string {
+	name := level.String()
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// sortGroups orders groups with priority keywords first, then most
+// frequent first.
+func sortGroups(groups []*entryGroup) []*entryGroup {
+	sorted := append([]*entryGroup(nil), groups...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		pi, pj := len(sorted[i].keywords) > 0, len(sorted[j].keywords) > 0
+		if pi != pj {
+			return pi
+		}
+		return sorted[i].count > sorted[j].count
+	})
+	return sorted
+}
+
+// writeGroups writes one line per group, up to maxGroupsPerLevel.
+func writeGroups(sb *strings.Builder, groups []*entryGroup) {
+	for i, g := range groups {
+		if i >= maxGroupsPerLevel {
+			fmt.Fprintf(sb, "... and %d more unique entries\n", len(groups)-maxGroupsPerLevel)
+			break
+		}
+
+		sb.WriteString("- ")
+		switch {
+		case g.first.IsZero() && g.count > 1:
+			fmt.Fprintf(sb, "[%dx] ", g.count)
+		case g.first.IsZero():
+		case g.count == 1:
+			fmt.Fprintf(sb, "[%s] ", g.last.Format(timeFormatDateTime))
+		default:
+			fmt.Fprintf(sb, "[%dx, %s to %s] ", g.count, g.first.Format(timeFormatDateTime), g.last.Format(timeFormatDateTime))
+		}
+		if len(g.keywords) > 0 {
+			fmt.Fprintf(sb, "(priority: %s) ", strings.Join(g.keywords, ", "))
+		}
+		if g.component != "" {
+			sb.WriteString(g.component)
+			sb.WriteString(": ")
+		}
+		sb.WriteString(analyzer.TruncateMessage(g.example, maxMessageLen))
+		sb.WriteString("\n")
+	}
+}
+
+// cleanMessage removes terminal color codes and surrounding whitespace.
+func cleanMessage(msg string) string {
+	return strings.TrimSpace(ansiRegex.ReplaceAllString(msg, ""))
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package customlog
+
+import (
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+)
+
+// Compile-time interface check
+var (
+	_ analyzer.Preprocessor       = (*Preprocessor)(nil)
+	_ analyzer.BudgetPreprocessor = (*Preprocessor)(nil)
+)
+
+// sectionPriority returns the priority of a section written by
+// digest.format: info and debug entries are shortened first, then
+// warnings.
+func sectionPriority(name string) int {
+	switch name {
+	case "Summary Statistics", "Components", "Critical", "Error":
+		return analyzer.SectionPriorityHigh
+	case "Warning":
+		return analyzer.SectionPriorityMedium
+	default:
+		return analyzer.SectionPriorityLow
+	}
+}
+
+// Preprocessor handles preprocessing of large custom log digests. It keeps
+// the statistics, components, and critical and error entries, and shortens
+// the info and debug sections first, then warnings.
+type Preprocessor struct {
+	*analyzer.SectionPreprocessor
+}
+
+// NewPreprocessor creates a new custom log preprocessor.
+func NewPreprocessor(maxTokens int) *Preprocessor {
+	return &Preprocessor{analyzer.NewSectionPreprocessor(maxTokens, sectionPriority)}
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package customlog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/olegiv/logwatch-ai-go/internal/ai"
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+)
+
+// defaultRole is the analyst role of sources without a role.
+const defaultRole = "senior software engineer and site reliability engineer"
+
+// Compile-time interface check
+var _ analyzer.PromptBuilder = (*PromptBuilder)(nil)
+
+// PromptBuilder implements analyzer.PromptBuilder for custom log analysis.
+// The prompts are built from the role, description, and priority keywords
+// of the source definition.
+type PromptBuilder struct {
+	source *Source
+}
+
+// NewPromptBuilder creates a new prompt builder of a custom source.
+func NewPromptBuilder(source *Source) *PromptBuilder {
+	return &PromptBuilder{source: source}
+}
+
+// GetLogType returns the log type identifier: the custom source type.
+func (p *PromptBuilder) GetLogType() string {
+	return p.source.Type
+}
+
+// GetSystemPrompt returns the system prompt for custom log analysis.
+func (p *PromptBuilder) GetSystemPrompt(globalExclusions []string) string {
+	role := p.source.Role
+	if role == "" {
+		role = defaultRole
+	}
+
+	var prompt strings.Builder
+	fmt.Fprintf(&prompt, "You are a %s. Your role is to analyze %s log digests and provide actionable insights.\n\n", role, p.source.Name)
+
+	if p.source.Description != "" {
+		prompt.WriteString("**Application:**\n")
+		prompt.WriteString(p.source.Description)
+		prompt.WriteString("\n\n")
+	}
+
+	prompt.WriteString(`**Input Format:**
+The log is pre-aggregated. "## Summary Statistics" gives the time range, entry counts per level, and priority keyword matches; "## Components" lists the components with the most errors. One section per level ("## Critical", "## Error", "## Warning", "## Info", "## Debug") lists the entries of that level. A line like
+"- [12x, 2026-01-01 02:00:00 to 2026-01-01 03:10:00] component: message" stands for 12 similar entries in that time range; numbers, IDs and IP addresses are normalized when grouping. Entries marked "(priority: ...)" contain priority keywords.
+
+**Analysis Framework:**
+
+1. **System Status Assessment** - Classify overall health of the application:
+   - "Excellent" - No issues, optimal operation
+   - "Good" - Minor issues that don't affect operations
+   - "Satisfactory" - Some concerns but the application is stable
+   - "Bad" - Significant issues requiring attention
+   - "Awful" - Critical failures, the application is down or failing for users
+
+2. **Application Health Indicators:**
+   - Critical and error entries, unhandled exceptions, and crashes
+   - Failures of dependencies: databases, caches, queues, and upstream services
+   - Timeouts, retries, and degraded performance
+   - Resource exhaustion: memory, disk, connection pools, rate limits
+   - Security-relevant entries: authentication failures, permission errors, suspicious input
+
+3. **Recommendations** - Provide specific, actionable steps:
+   - Prioritize by urgency
+   - Focus on root causes over symptoms
+
+4. **Metrics Extraction:**
+   - errorCount: number of critical and error entries
+   - warningCount: number of warning entries
+   - topErrorComponents: components with the most errors
+`)
+
+	if len(p.source.PriorityKeywords) > 0 {
+		prompt.WriteString("\n**Priority Keywords:**\n")
+		fmt.Fprintf(&prompt, "The operator considers entries mentioning these keywords most important: %s. Report them even at warning or info level, and name the keyword in the finding.\n", strings.Join(p.source.PriorityKeywords, ", "))
+	}
+
+	prompt.WriteString(`
+**Output Requirements:**
+
+You MUST respond with a valid JSON object (and ONLY JSON) in this exact format:
+
+{
+  "systemStatus": "Excellent|Good|Satisfactory|Bad|Awful",
+  "summary": "2-3 sentence overview of the application's state",
+  "criticalIssues": [
+    "Urgent issue requiring immediate action"
+  ],
+  "warnings": [
+    "Concerning issue that should be monitored"
+  ],
+  "recommendations": [
+    "Specific actionable recommendation"
+  ],
+  "metrics": {
+    "errorCount": 0,
+    "warningCount": 0,
+    "topErrorComponents": ["component"]
+  }
+}
+
+**Analysis Principles:**
+- Name the affected component in every finding
+- Repeats of the same entry are one issue; use the count to judge its severity
+- Distinguish transient errors from persistent failures
+- Consider historical context for trend analysis
+- Empty arrays are acceptable if no issues/warnings/recommendations exist`)
+
+	return prompt.String() + ai.GlobalExclusionsBlock(globalExclusions) + ai.StringArrayFormatReminder
+}
+
+// GetUserPrompt constructs the user prompt with the log digest and historical context.
+func (p *PromptBuilder) GetUserPrompt(logContent, historicalContext string, contextualExclusions []string) string {
+	var prompt strings.Builder
+
+	fmt.Fprintf(&prompt, "%s LOG:\n", strings.ToUpper(p.source.Name))
+	prompt.WriteString(ai.SanitizeLogContent(logContent))
+	prompt.WriteString("\n\n")
+
+	if historicalContext != "" {
+		prompt.WriteString("HISTORICAL CONTEXT:\n")
+		prompt.WriteString(ai.SanitizeLogContent(historicalContext))
+		prompt.WriteString("\n\n")
+	}
+
+	prompt.WriteString(ai.ContextualExclusionsBlock(contextualExclusions))
+	fmt.Fprintf(&prompt, "Please analyze the %s log above and provide your assessment in JSON format as specified.", p.source.Name)
+
+	return prompt.String()
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package customlog
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPromptBuilder_GetSystemPrompt(t *testing.T) {
+	prompt := NewPromptBuilder(mustCompile(t, testDefinition())).GetSystemPrompt(nil)
+	for _, want := range []string{
+		"You are a senior Java engineer operating the order service.",
+		"analyze Order Service log digests",
+		"**Application:**\nTakes orders from the web shop",
+		"most important: payment, deadlock.",
+		"topErrorComponents",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("system prompt missing %q", want)
+		}
+	}
+
+	minimal := mustCompile(t, &Definition{LineRegex: `^(?P<message>.*)$`})
+	prompt = NewPromptBuilder(minimal).GetSystemPrompt([]string{"health check"})
+	if !strings.Contains(prompt, "You are a "+defaultRole) || !strings.Contains(prompt, "health check") {
+		t.Errorf("minimal system prompt:\n%s", prompt)
+	}
+	if strings.Contains(prompt, "**Application:**") || strings.Contains(prompt, "**Priority Keywords:**") {
+		t.Error("system prompt should omit empty description and keywords")
+	}
+}
+
+func TestPromptBuilder_GetUserPrompt(t *testing.T) {
+	// The log is labeled with the name of the definition
+	prompt := NewPromptBuilder(mustCompile(t, testDefinition())).GetUserPrompt("## Error\n- payment failed", "", nil)
+	for _, want := range []string{"ORDER SERVICE LOG:\n", "analyze the Order Service log"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("user prompt missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package customlog
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+)
+
+// NoEntriesContent is returned when the log contains no entries.
+// This is a valid state for idle applications on a rotated log.
+// Use IsNoEntriesContent() to check for this condition.
+const NoEntriesContent = "=== NO LOG ENTRIES ===\n\nNo log entries were found for the analyzed time period.\nThis typically means the log was rotated empty or the application was idle."
+
+// maxStatsItems limits the breakdowns of ReadStats.
+const maxStatsItems = 10
+
+// IsNoEntriesContent checks if the content indicates no log entries were found.
+func IsNoEntriesContent(content string) bool {
+	return strings.HasPrefix(content, "=== NO LOG ENTRIES ===")
+}
+
+// Compile-time interface checks
+var (
+	_ analyzer.LogReader     = (*Reader)(nil)
+	_ analyzer.StatsReporter = (*Reader)(nil)
+	_ analyzer.ContentReader = (*Reader)(nil)
+)
+
+// Reader handles reading the log of a custom source.
+// Implements analyzer.LogReader interface.
+type Reader struct {
+	source              *Source
+	maxSizeMB           int
+	enablePreprocessing bool
+	maxTokens           int
+	preprocessor        *Preprocessor
+	digest              *digest
+}
+
+// NewReader creates a new reader of a custom source.
+func NewReader(source *Source, maxSizeMB int, enablePreprocessing bool, maxTokens int) *Reader {
+	return &Reader{
+		source:              source,
+		maxSizeMB:           maxSizeMB,
+		enablePreprocessing: enablePreprocessing,
+		maxTokens:           maxTokens,
+		preprocessor:        NewPreprocessor(maxTokens),
+	}
+}
+
+// Read implements analyzer.LogReader.Read.
+// Parses the log file and returns a digest with one section per level.
+func (r *Reader) Read(sourcePath string) (string, error) {
+	r.digest = nil
+
+	content, err := analyzer.ReadSourceFileWithGuards(
+		sourcePath,
+		analyzer.FileReadOptions{
+			SourceLabel: r.source.Name,
+			MaxSizeMB:   r.maxSizeMB,
+			MaxAge:      24 * time.Hour,
+		},
+		func(string) error { return nil }, // an empty log is a valid idle period
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return r.ReadContent(content)
+}
+
+// ReadContent implements analyzer.ContentReader.
+// Digests log lines that were not read from a file.
+func (r *Reader) ReadContent(content string) (string, error) {
+	r.digest = nil
+
+	d, err := parse(r.source, content)
+	if err != nil {
+		return "", err
+	}
+	r.digest = d
+
+	if d.entries == 0 {
+		return NoEntriesContent, nil
+	}
+
+	formattedContent := d.format()
+
+	if err := r.Validate(formattedContent); err != nil {
+		return "", fmt.Errorf("%s content validation failed: %w", r.source.Name, err)
+	}
+
+	if r.enablePreprocessing && r.preprocessor.ShouldProcess(formattedContent, r.maxTokens) {
+		processedContent, err := r.preprocessor.Process(formattedContent)
+		if err != nil {
+			return "", fmt.Errorf("preprocessing failed: %w", err)
+		}
+		return processedContent, nil
+	}
+
+	return formattedContent, nil
+}
+
+// parse aggregates the log lines. Indented lines following an entry are
+// continuation lines; other lines not matching the line regex are
+// unparsed. A log in which no line matches is an error: the line regex
+// most likely does not fit the file.
+func parse(source *Source, content string) (*digest, error) {
+	d := newDigest(source)
+	for line := range strings.Lines(content) {
+		line = strings.TrimRight(line, "\r\n")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		entry, ok := source.ParseLine(line)
+		if !ok {
+			if d.entries > 0 && (line[0] == ' ' || line[0] == '\t') {
+				d.continuation++
+			} else {
+				d.unparsed++
+			}
+			continue
+		}
+		d.add(entry)
+	}
+
+	if d.entries == 0 && d.unparsed > 0 {
+		return nil, fmt.Errorf("no %s lines match line_regex (%d lines skipped)", source.Name, d.unparsed)
+	}
+	return d, nil
+}
+
+// ReadStats implements analyzer.StatsReporter.
+// Summarizes the entries of the last Read by level, component, priority
+// keyword, and repeated message.
+func (r *Reader) ReadStats() *analyzer.ReadStats {
+	d := r.digest
+	if d == nil {
+		return nil
+	}
+
+	stats := &analyzer.ReadStats{
+		Totals: []analyzer.StatsItem{
+			{Name: "Entries", Count: d.entries},
+			{Name: "Critical/error entries", Count: d.errorCount()},
+		},
+	}
+	if len(d.components) > 0 {
+		stats.Totals = append(stats.Totals, analyzer.StatsItem{Name: "Components", Count: len(d.components)})
+	}
+	if d.unparsed > 0 {
+		stats.Totals = append(stats.Totals, analyzer.StatsItem{Name: "Unparsed lines", Count: d.unparsed})
+	}
+
+	var levels []analyzer.StatsItem
+	for level := LevelCritical; level <= LevelDebug; level++ {
+		if count := d.levels[level]; count > 0 {
+			levels = append(levels, analyzer.StatsItem{Name: level.String(), Count: count})
+		}
+	}
+	stats.AddBreakdown("Level", levels)
+
+	componentErrors := make(map[string]int)
+	for name, cs := range d.components {
+		if cs.errors > 0 {
+			componentErrors[name] = cs.errors
+		}
+	}
+	stats.AddBreakdown("Components with errors", analyzer.TopCounts(componentErrors, maxStatsItems))
+	stats.AddBreakdown("Priority keywords", analyzer.TopCounts(d.keywords, maxStatsItems))
+
+	repeated := make(map[string]int)
+	for _, g := range d.groups {
+		if g.count > 1 {
+			name := analyzer.TruncateMessage(g.example, 80)
+			if g.component != "" {
+				name = g.component + ": " + name
+			}
+			repeated[name] += g.count
+		}
+	}
+	stats.AddBreakdown("Top repeated messages", analyzer.TopCounts(repeated, maxStatsItems))
+
+	return stats
+}
+
+// Validate implements analyzer.LogReader.Validate.
+// Performs basic validation on the formatted digest.
+func (r *Reader) Validate(content string) error {
+	if len(content) == 0 {
+		return fmt.Errorf("log content is empty")
+	}
+
+	// NoEntriesContent is a valid state - no entries for the time period
+	if IsNoEntriesContent(content) {
+		return nil
+	}
+
+	if len(content) < 50 {
+		return fmt.Errorf("log content seems too small to be valid (only %d bytes)", len(content))
+	}
+
+	return nil
+}
+
+// GetSourceInfo implements analyzer.LogReader.GetSourceInfo.
+// Returns metadata about the log file.
+func (r *Reader) GetSourceInfo(sourcePath string) (map[string]any, error) {
+	return analyzer.GetSourceFileInfo(sourcePath)
+}
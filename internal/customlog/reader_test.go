@@ -0,0 +1,96 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package customlog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testLog = `2026-10-17 02:15:04,123 ERROR [exec-3] com.shop.PaymentClient - Payment of order 8812 failed: timeout after 30000 ms
+2026-10-17 02:16:00,001 ERROR [exec-9] com.shop.PaymentClient - Payment of order 8813 failed: timeout after 30000 ms
+java.net.SocketTimeoutException: Read timed out
+	at com.shop.PaymentClient.charge(PaymentClient.java:88)
+	at com.shop.OrderService.checkout(OrderService.java:41)
+2026-10-17 02:20:00,000 WARN [pool-1] com.shop.db.Pool - Connection pool at 90% capacity
+2026-10-17 03:00:00,000 INFO [main] com.shop.OrderService - Order 8814 placed
+2026-10-17 03:05:00,000 INFO [main] com.shop.OrderService - Order 8815 placed
+2026-10-17 03:10:00,000 FATAL [main] com.shop.db.Pool - Transaction deadlock detected, giving up
+`
+
+func TestReader_ReadContent(t *testing.T) {
+	r := NewReader(mustCompile(t, testDefinition()), 10, false, 150000)
+
+	content, err := r.ReadContent(testLog)
+	if err != nil {
+		t.Fatalf("ReadContent() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"=== ORDER SERVICE LOG DIGEST ===",
+		"Time range: 2026-10-17 02:15:04 to 2026-10-17 03:10:00\n",
+		"Entries: 6\n",
+		"Levels: critical: 1, error: 2, warning: 1, info: 2\n",
+		"Priority keyword matches: payment: 2, deadlock: 1\n",
+		"Continuation lines (e.g. stack traces): 2\n",
+		"Unparsed lines: 1\n",
+		"## Components\n- com.shop.PaymentClient: 2 entries (critical/error: 2)\n- com.shop.db.Pool: 2 entries (critical/error: 1)\n",
+		"## Critical\n- [2026-10-17 03:10:00] (priority: deadlock) com.shop.db.Pool: Transaction deadlock detected, giving up\n",
+		"## Error\n- [2x, 2026-10-17 02:15:04 to 2026-10-17 02:16:00] (priority: payment) com.shop.PaymentClient: Payment of order 8812 failed",
+		"## Info\n- [2x, 2026-10-17 03:00:00 to 2026-10-17 03:05:00] com.shop.OrderService: Order 8814 placed\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("digest missing %q:\n%s", want, content)
+		}
+	}
+	if strings.Index(content, "## Critical") > strings.Index(content, "## Warning") {
+		t.Errorf("level sections not ordered by severity:\n%s", content)
+	}
+
+	stats := r.ReadStats()
+	if stats == nil || stats.Totals[0].Count != 6 || stats.Totals[1].Count != 3 {
+		t.Fatalf("ReadStats() = %+v", stats)
+	}
+}
+
+func TestReader_ReadContentNoEntries(t *testing.T) {
+	r := NewReader(mustCompile(t, testDefinition()), 10, false, 150000)
+
+	content, err := r.ReadContent("\n\n")
+	if err != nil {
+		t.Fatalf("ReadContent() error = %v", err)
+	}
+	if !IsNoEntriesContent(content) {
+		t.Errorf("ReadContent() = %q, want no-entries content", content)
+	}
+	if err := r.Validate(content); err != nil {
+		t.Errorf("Validate(no entries) error = %v", err)
+	}
+
+	if _, err := r.ReadContent("this is not\nan order service log\n"); err == nil || !strings.Contains(err.Error(), "match line_regex") {
+		t.Errorf("ReadContent(other format) error = %v", err)
+	}
+}
+
+func TestReader_Read(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "orders.log")
+	if err := os.WriteFile(path, []byte(testLog), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewReader(mustCompile(t, testDefinition()), 10, false, 150000)
+	content, err := r.Read(path)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if !strings.Contains(content, "Entries: 6") {
+		t.Errorf("Read() digest:\n%s", content)
+	}
+
+	if _, err := r.Read(filepath.Join(t.TempDir(), "missing.log")); err == nil || !strings.Contains(err.Error(), "Order Service file not found") {
+		t.Errorf("Read(missing) error = %v", err)
+	}
+}
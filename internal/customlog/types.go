@@ -0,0 +1,298 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package customlog analyzes line-oriented application logs whose format is
+// declared in configuration (custom-sources.json): a line regex with named
+// groups, a level mapping, grouping keys, and prompt hints. One generic
+// reader, preprocessor, and prompt builder serve every declared source.
+package customlog
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+)
+
+// Named groups of the line regex with a meaning to the reader. Other named
+// groups may be used as grouping keys.
+const (
+	GroupTimestamp = "timestamp"
+	GroupLevel     = "level"
+	GroupComponent = "component"
+	GroupMessage   = "message"
+)
+
+// Level is the normalized severity of an entry, most severe first.
+type Level int
+
+// Normalized levels.
+const (
+	LevelCritical Level = iota
+	LevelError
+	LevelWarning
+	LevelInfo
+	LevelDebug
+)
+
+// LevelName maps levels to their names, used in levels mappings and reports.
+var LevelName = map[Level]string{
+	LevelCritical: "critical",
+	LevelError:    "error",
+	LevelWarning:  "warning",
+	LevelInfo:     "info",
+	LevelDebug:    "debug",
+}
+
+// String returns the level name.
+func (l Level) String() string {
+	return LevelName[l]
+}
+
+// ParseLevel returns the level of a normalized level name.
+func ParseLevel(name string) (Level, bool) {
+	for level, levelName := range LevelName {
+		if levelName == name {
+			return level, true
+		}
+	}
+	return LevelInfo, false
+}
+
+// defaultLevels maps common raw level names (lowercase) that a source's
+// levels mapping does not cover. Any other level is info.
+var defaultLevels = map[string]Level{
+	"emerg":       LevelCritical,
+	"emergency":   LevelCritical,
+	"alert":       LevelCritical,
+	"crit":        LevelCritical,
+	"critical":    LevelCritical,
+	"fatal":       LevelCritical,
+	"panic":       LevelCritical,
+	"severe":      LevelCritical,
+	"err":         LevelError,
+	"error":       LevelError,
+	"warn":        LevelWarning,
+	"warning":     LevelWarning,
+	"notice":      LevelInfo,
+	"info":        LevelInfo,
+	"information": LevelInfo,
+	"debug":       LevelDebug,
+	"trace":       LevelDebug,
+	"fine":        LevelDebug,
+	"verbose":     LevelDebug,
+}
+
+// timestampLayouts are tried in order when a source has no timestamp_layout.
+// Fractional seconds are accepted after a dot or a comma.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04:05,999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999 -0700",
+	"02/Jan/2006:15:04:05 -0700",
+	time.ANSIC,
+	time.StampMicro,
+}
+
+// Definition declares a custom source in custom-sources.json.
+type Definition struct {
+	Name             string            `json:"name"`              // Display name in reports, e.g. "Order Service"
+	LogPath          string            `json:"log_path"`          // Log file, glob, or brace pattern
+	LineRegex        string            `json:"line_regex"`        // Named groups: message (required), timestamp, level, component
+	TimestampLayout  string            `json:"timestamp_layout"`  // Go time layout of the timestamp group; empty tries common layouts
+	Levels           map[string]string `json:"levels"`            // Raw level -> critical, error, warning, info, or debug
+	GroupBy          []string          `json:"group_by"`          // Named groups identifying repeated entries (default: component and message)
+	Role             string            `json:"role"`              // Role of the analyst in the prompt
+	Description      string            `json:"description"`       // What the application does, for the prompt
+	PriorityKeywords []string          `json:"priority_keywords"` // Entries containing these words are reported first
+}
+
+// Source is a compiled Definition.
+type Source struct {
+	Type             string
+	Name             string
+	Role             string
+	Description      string
+	PriorityKeywords []string
+
+	lineRegex       *regexp.Regexp
+	groupIndex      map[string]int
+	timestampLayout string
+	levels          map[string]Level // keyed by lowercase raw level
+	groupBy         []string
+}
+
+// Compile validates a definition and compiles it into the source of
+// sourceType.
+func (d *Definition) Compile(sourceType string) (*Source, error) {
+	if d.LineRegex == "" {
+		return nil, fmt.Errorf("line_regex is required")
+	}
+	lineRegex, err := regexp.Compile(d.LineRegex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid line_regex: %w", err)
+	}
+
+	groupIndex := make(map[string]int)
+	for i, name := range lineRegex.SubexpNames() {
+		if name != "" {
+			groupIndex[name] = i
+		}
+	}
+	if _, ok := groupIndex[GroupMessage]; !ok {
+		return nil, fmt.Errorf("line_regex must have a named group (?P<%s>...)", GroupMessage)
+	}
+
+	levels := make(map[string]Level, len(d.Levels))
+	for raw, name := range d.Levels {
+		level, ok := ParseLevel(strings.ToLower(name))
+		if !ok {
+			return nil, fmt.Errorf("levels: %q maps to unknown level %q (valid: critical, error, warning, info, debug)", raw, name)
+		}
+		levels[strings.ToLower(raw)] = level
+	}
+
+	// Entries are always grouped by level as well, so each group belongs
+	// to one level section of the digest
+	groupBy := d.GroupBy
+	if len(groupBy) == 0 {
+		groupBy = []string{GroupMessage}
+		if _, ok := groupIndex[GroupComponent]; ok {
+			groupBy = []string{GroupComponent, GroupMessage}
+		}
+	}
+	for _, name := range groupBy {
+		if _, ok := groupIndex[name]; !ok {
+			return nil, fmt.Errorf("group_by: line_regex has no named group %q", name)
+		}
+	}
+
+	var keywords []string
+	for _, keyword := range d.PriorityKeywords {
+		keyword = strings.ToLower(strings.TrimSpace(keyword))
+		if keyword == "" {
+			return nil, fmt.Errorf("priority_keywords: empty keyword")
+		}
+		if !slices.Contains(keywords, keyword) {
+			keywords = append(keywords, keyword)
+		}
+	}
+
+	name := d.Name
+	if name == "" {
+		name = sourceType
+	}
+
+	return &Source{
+		Type:             sourceType,
+		Name:             name,
+		Role:             strings.TrimSpace(d.Role),
+		Description:      strings.TrimSpace(d.Description),
+		PriorityKeywords: keywords,
+		lineRegex:        lineRegex,
+		groupIndex:       groupIndex,
+		timestampLayout:  d.TimestampLayout,
+		levels:           levels,
+		groupBy:          groupBy,
+	}, nil
+}
+
+// Entry is a parsed log line.
+type Entry struct {
+	Time      time.Time // Zero if the line has no timestamp or it did not parse
+	Level     Level
+	RawLevel  string
+	Component string
+	Message   string
+	Keywords  []string // Priority keywords found in the line
+
+	key string // Grouping key built from the group_by groups
+}
+
+// ParseLine parses a log line. It returns false if the line does not
+// match the line regex.
+func (s *Source) ParseLine(line string) (Entry, bool) {
+	match := s.lineRegex.FindStringSubmatch(line)
+	if match == nil {
+		return Entry{}, false
+	}
+
+	group := func(name string) string {
+		if i, ok := s.groupIndex[name]; ok {
+			return strings.TrimSpace(match[i])
+		}
+		return ""
+	}
+
+	entry := Entry{
+		RawLevel:  group(GroupLevel),
+		Component: group(GroupComponent),
+		Message:   cleanMessage(group(GroupMessage)),
+	}
+	entry.Level = s.level(entry.RawLevel)
+	if timestamp := group(GroupTimestamp); timestamp != "" {
+		entry.Time = s.parseTimestamp(timestamp)
+	}
+
+	lower := strings.ToLower(line)
+	for _, keyword := range s.PriorityKeywords {
+		if strings.Contains(lower, keyword) {
+			entry.Keywords = append(entry.Keywords, keyword)
+		}
+	}
+
+	keyParts := make([]string, 0, len(s.groupBy)+1)
+	keyParts = append(keyParts, entry.Level.String())
+	for _, name := range s.groupBy {
+		value := group(name)
+		if name == GroupMessage {
+			value = analyzer.NormalizeMessage(entry.Message)
+		}
+		keyParts = append(keyParts, value)
+	}
+	entry.key = strings.Join(keyParts, "\x00")
+
+	return entry, true
+}
+
+// level maps a raw level: the source's levels mapping first, then the
+// common level names. Lines without a level, or with an unknown one, are
+// info.
+func (s *Source) level(raw string) Level {
+	if raw == "" {
+		return LevelInfo
+	}
+	lower := strings.ToLower(raw)
+	if level, ok := s.levels[lower]; ok {
+		return level
+	}
+	if level, ok := defaultLevels[lower]; ok {
+		return level
+	}
+	return LevelInfo
+}
+
+// parseTimestamp parses the timestamp group in local time. Timestamps
+// without a year get the current year.
+func (s *Source) parseTimestamp(value string) time.Time {
+	layouts := timestampLayouts
+	if s.timestampLayout != "" {
+		layouts = []string{s.timestampLayout}
+	}
+	for _, layout := range layouts {
+		t, err := time.ParseInLocation(layout, value, time.Local)
+		if err != nil {
+			continue
+		}
+		if t.Year() == 0 {
+			t = t.AddDate(time.Now().Year(), 0, 0)
+		}
+		return t
+	}
+	return time.Time{}
+}
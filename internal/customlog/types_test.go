@@ -0,0 +1,119 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package customlog
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// testDefinition declares a log4j-like application log.
+func testDefinition() *Definition {
+	return &Definition{
+		Name:             "Order Service",
+		LineRegex:        `^(?P<timestamp>\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2},\d{3}) (?P<level>[A-Z]+) +\[(?P<thread>[^\]]+)\] (?P<component>[\w.]+) - (?P<message>.*)$`,
+		Levels:           map[string]string{"SEVERE": "critical", "NOTE": "warning"},
+		Role:             "senior Java engineer operating the order service",
+		Description:      "Takes orders from the web shop and charges the payment provider.",
+		PriorityKeywords: []string{"Payment", "deadlock"},
+	}
+}
+
+func mustCompile(t *testing.T, d *Definition) *Source {
+	t.Helper()
+	source, err := d.Compile("orders")
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	return source
+}
+
+func TestDefinition_Compile(t *testing.T) {
+	source := mustCompile(t, testDefinition())
+	if source.Type != "orders" || source.Name != "Order Service" {
+		t.Errorf("Compile() = type %q, name %q", source.Type, source.Name)
+	}
+	if strings.Join(source.PriorityKeywords, ",") != "payment,deadlock" {
+		t.Errorf("PriorityKeywords = %v, want lowercase keywords", source.PriorityKeywords)
+	}
+
+	unnamed := testDefinition()
+	unnamed.Name = ""
+	if source := mustCompile(t, unnamed); source.Name != "orders" {
+		t.Errorf("Name = %q, want the source type", source.Name)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(d *Definition)
+		want   string
+	}{
+		{"missing regex", func(d *Definition) { d.LineRegex = "" }, "line_regex is required"},
+		{"invalid regex", func(d *Definition) { d.LineRegex = "(?P<message>" }, "invalid line_regex"},
+		{"missing message group", func(d *Definition) { d.LineRegex = `^(?P<level>\w+) .*$` }, "(?P<message>...)"},
+		{"unknown level", func(d *Definition) { d.Levels = map[string]string{"WARN": "serious"} }, "unknown level"},
+		{"unknown group_by", func(d *Definition) { d.GroupBy = []string{"request_id"} }, `no named group "request_id"`},
+		{"empty keyword", func(d *Definition) { d.PriorityKeywords = []string{" "} }, "empty keyword"},
+	}
+	for _, tt := range tests {
+		d := testDefinition()
+		tt.modify(d)
+		if _, err := d.Compile("orders"); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: Compile() error = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestSource_ParseLine(t *testing.T) {
+	source := mustCompile(t, testDefinition())
+
+	entry, ok := source.ParseLine("2026-10-17 02:15:04,123 ERROR [http-nio-8080-exec-3] com.shop.PaymentClient - Payment of order 8812 failed: timeout after 30000 ms")
+	if !ok {
+		t.Fatal("ParseLine() did not match")
+	}
+	want := time.Date(2026, 10, 17, 2, 15, 4, 123000000, time.Local)
+	if !entry.Time.Equal(want) {
+		t.Errorf("Time = %v, want %v", entry.Time, want)
+	}
+	if entry.Level != LevelError || entry.RawLevel != "ERROR" || entry.Component != "com.shop.PaymentClient" {
+		t.Errorf("ParseLine() = level %v (%q), component %q", entry.Level, entry.RawLevel, entry.Component)
+	}
+	if len(entry.Keywords) != 1 || entry.Keywords[0] != "payment" {
+		t.Errorf("Keywords = %v, want [payment]", entry.Keywords)
+	}
+
+	// Repeats differing in numbers share a group; levels never do
+	other, _ := source.ParseLine("2026-10-17 02:16:00,001 ERROR [http-nio-8080-exec-9] com.shop.PaymentClient - Payment of order 8813 failed: timeout after 30000 ms")
+	warning, _ := source.ParseLine("2026-10-17 02:16:00,001 WARN [main] com.shop.PaymentClient - Payment of order 8813 failed: timeout after 30000 ms")
+	if entry.key != other.key || entry.key == warning.key {
+		t.Error("grouping keys should match for repeats of one level only")
+	}
+
+	levels := map[string]Level{"SEVERE": LevelCritical, "NOTE": LevelWarning, "FATAL": LevelCritical, "TRACE": LevelDebug, "AUDIT": LevelInfo}
+	for raw, want := range levels {
+		entry, ok := source.ParseLine("2026-10-17 02:15:04,123 " + raw + " [main] com.shop.App - started")
+		if !ok || entry.Level != want {
+			t.Errorf("level %s = %v, want %v", raw, entry.Level, want)
+		}
+	}
+
+	if _, ok := source.ParseLine("\tat com.shop.PaymentClient.charge(PaymentClient.java:88)"); ok {
+		t.Error("ParseLine() matched a stack trace line")
+	}
+}
+
+func TestSource_ParseTimestamp(t *testing.T) {
+	d := &Definition{LineRegex: `^(?P<timestamp>\S+) (?P<message>.*)$`, TimestampLayout: "02.01.2006-15:04:05"}
+	source := mustCompile(t, d)
+	entry, _ := source.ParseLine("17.10.2026-02:15:04 started")
+	if want := time.Date(2026, 10, 17, 2, 15, 4, 0, time.Local); !entry.Time.Equal(want) {
+		t.Errorf("Time = %v, want %v", entry.Time, want)
+	}
+
+	entry, _ = source.ParseLine("yesterday started")
+	if !entry.Time.IsZero() {
+		t.Errorf("Time = %v, want zero for an unparsable timestamp", entry.Time)
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package docker
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+)
+
+// timeFormatDateTime is the standard date-time format of the report.
+const timeFormatDateTime = "2006-01-02 15:04:05"
+
+// Limits of the formatted report. Repeats are collapsed before these apply.
+const (
+	maxGroupsPerStream = 30
+	maxMessageLen      = 250
+)
+
+// Patterns used per output line, compiled once.
+var (
+	ansiRegex      = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+	errorLikeRegex = regexp.MustCompile(`(?i)\b(?:error|fatal|panic|exception|critical|failed|failure|traceback|refused|timed out|segfault)\b`)
+)
+
+// digest aggregates the output lines of the selected containers.
+type digest struct {
+	selector   Selector
+	since      time.Time
+	until      time.Time
+	containers []*containerStats // selected containers, sorted by name
+	missing    []string          // container references that matched no container
+	lines      int
+	stderr     int
+	errorLike  int
+	invalid    int // records that are not valid json-file lines
+	truncated  int // log files read only up to the size limit
+}
+
+// containerStats collects the output of one container.
+type containerStats struct {
+	container *Container
+	lines     int
+	errorLike int
+	streams   map[string]*streamStats
+}
+
+// streamStats collects the output of one stream of a container.
+type streamStats struct {
+	lines  int
+	groups []*lineGroup
+	index  map[string]*lineGroup
+}
+
+// lineGroup collapses repeated output lines of one stream.
+type lineGroup struct {
+	example   string
+	errorLike bool
+	count     int
+	first     time.Time
+	last      time.Time
+}
+
+func newContainerStats(c *Container) *containerStats {
+	return &containerStats{
+		container: c,
+		streams:   make(map[string]*streamStats),
+	}
+}
+
+// add records one output line of the container.
+func (d *digest) add(cs *containerStats, line Line) {
+	errorLike := errorLikeRegex.MatchString(line.Message)
+
+	d.lines++
+	cs.lines++
+	if line.Stream == StreamStderr {
+		d.stderr++
+	}
+	if errorLike {
+		d.errorLike++
+		cs.errorLike++
+	}
+
+	ss, ok := cs.streams[line.Stream]
+	if !ok {
+		ss = &streamStats{index: make(map[string]*lineGroup)}
+		cs.streams[line.Stream] = ss
+	}
+	ss.lines++
+
+	key := analyzer.NormalizeMessage(line.Message)
+	g, ok := ss.index[key]
+	if !ok {
+		g = &lineGroup{
+			example:   line.Message,
+			errorLike: errorLike,
+			first:     line.Time,
+			last:      line.Time,
+		}
+		ss.index[key] = g
+		ss.groups = append(ss.groups, g)
+	}
+	g.count++
+	if line.Time.Before(g.first) {
+		g.first = line.Time
+	}
+	if line.Time.After(g.last) {
+		g.last = line.Time
+	}
+}
+
+// format renders the report: summary, container overview, and one section
+// per container and stream, stderr first.
+func (d *digest) format() string {
+	var sb strings.Builder
+	sb.WriteString("=== DOCKER CONTAINER LOGS ===\n\n")
+
+	active := 0
+	for _, cs := range d.containers {
+		if cs.lines > 0 {
+			active++
+		}
+	}
+
+	sb.WriteString("## Summary Statistics\n")
+	fmt.Fprintf(&sb, "Selection: %s\n", d.selector)
+	fmt.Fprintf(&sb, "Time window: %s to %s\n", d.since.Format(timeFormatDateTime), d.until.Format(timeFormatDateTime))
+	fmt.Fprintf(&sb, "Containers: %d selected, %d with output\n", len(d.containers), active)
+	if len(d.missing) > 0 {
+		fmt.Fprintf(&sb, "Missing containers: %s\n", strings.Join(d.missing, ", "))
+	}
+	fmt.Fprintf(&sb, "Log lines: %d (stdout: %d, stderr: %d)\n", d.lines, d.lines-d.stderr, d.stderr)
+	fmt.Fprintf(&sb, "Error-like lines: %d\n", d.errorLike)
+	if d.invalid > 0 {
+		fmt.Fprintf(&sb, "Invalid log records: %d\n", d.invalid)
+	}
+	if d.truncated > 0 {
+		fmt.Fprintf(&sb, "Log files read partially (size limit): %d\n", d.truncated)
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("## Containers\n")
+	for _, cs := range d.containers {
+		c := cs.container
+		fmt.Fprintf(&sb, "- %s (%s, %s, id %s): ", c.Name, c.Image, c.Status(), c.ShortID())
+		if cs.lines == 0 {
+			sb.WriteString("no output in time window\n")
+			continue
+		}
+		fmt.Fprintf(&sb, "%d lines (stdout: %d, stderr: %d, error-like: %d)\n",
+			cs.lines, cs.streamLines(StreamStdout), cs.streamLines(StreamStderr), cs.errorLike)
+	}
+
+	for _, cs := range d.containers {
+		for _, stream := range []string{StreamStderr, StreamStdout} {
+			ss, ok := cs.streams[stream]
+			if !ok {
+				continue
+			}
+			fmt.Fprintf(&sb, "\n## %s / %s\n", cs.container.Name, stream)
+			writeGroups(&sb, sortGroups(ss.groups))
+		}
+	}
+
+	return sb.String()
+}
+
+func (cs *containerStats) streamLines(stream string) int {
+	if ss, ok := cs.streams[stream]; ok {
+		return ss.lines
+	}
+	return 0
+}
+
+// sortGroups orders groups error-like first, then most frequent first.
+func sortGroups(groups []*lineGroup) []*lineGroup {
+	sorted := append([]*lineGroup(nil), groups...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].errorLike != sorted[j].errorLike {
+			return sorted[i].errorLike
+		}
+		return sorted[i].count > sorted[j].count
+	})
+	return sorted
+}
+
+// writeGroups writes one line per group, up to maxGroupsPerStream.
+func writeGroups(sb *strings.Builder, groups []*lineGroup) {
+	for i, g := range groups {
+		if i >= maxGroupsPerStream {
+			fmt.Fprintf(sb, "... and %d more unique lines\n", len(groups)-maxGroupsPerStream)
+			break
+		}
+		message := analyzer.TruncateMessage(g.example, maxMessageLen)
+		if g.count == 1 {
+			fmt.Fprintf(sb, "- [%s] %s\n", g.last.Format(timeFormatDateTime), message)
+			continue
+		}
+		fmt.Fprintf(sb, "- [%dx, %s to %s] %s\n",
+			g.count, g.first.Format(timeFormatDateTime), g.last.Format(timeFormatDateTime), message)
+	}
+}
+
+// cleanMessage removes terminal color codes and surrounding whitespace.
+func cleanMessage(msg string) string {
+	return strings.TrimSpace(ansiRegex.ReplaceAllString(msg, ""))
+}
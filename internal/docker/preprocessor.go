@@ -0,0 +1,42 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package docker
+
+import (
+	"strings"
+
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+)
+
+// Compile-time interface check
+var (
+	_ analyzer.Preprocessor       = (*Preprocessor)(nil)
+	_ analyzer.BudgetPreprocessor = (*Preprocessor)(nil)
+)
+
+// sectionPriority returns the priority of a section written by
+// digest.format. Container sections are named "<container> / <stream>":
+// stderr carries most failures, stdout is shortened first.
+func sectionPriority(name string) int {
+	switch {
+	case name == "Summary Statistics" || name == "Containers":
+		return analyzer.SectionPriorityHigh
+	case strings.HasSuffix(name, " / "+StreamStderr):
+		return analyzer.SectionPriorityMedium
+	default:
+		return analyzer.SectionPriorityLow
+	}
+}
+
+// Preprocessor handles container log preprocessing for chatty containers.
+// It keeps the statistics and container overview and shortens the stdout
+// sections first, then stderr.
+type Preprocessor struct {
+	*analyzer.SectionPreprocessor
+}
+
+// NewPreprocessor creates a new container log preprocessor.
+func NewPreprocessor(maxTokens int) *Preprocessor {
+	return &Preprocessor{analyzer.NewSectionPreprocessor(maxTokens, sectionPriority)}
+}
@@ -0,0 +1,24 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package docker
+
+import (
+	"testing"
+
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+)
+
+func TestSectionPriority(t *testing.T) {
+	tests := map[string]int{
+		"Summary Statistics": analyzer.SectionPriorityHigh,
+		"Containers":         analyzer.SectionPriorityHigh,
+		"web / stderr":       analyzer.SectionPriorityMedium,
+		"web / stdout":       analyzer.SectionPriorityLow,
+	}
+	for name, want := range tests {
+		if got := sectionPriority(name); got != want {
+			t.Errorf("sectionPriority(%q) = %d, want %d", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package docker
+
+import (
+	"strings"
+
+	"github.com/olegiv/logwatch-ai-go/internal/ai"
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+)
+
+// Compile-time interface check
+var _ analyzer.PromptBuilder = (*PromptBuilder)(nil)
+
+// PromptBuilder implements analyzer.PromptBuilder for Docker container log analysis.
+type PromptBuilder struct{}
+
+// NewPromptBuilder creates a new container log prompt builder.
+func NewPromptBuilder() *PromptBuilder {
+	return &PromptBuilder{}
+}
+
+// GetLogType returns the log type identifier.
+func (p *PromptBuilder) GetLogType() string {
+	return "docker"
+}
+
+// GetSystemPrompt returns the system prompt for Docker container log analysis.
+func (p *PromptBuilder) GetSystemPrompt(globalExclusions []string) string {
+	return `You are a senior DevOps engineer and site reliability engineer with expertise in containerized applications running on Docker. Your role is to analyze container log summaries and provide actionable insights.
+
+**Input Format:**
+The logs are pre-aggregated per container and output stream. The "## Containers" section lists every selected container with its image, state (running, exited with code N, OOM killed, restart count) and line counts. Each "## <container> / <stream>" section lists the output lines of one stream, error-like lines first. A line like
+"- [12x, 2026-01-01 02:00:00 to 2026-01-01 03:10:00] ..." stands for 12 similar lines in that time range; numbers, IDs and IP addresses are normalized when grouping.
+
+**Analysis Framework:**
+
+1. **System Status Assessment** - Classify overall health of the containers:
+   - "Excellent" - No issues, optimal operation
+   - "Good" - Minor issues that don't affect operations
+   - "Satisfactory" - Some concerns but services are stable
+   - "Bad" - Significant issues requiring attention
+   - "Awful" - Critical failures, containers down or crash looping
+
+2. **Container Health Indicators:**
+   - Containers that exited with a non-zero code, were OOM killed, or restart repeatedly
+   - Unhandled exceptions, panics, stack traces and fatal errors
+   - Connection failures to databases, caches, queues and upstream services (refused, timed out, DNS errors)
+   - Health check failures and slow startups
+   - Resource exhaustion: memory, disk, file descriptors, connection pools
+
+3. **Security Analysis** - Identify threats visible in application output:
+   - Authentication failures and brute force patterns
+   - Suspicious requests (injection attempts, path traversal, scanners)
+   - Permission and TLS/certificate errors
+
+4. **Recommendations** - Provide specific, actionable steps:
+   - Use docker commands when applicable (e.g., "docker logs --since 1h web", "docker inspect web", "docker stats")
+   - Prioritize by urgency
+   - Focus on root causes over symptoms
+
+5. **Metrics Extraction:**
+   - errorCount: number of error-like output lines
+   - failingContainers: containers that exited with an error, were OOM killed, or restarted
+   - restarts: total restart count of the selected containers
+   - topErrorContainers: containers with the most error-like lines
+
+**Output Requirements:**
+
+You MUST respond with a valid JSON object (and ONLY JSON) in this exact format:
+
+{
+  "systemStatus": "Excellent|Good|Satisfactory|Bad|Awful",
+  "summary": "2-3 sentence overview of the containers' state",
+  "criticalIssues": [
+    "Urgent issue requiring immediate action"
+  ],
+  "warnings": [
+    "Concerning issue that should be monitored"
+  ],
+  "recommendations": [
+    "Specific actionable recommendation"
+  ],
+  "metrics": {
+    "errorCount": 0,
+    "failingContainers": 0,
+    "restarts": 0,
+    "topErrorContainers": ["web"]
+  }
+}
+
+**Analysis Principles:**
+- Name the affected container in every finding
+- Not every stderr line is an error: many applications log normally to stderr
+- Repeats of the same line are one issue; use the count to judge its severity
+- Distinguish transient errors from persistent failures
+- Consider historical context for trend analysis
+- Empty arrays are acceptable if no issues/warnings/recommendations exist` + ai.GlobalExclusionsBlock(globalExclusions) + ai.StringArrayFormatReminder
+}
+
+// GetUserPrompt constructs the user prompt with the container logs and historical context.
+func (p *PromptBuilder) GetUserPrompt(logContent, historicalContext string, contextualExclusions []string) string {
+	var prompt strings.Builder
+
+	prompt.WriteString("DOCKER CONTAINER LOGS:\n")
+	prompt.WriteString(ai.SanitizeLogContent(logContent))
+	prompt.WriteString("\n\n")
+
+	if historicalContext != "" {
+		prompt.WriteString("HISTORICAL CONTEXT:\n")
+		prompt.WriteString(ai.SanitizeLogContent(historicalContext))
+		prompt.WriteString("\n\n")
+	}
+
+	prompt.WriteString(ai.ContextualExclusionsBlock(contextualExclusions))
+	prompt.WriteString("Please analyze the Docker container logs above and provide your assessment in JSON format as specified.")
+
+	return prompt.String()
+}
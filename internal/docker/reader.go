@@ -0,0 +1,336 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package docker
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+)
+
+// NoEntriesContent is returned when the selected containers logged nothing
+// in the time window. Use IsNoEntriesContent() to check for this condition.
+const NoEntriesContent = "=== NO CONTAINER LOG ENTRIES ===\n\nThe selected Docker containers wrote no log lines in the analyzed time window.\nThis typically means the containers were idle or use another logging driver."
+
+// DefaultWindow is the time window analyzed when none is configured.
+const DefaultWindow = 24 * time.Hour
+
+// maxRecordBytes is the maximum size of a single json-file record. The
+// daemon splits output lines at 16KB, so records are small.
+const maxRecordBytes = 1024 * 1024
+
+// maxStatsItems limits the breakdowns of ReadStats.
+const maxStatsItems = 10
+
+// IsNoEntriesContent checks if the content indicates no container log lines were found.
+func IsNoEntriesContent(content string) bool {
+	return strings.HasPrefix(content, "=== NO CONTAINER LOG ENTRIES ===")
+}
+
+// Compile-time interface checks
+var (
+	_ analyzer.LogReader     = (*Reader)(nil)
+	_ analyzer.StatsReporter = (*Reader)(nil)
+)
+
+// Reader handles reading Docker json-file container logs.
+// Implements analyzer.LogReader interface; the source path is the Docker
+// containers directory, usually /var/lib/docker/containers.
+type Reader struct {
+	maxSizeMB           int
+	enablePreprocessing bool
+	maxTokens           int
+	selector            Selector
+	window              time.Duration
+	preprocessor        *Preprocessor
+	digest              *digest
+	files               []string // log files read by the last Read, for GetSourceInfo
+}
+
+// NewReader creates a new container log reader. Only lines written within
+// window before the read are analyzed; a non-positive window uses
+// DefaultWindow. maxSizeMB limits how much of each log file is read,
+// counted from its end.
+func NewReader(maxSizeMB int, enablePreprocessing bool, maxTokens int, selector Selector, window time.Duration) *Reader {
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	return &Reader{
+		maxSizeMB:           maxSizeMB,
+		enablePreprocessing: enablePreprocessing,
+		maxTokens:           maxTokens,
+		selector:            selector,
+		window:              window,
+		preprocessor:        NewPreprocessor(maxTokens),
+	}
+}
+
+// Read implements analyzer.LogReader.Read.
+// Reads the logs of the selected containers below the containers directory
+// and formats them grouped by container and stream.
+func (r *Reader) Read(sourcePath string) (string, error) {
+	r.digest = nil
+	r.files = nil
+
+	containers, skipped, err := loadContainers(sourcePath)
+	if err != nil {
+		return "", err
+	}
+
+	until := time.Now()
+	d := &digest{
+		selector: r.selector,
+		since:    until.Add(-r.window),
+		until:    until,
+	}
+
+	matchedRefs := make(map[string]bool)
+	for _, c := range containers {
+		ok, ref := r.selector.matches(c)
+		if !ok {
+			continue
+		}
+		matchedRefs[ref] = true
+		d.containers = append(d.containers, newContainerStats(c))
+	}
+	for _, ref := range r.selector.Containers {
+		if !matchedRefs[ref] {
+			d.missing = append(d.missing, ref)
+		}
+	}
+
+	if len(d.containers) == 0 {
+		return "", fmt.Errorf("no docker containers match %s (%d containers found, %d without readable %s)",
+			r.selector, len(containers), skipped, containerConfigFile)
+	}
+
+	for _, cs := range d.containers {
+		if err := r.readContainer(d, cs); err != nil {
+			return "", err
+		}
+	}
+	r.digest = d
+
+	if d.lines == 0 {
+		return NoEntriesContent, nil
+	}
+
+	formattedContent := d.format()
+
+	if err := r.Validate(formattedContent); err != nil {
+		return "", fmt.Errorf("container log content validation failed: %w", err)
+	}
+
+	if r.enablePreprocessing && r.preprocessor.ShouldProcess(formattedContent, r.maxTokens) {
+		processedContent, err := r.preprocessor.Process(formattedContent)
+		if err != nil {
+			return "", fmt.Errorf("preprocessing failed: %w", err)
+		}
+		return processedContent, nil
+	}
+
+	return formattedContent, nil
+}
+
+// readContainer reads the current and rotated json-file logs of one
+// container, oldest first, skipping files last written before the window.
+func (r *Reader) readContainer(d *digest, cs *containerStats) error {
+	for _, path := range logFiles(cs.container.LogPath) {
+		info, err := os.Stat(path)
+		if err != nil || info.ModTime().Before(d.since) {
+			continue
+		}
+		if err := r.readLogFile(d, cs, path, info.Size()); err != nil {
+			return fmt.Errorf("failed to read log of container %s: %w", cs.container.Name, err)
+		}
+		r.files = append(r.files, path)
+	}
+	return nil
+}
+
+// logFiles returns the log file and its rotated siblings (log.1, log.2,
+// ...), oldest first. Compressed rotations are not read.
+func logFiles(logPath string) []string {
+	rotated, _ := filepath.Glob(logPath + ".*")
+
+	type rotation struct {
+		path  string
+		index int
+	}
+	var rotations []rotation
+	for _, path := range rotated {
+		index, err := strconv.Atoi(strings.TrimPrefix(path, logPath+"."))
+		if err != nil {
+			continue // compressed (.gz) or foreign file
+		}
+		rotations = append(rotations, rotation{path: path, index: index})
+	}
+	slices.SortFunc(rotations, func(a, b rotation) int { return b.index - a.index })
+
+	files := make([]string, 0, len(rotations)+1)
+	for _, rot := range rotations {
+		files = append(files, rot.path)
+	}
+	return append(files, logPath)
+}
+
+// readLogFile streams one json-file log into the digest. Files larger
+// than maxSizeMB are read from their last maxSizeMB: the time window
+// selects the newest lines anyway.
+func (r *Reader) readLogFile(d *digest, cs *containerStats, path string, size int64) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	maxBytes := int64(r.maxSizeMB) * 1024 * 1024
+	skipFirst := false
+	if maxBytes > 0 && size > maxBytes {
+		if _, err := f.Seek(size-maxBytes, io.SeekStart); err != nil {
+			return err
+		}
+		d.truncated++
+		skipFirst = true // the first line is most likely cut
+	}
+
+	// Lines longer than 16KB arrive as several records; only the last
+	// record of a line ends with a newline.
+	pending := make(map[string]string)
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), maxRecordBytes)
+	for scanner.Scan() {
+		if skipFirst {
+			skipFirst = false
+			continue
+		}
+
+		var rec record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			d.invalid++
+			continue
+		}
+		if !strings.HasSuffix(rec.Log, "\n") {
+			pending[rec.Stream] += rec.Log
+			continue
+		}
+		message := pending[rec.Stream] + rec.Log
+		delete(pending, rec.Stream)
+
+		if rec.Time.Before(d.since) || rec.Time.After(d.until) {
+			continue
+		}
+		message = cleanMessage(message)
+		if message == "" {
+			continue
+		}
+		d.add(cs, Line{Time: rec.Time.Local(), Stream: rec.Stream, Message: message})
+	}
+	return scanner.Err()
+}
+
+// ReadStats implements analyzer.StatsReporter.
+// Summarizes the output of the last Read by container and repeated line.
+func (r *Reader) ReadStats() *analyzer.ReadStats {
+	d := r.digest
+	if d == nil {
+		return nil
+	}
+
+	stats := &analyzer.ReadStats{
+		Totals: []analyzer.StatsItem{
+			{Name: "Log lines", Count: d.lines},
+			{Name: "Stderr lines", Count: d.stderr},
+			{Name: "Error-like lines", Count: d.errorLike},
+			{Name: "Containers", Count: len(d.containers)},
+		},
+	}
+	if len(d.missing) > 0 {
+		stats.Totals = append(stats.Totals, analyzer.StatsItem{Name: "Missing containers", Count: len(d.missing)})
+	}
+
+	lineCounts := make(map[string]int)
+	errorCounts := make(map[string]int)
+	repeated := make(map[string]int)
+	for _, cs := range d.containers {
+		name := cs.container.Name
+		if cs.lines > 0 {
+			lineCounts[name] = cs.lines
+		}
+		if cs.errorLike > 0 {
+			errorCounts[name] = cs.errorLike
+		}
+		for _, ss := range cs.streams {
+			for _, g := range ss.groups {
+				if g.count > 1 {
+					repeated[name+": "+analyzer.TruncateMessage(g.example, 80)] += g.count
+				}
+			}
+		}
+	}
+	stats.AddBreakdown("Lines by container", analyzer.TopCounts(lineCounts, maxStatsItems))
+	stats.AddBreakdown("Error-like lines by container", analyzer.TopCounts(errorCounts, maxStatsItems))
+	stats.AddBreakdown("Top repeated lines", analyzer.TopCounts(repeated, maxStatsItems))
+
+	return stats
+}
+
+// Validate implements analyzer.LogReader.Validate.
+// Performs basic validation on the formatted container log content.
+func (r *Reader) Validate(content string) error {
+	if len(content) == 0 {
+		return fmt.Errorf("container log content is empty")
+	}
+
+	// NoEntriesContent is a valid state - no output in the time window
+	if IsNoEntriesContent(content) {
+		return nil
+	}
+
+	if len(content) < 50 {
+		return fmt.Errorf("container log content seems too small to be valid (only %d bytes)", len(content))
+	}
+
+	return nil
+}
+
+// GetSourceInfo implements analyzer.LogReader.GetSourceInfo.
+// Returns the total size and newest modification time of the log files
+// read by the last Read, or the containers directory metadata before a Read.
+func (r *Reader) GetSourceInfo(sourcePath string) (map[string]any, error) {
+	if len(r.files) == 0 {
+		return analyzer.GetSourceFileInfo(sourcePath)
+	}
+
+	var size int64
+	var modified time.Time
+	for _, path := range r.files {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		size += info.Size()
+		if info.ModTime().After(modified) {
+			modified = info.ModTime()
+		}
+	}
+
+	return map[string]any{
+		"size_bytes": size,
+		"size_mb":    float64(size) / 1024 / 1024,
+		"modified":   modified,
+		"age_hours":  time.Since(modified).Hours(),
+		"files":      len(r.files),
+	}, nil
+}
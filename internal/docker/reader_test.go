@@ -0,0 +1,286 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package docker
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeContainer creates a container directory with a config.v2.json.
+func writeContainer(t *testing.T, dir, id, name string, labels map[string]string) string {
+	t.Helper()
+	containerDir := filepath.Join(dir, id)
+	if err := os.MkdirAll(containerDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	var raw rawContainerConfig
+	raw.ID = id
+	raw.Name = name
+	raw.Config.Image = "nginx:1.27"
+	raw.Config.Labels = labels
+	raw.State.Running = true
+	data, err := json.Marshal(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(containerDir, containerConfigFile), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return filepath.Join(containerDir, id+"-json.log")
+}
+
+// writeRecords writes a json-file log with the records.
+func writeRecords(t *testing.T, path string, records ...record) {
+	t.Helper()
+	var sb strings.Builder
+	for _, rec := range records {
+		data, err := json.Marshal(rec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sb.Write(data)
+		sb.WriteString("\n")
+	}
+	if err := os.WriteFile(path, []byte(sb.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// rec builds a record written age before now.
+func rec(stream string, age time.Duration, log string) record {
+	return record{Log: log, Stream: stream, Time: time.Now().Add(-age).UTC()}
+}
+
+func sampleContainers(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+
+	webLog := writeContainer(t, dir, testID, "/web", map[string]string{"com.docker.compose.project": "ocms"})
+	writeRecords(t, webLog,
+		rec(StreamStdout, 48*time.Hour, "GET /old HTTP/1.1 200\n"),
+		rec(StreamStdout, 3*time.Hour, "GET /page/1 HTTP/1.1 200\n"),
+		rec(StreamStdout, 2*time.Hour, "GET /page/2 HTTP/1.1 200\n"),
+		rec(StreamStderr, 90*time.Minute, "\x1b[31mupstream connect() failed (111: Connection refused)\x1b[0m\n"),
+		rec(StreamStderr, time.Hour, "very long line "),
+		rec(StreamStderr, time.Hour, "split by the daemon\n"),
+	)
+	// Rotated file with an older line of the same container
+	writeRecords(t, webLog+".1", rec(StreamStdout, 5*time.Hour, "server started on port 80\n"))
+	if err := os.WriteFile(webLog+".2.gz", []byte("compressed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	dbLog := writeContainer(t, dir, strings.Repeat("d", 64), "/db", nil)
+	writeRecords(t, dbLog, rec(StreamStdout, 72*time.Hour, "ready for connections\n"))
+
+	return dir
+}
+
+func TestReader_Read(t *testing.T) {
+	r := NewReader(10, false, 150000, Selector{}, 24*time.Hour)
+
+	result, err := r.Read(sampleContainers(t))
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"=== DOCKER CONTAINER LOGS ===",
+		"Selection: all containers",
+		"Containers: 2 selected, 1 with output",
+		"Log lines: 5 (stdout: 3, stderr: 2)",
+		"Error-like lines: 1",
+		"- db (nginx:1.27, running, id dddddddddddd): no output in time window",
+		"- web (nginx:1.27, running, id 0123456789ab): 5 lines (stdout: 3, stderr: 2, error-like: 1)",
+		"## web / stderr",
+		"upstream connect() failed (111: Connection refused)",
+		"very long line split by the daemon",
+		"## web / stdout",
+		"[2x, ",
+		"server started on port 80",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Read() result missing %q\n%s", want, result)
+		}
+	}
+
+	if strings.Contains(result, "/old") || strings.Contains(result, "\x1b[") || strings.Contains(result, "## db /") {
+		t.Errorf("Read() result should skip old lines, color codes and silent streams\n%s", result)
+	}
+	// stderr comes before stdout, error-like lines first within a stream
+	if strings.Index(result, "## web / stderr") > strings.Index(result, "## web / stdout") ||
+		strings.Index(result, "Connection refused") > strings.Index(result, "very long line") {
+		t.Errorf("sections out of order\n%s", result)
+	}
+}
+
+func TestReader_Read_Selector(t *testing.T) {
+	dir := sampleContainers(t)
+
+	sel, _ := ParseSelector("db,missing", "")
+	r := NewReader(10, false, 150000, sel, 0)
+	result, err := r.Read(dir)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if !IsNoEntriesContent(result) {
+		t.Errorf("Read() = %q, want NoEntriesContent (db is idle)", result)
+	}
+	if err := r.Validate(result); err != nil {
+		t.Errorf("Validate(NoEntriesContent) error = %v", err)
+	}
+
+	// The db container becomes active with a wider window
+	r = NewReader(10, false, 150000, sel, 96*time.Hour)
+	result, err = r.Read(dir)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	for _, want := range []string{"Missing containers: missing", "## db / stdout", "ready for connections"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Read() result missing %q\n%s", want, result)
+		}
+	}
+	if strings.Contains(result, "## web") {
+		t.Errorf("Read() result should only contain db\n%s", result)
+	}
+
+	sel, _ = ParseSelector("", "com.docker.compose.project=ocms")
+	result, err = NewReader(10, false, 150000, sel, 0).Read(dir)
+	if err != nil || !strings.Contains(result, "## web / stderr") || strings.Contains(result, "- db ") {
+		t.Errorf("Read() by label = %v\n%s", err, result)
+	}
+}
+
+func TestReader_Read_Preprocessing(t *testing.T) {
+	dir := t.TempDir()
+	logPath := writeContainer(t, dir, testID, "/web", nil)
+
+	records := []record{rec(StreamStderr, time.Hour, "FATAL: database connection failed\n")}
+	for i := range 3000 {
+		records = append(records, rec(StreamStdout, time.Minute,
+			fmt.Sprintf("routine request variant %c%c with some padding text\n", 'a'+i%26, 'a'+(i/26)%26)))
+	}
+	writeRecords(t, logPath, records...)
+
+	r := NewReader(10, true, 2000, Selector{}, 0)
+	result, err := r.Read(dir)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if r.preprocessor.EstimateTokens(result) > 2000 {
+		t.Errorf("preprocessed logs have %d tokens, want <= 2000", r.preprocessor.EstimateTokens(result))
+	}
+	if !strings.Contains(result, "FATAL: database connection failed") {
+		t.Errorf("preprocessing dropped the stderr section\n%s", result)
+	}
+}
+
+func TestReader_Read_SizeLimit(t *testing.T) {
+	dir := t.TempDir()
+	logPath := writeContainer(t, dir, testID, "/web", nil)
+
+	line := strings.Repeat("x", 1000) + "\n"
+	var records []record
+	for range 2000 {
+		records = append(records, rec(StreamStdout, time.Minute, line))
+	}
+	writeRecords(t, logPath, append(records, rec(StreamStdout, 0, "last line\n"))...)
+
+	result, err := NewReader(1, false, 150000, Selector{}, 0).Read(dir)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	for _, want := range []string{"Log files read partially (size limit): 1", "last line"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Read() result missing %q", want)
+		}
+	}
+	if strings.Contains(result, "Invalid log records") {
+		t.Error("the partial first record should be skipped, not counted as invalid")
+	}
+}
+
+func TestReader_Read_Errors(t *testing.T) {
+	dir := sampleContainers(t)
+
+	sel, _ := ParseSelector("missing", "")
+	if _, err := NewReader(10, false, 150000, sel, 0).Read(dir); err == nil || !strings.Contains(err.Error(), "no docker containers match") {
+		t.Errorf("Read() error = %v, want no match error", err)
+	}
+
+	if _, err := NewReader(10, false, 150000, Selector{}, 0).Read(filepath.Join(dir, "missing")); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Read() error = %v, want not found error", err)
+	}
+}
+
+func TestReader_ReadStats(t *testing.T) {
+	r := NewReader(10, false, 150000, Selector{}, 0)
+	if r.ReadStats() != nil {
+		t.Error("ReadStats() before Read should be nil")
+	}
+
+	if _, err := r.Read(sampleContainers(t)); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	stats := r.ReadStats()
+	if stats == nil {
+		t.Fatal("ReadStats() = nil")
+	}
+
+	totals := make(map[string]int)
+	for _, item := range stats.Totals {
+		totals[item.Name] = item.Count
+	}
+	want := map[string]int{"Log lines": 5, "Stderr lines": 2, "Error-like lines": 1, "Containers": 2}
+	for name, count := range want {
+		if totals[name] != count {
+			t.Errorf("Totals[%q] = %d, want %d", name, totals[name], count)
+		}
+	}
+
+	var titles []string
+	for _, b := range stats.Breakdowns {
+		titles = append(titles, b.Title)
+	}
+	if got := strings.Join(titles, ", "); got != "Lines by container, Error-like lines by container, Top repeated lines" {
+		t.Errorf("Breakdowns = %s", got)
+	}
+}
+
+func TestReader_GetSourceInfo(t *testing.T) {
+	dir := sampleContainers(t)
+	r := NewReader(10, false, 150000, Selector{}, 0)
+
+	info, err := r.GetSourceInfo(dir)
+	if err != nil {
+		t.Fatalf("GetSourceInfo() before Read error = %v", err)
+	}
+	if _, ok := info["size_mb"].(float64); !ok {
+		t.Errorf("GetSourceInfo() size_mb = %v", info["size_mb"])
+	}
+
+	if _, err := r.Read(dir); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	info, err = r.GetSourceInfo(dir)
+	if err != nil {
+		t.Fatalf("GetSourceInfo() error = %v", err)
+	}
+	// web's current and rotated log and db's log; the .gz rotation is skipped
+	if info["files"] != 3 {
+		t.Errorf("GetSourceInfo() files = %v, want 3", info["files"])
+	}
+	if _, ok := info["age_hours"].(float64); !ok {
+		t.Errorf("GetSourceInfo() age_hours = %v", info["age_hours"])
+	}
+}
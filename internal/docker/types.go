@@ -0,0 +1,274 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package docker provides log analysis for Docker containers that use the
+// json-file logging driver. It reads /var/lib/docker/containers/*/*-json.log
+// for a selected set of containers, maps container IDs to names through the
+// config.v2.json stored next to each log, and implements the analyzer
+// interfaces to enable container analysis alongside other log sources.
+package docker
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Output streams of a container.
+const (
+	StreamStdout = "stdout"
+	StreamStderr = "stderr"
+)
+
+// minIDPrefixLen is the shortest container ID prefix accepted as a
+// container reference; docker ps prints 12 characters.
+const minIDPrefixLen = 12
+
+// containerConfigFile is the file the Docker daemon keeps container
+// metadata in, next to the json-file log.
+const containerConfigFile = "config.v2.json"
+
+// Selector chooses the containers to analyze. A container is selected when
+// its name or ID is listed in Containers, or when it carries every label in
+// Labels (like repeated `docker ps --filter label=...`). An empty selector
+// selects all containers.
+type Selector struct {
+	Containers []string      // container names or IDs (full or 12+ character prefix)
+	Labels     []LabelFilter // all must match
+}
+
+// LabelFilter matches a container label by key, and by value when HasValue is set.
+type LabelFilter struct {
+	Key      string
+	Value    string
+	HasValue bool
+}
+
+// String returns the filter in `key` or `key=value` form.
+func (f LabelFilter) String() string {
+	if f.HasValue {
+		return f.Key + "=" + f.Value
+	}
+	return f.Key
+}
+
+// ParseSelector parses comma-separated container references and label
+// filters, e.g. "web,worker" and "com.docker.compose.project=ocms".
+func ParseSelector(containers, labels string) (Selector, error) {
+	var sel Selector
+
+	for part := range strings.SplitSeq(containers, ",") {
+		part = strings.TrimPrefix(strings.TrimSpace(part), "/")
+		if part != "" && !slices.Contains(sel.Containers, part) {
+			sel.Containers = append(sel.Containers, part)
+		}
+	}
+
+	for part := range strings.SplitSeq(labels, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, value, hasValue := strings.Cut(part, "=")
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return Selector{}, fmt.Errorf("invalid label filter %q: label key is required", part)
+		}
+		sel.Labels = append(sel.Labels, LabelFilter{Key: key, Value: strings.TrimSpace(value), HasValue: hasValue})
+	}
+
+	return sel, nil
+}
+
+// IsEmpty returns true if the selector selects all containers.
+func (s Selector) IsEmpty() bool {
+	return len(s.Containers) == 0 && len(s.Labels) == 0
+}
+
+// String describes the selector for reports.
+func (s Selector) String() string {
+	if s.IsEmpty() {
+		return "all containers"
+	}
+	var parts []string
+	if len(s.Containers) > 0 {
+		parts = append(parts, "containers "+strings.Join(s.Containers, ", "))
+	}
+	if len(s.Labels) > 0 {
+		labels := make([]string, len(s.Labels))
+		for i, f := range s.Labels {
+			labels[i] = f.String()
+		}
+		parts = append(parts, "labels "+strings.Join(labels, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// matches reports whether the selector selects the container, and which
+// container reference matched it ("" for label or empty selectors).
+func (s Selector) matches(c *Container) (bool, string) {
+	if s.IsEmpty() {
+		return true, ""
+	}
+	for _, ref := range s.Containers {
+		if ref == c.Name || ref == c.ID || (len(ref) >= minIDPrefixLen && strings.HasPrefix(c.ID, ref)) {
+			return true, ref
+		}
+	}
+	if len(s.Labels) == 0 {
+		return false, ""
+	}
+	for _, f := range s.Labels {
+		value, ok := c.Labels[f.Key]
+		if !ok || (f.HasValue && value != f.Value) {
+			return false, ""
+		}
+	}
+	return true, ""
+}
+
+// Container is the metadata of one container, read from config.v2.json.
+type Container struct {
+	ID           string
+	Name         string
+	Image        string
+	Labels       map[string]string
+	Running      bool
+	Restarting   bool
+	ExitCode     int
+	OOMKilled    bool
+	RestartCount int
+	LogPath      string // json-file log in the container directory
+}
+
+// Status describes the container state for reports, e.g. "running" or
+// "exited with code 137, OOM killed".
+func (c *Container) Status() string {
+	var status string
+	switch {
+	case c.Restarting:
+		status = "restarting"
+	case c.Running:
+		status = "running"
+	default:
+		status = fmt.Sprintf("exited with code %d", c.ExitCode)
+	}
+	if c.OOMKilled {
+		status += ", OOM killed"
+	}
+	if c.RestartCount > 0 {
+		status += fmt.Sprintf(", %d restarts", c.RestartCount)
+	}
+	return status
+}
+
+// ShortID returns the 12-character ID that docker ps prints.
+func (c *Container) ShortID() string {
+	if len(c.ID) > minIDPrefixLen {
+		return c.ID[:minIDPrefixLen]
+	}
+	return c.ID
+}
+
+// rawContainerConfig mirrors the config.v2.json fields used by the reader.
+type rawContainerConfig struct {
+	ID           string `json:"ID"`
+	Name         string `json:"Name"`
+	RestartCount int    `json:"RestartCount"`
+	Config       struct {
+		Image  string            `json:"Image"`
+		Labels map[string]string `json:"Labels"`
+	} `json:"Config"`
+	State struct {
+		Running    bool `json:"Running"`
+		Restarting bool `json:"Restarting"`
+		OOMKilled  bool `json:"OOMKilled"`
+		ExitCode   int  `json:"ExitCode"`
+	} `json:"State"`
+}
+
+// loadContainers reads the metadata of every container below dir, sorted
+// by name. Directories without a readable config.v2.json are skipped and
+// counted.
+func loadContainers(dir string) ([]*Container, int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, 0, fmt.Errorf("docker containers directory not found: %s: %w", dir, err)
+		}
+		return nil, 0, fmt.Errorf("failed to read docker containers directory: %w", err)
+	}
+
+	var containers []*Container
+	skipped := 0
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		c, err := loadContainer(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			skipped++
+			continue
+		}
+		containers = append(containers, c)
+	}
+
+	slices.SortFunc(containers, func(a, b *Container) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return containers, skipped, nil
+}
+
+// loadContainer reads config.v2.json of one container directory.
+func loadContainer(containerDir string) (*Container, error) {
+	data, err := os.ReadFile(filepath.Join(containerDir, containerConfigFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var raw rawContainerConfig
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", containerConfigFile, err)
+	}
+
+	id := raw.ID
+	if id == "" {
+		id = filepath.Base(containerDir)
+	}
+	name := strings.TrimPrefix(raw.Name, "/")
+	if name == "" {
+		name = id
+	}
+
+	return &Container{
+		ID:           id,
+		Name:         name,
+		Image:        raw.Config.Image,
+		Labels:       raw.Config.Labels,
+		Running:      raw.State.Running,
+		Restarting:   raw.State.Restarting,
+		ExitCode:     raw.State.ExitCode,
+		OOMKilled:    raw.State.OOMKilled,
+		RestartCount: raw.RestartCount,
+		LogPath:      filepath.Join(containerDir, id+"-json.log"),
+	}, nil
+}
+
+// record is one line of a json-file log. Lines longer than 16KB are split
+// into several records; only the last one ends with a newline.
+type record struct {
+	Log    string    `json:"log"`
+	Stream string    `json:"stream"`
+	Time   time.Time `json:"time"`
+}
+
+// Line is one reassembled output line of a container.
+type Line struct {
+	Time    time.Time
+	Stream  string
+	Message string
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package docker
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testID = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestParseSelector(t *testing.T) {
+	sel, err := ParseSelector(" web, /worker ,web", "com.docker.compose.project=ocms, traefik.enable")
+	if err != nil {
+		t.Fatalf("ParseSelector() error = %v", err)
+	}
+	if got := strings.Join(sel.Containers, ","); got != "web,worker" {
+		t.Errorf("Containers = %q, want web,worker", got)
+	}
+	want := []LabelFilter{
+		{Key: "com.docker.compose.project", Value: "ocms", HasValue: true},
+		{Key: "traefik.enable"},
+	}
+	if len(sel.Labels) != len(want) || sel.Labels[0] != want[0] || sel.Labels[1] != want[1] {
+		t.Errorf("Labels = %+v, want %+v", sel.Labels, want)
+	}
+	if got := sel.String(); got != "containers web, worker; labels com.docker.compose.project=ocms, traefik.enable" {
+		t.Errorf("String() = %q", got)
+	}
+
+	empty, err := ParseSelector("", " , ")
+	if err != nil || !empty.IsEmpty() || empty.String() != "all containers" {
+		t.Errorf("ParseSelector(empty) = %+v, %v", empty, err)
+	}
+
+	if _, err := ParseSelector("", "=value"); err == nil {
+		t.Error("ParseSelector() should reject a label filter without key")
+	}
+}
+
+func TestSelector_Matches(t *testing.T) {
+	c := &Container{
+		ID:     testID,
+		Name:   "web",
+		Labels: map[string]string{"com.docker.compose.project": "ocms", "tier": "front"},
+	}
+
+	tests := []struct {
+		name       string
+		containers string
+		labels     string
+		want       bool
+	}{
+		{"empty selector", "", "", true},
+		{"by name", "db,web", "", true},
+		{"by full id", testID, "", true},
+		{"by id prefix", testID[:12], "", true},
+		{"short id prefix", testID[:6], "", false},
+		{"other name", "db", "", false},
+		{"all labels", "", "com.docker.compose.project=ocms,tier", true},
+		{"label value differs", "", "com.docker.compose.project=shop", false},
+		{"one label missing", "", "tier=front,backup", false},
+		{"name or labels", "db", "tier=front", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sel, err := ParseSelector(tt.containers, tt.labels)
+			if err != nil {
+				t.Fatalf("ParseSelector() error = %v", err)
+			}
+			if got, _ := sel.matches(c); got != tt.want {
+				t.Errorf("matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainer_Status(t *testing.T) {
+	tests := []struct {
+		c    Container
+		want string
+	}{
+		{Container{Running: true}, "running"},
+		{Container{Running: true, Restarting: true, RestartCount: 5}, "restarting, 5 restarts"},
+		{Container{ExitCode: 137, OOMKilled: true, RestartCount: 1}, "exited with code 137, OOM killed, 1 restarts"},
+	}
+	for _, tt := range tests {
+		if got := tt.c.Status(); got != tt.want {
+			t.Errorf("Status() = %q, want %q", got, tt.want)
+		}
+	}
+
+	if got := (&Container{ID: testID}).ShortID(); got != "0123456789ab" {
+		t.Errorf("ShortID() = %q", got)
+	}
+}
+
+func TestLoadContainers(t *testing.T) {
+	dir := t.TempDir()
+	writeContainer(t, dir, testID, "/web", map[string]string{"tier": "front"})
+	writeContainer(t, dir, strings.Repeat("f", 64), "/api", nil)
+	if err := os.Mkdir(filepath.Join(dir, "not-a-container"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	containers, skipped, err := loadContainers(dir)
+	if err != nil {
+		t.Fatalf("loadContainers() error = %v", err)
+	}
+	if skipped != 1 || len(containers) != 2 {
+		t.Fatalf("loadContainers() = %d containers, %d skipped", len(containers), skipped)
+	}
+	if containers[0].Name != "api" || containers[1].Name != "web" {
+		t.Errorf("containers not sorted by name: %s, %s", containers[0].Name, containers[1].Name)
+	}
+	web := containers[1]
+	if web.Image != "nginx:1.27" || web.Labels["tier"] != "front" || !web.Running {
+		t.Errorf("web = %+v", web)
+	}
+	if want := filepath.Join(dir, testID, testID+"-json.log"); web.LogPath != want {
+		t.Errorf("LogPath = %q, want %q", web.LogPath, want)
+	}
+
+	if _, _, err := loadContainers(filepath.Join(dir, "missing")); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("loadContainers(missing) error = %v", err)
+	}
+}
@@ -0,0 +1,459 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package drupal
+
+import (
+	"container/heap"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+)
+
+// Limits of the digest. Entries are aggregated as they are parsed, so the
+// memory used depends on these limits, not on the size of the export.
+const (
+	maxCriticalEntries = 50
+	maxInfoEntries     = 20
+	maxNotFoundGroups  = 10
+	// maxPatternGroups caps the message patterns listed per section;
+	// entries of further patterns are only counted
+	maxPatternGroups = 1000
+	// maxStatsPatterns caps the message patterns counted for ReadStats
+	maxStatsPatterns = 10000
+	// maxTypes caps the entry types counted by name
+	maxTypes = 1000
+)
+
+// otherTypes collects the entries of types beyond maxTypes.
+const otherTypes = "(other types)"
+
+// Patterns used by normalizeMessage, compiled once: it runs for every entry.
+var (
+	uuidRegex   = regexp.MustCompile(`[a-f0-9]{8}-[a-f0-9]{4}-[a-f0-9]{4}-[a-f0-9]{4}-[a-f0-9]{12}`)
+	ipRegex     = regexp.MustCompile(`\b\d{1,3}\.\d{1,3}\.\d{1,3}\.\d{1,3}\b`)
+	numberRegex = regexp.MustCompile(`\b\d+\b`)
+	pathRegex   = regexp.MustCompile(`/[a-zA-Z0-9/_-]+`)
+)
+
+// digest aggregates watchdog entries into the sections of the analysis
+// content. It keeps counts, pattern groups, and the newest entries of
+// each section instead of the entries themselves.
+type digest struct {
+	total          int
+	oldest         int64
+	newest         int64
+	severityCounts map[string]int
+	typeCounts     map[string]int
+
+	critical *newestEntries
+	info     *newestEntries
+	warnings *patternGroups
+	access   *patternGroups
+	notFound *patternGroups
+	// patterns groups all entries for ReadStats
+	patterns *patternGroups
+
+	// notAnalyzed counts the older entries a database query left out
+	notAnalyzed int
+
+	seq int64
+}
+
+func newDigest() *digest {
+	return &digest{
+		severityCounts: make(map[string]int),
+		typeCounts:     make(map[string]int),
+		critical:       &newestEntries{limit: maxCriticalEntries},
+		info:           &newestEntries{limit: maxInfoEntries},
+		warnings:       newPatternGroups(maxPatternGroups, true),
+		access:         newPatternGroups(maxPatternGroups, true),
+		notFound:       newPatternGroups(maxPatternGroups, true),
+		patterns:       newPatternGroups(maxStatsPatterns, false),
+	}
+}
+
+// add records one entry.
+func (d *digest) add(e *WatchdogEntry) {
+	d.seq++
+	if d.total == 0 || e.Timestamp < d.oldest {
+		d.oldest = e.Timestamp
+	}
+	if d.total == 0 || e.Timestamp > d.newest {
+		d.newest = e.Timestamp
+	}
+	d.total++
+
+	d.severityCounts[e.SeverityName()]++
+	if _, ok := d.typeCounts[e.Type]; ok || len(d.typeCounts) < maxTypes {
+		d.typeCounts[e.Type]++
+	} else {
+		d.typeCounts[otherTypes]++
+	}
+
+	pattern := normalizeMessage(e.Message)
+	d.patterns.add(pattern, e)
+
+	switch {
+	case e.Severity >= SeverityEmergency && e.Severity <= SeverityError:
+		d.critical.add(e, d.seq)
+	case e.Severity == SeverityWarning:
+		d.warnings.add(pattern, e)
+	case e.Severity >= SeverityNotice && e.Severity <= SeverityInfo:
+		d.info.add(e, d.seq)
+	}
+
+	entryType := strings.ToLower(e.Type)
+	if strings.Contains(entryType, "access") {
+		d.access.add(pattern, e)
+	}
+	if strings.Contains(entryType, "page not found") {
+		d.notFound.add(pattern, e)
+	}
+}
+
+// format writes the analysis content.
+func (d *digest) format() string {
+	if d.total == 0 {
+		return NoEntriesContent
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("=== DRUPAL WATCHDOG LOG ANALYSIS ===\n\n")
+
+	sb.WriteString("## Summary Statistics\n")
+	fmt.Fprintf(&sb, "Total entries: %d\n", d.total)
+	fmt.Fprintf(&sb, "Time range: %s to %s\n",
+		time.Unix(d.oldest, 0).Format(timeFormatDateTime),
+		time.Unix(d.newest, 0).Format(timeFormatDateTime))
+	if d.notAnalyzed > 0 {
+		fmt.Fprintf(&sb, "Row limit reached: %d older entries of the window not analyzed\n", d.notAnalyzed)
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("## Severity Breakdown\n")
+	for _, sev := range []string{"emergency", "alert", "critical", "error", "warning", "notice", "info", "debug"} {
+		if count := d.severityCounts[sev]; count > 0 {
+			fmt.Fprintf(&sb, "- %s: %d\n", strings.ToUpper(sev), count)
+		}
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("## Entry Types\n")
+	for _, tc := range analyzer.TopCounts(d.typeCounts, len(d.typeCounts)) {
+		fmt.Fprintf(&sb, "- %s: %d\n", tc.Name, tc.Count)
+	}
+	sb.WriteString("\n")
+
+	// Critical and error entries (full detail)
+	if d.critical.count > 0 {
+		sb.WriteString("## Critical/Error Entries (Full Detail)\n")
+		for _, entry := range d.critical.sorted() {
+			sb.WriteString(formatEntry(entry))
+			sb.WriteString("\n")
+		}
+		if more := d.critical.count - d.critical.Len(); more > 0 {
+			fmt.Fprintf(&sb, "\n... and %d more critical/error entries\n", more)
+		}
+		sb.WriteString("\n")
+	}
+
+	// Warning entries (summarized)
+	if d.warnings.count > 0 {
+		sb.WriteString("## Warning Entries\n")
+		for _, g := range d.warnings.sorted() {
+			fmt.Fprintf(&sb, "- [%dx] %s: %s\n", g.count, g.example.Type, g.pattern)
+			sb.WriteString(formatExample(&g.example))
+		}
+		d.warnings.formatOverflow(&sb)
+		sb.WriteString("\n")
+	}
+
+	// Access denied entries (security relevant)
+	if d.access.count > 0 {
+		sb.WriteString("## Access/Permission Events\n")
+		for _, g := range d.access.sorted() {
+			fmt.Fprintf(&sb, "- [%dx] %s\n", g.count, g.pattern)
+			sb.WriteString(formatExample(&g.example))
+		}
+		d.access.formatOverflow(&sb)
+		sb.WriteString("\n")
+	}
+
+	// Page not found (404) summary
+	if d.notFound.count > 0 {
+		sb.WriteString("## Page Not Found (404) Summary\n")
+		fmt.Fprintf(&sb, "Total 404 errors: %d\n", d.notFound.count)
+		groups := d.notFound.sorted()
+		for i, g := range groups {
+			if i >= maxNotFoundGroups {
+				fmt.Fprintf(&sb, "... and %d more unique 404 patterns\n", len(groups)-maxNotFoundGroups)
+				break
+			}
+			fmt.Fprintf(&sb, "- [%dx] %s\n", g.count, g.pattern)
+			sb.WriteString(formatExample(&g.example))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Recent info/notice entries (sample)
+	if d.info.count > 0 {
+		sb.WriteString("## Recent Notice/Info Entries (Sample)\n")
+		for _, entry := range d.info.sorted() {
+			fmt.Fprintf(&sb, "- [%s] %s: %s\n",
+				entry.SeverityName(),
+				entry.Type,
+				truncateMessage(entry.RenderedMessage(), 100))
+		}
+		if more := d.info.count - d.info.Len(); more > 0 {
+			fmt.Fprintf(&sb, "... and %d more notice/info entries\n", more)
+		}
+	}
+
+	return sb.String()
+}
+
+// readStats summarizes the digest by severity, type, and repeated message
+// pattern.
+func (d *digest) readStats() *analyzer.ReadStats {
+	errorCount := 0
+	for sev := SeverityEmergency; sev <= SeverityError; sev++ {
+		errorCount += d.severityCounts[SeverityName[sev]]
+	}
+	result := &analyzer.ReadStats{
+		Totals: []analyzer.StatsItem{
+			{Name: "Entries", Count: d.total},
+			{Name: "Critical/error entries", Count: errorCount},
+			{Name: "Warning entries", Count: d.severityCounts[SeverityName[SeverityWarning]]},
+		},
+	}
+	if d.notAnalyzed > 0 {
+		result.Totals = append(result.Totals, analyzer.StatsItem{Name: "Entries not analyzed (row limit)", Count: d.notAnalyzed})
+	}
+
+	severities := make([]analyzer.StatsItem, 0, len(SeverityName))
+	for sev := SeverityEmergency; sev <= SeverityDebug; sev++ {
+		if count := d.severityCounts[SeverityName[sev]]; count > 0 {
+			severities = append(severities, analyzer.StatsItem{Name: SeverityName[sev], Count: count})
+		}
+	}
+	result.AddBreakdown("Severity", severities)
+	result.AddBreakdown("Entry types", analyzer.TopCounts(d.typeCounts, maxStatsItems))
+
+	patternCounts := make(map[string]int)
+	for pattern, g := range d.patterns.groups {
+		if g.count > 1 {
+			patternCounts[g.example.Type+": "+pattern] = g.count
+		}
+	}
+	result.AddBreakdown("Top repeated messages", analyzer.TopCounts(patternCounts, maxStatsItems))
+
+	return result
+}
+
+// patternGroup counts the entries of one message pattern and keeps the
+// newest of them as the example.
+type patternGroup struct {
+	pattern string
+	count   int
+	example WatchdogEntry
+}
+
+// patternGroups groups the entries of a section by message pattern, up to
+// limit patterns.
+type patternGroups struct {
+	groups   map[string]*patternGroup
+	limit    int
+	examples bool // keep whole example entries, not just their type
+	count    int
+	overflow int
+}
+
+func newPatternGroups(limit int, examples bool) *patternGroups {
+	return &patternGroups{groups: make(map[string]*patternGroup), limit: limit, examples: examples}
+}
+
+// add records an entry under its pattern.
+func (p *patternGroups) add(pattern string, e *WatchdogEntry) {
+	p.count++
+	g, ok := p.groups[pattern]
+	if !ok {
+		if len(p.groups) >= p.limit {
+			p.overflow++
+			return
+		}
+		g = &patternGroup{pattern: pattern}
+		p.groups[pattern] = g
+	}
+	g.count++
+	// Entries of the same second: the one read last is the newest
+	if g.count == 1 || e.Timestamp >= g.example.Timestamp {
+		if p.examples {
+			g.example = *e
+		} else {
+			g.example = WatchdogEntry{Type: e.Type, Timestamp: e.Timestamp}
+		}
+	}
+}
+
+// sorted returns the groups by count, most frequent first.
+func (p *patternGroups) sorted() []*patternGroup {
+	groups := make([]*patternGroup, 0, len(p.groups))
+	for _, g := range p.groups {
+		groups = append(groups, g)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].count != groups[j].count {
+			return groups[i].count > groups[j].count
+		}
+		return groups[i].pattern < groups[j].pattern
+	})
+	return groups
+}
+
+// formatOverflow notes the entries of patterns beyond maxPatternGroups.
+func (p *patternGroups) formatOverflow(sb *strings.Builder) {
+	if p.overflow > 0 {
+		fmt.Fprintf(sb, "- [%dx] entries of further patterns (over %d distinct patterns)\n",
+			p.overflow, p.limit)
+	}
+}
+
+// newestEntries keeps the newest entries of a section in a min-heap by
+// timestamp, so the oldest retained entry is replaced first.
+type newestEntries struct {
+	limit   int
+	count   int
+	entries []WatchdogEntry
+	seqs    []int64
+}
+
+// add records an entry, retaining it if it is among the newest.
+func (n *newestEntries) add(e *WatchdogEntry, seq int64) {
+	n.count++
+	if n.Len() < n.limit {
+		heap.Push(n, heapItem{entry: *e, seq: seq})
+		return
+	}
+	if n.older(e.Timestamp, seq, n.entries[0].Timestamp, n.seqs[0]) {
+		return
+	}
+	n.entries[0] = *e
+	n.seqs[0] = seq
+	heap.Fix(n, 0)
+}
+
+// sorted returns the retained entries, newest first.
+func (n *newestEntries) sorted() []*WatchdogEntry {
+	order := make([]int, n.Len())
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := order[i], order[j]
+		return n.older(n.entries[b].Timestamp, n.seqs[b], n.entries[a].Timestamp, n.seqs[a])
+	})
+	entries := make([]*WatchdogEntry, len(order))
+	for i, idx := range order {
+		entries[i] = &n.entries[idx]
+	}
+	return entries
+}
+
+// older reports whether an entry is older than another; entries of the
+// same second are ordered as read.
+func (n *newestEntries) older(ts, seq, otherTS, otherSeq int64) bool {
+	if ts != otherTS {
+		return ts < otherTS
+	}
+	return seq < otherSeq
+}
+
+// heapItem is the element type pushed onto newestEntries.
+type heapItem struct {
+	entry WatchdogEntry
+	seq   int64
+}
+
+// Len implements heap.Interface.
+func (n *newestEntries) Len() int { return len(n.entries) }
+
+// Less implements heap.Interface.
+func (n *newestEntries) Less(i, j int) bool {
+	return n.older(n.entries[i].Timestamp, n.seqs[i], n.entries[j].Timestamp, n.seqs[j])
+}
+
+// Swap implements heap.Interface.
+func (n *newestEntries) Swap(i, j int) {
+	n.entries[i], n.entries[j] = n.entries[j], n.entries[i]
+	n.seqs[i], n.seqs[j] = n.seqs[j], n.seqs[i]
+}
+
+// Push implements heap.Interface.
+func (n *newestEntries) Push(x any) {
+	item := x.(heapItem)
+	n.entries = append(n.entries, item.entry)
+	n.seqs = append(n.seqs, item.seq)
+}
+
+// Pop implements heap.Interface.
+func (n *newestEntries) Pop() any {
+	last := len(n.entries) - 1
+	item := heapItem{entry: n.entries[last], seq: n.seqs[last]}
+	n.entries = n.entries[:last]
+	n.seqs = n.seqs[:last]
+	return item
+}
+
+// formatEntry formats a single entry for display.
+func formatEntry(entry *WatchdogEntry) string {
+	return fmt.Sprintf("[%s] %s | %s | %s | %s\n  Message: %s",
+		entry.Time().Format(timeFormatDateTime),
+		strings.ToUpper(entry.SeverityName()),
+		entry.Type,
+		entry.Hostname,
+		entry.Location,
+		entry.RenderedMessage())
+}
+
+// formatExample formats the rendered message of the most recent entry of a
+// group, if it differs from the template the group is listed by.
+func formatExample(example *WatchdogEntry) string {
+	rendered := example.RenderedMessage()
+	if rendered == example.Message {
+		return ""
+	}
+	return fmt.Sprintf("  Example: %s\n", truncateMessage(rendered, 200))
+}
+
+// normalizeMessage normalizes a message for pattern grouping. Patterns
+// are built from the message templates, so errors with the same template
+// but different placeholder values are grouped together.
+func normalizeMessage(msg string) string {
+	// Truncate long messages
+	if len(msg) > 80 {
+		msg = msg[:80] + "..."
+	}
+
+	// UUIDs first (before numbers, since UUIDs contain hex digits and numbers)
+	msg = uuidRegex.ReplaceAllString(msg, "[UUID]")
+	// IPs (before numbers)
+	msg = ipRegex.ReplaceAllString(msg, "[IP]")
+	msg = numberRegex.ReplaceAllString(msg, "[N]")
+	msg = pathRegex.ReplaceAllString(msg, "[PATH]")
+
+	return msg
+}
+
+// truncateMessage truncates a message to the specified length.
+func truncateMessage(msg string, maxLen int) string {
+	if len(msg) <= maxLen {
+		return msg
+	}
+	return msg[:maxLen-3] + "..."
+}
@@ -43,6 +43,9 @@ func (p *PromptBuilder) GetLogType() string {
 func (p *PromptBuilder) GetSystemPrompt(globalExclusions []string) string {
 	return `You are a senior Drupal developer and security analyst with expertise in Drupal application security, performance, and operations. Your role is to analyze Drupal watchdog logs and provide actionable insights.
 
+**Input Format:**
+Messages of individual entries have their placeholders (@name, %name, :name) filled in. Grouped entries ("- [12x] ...") are listed by message template with numbers and paths normalized; the "Example:" line below a group shows the most recent message of that group with its placeholders filled in.
+
 **Drupal Watchdog Severity Levels (RFC 5424):**
 - 0 (Emergency): System is unusable
 - 1 (Alert): Action must be taken immediately
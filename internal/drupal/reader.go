@@ -5,11 +5,13 @@ package drupal
 
 import (
 	"bufio"
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
+	"io"
 	"regexp"
-	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -35,8 +37,25 @@ func IsNoEntriesContent(content string) bool {
 	return strings.HasPrefix(content, "=== NO WATCHDOG ENTRIES ===")
 }
 
-// Compile-time interface check
-var _ analyzer.LogReader = (*Reader)(nil)
+// Compile-time interface checks
+var (
+	_ analyzer.LogReader     = (*Reader)(nil)
+	_ analyzer.StatsReporter = (*Reader)(nil)
+	_ analyzer.ContentReader = (*Reader)(nil)
+)
+
+// Parse errors of exports without a single entry.
+var (
+	errNoJSONEntries  = errors.New("failed to parse JSON: no valid entries found")
+	errNoDrushEntries = errors.New("no valid entries found in drush output")
+)
+
+// drushLineRegex parses drush output lines.
+// Matches: ID, Date, Type, Severity, Message
+var drushLineRegex = regexp.MustCompile(`^\s*(\d+)\s+(\d{4}-\d{2}-\d{2}\s+\d{2}:\d{2}:\d{2})\s+(\S+)\s+(\S+)\s+(.*)$`)
+
+// maxStatsItems caps the entry types and repeated messages listed by ReadStats.
+const maxStatsItems = 10
 
 // InputFormat specifies the format of the watchdog input file.
 type InputFormat string
@@ -57,6 +76,8 @@ type Reader struct {
 	maxTokens           int
 	format              InputFormat
 	preprocessor        *Preprocessor
+	observer            func(*WatchdogEntry)
+	digest              *digest
 }
 
 // NewReader creates a new Drupal watchdog reader.
@@ -71,39 +92,45 @@ func NewReader(maxSizeMB int, enablePreprocessing bool, maxTokens int, format In
 }
 
 // Read implements analyzer.LogReader.Read.
-// Reads and processes the Drupal watchdog file.
+// Streams the Drupal watchdog file into a digest of its entries, so
+// exports of any size are summarized without holding them in memory.
 func (r *Reader) Read(sourcePath string) (string, error) {
-	// Check file exists and get info
-	fileInfo, err := os.Stat(sourcePath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return "", fmt.Errorf("watchdog file not found: %s", sourcePath)
-		}
-		return "", fmt.Errorf("failed to stat watchdog file: %w", err)
-	}
+	r.digest = nil
 
-	// Check file permissions
-	if fileInfo.Mode().Perm()&0o400 == 0 {
-		return "", fmt.Errorf("watchdog file is not readable: %s", sourcePath)
+	// Exports are not checked for age: the entries carry their own timestamps
+	opts := analyzer.FileReadOptions{
+		SourceLabel:  "watchdog",
+		MaxSizeMB:    r.maxSizeMB,
+		MaxLineBytes: maxNDJSONLineBytes,
 	}
-
-	// Check file size
-	maxBytes := int64(r.maxSizeMB) * 1024 * 1024
-	if fileInfo.Size() > maxBytes {
-		return "", fmt.Errorf("watchdog file exceeds maximum size of %dMB (size: %.2fMB)",
-			r.maxSizeMB, float64(fileInfo.Size())/1024/1024)
+	d := newDigest()
+	var err error
+	switch r.format {
+	case FormatJSON:
+		err = r.streamJSON(sourcePath, opts, d)
+	case FormatDrush:
+		err = r.streamDrush(sourcePath, opts, d)
+	default:
+		return "", fmt.Errorf("unsupported watchdog format: %s", r.format)
 	}
-
-	// Read file content
-	content, err := os.ReadFile(sourcePath)
 	if err != nil {
-		return "", fmt.Errorf("failed to read watchdog file: %w", err)
+		return "", err
 	}
 
-	contentStr := string(content)
+	return r.process(d)
+}
+
+// ReadContent implements analyzer.ContentReader.
+// Processes a watchdog export that was not read from a file, such as the
+// output of `drush watchdog:show --format=json`.
+func (r *Reader) ReadContent(contentStr string) (string, error) {
+	r.digest = nil
 
 	// Parse entries based on format
-	var entries []WatchdogEntry
+	var (
+		entries []WatchdogEntry
+		err     error
+	)
 	switch r.format {
 	case FormatJSON:
 		entries, err = r.parseJSON(contentStr)
@@ -117,8 +144,56 @@ func (r *Reader) Read(sourcePath string) (string, error) {
 		return "", fmt.Errorf("failed to parse watchdog content: %w", err)
 	}
 
+	return r.process(r.digestEntries(entries))
+}
+
+// ReadDatabase reads the entries matching the query directly from the
+// watchdog table of a Drupal database, instead of an export file, and
+// processes them like Read.
+func (r *Reader) ReadDatabase(ctx context.Context, db *Database, q DatabaseQuery) (string, error) {
+	r.digest = nil
+
+	entries, err := db.Entries(ctx, q)
+	if err != nil {
+		return "", err
+	}
+
+	d := r.digestEntries(entries)
+	if len(entries) == q.limit() {
+		// The limit drops the oldest entries of the window; report how many
+		count, err := db.Count(ctx, q)
+		if err != nil {
+			return "", err
+		}
+		d.notAnalyzed = max(count-len(entries), 0)
+	}
+	return r.process(d)
+}
+
+// digestEntries adds parsed entries to a new digest.
+func (r *Reader) digestEntries(entries []WatchdogEntry) *digest {
+	d := newDigest()
+	for i := range entries {
+		r.add(d, &entries[i])
+	}
+	return d
+}
+
+// add passes an entry to the observer and the digest. Placeholders are
+// substituted only for the entries the digest shows, or on demand.
+func (r *Reader) add(d *digest, entry *WatchdogEntry) {
+	if r.observer != nil {
+		r.observer(entry)
+	}
+	d.add(entry)
+}
+
+// process formats, validates, and preprocesses the digest of the entries.
+func (r *Reader) process(d *digest) (string, error) {
+	r.digest = d
+
 	// Format entries for analysis
-	formattedContent := r.formatEntriesForAnalysis(entries)
+	formattedContent := d.format()
 
 	// Validate content
 	if err := r.Validate(formattedContent); err != nil {
@@ -137,6 +212,190 @@ func (r *Reader) Read(sourcePath string) (string, error) {
 	return formattedContent, nil
 }
 
+// SetEntryObserver registers a function that is called with every entry
+// parsed by the following reads, before formatting. The reader does not
+// retain the entries, so this is how the rule engine evaluates severity
+// and type conditions on the raw entries. The function must not retain
+// the entry.
+func (r *Reader) SetEntryObserver(observe func(*WatchdogEntry)) {
+	r.observer = observe
+}
+
+// EntryCount returns the number of watchdog entries parsed by the last
+// successful Read.
+func (r *Reader) EntryCount() int {
+	if r.digest == nil {
+		return 0
+	}
+	return r.digest.total
+}
+
+// NotAnalyzed returns the number of entries of the last ReadDatabase window
+// left out because the query reached its row limit.
+func (r *Reader) NotAnalyzed() int {
+	if r.digest == nil {
+		return 0
+	}
+	return r.digest.notAnalyzed
+}
+
+// ReadStats implements analyzer.StatsReporter.
+// Summarizes the entries of the last Read by severity, type, and repeated
+// message pattern.
+func (r *Reader) ReadStats() *analyzer.ReadStats {
+	if r.digest == nil {
+		return nil
+	}
+	return r.digest.readStats()
+}
+
+// streamJSON parses a JSON export line by line as NDJSON, the format of
+// large exports, skipping lines that are not entries. An export that is
+// one JSON document spread over lines, such as a pretty-printed array,
+// is decoded as a stream of its elements instead.
+func (r *Reader) streamJSON(sourcePath string, opts analyzer.FileReadOptions, d *digest) error {
+	parsed, document, err := r.streamNDJSON(sourcePath, opts, d, true)
+	if err != nil {
+		return err
+	}
+	if parsed > 0 {
+		return nil
+	}
+
+	decoded, err := r.decodeJSONDocument(sourcePath, opts, d)
+	if err == nil {
+		return nil
+	}
+	if decoded > 0 {
+		return fmt.Errorf("failed to parse watchdog content: %w", err)
+	}
+
+	// Neither a document nor NDJSON starting with an entry: keep the
+	// entries of any valid lines
+	if document {
+		if parsed, _, err = r.streamNDJSON(sourcePath, opts, d, false); err != nil {
+			return err
+		}
+	}
+	if parsed == 0 {
+		return fmt.Errorf("failed to parse watchdog content: %w", errNoJSONEntries)
+	}
+	return nil
+}
+
+// streamNDJSON adds the entries of the NDJSON lines of the export. With
+// stopAtInvalid, it stops at an invalid line before the first entry and
+// reports that the export may be a JSON document.
+func (r *Reader) streamNDJSON(sourcePath string, opts analyzer.FileReadOptions, d *digest, stopAtInvalid bool) (parsed int, document bool, err error) {
+	scanner, err := analyzer.OpenSourceLines(sourcePath, opts)
+	if err != nil {
+		return 0, false, err
+	}
+	defer func() { _ = scanner.Close() }()
+
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 || bytes.Equal(line, []byte("[")) || bytes.Equal(line, []byte("]")) {
+			continue
+		}
+		// Remove trailing comma if present
+		line = bytes.TrimSuffix(line, []byte(","))
+
+		var entry WatchdogEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			if parsed == 0 && stopAtInvalid {
+				return 0, true, nil
+			}
+			continue // Skip invalid lines
+		}
+		r.add(d, &entry)
+		parsed++
+	}
+	return parsed, false, scanner.Err()
+}
+
+// decodeJSONDocument adds the entries of an export that is a JSON array
+// or a sequence of JSON objects, decoding one entry at a time.
+func (r *Reader) decodeJSONDocument(sourcePath string, opts analyzer.FileReadOptions, d *digest) (int, error) {
+	source, err := analyzer.OpenSourceReader(sourcePath, opts)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = source.Close() }()
+
+	reader := bufio.NewReader(source)
+	first, err := firstNonSpace(reader)
+	if err != nil {
+		return 0, err
+	}
+
+	decoded := 0
+	decoder := json.NewDecoder(reader)
+	if first == '[' {
+		if _, err := decoder.Token(); err != nil {
+			return 0, err
+		}
+	}
+	for decoder.More() {
+		var entry WatchdogEntry
+		if err := decoder.Decode(&entry); err != nil {
+			return decoded, err
+		}
+		r.add(d, &entry)
+		decoded++
+	}
+	if first == '[' {
+		if _, err := decoder.Token(); err != nil {
+			return decoded, err
+		}
+	}
+	// Trailing content after the array or a truncated last object
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		if err == nil {
+			err = errors.New("unexpected content after JSON entries")
+		}
+		return decoded, err
+	}
+	return decoded, nil
+}
+
+// firstNonSpace returns the first byte of the reader that is not white
+// space, without consuming it.
+func firstNonSpace(reader *bufio.Reader) (byte, error) {
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if b != ' ' && b != '\t' && b != '\r' && b != '\n' {
+			return b, reader.UnreadByte()
+		}
+	}
+}
+
+// streamDrush adds the entries of drush watchdog-show output line by line.
+func (r *Reader) streamDrush(sourcePath string, opts analyzer.FileReadOptions, d *digest) error {
+	scanner, err := analyzer.OpenSourceLines(sourcePath, opts)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = scanner.Close() }()
+
+	var parser drushParser
+	for scanner.Scan() {
+		if entry, ok := parser.parseLine(scanner.Text()); ok {
+			r.add(d, &entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if d.total == 0 {
+		return fmt.Errorf("failed to parse watchdog content: %w", errNoDrushEntries)
+	}
+	return nil
+}
+
 // Validate implements analyzer.LogReader.Validate.
 // Performs basic validation on watchdog content.
 func (r *Reader) Validate(content string) error {
@@ -160,18 +419,11 @@ func (r *Reader) Validate(content string) error {
 // GetSourceInfo implements analyzer.LogReader.GetSourceInfo.
 // Returns metadata about the watchdog file.
 func (r *Reader) GetSourceInfo(sourcePath string) (map[string]any, error) {
-	fileInfo, err := os.Stat(sourcePath)
+	info, err := analyzer.GetSourceFileInfo(sourcePath)
 	if err != nil {
 		return nil, err
 	}
-
-	info := map[string]any{
-		"size_bytes": fileInfo.Size(),
-		"size_mb":    float64(fileInfo.Size()) / 1024 / 1024,
-		"modified":   fileInfo.ModTime(),
-		"age_hours":  time.Since(fileInfo.ModTime()).Hours(),
-		"format":     string(r.format),
-	}
+	info["format"] = string(r.format)
 
 	return info, nil
 }
@@ -219,7 +471,7 @@ func (r *Reader) parseJSON(content string) ([]WatchdogEntry, error) {
 		return entries, nil
 	}
 
-	return nil, fmt.Errorf("failed to parse JSON: no valid entries found")
+	return nil, errNoJSONEntries
 }
 
 // parseDrush parses drush watchdog-show output format.
@@ -230,305 +482,76 @@ func (r *Reader) parseJSON(content string) ([]WatchdogEntry, error) {
 //	12345   2024-11-13 10:00:00  php      error     PDOException: SQLSTATE[HY000]...
 func (r *Reader) parseDrush(content string) ([]WatchdogEntry, error) {
 	var entries []WatchdogEntry
-
-	lines := strings.Split(content, "\n")
-	headerPassed := false
-
-	// Regex to parse drush output lines
-	// Matches: ID, Date, Type, Severity, Message
-	lineRegex := regexp.MustCompile(`^\s*(\d+)\s+(\d{4}-\d{2}-\d{2}\s+\d{2}:\d{2}:\d{2})\s+(\S+)\s+(\S+)\s+(.*)$`)
-
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-
-		// Skip header lines
-		if strings.HasPrefix(line, "ID") || strings.HasPrefix(line, "---") {
-			headerPassed = true
-			continue
-		}
-
-		if !headerPassed {
-			continue
-		}
-
-		matches := lineRegex.FindStringSubmatch(line)
-		if matches == nil {
-			continue
+	var parser drushParser
+	for line := range strings.Lines(content) {
+		if entry, ok := parser.parseLine(line); ok {
+			entries = append(entries, entry)
 		}
-
-		wid, err := strconv.ParseInt(matches[1], 10, 64)
-		if err != nil {
-			continue // Skip entries with invalid WID
-		}
-
-		timestamp, err := time.Parse(timeFormatDateTime, matches[2])
-		if err != nil {
-			continue // Skip entries with invalid timestamp
-		}
-
-		severity := SeverityFromName(strings.ToLower(matches[4]))
-		if severity == -1 {
-			severity = SeverityNotice // Default
-		}
-
-		entry := WatchdogEntry{
-			WID:       wid,
-			Timestamp: timestamp.Unix(),
-			Type:      matches[3],
-			Severity:  severity,
-			Message:   matches[5],
-		}
-		entries = append(entries, entry)
 	}
 
 	if len(entries) == 0 {
-		return nil, fmt.Errorf("no valid entries found in drush output")
+		return nil, errNoDrushEntries
 	}
 
 	return entries, nil
 }
 
-// formatEntriesForAnalysis formats watchdog entries into a readable format for Claude.
-func (r *Reader) formatEntriesForAnalysis(entries []WatchdogEntry) string {
-	if len(entries) == 0 {
-		return NoEntriesContent
-	}
-
-	var sb strings.Builder
-
-	// Sort entries by timestamp (newest first)
-	sort.Slice(entries, func(i, j int) bool {
-		return entries[i].Timestamp > entries[j].Timestamp
-	})
-
-	// Write summary header
-	sb.WriteString("=== DRUPAL WATCHDOG LOG ANALYSIS ===\n\n")
-
-	// Calculate statistics
-	stats := r.calculateStats(entries)
-	sb.WriteString("## Summary Statistics\n")
-	fmt.Fprintf(&sb, "Total entries: %d\n", stats["total"])
-	fmt.Fprintf(&sb, "Time range: %s to %s\n", stats["oldest"], stats["newest"])
-	sb.WriteString("\n")
-
-	// Severity breakdown
-	sb.WriteString("## Severity Breakdown\n")
-	severityCounts := stats["severity_counts"].(map[string]int)
-	for _, sev := range []string{"emergency", "alert", "critical", "error", "warning", "notice", "info", "debug"} {
-		if count, ok := severityCounts[sev]; ok && count > 0 {
-			fmt.Fprintf(&sb, "- %s: %d\n", strings.ToUpper(sev), count)
-		}
-	}
-	sb.WriteString("\n")
-
-	// Type breakdown
-	sb.WriteString("## Entry Types\n")
-	typeCounts := stats["type_counts"].(map[string]int)
-	// Sort types by count
-	type typeCount struct {
-		name  string
-		count int
-	}
-	var sortedTypes []typeCount
-	for name, count := range typeCounts {
-		sortedTypes = append(sortedTypes, typeCount{name, count})
-	}
-	sort.Slice(sortedTypes, func(i, j int) bool {
-		return sortedTypes[i].count > sortedTypes[j].count
-	})
-	for _, tc := range sortedTypes {
-		fmt.Fprintf(&sb, "- %s: %d\n", tc.name, tc.count)
-	}
-	sb.WriteString("\n")
-
-	// Critical and error entries (full detail)
-	criticalEntries := r.filterBySeverity(entries, SeverityEmergency, SeverityError)
-	if len(criticalEntries) > 0 {
-		sb.WriteString("## Critical/Error Entries (Full Detail)\n")
-		for i, entry := range criticalEntries {
-			if i >= 50 { // Limit to 50 critical entries
-				fmt.Fprintf(&sb, "\n... and %d more critical/error entries\n", len(criticalEntries)-50)
-				break
-			}
-			sb.WriteString(r.formatEntry(entry))
-			sb.WriteString("\n")
-		}
-		sb.WriteString("\n")
-	}
-
-	// Warning entries (summarized)
-	warningEntries := r.filterBySeverity(entries, SeverityWarning, SeverityWarning)
-	if len(warningEntries) > 0 {
-		sb.WriteString("## Warning Entries\n")
-		// Group by type and message pattern
-		warningGroups := r.groupByPattern(warningEntries)
-		for pattern, group := range warningGroups {
-			fmt.Fprintf(&sb, "- [%dx] %s: %s\n", len(group), group[0].Type, pattern)
-		}
-		sb.WriteString("\n")
-	}
-
-	// Access denied entries (security relevant)
-	accessDenied := r.filterByType(entries, "access denied", "access")
-	if len(accessDenied) > 0 {
-		sb.WriteString("## Access/Permission Events\n")
-		accessGroups := r.groupByPattern(accessDenied)
-		for pattern, group := range accessGroups {
-			fmt.Fprintf(&sb, "- [%dx] %s\n", len(group), pattern)
-		}
-		sb.WriteString("\n")
-	}
-
-	// Page not found (404) summary
-	notFound := r.filterByType(entries, "page not found")
-	if len(notFound) > 0 {
-		sb.WriteString("## Page Not Found (404) Summary\n")
-		fmt.Fprintf(&sb, "Total 404 errors: %d\n", len(notFound))
-		notFoundGroups := r.groupByPattern(notFound)
-		count := 0
-		for pattern, group := range notFoundGroups {
-			if count >= 10 {
-				fmt.Fprintf(&sb, "... and %d more unique 404 patterns\n", len(notFoundGroups)-10)
-				break
-			}
-			fmt.Fprintf(&sb, "- [%dx] %s\n", len(group), pattern)
-			count++
-		}
-		sb.WriteString("\n")
-	}
-
-	// Recent info/notice entries (sample)
-	infoEntries := r.filterBySeverity(entries, SeverityNotice, SeverityInfo)
-	if len(infoEntries) > 0 {
-		sb.WriteString("## Recent Notice/Info Entries (Sample)\n")
-		for i, entry := range infoEntries {
-			if i >= 20 { // Only show first 20
-				fmt.Fprintf(&sb, "... and %d more notice/info entries\n", len(infoEntries)-20)
-				break
-			}
-			fmt.Fprintf(&sb, "- [%s] %s: %s\n",
-				entry.SeverityName(),
-				entry.Type,
-				r.truncateMessage(entry.Message, 100))
-		}
-	}
-
-	return sb.String()
+// drushParser parses drush watchdog-show output one line at a time.
+type drushParser struct {
+	headerPassed bool
 }
 
-// calculateStats calculates statistics about the entries.
-func (r *Reader) calculateStats(entries []WatchdogEntry) map[string]any {
-	stats := make(map[string]any)
-	stats["total"] = len(entries)
-
-	if len(entries) == 0 {
-		return stats
-	}
-
-	// Find time range
-	oldest := entries[0].Timestamp
-	newest := entries[0].Timestamp
-	for _, e := range entries {
-		if e.Timestamp < oldest {
-			oldest = e.Timestamp
-		}
-		if e.Timestamp > newest {
-			newest = e.Timestamp
-		}
+// parseLine returns the entry of a line, if it is one.
+func (p *drushParser) parseLine(line string) (WatchdogEntry, bool) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return WatchdogEntry{}, false
 	}
-	stats["oldest"] = time.Unix(oldest, 0).Format(timeFormatDateTime)
-	stats["newest"] = time.Unix(newest, 0).Format(timeFormatDateTime)
 
-	// Count by severity
-	severityCounts := make(map[string]int)
-	for _, e := range entries {
-		severityCounts[e.SeverityName()]++
+	// Skip header lines
+	if strings.HasPrefix(line, "ID") || strings.HasPrefix(line, "---") {
+		p.headerPassed = true
+		return WatchdogEntry{}, false
 	}
-	stats["severity_counts"] = severityCounts
 
-	// Count by type
-	typeCounts := make(map[string]int)
-	for _, e := range entries {
-		typeCounts[e.Type]++
+	if !p.headerPassed {
+		return WatchdogEntry{}, false
 	}
-	stats["type_counts"] = typeCounts
-
-	return stats
-}
 
-// filterBySeverity filters entries by severity range (inclusive).
-func (r *Reader) filterBySeverity(entries []WatchdogEntry, minSev, maxSev int) []WatchdogEntry {
-	var filtered []WatchdogEntry
-	for _, e := range entries {
-		if e.Severity >= minSev && e.Severity <= maxSev {
-			filtered = append(filtered, e)
-		}
+	matches := drushLineRegex.FindStringSubmatch(line)
+	if matches == nil {
+		return WatchdogEntry{}, false
 	}
-	return filtered
-}
 
-// filterByType filters entries by type (case-insensitive, partial match).
-func (r *Reader) filterByType(entries []WatchdogEntry, types ...string) []WatchdogEntry {
-	var filtered []WatchdogEntry
-	for _, e := range entries {
-		for _, t := range types {
-			if strings.Contains(strings.ToLower(e.Type), strings.ToLower(t)) {
-				filtered = append(filtered, e)
-				break
-			}
-		}
+	wid, err := strconv.ParseInt(matches[1], 10, 64)
+	if err != nil {
+		return WatchdogEntry{}, false // Skip entries with invalid WID
 	}
-	return filtered
-}
-
-// formatEntry formats a single entry for display.
-func (r *Reader) formatEntry(entry WatchdogEntry) string {
-	return fmt.Sprintf("[%s] %s | %s | %s | %s\n  Message: %s",
-		entry.Time().Format(timeFormatDateTime),
-		strings.ToUpper(entry.SeverityName()),
-		entry.Type,
-		entry.Hostname,
-		entry.Location,
-		entry.Message)
-}
 
-// groupByPattern groups entries by normalized message pattern.
-func (r *Reader) groupByPattern(entries []WatchdogEntry) map[string][]WatchdogEntry {
-	groups := make(map[string][]WatchdogEntry)
-	for _, e := range entries {
-		pattern := r.normalizeMessage(e.Message)
-		groups[pattern] = append(groups[pattern], e)
+	timestamp, err := time.Parse(timeFormatDateTime, matches[2])
+	if err != nil {
+		return WatchdogEntry{}, false // Skip entries with invalid timestamp
 	}
-	return groups
-}
 
-// normalizeMessage normalizes a message for pattern grouping.
-func (r *Reader) normalizeMessage(msg string) string {
-	// Truncate long messages
-	if len(msg) > 80 {
-		msg = msg[:80] + "..."
+	severity := SeverityFromName(strings.ToLower(matches[4]))
+	if severity == -1 {
+		severity = SeverityNotice // Default
 	}
 
-	// Replace common variable patterns
-	// UUIDs FIRST (before numbers, since UUIDs contain hex digits and numbers)
-	msg = regexp.MustCompile(`[a-f0-9]{8}-[a-f0-9]{4}-[a-f0-9]{4}-[a-f0-9]{4}-[a-f0-9]{12}`).ReplaceAllString(msg, "[UUID]")
-	// IPs (before numbers)
-	msg = regexp.MustCompile(`\b\d{1,3}\.\d{1,3}\.\d{1,3}\.\d{1,3}\b`).ReplaceAllString(msg, "[IP]")
-	// Numbers
-	msg = regexp.MustCompile(`\b\d+\b`).ReplaceAllString(msg, "[N]")
-	// Paths
-	msg = regexp.MustCompile(`/[a-zA-Z0-9/_-]+`).ReplaceAllString(msg, "[PATH]")
-
-	return msg
+	return WatchdogEntry{
+		WID:       wid,
+		Timestamp: timestamp.Unix(),
+		Type:      matches[3],
+		Severity:  severity,
+		Message:   matches[5],
+	}, true
 }
 
-// truncateMessage truncates a message to the specified length.
-func (r *Reader) truncateMessage(msg string, maxLen int) string {
-	if len(msg) <= maxLen {
-		return msg
+// formatEntriesForAnalysis formats watchdog entries into a readable format for Claude.
+func (r *Reader) formatEntriesForAnalysis(entries []WatchdogEntry) string {
+	d := newDigest()
+	for i := range entries {
+		d.add(&entries[i])
 	}
-	return msg[:maxLen-3] + "..."
+	return d.format()
 }
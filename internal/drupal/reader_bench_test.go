@@ -0,0 +1,77 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package drupal
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/olegiv/logwatch-ai-go/internal/memtest"
+)
+
+// writeWatchdogExport writes an NDJSON watchdog export of about size
+// bytes, with a mix of severities, types, repeated messages, and messages
+// unique enough to exceed the pattern limits of the digest.
+func writeWatchdogExport(tb testing.TB, size int64) string {
+	tb.Helper()
+
+	path := filepath.Join(tb.TempDir(), "watchdog.json")
+	file, err := os.Create(path)
+	if err != nil {
+		tb.Fatalf("Create() error = %v", err)
+	}
+	w := bufio.NewWriterSize(file, 1<<20)
+
+	types := []string{"php", "access denied", "page not found", "cron", "user", "mymodule"}
+	messages := []string{
+		"%type: @message in %function (line %line of %file).",
+		"Login attempt failed for @user.",
+		"Import of @file failed",
+		"Cron run completed.",
+		"Session opened for @user.",
+	}
+	var written int64
+	for i := 0; written < size; i++ {
+		message := messages[i%len(messages)]
+		if i%10 == 0 {
+			// Unique message templates, such as a module logging its data
+			message = fmt.Sprintf("Cache tag %x rebuilt for bundle %s", i*7919, strings.Repeat("x", i%13))
+		}
+		n, err := fmt.Fprintf(w, `{"wid":%d,"uid":%d,"type":%q,"message":%q,"variables":"{\"@user\":\"user%d\",\"@file\":\"import-%d.csv\"}","severity":%d,"location":"https://example.com/node/%d","hostname":"192.0.2.%d","timestamp":%d}`+"\n",
+			i, i%50, types[i%len(types)], message, i%500, i, i%8, i%1000, i%250, 1699900800+int64(i/20))
+		if err != nil {
+			tb.Fatalf("Fprintf() error = %v", err)
+		}
+		written += int64(n)
+	}
+	if err := w.Flush(); err != nil {
+		tb.Fatalf("Flush() error = %v", err)
+	}
+	if err := file.Close(); err != nil {
+		tb.Fatalf("Close() error = %v", err)
+	}
+	return path
+}
+
+// readBenchmark summarizes a generated export for the memory checks.
+func readBenchmark(path string, maxSizeMB int) (string, error) {
+	return NewReader(maxSizeMB, false, 150000, FormatJSON).Read(path)
+}
+
+// BenchmarkReader_Read reports the peak heap of streaming exports of
+// growing size, which stays flat since the digest state is bounded.
+func BenchmarkReader_Read(b *testing.B) {
+	memtest.BenchmarkRead(b, writeWatchdogExport, readBenchmark)
+}
+
+// TestReader_Read_BoundedMemory checks that a large export is summarized
+// within a fixed heap, far below its size: the digest state is capped, not
+// proportional to the entries read.
+func TestReader_Read_BoundedMemory(t *testing.T) {
+	memtest.CheckBoundedRead(t, writeWatchdogExport, readBenchmark, 16, "entries of further patterns")
+}
@@ -1,6 +1,7 @@
 package drupal
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -252,6 +253,147 @@ func TestReader_Read(t *testing.T) {
 	if !strings.Contains(result, "php") {
 		t.Error("Read() result missing entry type")
 	}
+
+	if count := r.EntryCount(); count != 2 {
+		t.Errorf("EntryCount() = %d, want the 2 parsed entries", count)
+	}
+}
+
+func TestReader_Read_Streaming(t *testing.T) {
+	entries := []string{
+		`{"wid": 1, "type": "php", "message": "Error 1", "severity": 3, "timestamp": 1699900800}`,
+		`{"wid": 2, "type": "access", "message": "Access denied", "severity": 4, "timestamp": 1699900801}`,
+	}
+	prettyArray := "[\n  {\n    \"wid\": 1,\n    \"type\": \"php\",\n    \"message\": \"Error 1\",\n    \"severity\": 3,\n    \"timestamp\": 1699900800\n  },\n" +
+		"  {\n    \"wid\": 2,\n    \"type\": \"access\",\n    \"message\": \"Access denied\",\n    \"severity\": 4,\n    \"timestamp\": 1699900801\n  }\n]\n"
+
+	tests := []struct {
+		name      string
+		format    InputFormat
+		content   string
+		wantCount int
+		wantErr   string
+	}{
+		{name: "NDJSON", format: FormatJSON, content: strings.Join(entries, "\n") + "\n", wantCount: 2},
+		{name: "NDJSON with invalid lines", format: FormatJSON, content: "export of site\n" + entries[0] + "\n{broken\n" + entries[1], wantCount: 2},
+		{name: "one entry per line array", format: FormatJSON, content: "[\n" + entries[0] + ",\n" + entries[1] + "\n]", wantCount: 2},
+		{name: "compact array", format: FormatJSON, content: "[" + entries[0] + "," + entries[1] + "]", wantCount: 2},
+		{name: "pretty-printed array", format: FormatJSON, content: prettyArray, wantCount: 2},
+		{name: "pretty-printed single entry", format: FormatJSON, content: "{\n  \"wid\": 1,\n  \"type\": \"php\",\n  \"message\": \"Error 1\",\n  \"severity\": 3,\n  \"timestamp\": 1699900800\n}\n", wantCount: 1},
+		{name: "empty array", format: FormatJSON, content: "[\n]\n", wantCount: 0},
+		{name: "not JSON", format: FormatJSON, content: "not json at all\n", wantErr: "no valid entries found"},
+		{name: "drush", format: FormatDrush, content: "ID      Date                 Type     Severity  Message\n" +
+			"------- -------------------- -------- --------- -------\n" +
+			"12345   2024-11-13 10:00:00  php      error     PDOException: SQLSTATE[HY000]\n", wantCount: 1},
+		{name: "drush without entries", format: FormatDrush, content: "ID      Date\n", wantErr: "no valid entries found in drush output"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpFile := filepath.Join(t.TempDir(), "watchdog.json")
+			if err := os.WriteFile(tmpFile, []byte(tt.content), 0o644); err != nil {
+				t.Fatalf("Failed to write temp file: %v", err)
+			}
+
+			r := NewReader(10, false, 150000, tt.format)
+			result, err := r.Read(tmpFile)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Read() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+			if r.EntryCount() != tt.wantCount {
+				t.Errorf("EntryCount() = %d, want %d", r.EntryCount(), tt.wantCount)
+			}
+			if tt.wantCount == 0 && !IsNoEntriesContent(result) {
+				t.Errorf("Read() = %q, want NoEntriesContent", result)
+			}
+		})
+	}
+}
+
+// TestReader_Read_MatchesReadContent checks that streaming a file gives the
+// same digest as processing its content at once.
+func TestReader_Read_MatchesReadContent(t *testing.T) {
+	var sb strings.Builder
+	for i := range 500 {
+		fmt.Fprintf(&sb, `{"wid": %d, "type": "%s", "message": "Event @n on node %d", "variables": "{\"@n\":\"%d\"}", "severity": %d, "timestamp": %d}`+"\n",
+			i, []string{"php", "access denied", "page not found", "cron"}[i%4], i%7, i, i%8, 1699900800+int64(i/3))
+	}
+	tmpFile := filepath.Join(t.TempDir(), "watchdog.json")
+	if err := os.WriteFile(tmpFile, []byte(sb.String()), 0o644); err != nil {
+		t.Fatalf("Failed to write temp file: %v", err)
+	}
+
+	streamed, err := NewReader(10, false, 150000, FormatJSON).Read(tmpFile)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	content, err := NewReader(10, false, 150000, FormatJSON).ReadContent(sb.String())
+	if err != nil {
+		t.Fatalf("ReadContent() error = %v", err)
+	}
+	if streamed != content {
+		t.Errorf("Read() and ReadContent() differ:\n%s\n---\n%s", streamed, content)
+	}
+	if !strings.Contains(streamed, "... and 202 more critical/error entries") {
+		t.Errorf("Read() misses the critical entries beyond the limit:\n%s", streamed)
+	}
+}
+
+func TestReader_Read_SizeLimit(t *testing.T) {
+	tmpFile := filepath.Join(t.TempDir(), "watchdog.json")
+	line := `{"wid": 1, "type": "php", "message": "Error 1", "severity": 3, "timestamp": 1699900800}` + "\n"
+	if err := os.WriteFile(tmpFile, []byte(strings.Repeat(line, (1<<20)/len(line)+1)), 0o644); err != nil {
+		t.Fatalf("Failed to write temp file: %v", err)
+	}
+
+	if _, err := NewReader(1, false, 150000, FormatJSON).Read(tmpFile); err == nil || !strings.Contains(err.Error(), "exceeds maximum size of 1MB") {
+		t.Errorf("Read() error = %v, want the size limit", err)
+	}
+}
+
+func TestReader_ReadStats(t *testing.T) {
+	tmpDir := t.TempDir()
+	tmpFile := filepath.Join(tmpDir, "watchdog.json")
+
+	content := `[
+		{"wid": 1, "type": "php", "message": "Undefined index 12 in foo()", "severity": 3, "timestamp": 1699900800},
+		{"wid": 2, "type": "php", "message": "Undefined index 34 in foo()", "severity": 3, "timestamp": 1699900801},
+		{"wid": 3, "type": "access", "message": "Access denied for user", "severity": 4, "timestamp": 1699900802}
+	]`
+	if err := os.WriteFile(tmpFile, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write temp file: %v", err)
+	}
+
+	r := NewReader(10, false, 150000, FormatJSON)
+	if stats := r.ReadStats(); stats != nil {
+		t.Errorf("ReadStats() before Read = %+v, want nil", stats)
+	}
+	if _, err := r.Read(tmpFile); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	stats := r.ReadStats()
+	if stats == nil {
+		t.Fatal("ReadStats() = nil")
+	}
+	wantTotals := []int{3, 2, 1}
+	for i, want := range wantTotals {
+		if stats.Totals[i].Count != want {
+			t.Errorf("Totals[%d] = %+v, want count %d", i, stats.Totals[i], want)
+		}
+	}
+	if len(stats.Breakdowns) != 3 {
+		t.Fatalf("Breakdowns = %+v, want severity, types and repeated messages", stats.Breakdowns)
+	}
+	if repeated := stats.Breakdowns[2].Items; len(repeated) != 1 || repeated[0].Count != 2 || !strings.HasPrefix(repeated[0].Name, "php: ") {
+		t.Errorf("repeated messages = %+v", repeated)
+	}
 }
 
 func TestReader_Read_FileNotFound(t *testing.T) {
@@ -339,9 +481,7 @@ func TestReader_formatEntriesForAnalysis_Empty(t *testing.T) {
 	}
 }
 
-func TestReader_normalizeMessage(t *testing.T) {
-	r := NewReader(10, false, 150000, FormatJSON)
-
+func TestNormalizeMessage(t *testing.T) {
 	tests := []struct {
 		input string
 		want  string
@@ -362,7 +502,7 @@ func TestReader_normalizeMessage(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.input, func(t *testing.T) {
-			got := r.normalizeMessage(tt.input)
+			got := normalizeMessage(tt.input)
 			if got != tt.want {
 				t.Errorf("normalizeMessage() = %q, want %q", got, tt.want)
 			}
@@ -370,9 +510,7 @@ func TestReader_normalizeMessage(t *testing.T) {
 	}
 }
 
-func TestReader_truncateMessage(t *testing.T) {
-	r := NewReader(10, false, 150000, FormatJSON)
-
+func TestTruncateMessage(t *testing.T) {
 	tests := []struct {
 		input  string
 		maxLen int
@@ -385,10 +523,48 @@ func TestReader_truncateMessage(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.input, func(t *testing.T) {
-			got := r.truncateMessage(tt.input, tt.maxLen)
+			got := truncateMessage(tt.input, tt.maxLen)
 			if got != tt.want {
 				t.Errorf("truncateMessage() = %q, want %q", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestReader_ReadContent_RendersPlaceholders(t *testing.T) {
+	r := NewReader(10, false, 150000, FormatJSON)
+
+	content := `[
+		{"wid": 1, "type": "php", "severity": 3, "timestamp": 1699900800,
+		 "message": "%type: @message in %function (line %line of %file).",
+		 "variables": "a:5:{s:5:\"%type\";s:5:\"Error\";s:8:\"@message\";s:19:\"Class \"Foo\" missing\";s:9:\"%function\";s:5:\"bar()\";s:5:\"%line\";i:7;s:5:\"%file\";s:5:\"x.php\";}"},
+		{"wid": 2, "type": "mymodule", "severity": 4, "timestamp": 1699900801,
+		 "message": "Import of @file failed", "variables": "{\"@file\":\"a.csv\"}"},
+		{"wid": 3, "type": "mymodule", "severity": 4, "timestamp": 1699900802,
+		 "message": "Import of @file failed", "variables": "{\"@file\":\"b.csv\"}"}
+	]`
+
+	var observed []WatchdogEntry
+	r.SetEntryObserver(func(entry *WatchdogEntry) { observed = append(observed, *entry) })
+	result, err := r.ReadContent(content)
+	if err != nil {
+		t.Fatalf("ReadContent() error = %v", err)
+	}
+
+	for _, want := range []string{
+		`Message: Error: Class "Foo" missing in bar() (line 7 of x.php).`,
+		"- [2x] mymodule: Import of @file failed\n  Example: Import of b.csv failed\n",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("ReadContent() missing %q in:\n%s", want, result)
+		}
+	}
+
+	// Observed entries keep the template
+	if len(observed) != 3 {
+		t.Fatalf("observed %d entries, want 3", len(observed))
+	}
+	if last := observed[2]; last.Message != "Import of @file failed" || last.RenderedMessage() != "Import of b.csv failed" {
+		t.Errorf("entry = %q / %q", last.Message, last.RenderedMessage())
+	}
+}
@@ -93,6 +93,9 @@ type WatchdogEntry struct {
 
 	// Timestamp is the Unix timestamp when this entry was created
 	Timestamp int64 `json:"timestamp"`
+
+	// rendered caches the message with its placeholders substituted
+	rendered string
 }
 
 // Time returns the entry timestamp as a time.Time.
@@ -100,6 +103,16 @@ func (e *WatchdogEntry) Time() time.Time {
 	return time.Unix(e.Timestamp, 0)
 }
 
+// RenderedMessage returns the message with its placeholders replaced by
+// the values in Variables (see RenderMessage). Message itself stays the
+// template, which is what entries are grouped by.
+func (e *WatchdogEntry) RenderedMessage() string {
+	if e.rendered == "" {
+		e.rendered = RenderMessage(e.Message, e.Variables)
+	}
+	return e.rendered
+}
+
 // SeverityName returns the human-readable severity name.
 func (e *WatchdogEntry) SeverityName() string {
 	if name, ok := SeverityName[e.Severity]; ok {
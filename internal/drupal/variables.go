@@ -0,0 +1,436 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package drupal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olegiv/logwatch-ai-go/internal/ai"
+)
+
+// Limits of the placeholder variables decoder. Variables come from the
+// watchdog table, which any code on the site can write to, so decoding is
+// bounded instead of trusting the serialized lengths and nesting.
+const (
+	// maxVariablesBytes is the largest serialized variables value that is
+	// decoded; messages with larger values are shown as templates.
+	maxVariablesBytes = 64 * 1024
+
+	// maxVariablesDepth is the deepest nesting of arrays and objects.
+	maxVariablesDepth = 8
+
+	// maxVariablesItems is the total number of decoded values, nested included.
+	maxVariablesItems = 512
+
+	// maxPlaceholderValueLen is the number of characters kept of a
+	// substituted value, so a backtrace cannot crowd out the other entries.
+	maxPlaceholderValueLen = 300
+)
+
+// isPlaceholder reports whether a variables key is a message placeholder:
+// @escaped, %emphasized, :url, or !raw (Drupal 7).
+func isPlaceholder(key string) bool {
+	return len(key) > 1 && strings.ContainsRune("@%:!", rune(key[0]))
+}
+
+// RenderMessage replaces the placeholders of a watchdog message template,
+// such as "%type: @message in %function (line %line of %file)", with the
+// values in variables, the PHP-serialized array (a:N:{...}) or JSON object
+// stored by Drupal. Substituted values are sanitized, flattened to one
+// line, and truncated. The template is returned unchanged if it has no
+// placeholders or the variables cannot be decoded.
+func RenderMessage(message, variables string) string {
+	if !strings.ContainsAny(message, "@%:!") {
+		return message
+	}
+	values, err := ParseVariables(variables)
+	if err != nil || len(values) == 0 {
+		return message
+	}
+
+	// Longest placeholders first, like PHP strtr(), so "@message" is not
+	// replaced by the value of "@mess"
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+
+	pairs := make([]string, 0, 2*len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key, cleanPlaceholderValue(values[key]))
+	}
+	return strings.NewReplacer(pairs...).Replace(message)
+}
+
+// cleanPlaceholderValue prepares a substituted value for the analysis
+// content: sanitized against prompt injection, whitespace collapsed to
+// keep entries on one line, and truncated.
+func cleanPlaceholderValue(value string) string {
+	value = strings.Join(strings.Fields(ai.SanitizeLogContent(value)), " ")
+	if runes := []rune(value); len(runes) > maxPlaceholderValueLen {
+		value = string(runes[:maxPlaceholderValueLen-3]) + "..."
+	}
+	return value
+}
+
+// ParseVariables decodes the placeholder values of a watchdog entry.
+// Both the PHP-serialized arrays of the dblog module and JSON objects are
+// supported; only keys that are placeholders are returned. Scalars are
+// converted to strings like PHP would, nested arrays become "Array", and
+// objects their string property (as in TranslatableMarkup) or class name.
+// PHP objects are never instantiated; their serialized form is only read.
+func ParseVariables(variables string) (map[string]string, error) {
+	variables = strings.TrimSpace(variables)
+	if variables == "" || variables == "N;" {
+		return nil, nil
+	}
+	if len(variables) > maxVariablesBytes {
+		return nil, fmt.Errorf("variables exceed %d bytes", maxVariablesBytes)
+	}
+
+	var (
+		values map[string]string
+		err    error
+	)
+	if variables[0] == '{' {
+		values, err = parseJSONVariables(variables)
+	} else {
+		values, err = parsePHPVariables(variables)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for key := range values {
+		if !isPlaceholder(key) {
+			delete(values, key)
+		}
+	}
+	return values, nil
+}
+
+// parseJSONVariables decodes a JSON object of placeholder values.
+func parseJSONVariables(variables string) (map[string]string, error) {
+	decoder := json.NewDecoder(strings.NewReader(variables))
+	decoder.UseNumber()
+
+	var raw map[string]any
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("invalid JSON variables: %w", err)
+	}
+	if len(raw) > maxVariablesItems {
+		return nil, fmt.Errorf("variables have more than %d values", maxVariablesItems)
+	}
+
+	values := make(map[string]string, len(raw))
+	for key, value := range raw {
+		switch v := value.(type) {
+		case string:
+			values[key] = v
+		case json.Number:
+			values[key] = v.String()
+		case bool:
+			values[key] = phpBool(v)
+		case nil:
+			values[key] = ""
+		default:
+			values[key] = "Array"
+		}
+	}
+	return values, nil
+}
+
+// parsePHPVariables decodes a PHP-serialized array of placeholder values.
+func parsePHPVariables(variables string) (map[string]string, error) {
+	d := &phpDecoder{data: []byte(variables)}
+	if d.peek() != 'a' {
+		// A scalar or object instead of an array has no placeholders
+		if _, err := d.value(0); err != nil {
+			return nil, err
+		}
+		return nil, d.end()
+	}
+
+	values, err := d.array(0)
+	if err != nil {
+		return nil, err
+	}
+	return values, d.end()
+}
+
+// phpDecoder reads the PHP serialize() format without instantiating
+// anything. Nested values are reduced to the strings PHP would print.
+type phpDecoder struct {
+	data  []byte
+	pos   int
+	items int
+}
+
+func (d *phpDecoder) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid serialized variables at offset %d: %s", d.pos, fmt.Sprintf(format, args...))
+}
+
+func (d *phpDecoder) peek() byte {
+	if d.pos >= len(d.data) {
+		return 0
+	}
+	return d.data[d.pos]
+}
+
+// end checks that the whole input was consumed.
+func (d *phpDecoder) end() error {
+	if d.pos != len(d.data) {
+		return d.errorf("unexpected trailing data")
+	}
+	return nil
+}
+
+// expect consumes the given literal.
+func (d *phpDecoder) expect(literal string) error {
+	if !bytes.HasPrefix(d.data[d.pos:], []byte(literal)) {
+		return d.errorf("expected %q", literal)
+	}
+	d.pos += len(literal)
+	return nil
+}
+
+// until consumes and returns the bytes before the next delimiter.
+func (d *phpDecoder) until(delimiter byte) (string, error) {
+	end := bytes.IndexByte(d.data[d.pos:], delimiter)
+	if end < 0 {
+		return "", d.errorf("missing %q", delimiter)
+	}
+	token := string(d.data[d.pos : d.pos+end])
+	d.pos += end + 1
+	return token, nil
+}
+
+// length reads a non-negative length followed by the delimiter.
+func (d *phpDecoder) length(delimiter byte) (int, error) {
+	token, err := d.until(delimiter)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(token)
+	if err != nil || n < 0 {
+		return 0, d.errorf("invalid length %q", token)
+	}
+	return n, nil
+}
+
+// quoted reads a string of n bytes in double quotes, as in s:N:"...".
+func (d *phpDecoder) quoted(n int) (string, error) {
+	if err := d.expect(`"`); err != nil {
+		return "", err
+	}
+	if n > len(d.data)-d.pos {
+		return "", d.errorf("string length %d exceeds the input", n)
+	}
+	s := string(d.data[d.pos : d.pos+n])
+	d.pos += n
+	if err := d.expect(`"`); err != nil {
+		return "", err
+	}
+	return s, nil
+}
+
+// value decodes one value and returns its string form.
+func (d *phpDecoder) value(depth int) (string, error) {
+	if d.items++; d.items > maxVariablesItems {
+		return "", fmt.Errorf("variables have more than %d values", maxVariablesItems)
+	}
+
+	kind := d.peek()
+	switch kind {
+	case 'N':
+		return "", d.expect("N;")
+	case 'b', 'i', 'd', 'r', 'R':
+		d.pos++
+		if err := d.expect(":"); err != nil {
+			return "", err
+		}
+		token, err := d.until(';')
+		if err != nil {
+			return "", err
+		}
+		switch kind {
+		case 'b':
+			return phpBool(token == "1"), nil
+		case 'i', 'd':
+			return token, nil
+		default:
+			// References to other values are not resolved
+			return "", nil
+		}
+	case 's':
+		d.pos++
+		if err := d.expect(":"); err != nil {
+			return "", err
+		}
+		n, err := d.length(':')
+		if err != nil {
+			return "", err
+		}
+		s, err := d.quoted(n)
+		if err != nil {
+			return "", err
+		}
+		return s, d.expect(";")
+	case 'E':
+		// Enum case, E:N:"Class:Case";
+		d.pos++
+		if err := d.expect(":"); err != nil {
+			return "", err
+		}
+		n, err := d.length(':')
+		if err != nil {
+			return "", err
+		}
+		name, err := d.quoted(n)
+		if err != nil {
+			return "", err
+		}
+		return name, d.expect(";")
+	case 'a':
+		if _, err := d.array(depth); err != nil {
+			return "", err
+		}
+		return "Array", nil
+	case 'O':
+		return d.object(depth)
+	case 'C':
+		// Custom serialization, C:N:"Class":M:{data}; the data is opaque
+		d.pos++
+		if err := d.expect(":"); err != nil {
+			return "", err
+		}
+		n, err := d.length(':')
+		if err != nil {
+			return "", err
+		}
+		class, err := d.quoted(n)
+		if err != nil {
+			return "", err
+		}
+		if err := d.expect(":"); err != nil {
+			return "", err
+		}
+		m, err := d.length(':')
+		if err != nil {
+			return "", err
+		}
+		if err := d.expect("{"); err != nil {
+			return "", err
+		}
+		if m > len(d.data)-d.pos {
+			return "", d.errorf("data length %d exceeds the input", m)
+		}
+		d.pos += m
+		return class, d.expect("}")
+	default:
+		return "", d.errorf("unsupported type %q", kind)
+	}
+}
+
+// members decodes the n key/value pairs of an array or object body.
+func (d *phpDecoder) members(n, depth int) (map[string]string, error) {
+	if depth >= maxVariablesDepth {
+		return nil, fmt.Errorf("variables are nested deeper than %d levels", maxVariablesDepth)
+	}
+	if n > maxVariablesItems {
+		return nil, fmt.Errorf("variables have more than %d values", maxVariablesItems)
+	}
+	if err := d.expect("{"); err != nil {
+		return nil, err
+	}
+
+	members := make(map[string]string, n)
+	for range n {
+		var key string
+		switch d.peek() {
+		case 'i', 's':
+			k, err := d.value(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			key = k
+		default:
+			return nil, d.errorf("invalid key type %q", d.peek())
+		}
+		value, err := d.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		members[key] = value
+	}
+	return members, d.expect("}")
+}
+
+// array decodes a:N:{...}.
+func (d *phpDecoder) array(depth int) (map[string]string, error) {
+	if err := d.expect("a:"); err != nil {
+		return nil, err
+	}
+	n, err := d.length(':')
+	if err != nil {
+		return nil, err
+	}
+	return d.members(n, depth)
+}
+
+// object decodes O:N:"Class":M:{...} and returns the string property of
+// markup objects or the class name.
+func (d *phpDecoder) object(depth int) (string, error) {
+	if err := d.expect("O:"); err != nil {
+		return "", err
+	}
+	n, err := d.length(':')
+	if err != nil {
+		return "", err
+	}
+	class, err := d.quoted(n)
+	if err != nil {
+		return "", err
+	}
+	if err := d.expect(":"); err != nil {
+		return "", err
+	}
+	m, err := d.length(':')
+	if err != nil {
+		return "", err
+	}
+	properties, err := d.members(m, depth)
+	if err != nil {
+		return "", err
+	}
+
+	// Protected and private property names are prefixed with "\0*\0" or
+	// "\0Class\0"; TranslatableMarkup and FormattableMarkup keep their
+	// text in "string"
+	for name, value := range properties {
+		if name == "string" || strings.HasSuffix(name, "\x00string") {
+			return value, nil
+		}
+	}
+	return class, nil
+}
+
+// phpBool converts a boolean to a string the way PHP does.
+func phpBool(b bool) string {
+	if b {
+		return "1"
+	}
+	return ""
+}
@@ -0,0 +1,210 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package eval scores LLM analyses against golden log fixtures so provider
+// and model choices can be compared on status accuracy, recall of expected
+// findings, cost, and latency.
+//
+// A case is a directory holding a case.json manifest and the log input it
+// references. The manifest names the log source type, the expected system
+// status, and the findings a good analysis must report, each matched by
+// case-insensitive RE2 patterns against the analysis' critical issues and
+// warnings. Running the input through readers, preprocessors, and providers
+// is left to the caller (see AnalyzeFunc) so this package stays independent
+// of provider wiring.
+package eval
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/olegiv/logwatch-ai-go/internal/ai"
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+)
+
+// CaseFileName is the manifest file name inside each case directory.
+const CaseFileName = "case.json"
+
+// maxCaseFileSize caps case.json size; manifests are small and hand-written.
+const maxCaseFileSize = 1 << 20 // 1 MiB
+
+// Finding severities used by ExpectedFinding.Severity.
+const (
+	SeverityCritical = "critical"
+	SeverityWarning  = "warning"
+)
+
+// Case is one golden fixture: a log input and the analysis it should produce.
+type Case struct {
+	Name string `json:"-"` // Directory name
+	Dir  string `json:"-"` // Case directory path
+
+	SourceType   string `json:"source_type"`
+	Input        string `json:"input"`                   // Log file, relative to Dir
+	DrupalFormat string `json:"drupal_format,omitempty"` // "json" (default) or "drush"
+	SiteName     string `json:"site_name,omitempty"`
+	Description  string `json:"description,omitempty"`
+
+	ExpectedStatus     string            `json:"expected_status"`
+	AcceptableStatuses []string          `json:"acceptable_statuses,omitempty"`
+	ExpectedFindings   []ExpectedFinding `json:"expected_findings"`
+	ForbiddenFindings  []string          `json:"forbidden_findings,omitempty"`
+
+	forbidden []*regexp.Regexp
+}
+
+// ExpectedFinding is an issue a good analysis must report. Any one of the
+// Match patterns matching a finding counts as a hit.
+type ExpectedFinding struct {
+	Name     string   `json:"name"`
+	Severity string   `json:"severity,omitempty"` // "critical", "warning", or empty for either
+	Match    []string `json:"match"`
+
+	patterns []*regexp.Regexp
+}
+
+// InputPath returns the absolute or working-directory-relative input path.
+func (c *Case) InputPath() string {
+	return filepath.Join(c.Dir, c.Input)
+}
+
+// LoadCases loads every case directory under dir, sorted by name.
+// Subdirectories without a case.json are skipped.
+func LoadCases(dir string) ([]*Case, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read eval cases directory: %w", err)
+	}
+
+	var cases []*Case
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		caseDir := filepath.Join(dir, entry.Name())
+		if _, err := os.Stat(filepath.Join(caseDir, CaseFileName)); os.IsNotExist(err) {
+			continue
+		}
+
+		c, err := LoadCase(caseDir)
+		if err != nil {
+			return nil, err
+		}
+		cases = append(cases, c)
+	}
+
+	if len(cases) == 0 {
+		return nil, fmt.Errorf("no eval cases found in %s", dir)
+	}
+
+	sort.Slice(cases, func(i, j int) bool { return cases[i].Name < cases[j].Name })
+	return cases, nil
+}
+
+// LoadCase loads and validates a single case directory.
+func LoadCase(dir string) (*Case, error) {
+	path := filepath.Join(dir, CaseFileName)
+	f, err := os.Open(path) // #nosec G304 -- operator-selected eval directory
+	if err != nil {
+		return nil, fmt.Errorf("failed to open eval case: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxCaseFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read eval case %s: %w", path, err)
+	}
+	if len(data) > maxCaseFileSize {
+		return nil, fmt.Errorf("eval case %s exceeds %d bytes", path, maxCaseFileSize)
+	}
+
+	c := &Case{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("failed to parse eval case %s: %w", path, err)
+	}
+	c.Name = filepath.Base(dir)
+	c.Dir = dir
+
+	if err := c.validate(); err != nil {
+		return nil, fmt.Errorf("invalid eval case %s: %w", c.Name, err)
+	}
+
+	return c, nil
+}
+
+// validate checks the manifest and compiles its patterns.
+func (c *Case) validate() error {
+	if _, err := analyzer.ParseSourceType(c.SourceType); err != nil {
+		return err
+	}
+
+	if c.Input == "" {
+		return fmt.Errorf("input is required")
+	}
+	if !filepath.IsLocal(c.Input) {
+		return fmt.Errorf("input must be a path inside the case directory: %s", c.Input)
+	}
+
+	switch c.DrupalFormat {
+	case "", "json", "drush":
+	default:
+		return fmt.Errorf("drupal_format must be 'json' or 'drush' (got: %s)", c.DrupalFormat)
+	}
+
+	if ai.StatusRank(c.ExpectedStatus) < 0 {
+		return fmt.Errorf("expected_status is not a valid status: %q", c.ExpectedStatus)
+	}
+	for _, status := range c.AcceptableStatuses {
+		if ai.StatusRank(status) < 0 {
+			return fmt.Errorf("acceptable_statuses contains invalid status: %q", status)
+		}
+	}
+
+	for i := range c.ExpectedFindings {
+		f := &c.ExpectedFindings[i]
+		if f.Name == "" {
+			return fmt.Errorf("expected_findings[%d]: name is required", i)
+		}
+		switch f.Severity {
+		case "", SeverityCritical, SeverityWarning:
+		default:
+			return fmt.Errorf("expected finding %q: severity must be 'critical', 'warning', or empty", f.Name)
+		}
+		if len(f.Match) == 0 {
+			return fmt.Errorf("expected finding %q: at least one match pattern is required", f.Name)
+		}
+		patterns, err := compilePatterns(f.Match)
+		if err != nil {
+			return fmt.Errorf("expected finding %q: %w", f.Name, err)
+		}
+		f.patterns = patterns
+	}
+
+	forbidden, err := compilePatterns(c.ForbiddenFindings)
+	if err != nil {
+		return fmt.Errorf("forbidden_findings: %w", err)
+	}
+	c.forbidden = forbidden
+
+	return nil
+}
+
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		if p == "" {
+			return nil, fmt.Errorf("empty pattern")
+		}
+		re, err := regexp.Compile("(?i)" + p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", p, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
@@ -0,0 +1,292 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package eval
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/olegiv/logwatch-ai-go/internal/ai"
+)
+
+func writeCase(t *testing.T, root, name, manifest string) string {
+	t.Helper()
+	dir := filepath.Join(root, name)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, CaseFileName), []byte(manifest), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return dir
+}
+
+const validManifest = `{
+  "source_type": "logwatch",
+  "input": "logwatch.txt",
+  "expected_status": "Bad",
+  "acceptable_statuses": ["Awful"],
+  "expected_findings": [
+    {"name": "ssh brute force", "severity": "critical", "match": ["brute", "failed login"]},
+    {"name": "disk full", "match": ["disk"]}
+  ],
+  "forbidden_findings": ["kernel panic"]
+}`
+
+func TestLoadCases(t *testing.T) {
+	root := t.TempDir()
+	writeCase(t, root, "b-case", validManifest)
+	writeCase(t, root, "a-case", validManifest)
+	if err := os.MkdirAll(filepath.Join(root, "not-a-case"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	cases, err := LoadCases(root)
+	if err != nil {
+		t.Fatalf("LoadCases() error = %v", err)
+	}
+	if len(cases) != 2 || cases[0].Name != "a-case" || cases[1].Name != "b-case" {
+		t.Fatalf("unexpected cases: %+v", cases)
+	}
+	if cases[0].InputPath() != filepath.Join(root, "a-case", "logwatch.txt") {
+		t.Errorf("InputPath() = %s", cases[0].InputPath())
+	}
+}
+
+func TestLoadCases_Empty(t *testing.T) {
+	if _, err := LoadCases(t.TempDir()); err == nil {
+		t.Error("expected error for directory without cases")
+	}
+}
+
+func TestLoadCase_Invalid(t *testing.T) {
+	tests := []struct {
+		name          string
+		manifest      string
+		errorContains string
+	}{
+		{"bad source type", `{"source_type":"eventlog","input":"x","expected_status":"Good"}`, "invalid log source type"},
+		{"missing input", `{"source_type":"logwatch","expected_status":"Good"}`, "input is required"},
+		{"escaping input", `{"source_type":"logwatch","input":"../x","expected_status":"Good"}`, "inside the case directory"},
+		{"bad status", `{"source_type":"logwatch","input":"x","expected_status":"Fine"}`, "expected_status"},
+		{"bad acceptable status", `{"source_type":"logwatch","input":"x","expected_status":"Good","acceptable_statuses":["Meh"]}`, "acceptable_statuses"},
+		{"bad drupal format", `{"source_type":"drupal_watchdog","input":"x","drupal_format":"csv","expected_status":"Good"}`, "drupal_format"},
+		{"finding without patterns", `{"source_type":"logwatch","input":"x","expected_status":"Good","expected_findings":[{"name":"a"}]}`, "at least one match pattern"},
+		{"bad severity", `{"source_type":"logwatch","input":"x","expected_status":"Good","expected_findings":[{"name":"a","severity":"info","match":["a"]}]}`, "severity"},
+		{"bad regex", `{"source_type":"logwatch","input":"x","expected_status":"Good","expected_findings":[{"name":"a","match":["("]}]}`, "invalid pattern"},
+		{"malformed JSON", `{`, "failed to parse"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeCase(t, t.TempDir(), "case", tt.manifest)
+			_, err := LoadCase(dir)
+			if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("LoadCase() error = %v, want containing %q", err, tt.errorContains)
+			}
+		})
+	}
+}
+
+func loadValidCase(t *testing.T) *Case {
+	t.Helper()
+	c, err := LoadCase(writeCase(t, t.TempDir(), "case", validManifest))
+	if err != nil {
+		t.Fatalf("LoadCase() error = %v", err)
+	}
+	return c
+}
+
+func TestScore(t *testing.T) {
+	c := loadValidCase(t)
+
+	tests := []struct {
+		name              string
+		analysis          *ai.Analysis
+		wantStatusMatch   bool
+		wantDistance      int
+		wantMatched       int
+		wantMissed        int
+		wantMisclassified int
+		wantForbidden     int
+	}{
+		{
+			name: "perfect",
+			analysis: &ai.Analysis{
+				SystemStatus:   "Bad",
+				CriticalIssues: []string{"SSH brute force from 1.2.3.4"},
+				Warnings:       []string{"Disk at 95%"},
+			},
+			wantStatusMatch: true,
+			wantMatched:     2,
+		},
+		{
+			name: "acceptable status and misclassified finding",
+			analysis: &ai.Analysis{
+				SystemStatus: "Awful",
+				Warnings:     []string{"Many failed login attempts", "DISK nearly full"},
+			},
+			wantStatusMatch:   true,
+			wantDistance:      1,
+			wantMatched:       2,
+			wantMisclassified: 1,
+		},
+		{
+			name: "wrong status, missed finding, forbidden hit",
+			analysis: &ai.Analysis{
+				SystemStatus:   "Good",
+				CriticalIssues: []string{"Kernel panic on boot"},
+			},
+			wantDistance:  2,
+			wantMissed:    2,
+			wantForbidden: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := Score(c, tt.analysis)
+			if score.StatusMatch != tt.wantStatusMatch {
+				t.Errorf("StatusMatch = %v, want %v", score.StatusMatch, tt.wantStatusMatch)
+			}
+			if score.StatusDistance != tt.wantDistance {
+				t.Errorf("StatusDistance = %d, want %d", score.StatusDistance, tt.wantDistance)
+			}
+			if score.FindingsMatched != tt.wantMatched {
+				t.Errorf("FindingsMatched = %d, want %d", score.FindingsMatched, tt.wantMatched)
+			}
+			if len(score.Missed) != tt.wantMissed {
+				t.Errorf("Missed = %v, want %d", score.Missed, tt.wantMissed)
+			}
+			if len(score.Misclassified) != tt.wantMisclassified {
+				t.Errorf("Misclassified = %v, want %d", score.Misclassified, tt.wantMisclassified)
+			}
+			if len(score.ForbiddenHits) != tt.wantForbidden {
+				t.Errorf("ForbiddenHits = %v, want %d", score.ForbiddenHits, tt.wantForbidden)
+			}
+		})
+	}
+}
+
+func TestCaseScore_Recall(t *testing.T) {
+	if got := (CaseScore{}).Recall(); got != 1 {
+		t.Errorf("Recall() with no expected findings = %v, want 1", got)
+	}
+	if got := (CaseScore{FindingsExpected: 4, FindingsMatched: 1}).Recall(); got != 0.25 {
+		t.Errorf("Recall() = %v, want 0.25", got)
+	}
+}
+
+func TestParseTargets(t *testing.T) {
+	targets, err := ParseTargets("anthropic:claude-sonnet-4-6, ollama:llama3.3:latest,lmstudio")
+	if err != nil {
+		t.Fatalf("ParseTargets() error = %v", err)
+	}
+
+	want := []Target{
+		{Provider: "anthropic", Model: "claude-sonnet-4-6"},
+		{Provider: "ollama", Model: "llama3.3:latest"},
+		{Provider: "lmstudio"},
+	}
+	if len(targets) != len(want) {
+		t.Fatalf("got %d targets, want %d", len(targets), len(want))
+	}
+	for i := range want {
+		if targets[i] != want[i] {
+			t.Errorf("target %d = %+v, want %+v", i, targets[i], want[i])
+		}
+	}
+
+	if got := targets[1].Slug(); got != "ollama_llama3.3_latest" {
+		t.Errorf("Slug() = %q", got)
+	}
+
+	for _, spec := range []string{"", " , ", ":model", "ollama,ollama"} {
+		if _, err := ParseTargets(spec); err == nil {
+			t.Errorf("ParseTargets(%q) expected error", spec)
+		}
+	}
+}
+
+func TestRunAndReport(t *testing.T) {
+	c := loadValidCase(t)
+	targets := []Target{{Provider: "good"}, {Provider: "broken"}}
+
+	results, err := Run(context.Background(), []*Case{c}, targets, func(_ context.Context, target Target, _ *Case) (*ai.Analysis, *ai.Stats, error) {
+		if target.Provider == "broken" {
+			return nil, nil, fmt.Errorf("provider unavailable")
+		}
+		return &ai.Analysis{
+			SystemStatus:   "Bad",
+			CriticalIssues: []string{"SSH brute force"},
+		}, &ai.Stats{
+			Model:           "m1",
+			InputTokens:     1000,
+			OutputTokens:    200,
+			CostUSD:         0.002,
+			DurationSeconds: 3,
+		}, nil
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+
+	report := NewReport("live", results)
+	if len(report.Summaries) != 2 {
+		t.Fatalf("got %d summaries, want 2", len(report.Summaries))
+	}
+
+	good := report.Summaries[0]
+	if good.Target != "good" || good.StatusAccuracy != 1 || good.Recall != 0.5 || good.TotalCostUSD != 0.002 || good.MeanLatencySeconds != 3 {
+		t.Errorf("unexpected summary: %+v", good)
+	}
+	if broken := report.Summaries[1]; broken.Errors != 1 || broken.Recall != 0 {
+		t.Errorf("unexpected broken summary: %+v", broken)
+	}
+
+	var text bytes.Buffer
+	if err := report.WriteText(&text); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+	for _, want := range []string{"TARGET", "50% (1/2)", "missed disk full", "error: provider unavailable"} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("text report missing %q:\n%s", want, text.String())
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	var decoded Report
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("report JSON does not round-trip: %v", err)
+	}
+	if decoded.Summaries[0].FindingsMatched != 1 {
+		t.Errorf("decoded summary mismatch: %+v", decoded.Summaries[0])
+	}
+}
+
+func TestRun_ContextCanceled(t *testing.T) {
+	c := loadValidCase(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := Run(ctx, []*Case{c}, []Target{{Provider: "x"}}, func(context.Context, Target, *Case) (*ai.Analysis, *ai.Stats, error) {
+		t.Fatal("analyze should not be called after cancellation")
+		return nil, nil, nil
+	})
+	if err == nil {
+		t.Error("expected context error")
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package eval
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Result is the outcome of one case against one target.
+type Result struct {
+	Target          string    `json:"target"`
+	Model           string    `json:"model,omitempty"`
+	Case            string    `json:"case"`
+	SourceType      string    `json:"source_type"`
+	Status          string    `json:"status,omitempty"`
+	Score           CaseScore `json:"score"`
+	Error           string    `json:"error,omitempty"`
+	InputTokens     int       `json:"input_tokens"`
+	OutputTokens    int       `json:"output_tokens"`
+	CostUSD         float64   `json:"cost_usd"`
+	DurationSeconds float64   `json:"duration_seconds"`
+}
+
+// TargetSummary aggregates the results of one target across all cases.
+// Accuracy, distance, and recall are computed over successful cases only.
+type TargetSummary struct {
+	Target             string  `json:"target"`
+	Cases              int     `json:"cases"`
+	Errors             int     `json:"errors"`
+	StatusAccuracy     float64 `json:"status_accuracy"`
+	MeanStatusDistance float64 `json:"mean_status_distance"`
+	FindingsExpected   int     `json:"findings_expected"`
+	FindingsMatched    int     `json:"findings_matched"`
+	Recall             float64 `json:"recall"`
+	Misclassified      int     `json:"misclassified"`
+	ForbiddenHits      int     `json:"forbidden_hits"`
+	InputTokens        int     `json:"input_tokens"`
+	OutputTokens       int     `json:"output_tokens"`
+	TotalCostUSD       float64 `json:"total_cost_usd"`
+	MeanLatencySeconds float64 `json:"mean_latency_seconds"`
+}
+
+// Report is the comparison report for an eval run.
+type Report struct {
+	GeneratedAt time.Time       `json:"generated_at"`
+	Mode        string          `json:"mode"` // "live", "record", or "replay"
+	Summaries   []TargetSummary `json:"summaries"`
+	Results     []Result        `json:"results"`
+}
+
+// NewReport aggregates results into per-target summaries, keeping targets
+// in the order they were evaluated.
+func NewReport(mode string, results []Result) *Report {
+	report := &Report{
+		GeneratedAt: time.Now().UTC(),
+		Mode:        mode,
+		Results:     results,
+	}
+
+	index := make(map[string]int)
+	for _, r := range results {
+		i, ok := index[r.Target]
+		if !ok {
+			i = len(report.Summaries)
+			index[r.Target] = i
+			report.Summaries = append(report.Summaries, TargetSummary{Target: r.Target})
+		}
+		s := &report.Summaries[i]
+		s.Cases++
+		if r.Error != "" {
+			s.Errors++
+			continue
+		}
+		if r.Score.StatusMatch {
+			s.StatusAccuracy++
+		}
+		s.MeanStatusDistance += float64(r.Score.StatusDistance)
+		s.FindingsExpected += r.Score.FindingsExpected
+		s.FindingsMatched += r.Score.FindingsMatched
+		s.Misclassified += len(r.Score.Misclassified)
+		s.ForbiddenHits += len(r.Score.ForbiddenHits)
+		s.InputTokens += r.InputTokens
+		s.OutputTokens += r.OutputTokens
+		s.TotalCostUSD += r.CostUSD
+		s.MeanLatencySeconds += r.DurationSeconds
+	}
+
+	for i := range report.Summaries {
+		s := &report.Summaries[i]
+		succeeded := float64(s.Cases - s.Errors)
+		if succeeded > 0 {
+			s.StatusAccuracy /= succeeded
+			s.MeanStatusDistance /= succeeded
+			s.MeanLatencySeconds /= succeeded
+		}
+		if s.FindingsExpected > 0 {
+			s.Recall = float64(s.FindingsMatched) / float64(s.FindingsExpected)
+		} else if succeeded > 0 {
+			s.Recall = 1
+		}
+	}
+
+	return report
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteText writes a human-readable comparison table followed by per-case
+// details for anything that was missed, misclassified, or failed.
+func (r *Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintf(tw, "Eval report (%s mode, %s)\n\n", r.Mode, r.GeneratedAt.Format(time.RFC3339))
+	_, _ = fmt.Fprintln(tw, "TARGET\tCASES\tERRORS\tSTATUS ACC\tSTATUS DIST\tRECALL\tMISCLASS\tFORBIDDEN\tTOKENS IN/OUT\tCOST USD\tAVG LATENCY")
+	for _, s := range r.Summaries {
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%d\t%.0f%%\t%.2f\t%.0f%% (%d/%d)\t%d\t%d\t%d/%d\t$%.4f\t%.1fs\n",
+			s.Target, s.Cases, s.Errors,
+			s.StatusAccuracy*100, s.MeanStatusDistance,
+			s.Recall*100, s.FindingsMatched, s.FindingsExpected,
+			s.Misclassified, s.ForbiddenHits,
+			s.InputTokens, s.OutputTokens,
+			s.TotalCostUSD, s.MeanLatencySeconds)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	var details strings.Builder
+	for _, res := range r.Results {
+		if line := resultDetail(res); line != "" {
+			details.WriteString(line)
+		}
+	}
+	if details.Len() > 0 {
+		if _, err := fmt.Fprintf(w, "\nDetails:\n%s", details.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resultDetail(res Result) string {
+	prefix := fmt.Sprintf("  [%s] %s: ", res.Target, res.Case)
+	if res.Error != "" {
+		return prefix + "error: " + res.Error + "\n"
+	}
+
+	var parts []string
+	if !res.Score.StatusMatch {
+		parts = append(parts, fmt.Sprintf("status %s (off by %d)", res.Status, res.Score.StatusDistance))
+	}
+	if len(res.Score.Missed) > 0 {
+		parts = append(parts, "missed "+strings.Join(res.Score.Missed, ", "))
+	}
+	if len(res.Score.Misclassified) > 0 {
+		parts = append(parts, "misclassified "+strings.Join(res.Score.Misclassified, ", "))
+	}
+	if len(res.Score.ForbiddenHits) > 0 {
+		parts = append(parts, "forbidden "+strings.Join(res.Score.ForbiddenHits, ", "))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+
+	return prefix + strings.Join(parts, "; ") + "\n"
+}
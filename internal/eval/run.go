@@ -0,0 +1,102 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package eval
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/olegiv/logwatch-ai-go/internal/ai"
+	"github.com/olegiv/logwatch-ai-go/internal/config"
+)
+
+// Target identifies a provider and optional model to evaluate, written on
+// the command line as "provider" or "provider:model". Only the first colon
+// separates the two, so Ollama tags like "llama3.3:latest" are preserved.
+type Target struct {
+	Provider string
+	Model    string
+}
+
+// Label returns the command-line form of the target.
+func (t Target) Label() string {
+	if t.Model == "" {
+		return t.Provider
+	}
+	return t.Provider + ":" + t.Model
+}
+
+// Slug returns a file-system safe name for the target, used as the
+// recording subdirectory.
+func (t Target) Slug() string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, t.Label())
+}
+
+// ParseTargets parses a comma-separated list of "provider[:model]" targets.
+func ParseTargets(spec string) ([]Target, error) {
+	specs, err := config.ParseProviderSpecs(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid eval targets: %w", err)
+	}
+
+	targets := make([]Target, 0, len(specs))
+	for _, ps := range specs {
+		targets = append(targets, Target{Provider: ps.Provider, Model: ps.Model})
+	}
+
+	return targets, nil
+}
+
+// AnalyzeFunc runs one case against one target and returns the analysis.
+// The returned stats feed the cost and latency columns of the report.
+type AnalyzeFunc func(ctx context.Context, target Target, c *Case) (*ai.Analysis, *ai.Stats, error)
+
+// Run evaluates every case against every target. Per-case failures are
+// recorded in the results rather than aborting the run; only context
+// cancellation stops it early.
+func Run(ctx context.Context, cases []*Case, targets []Target, analyze AnalyzeFunc) ([]Result, error) {
+	results := make([]Result, 0, len(cases)*len(targets))
+
+	for _, target := range targets {
+		for _, c := range cases {
+			if err := ctx.Err(); err != nil {
+				return results, err
+			}
+
+			result := Result{
+				Target:     target.Label(),
+				Case:       c.Name,
+				SourceType: c.SourceType,
+			}
+
+			analysis, stats, err := analyze(ctx, target, c)
+			if err != nil {
+				result.Error = err.Error()
+				results = append(results, result)
+				continue
+			}
+
+			result.Status = analysis.SystemStatus
+			result.Score = Score(c, analysis)
+			if stats != nil {
+				result.Model = stats.Model
+				result.InputTokens = stats.InputTokens
+				result.OutputTokens = stats.OutputTokens
+				result.CostUSD = stats.CostUSD
+				result.DurationSeconds = stats.DurationSeconds
+			}
+			results = append(results, result)
+		}
+	}
+
+	return results, nil
+}
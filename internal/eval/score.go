@@ -0,0 +1,88 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package eval
+
+import (
+	"regexp"
+	"slices"
+
+	"github.com/olegiv/logwatch-ai-go/internal/ai"
+)
+
+// CaseScore holds how well one analysis matched its case.
+type CaseScore struct {
+	StatusMatch      bool     `json:"status_match"`
+	StatusDistance   int      `json:"status_distance"` // Rank steps between actual and expected status
+	FindingsExpected int      `json:"findings_expected"`
+	FindingsMatched  int      `json:"findings_matched"`
+	Misclassified    []string `json:"misclassified,omitempty"` // Found, but under the other severity
+	Missed           []string `json:"missed,omitempty"`
+	ForbiddenHits    []string `json:"forbidden_hits,omitempty"`
+}
+
+// Recall returns the fraction of expected findings that were reported.
+// Cases without expected findings have a recall of 1.
+func (s CaseScore) Recall() float64 {
+	if s.FindingsExpected == 0 {
+		return 1
+	}
+	return float64(s.FindingsMatched) / float64(s.FindingsExpected)
+}
+
+// Score compares an analysis against the case expectations.
+func Score(c *Case, analysis *ai.Analysis) CaseScore {
+	score := CaseScore{
+		FindingsExpected: len(c.ExpectedFindings),
+		StatusMatch:      analysis.SystemStatus == c.ExpectedStatus || slices.Contains(c.AcceptableStatuses, analysis.SystemStatus),
+		StatusDistance:   statusDistance(analysis.SystemStatus, c.ExpectedStatus),
+	}
+
+	for _, expected := range c.ExpectedFindings {
+		inCritical := matchesAny(expected.patterns, analysis.CriticalIssues)
+		inWarnings := matchesAny(expected.patterns, analysis.Warnings)
+
+		switch {
+		case !inCritical && !inWarnings:
+			score.Missed = append(score.Missed, expected.Name)
+			continue
+		case expected.Severity == SeverityCritical && !inCritical,
+			expected.Severity == SeverityWarning && !inWarnings:
+			score.Misclassified = append(score.Misclassified, expected.Name)
+		}
+		score.FindingsMatched++
+	}
+
+	for i, re := range c.forbidden {
+		patterns := []*regexp.Regexp{re}
+		if matchesAny(patterns, analysis.CriticalIssues) || matchesAny(patterns, analysis.Warnings) {
+			score.ForbiddenHits = append(score.ForbiddenHits, c.ForbiddenFindings[i])
+		}
+	}
+
+	return score
+}
+
+func matchesAny(patterns []*regexp.Regexp, findings []string) bool {
+	for _, finding := range findings {
+		for _, re := range patterns {
+			if re.MatchString(finding) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// statusDistance returns the absolute rank difference between two statuses,
+// or the full scale width when the actual status is unknown.
+func statusDistance(actual, expected string) int {
+	a, e := ai.StatusRank(actual), ai.StatusRank(expected)
+	if a < 0 || e < 0 {
+		return ai.StatusRank("Awful")
+	}
+	if a > e {
+		return a - e
+	}
+	return e - a
+}
@@ -52,8 +52,10 @@ const maxConfigFileSize = 1 << 20 // 1 MiB
 
 // supportedVersions lists the exclusions.json schema versions this build
 // understands. "1.0" is accepted for backward compatibility; "1.1" adds the
-// optional `logwatch` and `drupal` scope lists; "1.2" adds optional `ocms`.
-var supportedVersions = []string{"1.0", "1.1", "1.2"}
+// optional `logwatch` and `drupal` scope lists; "1.2" adds optional `ocms`;
+// "1.3" adds optional `journald`; "1.4" adds optional `access_log`; "1.5"
+// adds optional `syslog`; "1.6" adds optional `docker`.
+var supportedVersions = []string{"1.0", "1.1", "1.2", "1.3", "1.4", "1.5", "1.6"}
 
 // maxPatternsPerList caps the number of patterns allowed in any single list
 // (global, logwatch, drupal, or a single sites entry). Set to a value that
@@ -74,13 +76,18 @@ const maxPatternRunes = 200
 // Resolution:
 //   - logwatch runs: global (system) + logwatch (user)
 //   - drupal runs:   global (system) + drupal + sites[siteID] (both user)
+//   - access_log:    global (system) + access_log + sites[siteID] (both user)
 type Config struct {
-	Version  string              `json:"version"`
-	Global   []string            `json:"global,omitempty"`
-	Logwatch []string            `json:"logwatch,omitempty"`
-	Drupal   []string            `json:"drupal,omitempty"`
-	OCMS     []string            `json:"ocms,omitempty"`
-	Sites    map[string][]string `json:"sites,omitempty"`
+	Version   string              `json:"version"`
+	Global    []string            `json:"global,omitempty"`
+	Logwatch  []string            `json:"logwatch,omitempty"`
+	Drupal    []string            `json:"drupal,omitempty"`
+	OCMS      []string            `json:"ocms,omitempty"`
+	Journald  []string            `json:"journald,omitempty"`
+	AccessLog []string            `json:"access_log,omitempty"`
+	Syslog    []string            `json:"syslog,omitempty"`
+	Docker    []string            `json:"docker,omitempty"`
+	Sites     map[string][]string `json:"sites,omitempty"`
 }
 
 // Validate checks the configuration for structural errors. It is called
@@ -107,6 +114,18 @@ func (c *Config) Validate() error {
 	if err := validatePatternList("ocms", c.OCMS); err != nil {
 		return err
 	}
+	if err := validatePatternList("journald", c.Journald); err != nil {
+		return err
+	}
+	if err := validatePatternList("access_log", c.AccessLog); err != nil {
+		return err
+	}
+	if err := validatePatternList("syslog", c.Syslog); err != nil {
+		return err
+	}
+	if err := validatePatternList("docker", c.Docker); err != nil {
+		return err
+	}
 
 	for siteID, patterns := range c.Sites {
 		if strings.TrimSpace(siteID) == "" {
@@ -177,8 +196,13 @@ func (c *Config) GlobalPatterns() []string {
 //   - logType == analyzer.LogSourceLogwatch:       c.Logwatch
 //   - logType == analyzer.LogSourceDrupalWatchdog: c.Drupal + c.Sites[siteID]
 //   - logType == analyzer.LogSourceOCMS:           c.OCMS
+//   - logType == analyzer.LogSourceJournald:       c.Journald
+//   - logType == analyzer.LogSourceAccessLog:      c.AccessLog + c.Sites[siteID]
+//   - logType == analyzer.LogSourceSyslog:         c.Syslog
+//   - logType == analyzer.LogSourceDocker:         c.Docker
 //
-// An empty or unknown siteID for drupal_watchdog returns just c.Drupal.
+// An empty or unknown siteID for drupal_watchdog returns just c.Drupal
+// (c.AccessLog for access_log).
 // Other logTypes return nil (defensive).
 //
 // Each source list is sanitized independently before merging so that the
@@ -201,6 +225,18 @@ func (c *Config) ContextualPatterns(logType analyzer.LogSourceType, siteID strin
 		return out
 	case analyzer.LogSourceOCMS:
 		return sanitizePatternsForPrompt(c.OCMS)
+	case analyzer.LogSourceJournald:
+		return sanitizePatternsForPrompt(c.Journald)
+	case analyzer.LogSourceAccessLog:
+		out := sanitizePatternsForPrompt(c.AccessLog)
+		if siteID != "" {
+			out = append(out, sanitizePatternsForPrompt(c.Sites[siteID])...)
+		}
+		return out
+	case analyzer.LogSourceSyslog:
+		return sanitizePatternsForPrompt(c.Syslog)
+	case analyzer.LogSourceDocker:
+		return sanitizePatternsForPrompt(c.Docker)
 	default:
 		return nil
 	}
@@ -46,6 +46,28 @@ func TestConfig_Validate(t *testing.T) {
 				OCMS:    []string{"healthcheck timeout"},
 			},
 		},
+		{
+			name: "valid v1.4 with access_log scope",
+			cfg: Config{
+				Version:   "1.4",
+				AccessLog: []string{"uptime monitor 404s"},
+				Sites:     map[string][]string{"shop": {"legacy /feed 410"}},
+			},
+		},
+		{
+			name: "valid v1.5 with syslog scope",
+			cfg: Config{
+				Version: "1.5",
+				Syslog:  []string{"pam_unix(cron:session)"},
+			},
+		},
+		{
+			name: "valid v1.6 with docker scope",
+			cfg: Config{
+				Version: "1.6",
+				Docker:  []string{"healthcheck 200"},
+			},
+		},
 		{
 			name:    "missing version",
 			cfg:     Config{Global: []string{"foo"}},
@@ -216,11 +238,15 @@ func TestConfig_GlobalPatterns_ReturnsCopyNotReference(t *testing.T) {
 
 func TestConfig_ContextualPatterns(t *testing.T) {
 	cfg := &Config{
-		Version:  "1.1",
-		Global:   []string{"must-not-appear-in-contextual"},
-		Logwatch: []string{"kernel watchdog"},
-		Drupal:   []string{"deprecated function"},
-		OCMS:     []string{"request timeout"},
+		Version:   "1.1",
+		Global:    []string{"must-not-appear-in-contextual"},
+		Logwatch:  []string{"kernel watchdog"},
+		Drupal:    []string{"deprecated function"},
+		OCMS:      []string{"request timeout"},
+		Journald:  []string{"nm-dispatcher"},
+		AccessLog: []string{"uptime monitor"},
+		Syslog:    []string{"cron session opened"},
+		Docker:    []string{"healthcheck 200"},
 		Sites: map[string][]string{
 			"production": {"cron exceeded"},
 			"staging":    {"email delayed"},
@@ -275,6 +301,30 @@ func TestConfig_ContextualPatterns(t *testing.T) {
 			siteID:  "",
 			want:    []string{"request timeout"},
 		},
+		{
+			name:    "journald returns journald only",
+			logType: analyzer.LogSourceJournald,
+			siteID:  "production",
+			want:    []string{"nm-dispatcher"},
+		},
+		{
+			name:    "access_log with known siteID returns access_log + site in order",
+			logType: analyzer.LogSourceAccessLog,
+			siteID:  "production",
+			want:    []string{"uptime monitor", "cron exceeded"},
+		},
+		{
+			name:    "syslog returns syslog only",
+			logType: analyzer.LogSourceSyslog,
+			siteID:  "production",
+			want:    []string{"cron session opened"},
+		},
+		{
+			name:    "docker returns docker only",
+			logType: analyzer.LogSourceDocker,
+			siteID:  "production",
+			want:    []string{"healthcheck 200"},
+		},
 		{
 			name:    "unknown logType returns nil",
 			logType: analyzer.LogSourceType("unknown"),
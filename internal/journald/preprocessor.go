@@ -0,0 +1,39 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package journald
+
+import (
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+)
+
+// Compile-time interface check
+var (
+	_ analyzer.Preprocessor       = (*Preprocessor)(nil)
+	_ analyzer.BudgetPreprocessor = (*Preprocessor)(nil)
+)
+
+// sectionPriority returns the priority of a section written by
+// formatEntriesForAnalysis. Unknown sections are low priority.
+func sectionPriority(name string) int {
+	switch name {
+	case "Summary Statistics", "Priority Breakdown", "Critical/Error Entries":
+		return analyzer.SectionPriorityHigh
+	case "Units", "Warning Entries":
+		return analyzer.SectionPriorityMedium
+	default:
+		return analyzer.SectionPriorityLow
+	}
+}
+
+// Preprocessor handles journal content preprocessing for large exports.
+// It keeps the statistics and error entries and shortens the unit,
+// warning, and notice/info lists first.
+type Preprocessor struct {
+	*analyzer.SectionPreprocessor
+}
+
+// NewPreprocessor creates a new journal preprocessor.
+func NewPreprocessor(maxTokens int) *Preprocessor {
+	return &Preprocessor{analyzer.NewSectionPreprocessor(maxTokens, sectionPriority)}
+}
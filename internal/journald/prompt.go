@@ -0,0 +1,129 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package journald
+
+import (
+	"strings"
+
+	"github.com/olegiv/logwatch-ai-go/internal/ai"
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+)
+
+// Compile-time interface check
+var _ analyzer.PromptBuilder = (*PromptBuilder)(nil)
+
+// PromptBuilder implements analyzer.PromptBuilder for systemd journal analysis.
+type PromptBuilder struct{}
+
+// NewPromptBuilder creates a new journal prompt builder.
+func NewPromptBuilder() *PromptBuilder {
+	return &PromptBuilder{}
+}
+
+// GetLogType returns the log type identifier.
+func (p *PromptBuilder) GetLogType() string {
+	return "journald"
+}
+
+// GetSystemPrompt returns the system prompt for systemd journal analysis.
+func (p *PromptBuilder) GetSystemPrompt(globalExclusions []string) string {
+	return `You are a senior Linux system administrator and security analyst with expertise in systemd-based systems. Your role is to analyze systemd journal summaries and provide actionable insights.
+
+**Input Format:**
+The journal is pre-aggregated: entries are grouped by unit, priority, and message pattern. A line like
+"- [12x, 2026-01-01 02:00:00 to 2026-01-01 03:10:00] sshd.service [err]: ..." stands for 12 similar messages in that time range.
+
+**Journal Priority Levels (syslog):**
+- 0 emerg, 1 alert, 2 crit, 3 err: critical and error conditions
+- 4 warning: warning conditions
+- 5 notice, 6 info: normal operation
+- 7 debug: debug messages
+
+**Analysis Framework:**
+
+1. **System Status Assessment** - Classify overall system health:
+   - "Excellent" - No issues, optimal operation
+   - "Good" - Minor issues that don't affect operations
+   - "Satisfactory" - Some concerns but system is stable
+   - "Bad" - Significant issues requiring attention
+   - "Awful" - Critical failures, system stability at risk
+
+2. **Security Analysis** - Identify threats:
+   - Failed SSH logins and brute force patterns (sshd)
+   - sudo/su failures and unexpected privilege escalation
+   - New users, groups, or authorized keys
+   - Firewall blocks and suspicious network activity
+   - Audit and SELinux/AppArmor denials
+
+3. **Service Health Indicators:**
+   - Units that failed, crashed, or entered restart loops ("Failed with result", "Start request repeated too quickly")
+   - Kernel errors: OOM killer, segfaults, hardware and filesystem errors (I/O errors, EXT4/XFS errors)
+   - Disk space, memory, and resource exhaustion
+   - Time synchronization, DNS, and network interface problems
+   - Timer and cron job failures
+
+4. **Recommendations** - Provide specific, actionable steps:
+   - Use systemctl and journalctl commands when applicable (e.g., "systemctl status nginx.service", "journalctl -u nginx.service -p err")
+   - Prioritize by urgency
+   - Focus on root causes over symptoms
+
+5. **Metrics Extraction:**
+   - failedLogins: number of failed authentication attempts
+   - failedUnits: units that failed or were restarted by systemd
+   - oomKills: processes killed by the OOM killer
+   - errorCount: entries with priority err or higher
+   - topErrorUnits: units with the most errors
+
+**Output Requirements:**
+
+You MUST respond with a valid JSON object (and ONLY JSON) in this exact format:
+
+{
+  "systemStatus": "Excellent|Good|Satisfactory|Bad|Awful",
+  "summary": "2-3 sentence overview of system state",
+  "criticalIssues": [
+    "Urgent issue requiring immediate action"
+  ],
+  "warnings": [
+    "Concerning issue that should be monitored"
+  ],
+  "recommendations": [
+    "Specific actionable recommendation"
+  ],
+  "metrics": {
+    "failedLogins": 0,
+    "failedUnits": 0,
+    "oomKills": 0,
+    "errorCount": 0,
+    "topErrorUnits": ["nginx.service"]
+  }
+}
+
+**Analysis Principles:**
+- Name the affected unit in every finding
+- Repeats of the same message are one issue; use the count to judge its severity
+- Distinguish transient errors from persistent failures
+- Consider historical context for trend analysis
+- Empty arrays are acceptable if no issues/warnings/recommendations exist` + ai.GlobalExclusionsBlock(globalExclusions) + ai.StringArrayFormatReminder
+}
+
+// GetUserPrompt constructs the user prompt with the journal summary and historical context.
+func (p *PromptBuilder) GetUserPrompt(logContent, historicalContext string, contextualExclusions []string) string {
+	var prompt strings.Builder
+
+	prompt.WriteString("SYSTEMD JOURNAL:\n")
+	prompt.WriteString(ai.SanitizeLogContent(logContent))
+	prompt.WriteString("\n\n")
+
+	if historicalContext != "" {
+		prompt.WriteString("HISTORICAL CONTEXT:\n")
+		prompt.WriteString(ai.SanitizeLogContent(historicalContext))
+		prompt.WriteString("\n\n")
+	}
+
+	prompt.WriteString(ai.ContextualExclusionsBlock(contextualExclusions))
+	prompt.WriteString("Please analyze the systemd journal above and provide your assessment in JSON format as specified.")
+
+	return prompt.String()
+}
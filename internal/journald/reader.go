@@ -0,0 +1,476 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package journald
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+)
+
+// NoEntriesContent is returned when the journal export contains no entries.
+// This is a valid state for exports filtered by priority or time range.
+// Use IsNoEntriesContent() to check for this condition.
+const NoEntriesContent = "=== NO JOURNAL ENTRIES ===\n\nNo systemd journal entries were found for the analyzed time period.\nThis typically means no unit logged at the exported priority levels."
+
+// timeFormatDateTime is the standard date-time format for journal entries.
+const timeFormatDateTime = "2006-01-02 15:04:05"
+
+// maxLineBytes is the maximum allowed size for a single NDJSON line.
+// Journal messages are limited to a few MB by journald itself.
+const maxLineBytes = 10 * 1024 * 1024
+
+// Limits of the formatted report. Repeats are collapsed before these apply.
+const (
+	maxCriticalGroups = 50
+	maxWarningGroups  = 50
+	maxInfoGroups     = 40
+	maxUnitsListed    = 30
+	maxStatsItems     = 10
+)
+
+// IsNoEntriesContent checks if the content indicates no journal entries were found.
+func IsNoEntriesContent(content string) bool {
+	return strings.HasPrefix(content, "=== NO JOURNAL ENTRIES ===")
+}
+
+// Compile-time interface checks
+var (
+	_ analyzer.LogReader     = (*Reader)(nil)
+	_ analyzer.StatsReporter = (*Reader)(nil)
+	_ analyzer.ContentReader = (*Reader)(nil)
+)
+
+// Reader handles reading and validating systemd journal exports.
+// Implements analyzer.LogReader interface.
+type Reader struct {
+	maxSizeMB           int
+	enablePreprocessing bool
+	maxTokens           int
+	preprocessor        *Preprocessor
+	entries             []Entry
+}
+
+// NewReader creates a new journal reader.
+func NewReader(maxSizeMB int, enablePreprocessing bool, maxTokens int) *Reader {
+	return &Reader{
+		maxSizeMB:           maxSizeMB,
+		enablePreprocessing: enablePreprocessing,
+		maxTokens:           maxTokens,
+		preprocessor:        NewPreprocessor(maxTokens),
+	}
+}
+
+// Read implements analyzer.LogReader.Read.
+// Reads a `journalctl -o json` export and formats it for analysis.
+func (r *Reader) Read(sourcePath string) (string, error) {
+	r.entries = nil
+
+	content, err := analyzer.ReadSourceFileWithGuards(
+		sourcePath,
+		analyzer.FileReadOptions{
+			SourceLabel: "journal",
+			MaxSizeMB:   r.maxSizeMB,
+			MaxAge:      24 * time.Hour,
+		},
+		validateExport,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return r.format(content)
+}
+
+// ReadContent implements analyzer.ContentReader.
+// Formats `journalctl -o json` output that was not read from a file.
+func (r *Reader) ReadContent(content string) (string, error) {
+	r.entries = nil
+
+	if err := validateExport(content); err != nil {
+		return "", fmt.Errorf("journal content validation failed: %w", err)
+	}
+
+	return r.format(content)
+}
+
+// validateExport rejects empty exports.
+func validateExport(content string) error {
+	if strings.TrimSpace(content) == "" {
+		return fmt.Errorf("journal export is empty")
+	}
+	return nil
+}
+
+// format parses the export and formats it for analysis.
+func (r *Reader) format(content string) (string, error) {
+	entries, err := parseExport(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse journal export: %w", err)
+	}
+	r.entries = entries
+
+	formattedContent := r.formatEntriesForAnalysis(entries)
+
+	if err := r.Validate(formattedContent); err != nil {
+		return "", fmt.Errorf("journal content validation failed: %w", err)
+	}
+
+	if r.enablePreprocessing && r.preprocessor.ShouldProcess(formattedContent, r.maxTokens) {
+		processedContent, err := r.preprocessor.Process(formattedContent)
+		if err != nil {
+			return "", fmt.Errorf("preprocessing failed: %w", err)
+		}
+		return processedContent, nil
+	}
+
+	return formattedContent, nil
+}
+
+// Entries returns the journal entries parsed by the last successful Read,
+// newest first.
+func (r *Reader) Entries() []Entry {
+	return r.entries
+}
+
+// ReadStats implements analyzer.StatsReporter.
+// Summarizes the entries of the last Read by priority, unit, and repeated
+// message.
+func (r *Reader) ReadStats() *analyzer.ReadStats {
+	if r.entries == nil {
+		return nil
+	}
+
+	priorityCounts := make(map[int]int)
+	unitCounts := make(map[string]int)
+	for _, e := range r.entries {
+		priorityCounts[e.Priority]++
+		unitCounts[e.Unit]++
+	}
+
+	errorCount := 0
+	for p := PriorityEmergency; p <= PriorityError; p++ {
+		errorCount += priorityCounts[p]
+	}
+	stats := &analyzer.ReadStats{
+		Totals: []analyzer.StatsItem{
+			{Name: "Entries", Count: len(r.entries)},
+			{Name: "Critical/error entries", Count: errorCount},
+			{Name: "Warning entries", Count: priorityCounts[PriorityWarning]},
+			{Name: "Units", Count: len(unitCounts)},
+		},
+	}
+
+	priorities := make([]analyzer.StatsItem, 0, len(PriorityName))
+	for p := PriorityEmergency; p <= PriorityDebug; p++ {
+		if count := priorityCounts[p]; count > 0 {
+			priorities = append(priorities, analyzer.StatsItem{Name: PriorityName[p], Count: count})
+		}
+	}
+	stats.AddBreakdown("Priority", priorities)
+	stats.AddBreakdown("Units", analyzer.TopCounts(unitCounts, maxStatsItems))
+
+	repeated := make(map[string]int)
+	for _, g := range groupEntries(r.entries) {
+		if g.count > 1 {
+			repeated[g.unit+": "+analyzer.TruncateMessage(g.example, 80)] += g.count
+		}
+	}
+	stats.AddBreakdown("Top repeated messages", analyzer.TopCounts(repeated, maxStatsItems))
+
+	return stats
+}
+
+// Validate implements analyzer.LogReader.Validate.
+// Performs basic validation on the formatted journal content.
+func (r *Reader) Validate(content string) error {
+	if len(content) == 0 {
+		return fmt.Errorf("journal content is empty")
+	}
+
+	// NoEntriesContent is a valid state - no entries for the time period
+	if IsNoEntriesContent(content) {
+		return nil
+	}
+
+	if len(content) < 50 {
+		return fmt.Errorf("journal content seems too small to be valid (only %d bytes)", len(content))
+	}
+
+	return nil
+}
+
+// GetSourceInfo implements analyzer.LogReader.GetSourceInfo.
+// Returns metadata about the journal export file.
+func (r *Reader) GetSourceInfo(sourcePath string) (map[string]any, error) {
+	return analyzer.GetSourceFileInfo(sourcePath)
+}
+
+// parseExport parses journal records from a `journalctl -o json` stream
+// (NDJSON), `-o json-pretty` output, or a JSON array of records. Lines that
+// are not valid JSON, such as a record truncated by an interrupted export,
+// are skipped.
+func parseExport(content string) ([]Entry, error) {
+	raws, err := decodeStream(content)
+	if err != nil {
+		raws, err = decodeLines(content)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	entries := make([]Entry, 0, len(raws))
+	for i := range raws {
+		if entry, ok := raws[i].toEntry(); ok {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+// decodeStream decodes a JSON array or a sequence of concatenated JSON
+// objects. Any syntax error fails the whole stream.
+func decodeStream(content string) ([]rawEntry, error) {
+	trimmed := strings.TrimSpace(content)
+
+	if strings.HasPrefix(trimmed, "[") {
+		var raws []rawEntry
+		if err := json.Unmarshal([]byte(trimmed), &raws); err != nil {
+			return nil, err
+		}
+		return raws, nil
+	}
+
+	var raws []rawEntry
+	decoder := json.NewDecoder(strings.NewReader(trimmed))
+	for {
+		var raw rawEntry
+		err := decoder.Decode(&raw)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		raws = append(raws, raw)
+	}
+	return raws, nil
+}
+
+// decodeLines decodes one record per line, skipping invalid lines. It also
+// accepts `journalctl -o json-seq` output, whose records start with an
+// ASCII record separator.
+func decodeLines(content string) ([]rawEntry, error) {
+	var raws []rawEntry
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Buffer(make([]byte, 1024), maxLineBytes)
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.TrimLeft(scanner.Text(), "\x1e"))
+		line = strings.TrimSuffix(line, ",")
+		if line == "" || line == "[" || line == "]" {
+			continue
+		}
+
+		var raw rawEntry
+		if err := json.Unmarshal([]byte(line), &raw); err != nil {
+			continue // Skip invalid lines
+		}
+		raws = append(raws, raw)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse NDJSON: %w", err)
+	}
+
+	if len(raws) == 0 {
+		return nil, fmt.Errorf("no valid journal records found")
+	}
+	return raws, nil
+}
+
+// entryGroup collapses repeated messages of one unit and priority.
+type entryGroup struct {
+	unit     string
+	priority int
+	example  string
+	count    int
+	first    time.Time
+	last     time.Time
+}
+
+// groupEntries groups entries by unit, priority, and normalized message, so
+// the same message from two units stays apart. Groups are returned in order
+// of first appearance in entries.
+func groupEntries(entries []Entry) []*entryGroup {
+	var groups []*entryGroup
+	index := make(map[string]*entryGroup)
+
+	for _, e := range entries {
+		key := fmt.Sprintf("%s\x00%d\x00%s", e.Unit, e.Priority, analyzer.NormalizeMessage(e.Message))
+		g, ok := index[key]
+		if !ok {
+			g = &entryGroup{
+				unit:     e.Unit,
+				priority: e.Priority,
+				example:  e.Message,
+				first:    e.Timestamp,
+				last:     e.Timestamp,
+			}
+			index[key] = g
+			groups = append(groups, g)
+		}
+		g.count++
+		if e.Timestamp.Before(g.first) {
+			g.first = e.Timestamp
+		}
+		if e.Timestamp.After(g.last) {
+			g.last = e.Timestamp
+		}
+	}
+
+	return groups
+}
+
+// formatEntriesForAnalysis formats journal entries into a readable format for the LLM.
+func (r *Reader) formatEntriesForAnalysis(entries []Entry) string {
+	if len(entries) == 0 {
+		return NoEntriesContent
+	}
+
+	// Sort entries by timestamp (newest first)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp.After(entries[j].Timestamp)
+	})
+
+	var sb strings.Builder
+	sb.WriteString("=== SYSTEMD JOURNAL ANALYSIS ===\n\n")
+
+	priorityCounts := make(map[int]int)
+	unitTotals := make(map[string]int)
+	unitCounts := make(map[string]map[int]int)
+	hosts := make(map[string]bool)
+	for _, e := range entries {
+		priorityCounts[e.Priority]++
+		unitTotals[e.Unit]++
+		if unitCounts[e.Unit] == nil {
+			unitCounts[e.Unit] = make(map[int]int)
+		}
+		unitCounts[e.Unit][e.Priority]++
+		if e.Hostname != "" {
+			hosts[e.Hostname] = true
+		}
+	}
+
+	sb.WriteString("## Summary Statistics\n")
+	fmt.Fprintf(&sb, "Total entries: %d\n", len(entries))
+	fmt.Fprintf(&sb, "Time range: %s to %s\n",
+		entries[len(entries)-1].Timestamp.Format(timeFormatDateTime),
+		entries[0].Timestamp.Format(timeFormatDateTime))
+	if len(hosts) > 0 {
+		fmt.Fprintf(&sb, "Hosts: %s\n", strings.Join(analyzer.SortedKeys(hosts), ", "))
+	}
+	fmt.Fprintf(&sb, "Units: %d\n\n", len(unitCounts))
+
+	sb.WriteString("## Priority Breakdown\n")
+	for p := PriorityEmergency; p <= PriorityDebug; p++ {
+		if count := priorityCounts[p]; count > 0 {
+			fmt.Fprintf(&sb, "- %s: %d\n", strings.ToUpper(PriorityName[p]), count)
+		}
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("## Units\n")
+	units := analyzer.TopCounts(unitTotals, 0)
+	for i, u := range units {
+		if i >= maxUnitsListed {
+			fmt.Fprintf(&sb, "... and %d more units\n", len(units)-maxUnitsListed)
+			break
+		}
+		fmt.Fprintf(&sb, "- %s: %d%s\n", u.Name, u.Count, unitPriorityDetail(unitCounts[u.Name]))
+	}
+	sb.WriteString("\n")
+
+	groups := groupEntries(entries)
+
+	critical := filterGroups(groups, PriorityEmergency, PriorityError)
+	if len(critical) > 0 {
+		sb.WriteString("## Critical/Error Entries\n")
+		writeGroups(&sb, critical, maxCriticalGroups, 300, "critical/error messages")
+		sb.WriteString("\n")
+	}
+
+	warnings := filterGroups(groups, PriorityWarning, PriorityWarning)
+	if len(warnings) > 0 {
+		sb.WriteString("## Warning Entries\n")
+		writeGroups(&sb, warnings, maxWarningGroups, 200, "warning messages")
+		sb.WriteString("\n")
+	}
+
+	info := filterGroups(groups, PriorityNotice, PriorityInfo)
+	if len(info) > 0 {
+		sb.WriteString("## Notice/Info Entries (Most Frequent)\n")
+		writeGroups(&sb, info, maxInfoGroups, 120, "notice/info messages")
+	}
+
+	return sb.String()
+}
+
+// filterGroups returns the groups within a priority range (inclusive),
+// most severe and most frequent first.
+func filterGroups(groups []*entryGroup, minPriority, maxPriority int) []*entryGroup {
+	var filtered []*entryGroup
+	for _, g := range groups {
+		if g.priority >= minPriority && g.priority <= maxPriority {
+			filtered = append(filtered, g)
+		}
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		if filtered[i].priority != filtered[j].priority {
+			return filtered[i].priority < filtered[j].priority
+		}
+		return filtered[i].count > filtered[j].count
+	})
+	return filtered
+}
+
+// writeGroups writes one line per group, up to limit groups.
+func writeGroups(sb *strings.Builder, groups []*entryGroup, limit, maxMessageLen int, label string) {
+	for i, g := range groups {
+		if i >= limit {
+			fmt.Fprintf(sb, "... and %d more unique %s\n", len(groups)-limit, label)
+			break
+		}
+		message := analyzer.TruncateMessage(g.example, maxMessageLen)
+		if g.count == 1 {
+			fmt.Fprintf(sb, "- [%s] %s [%s]: %s\n",
+				g.last.Format(timeFormatDateTime), g.unit, PriorityName[g.priority], message)
+			continue
+		}
+		fmt.Fprintf(sb, "- [%dx, %s to %s] %s [%s]: %s\n",
+			g.count, g.first.Format(timeFormatDateTime), g.last.Format(timeFormatDateTime),
+			g.unit, PriorityName[g.priority], message)
+	}
+}
+
+// unitPriorityDetail lists the error and warning counts of a unit.
+func unitPriorityDetail(counts map[int]int) string {
+	var parts []string
+	for p := PriorityEmergency; p <= PriorityWarning; p++ {
+		if counts[p] > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %d", PriorityName[p], counts[p]))
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package journald
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// journalRecord builds one `journalctl -o json` line.
+func journalRecord(usec int64, priority int, unit, message string) string {
+	return fmt.Sprintf(`{"__REALTIME_TIMESTAMP":"%d","PRIORITY":"%d","_SYSTEMD_UNIT":%q,"_HOSTNAME":"web1","MESSAGE":%q}`,
+		usec, priority, unit, message)
+}
+
+func writeExport(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "journal.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write temp file: %v", err)
+	}
+	return path
+}
+
+func sampleExport() string {
+	const base = int64(1767229200000000)
+	lines := []string{
+		journalRecord(base, 6, "sshd.service", "Accepted publickey for deploy from 203.0.113.5 port 50122"),
+		journalRecord(base+1, 3, "nginx.service", "worker process 811 exited on signal 9"),
+		journalRecord(base+2, 3, "nginx.service", "worker process 812 exited on signal 9"),
+		journalRecord(base+3, 3, "php-fpm.service", "worker process 813 exited on signal 9"),
+		journalRecord(base+4, 4, "sshd.service", "Failed password for root from 198.51.100.7 port 2222"),
+		journalRecord(base+5, 4, "sshd.service", "Failed password for root from 198.51.100.8 port 2223"),
+		`{"__REALTIME_TIMESTAMP":"1767229200000006","PRIORITY":"6","_SYSTEMD_UNIT":"app.servi`, // truncated record
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func TestParseExport_Formats(t *testing.T) {
+	records := []string{
+		journalRecord(1767229200000000, 3, "a.service", "first"),
+		journalRecord(1767229200000001, 4, "b.service", "second"),
+	}
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"ndjson", strings.Join(records, "\n")},
+		{"array", "[" + strings.Join(records, ",\n") + "]"},
+		{"pretty", strings.ReplaceAll(strings.Join(records, "\n"), ",", ",\n    ")},
+		{"json-seq", "\x1e" + strings.Join(records, "\n\x1e")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := parseExport(tt.content)
+			if err != nil {
+				t.Fatalf("parseExport() error = %v", err)
+			}
+			if len(entries) != 2 || entries[1].Unit != "b.service" {
+				t.Errorf("parseExport() = %+v, want 2 entries", entries)
+			}
+		})
+	}
+
+	if _, err := parseExport("not json at all"); err == nil {
+		t.Error("parseExport() expected error for invalid content")
+	}
+}
+
+func TestReader_Read(t *testing.T) {
+	r := NewReader(10, false, 150000)
+
+	result, err := r.Read(writeExport(t, sampleExport()))
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"=== SYSTEMD JOURNAL ANALYSIS ===",
+		"Total entries: 6",
+		"Hosts: web1",
+		"- ERR: 3",
+		"- nginx.service: 2 (err: 2)",
+		"- sshd.service: 3 (warning: 2)",
+		"## Critical/Error Entries",
+		// Unit-aware dedup: the nginx repeats collapse, php-fpm stays apart
+		"- [2x, ",
+		"nginx.service [err]: worker process 812 exited on signal 9",
+		"php-fpm.service [err]: worker process 813 exited on signal 9",
+		"sshd.service [warning]: Failed password for root from 198.51.100.8 port 2223",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Read() result missing %q\n%s", want, result)
+		}
+	}
+
+	if len(r.Entries()) != 6 {
+		t.Errorf("Entries() = %d entries, want 6", len(r.Entries()))
+	}
+}
+
+func TestReader_Read_NoEntries(t *testing.T) {
+	r := NewReader(10, false, 150000)
+
+	result, err := r.Read(writeExport(t, "[]"))
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if !IsNoEntriesContent(result) {
+		t.Errorf("Read() = %q, want no-entries content", result)
+	}
+}
+
+func TestReader_Read_Errors(t *testing.T) {
+	r := NewReader(10, false, 150000)
+
+	if _, err := r.Read(filepath.Join(t.TempDir(), "missing.json")); err == nil || !strings.Contains(err.Error(), "journal file not found") {
+		t.Errorf("Read() missing file error = %v", err)
+	}
+	if _, err := r.Read(writeExport(t, "  \n")); err == nil {
+		t.Error("Read() expected error for empty export")
+	}
+	if _, err := r.Read(writeExport(t, "garbage\n")); err == nil || !strings.Contains(err.Error(), "failed to parse journal export") {
+		t.Errorf("Read() garbage error = %v", err)
+	}
+}
+
+func TestReader_ReadStats(t *testing.T) {
+	r := NewReader(10, false, 150000)
+	if stats := r.ReadStats(); stats != nil {
+		t.Errorf("ReadStats() before Read = %+v, want nil", stats)
+	}
+
+	if _, err := r.Read(writeExport(t, sampleExport())); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	stats := r.ReadStats()
+	if stats == nil {
+		t.Fatal("ReadStats() = nil")
+	}
+	wantTotals := []int{6, 3, 2, 3}
+	for i, want := range wantTotals {
+		if stats.Totals[i].Count != want {
+			t.Errorf("Totals[%d] = %+v, want count %d", i, stats.Totals[i], want)
+		}
+	}
+	if len(stats.Breakdowns) != 3 {
+		t.Fatalf("Breakdowns = %+v, want priority, units and repeated messages", stats.Breakdowns)
+	}
+	if units := stats.Breakdowns[1].Items; units[0].Name != "sshd.service" || units[0].Count != 3 {
+		t.Errorf("units = %+v, want sshd.service first", units)
+	}
+	if repeated := stats.Breakdowns[2].Items; len(repeated) != 2 {
+		t.Errorf("repeated messages = %+v, want nginx and sshd repeats", repeated)
+	}
+}
+
+func TestReader_Validate(t *testing.T) {
+	r := NewReader(10, false, 150000)
+
+	if err := r.Validate(""); err == nil {
+		t.Error("Validate() expected error for empty content")
+	}
+	if err := r.Validate("too short"); err == nil {
+		t.Error("Validate() expected error for short content")
+	}
+	if err := r.Validate(NoEntriesContent); err != nil {
+		t.Errorf("Validate() no-entries error = %v", err)
+	}
+}
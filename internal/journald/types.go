@@ -0,0 +1,170 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package journald provides log analysis for systemd journal exports.
+// It reads the output of `journalctl -o json` (or an NDJSON stream of the
+// same records) and implements the analyzer interfaces to enable journal
+// analysis alongside other log sources like logwatch.
+package journald
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Priority levels of journal entries (syslog levels, RFC 5424).
+const (
+	PriorityEmergency = 0 // System is unusable
+	PriorityAlert     = 1 // Action must be taken immediately
+	PriorityCritical  = 2 // Critical conditions
+	PriorityError     = 3 // Error conditions
+	PriorityWarning   = 4 // Warning conditions
+	PriorityNotice    = 5 // Normal but significant condition
+	PriorityInfo      = 6 // Informational messages
+	PriorityDebug     = 7 // Debug-level messages
+)
+
+// PriorityName maps priority levels to the names journalctl uses.
+var PriorityName = map[int]string{
+	PriorityEmergency: "emerg",
+	PriorityAlert:     "alert",
+	PriorityCritical:  "crit",
+	PriorityError:     "err",
+	PriorityWarning:   "warning",
+	PriorityNotice:    "notice",
+	PriorityInfo:      "info",
+	PriorityDebug:     "debug",
+}
+
+// unknownUnit groups entries that carry no unit or identifier field.
+const unknownUnit = "unknown"
+
+// Entry represents a single journal entry, reduced to the fields used for
+// analysis.
+type Entry struct {
+	Timestamp  time.Time
+	Priority   int
+	Unit       string // systemd unit, or the syslog identifier for unit-less entries
+	Identifier string // SYSLOG_IDENTIFIER or _COMM
+	PID        string
+	Hostname   string
+	Message    string
+}
+
+// PriorityName returns the journalctl name of the entry priority.
+func (e *Entry) PriorityName() string {
+	if name, ok := PriorityName[e.Priority]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// IsCritical returns true if the entry has error priority or higher.
+func (e *Entry) IsCritical() bool {
+	return e.Priority >= PriorityEmergency && e.Priority <= PriorityError
+}
+
+// rawEntry mirrors the journal export fields used by the reader. journalctl
+// emits every field as a string; MESSAGE is an array of bytes when it is not
+// valid UTF-8, and any field may be an array when it is set more than once.
+type rawEntry struct {
+	RealtimeTimestamp json.RawMessage `json:"__REALTIME_TIMESTAMP"`
+	Priority          json.RawMessage `json:"PRIORITY"`
+	SystemdUnit       json.RawMessage `json:"_SYSTEMD_UNIT"`
+	SystemdUserUnit   json.RawMessage `json:"_SYSTEMD_USER_UNIT"`
+	UnitField         json.RawMessage `json:"UNIT"`
+	SyslogIdentifier  json.RawMessage `json:"SYSLOG_IDENTIFIER"`
+	Comm              json.RawMessage `json:"_COMM"`
+	PID               json.RawMessage `json:"_PID"`
+	Hostname          json.RawMessage `json:"_HOSTNAME"`
+	Transport         json.RawMessage `json:"_TRANSPORT"`
+	Message           json.RawMessage `json:"MESSAGE"`
+}
+
+// toEntry converts a raw export record. Records without a message (journal
+// metadata) are reported as not ok.
+func (r *rawEntry) toEntry() (Entry, bool) {
+	message := fieldString(r.Message)
+	if message == "" {
+		return Entry{}, false
+	}
+
+	entry := Entry{
+		Priority:   PriorityInfo, // journald default for records without PRIORITY
+		Identifier: firstNonEmpty(fieldString(r.SyslogIdentifier), fieldString(r.Comm)),
+		PID:        fieldString(r.PID),
+		Hostname:   fieldString(r.Hostname),
+		Message:    strings.TrimSpace(message),
+	}
+
+	if usec, err := strconv.ParseInt(fieldString(r.RealtimeTimestamp), 10, 64); err == nil {
+		entry.Timestamp = time.UnixMicro(usec)
+	}
+	if priority, err := strconv.Atoi(fieldString(r.Priority)); err == nil && priority >= PriorityEmergency && priority <= PriorityDebug {
+		entry.Priority = priority
+	}
+
+	entry.Unit = firstNonEmpty(
+		fieldString(r.SystemdUnit),
+		fieldString(r.SystemdUserUnit),
+		entry.Identifier,
+	)
+	if entry.Unit == "" && fieldString(r.Transport) == "kernel" {
+		entry.Unit = "kernel"
+	}
+	// Messages that systemd logs about a unit (start, stop, failure) come
+	// from init.scope and name the unit in the UNIT field
+	if unit := fieldString(r.UnitField); unit != "" && entry.Unit == "init.scope" {
+		entry.Unit = unit
+	}
+	if entry.Unit == "" {
+		entry.Unit = unknownUnit
+	}
+
+	return entry, true
+}
+
+// fieldString decodes a journal export field: a string, an array of bytes
+// (non-UTF-8 data), or an array of values for repeated fields (the first
+// one wins). Anything else decodes to "".
+func fieldString(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+
+	var data []byte
+	var ints []int
+	if err := json.Unmarshal(raw, &ints); err == nil {
+		data = make([]byte, 0, len(ints))
+		for _, b := range ints {
+			if b < 0 || b > 255 {
+				return ""
+			}
+			data = append(data, byte(b))
+		}
+		return strings.ToValidUTF8(string(data), "�")
+	}
+
+	var values []json.RawMessage
+	if err := json.Unmarshal(raw, &values); err == nil && len(values) > 0 {
+		return fieldString(values[0])
+	}
+
+	return ""
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package journald
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRawEntry_toEntry(t *testing.T) {
+	tests := []struct {
+		name         string
+		record       string
+		wantOK       bool
+		wantUnit     string
+		wantPriority int
+		wantMessage  string
+	}{
+		{
+			name:         "service entry",
+			record:       `{"__REALTIME_TIMESTAMP":"1767229200000000","PRIORITY":"3","_SYSTEMD_UNIT":"nginx.service","SYSLOG_IDENTIFIER":"nginx","_PID":"812","MESSAGE":"worker process exited on signal 9"}`,
+			wantOK:       true,
+			wantUnit:     "nginx.service",
+			wantPriority: PriorityError,
+			wantMessage:  "worker process exited on signal 9",
+		},
+		{
+			name:         "systemd message about a unit",
+			record:       `{"PRIORITY":"3","_SYSTEMD_UNIT":"init.scope","UNIT":"backup.service","MESSAGE":"backup.service: Failed with result 'exit-code'."}`,
+			wantOK:       true,
+			wantUnit:     "backup.service",
+			wantPriority: PriorityError,
+			wantMessage:  "backup.service: Failed with result 'exit-code'.",
+		},
+		{
+			name:         "kernel entry without unit",
+			record:       `{"PRIORITY":"2","_TRANSPORT":"kernel","MESSAGE":"Out of memory: Killed process 4242 (php-fpm)"}`,
+			wantOK:       true,
+			wantUnit:     "kernel",
+			wantPriority: PriorityCritical,
+			wantMessage:  "Out of memory: Killed process 4242 (php-fpm)",
+		},
+		{
+			name:         "identifier fallback and default priority",
+			record:       `{"SYSLOG_IDENTIFIER":"CRON","MESSAGE":"(root) CMD (run-parts /etc/cron.hourly)"}`,
+			wantOK:       true,
+			wantUnit:     "CRON",
+			wantPriority: PriorityInfo,
+			wantMessage:  "(root) CMD (run-parts /etc/cron.hourly)",
+		},
+		{
+			name:         "binary message",
+			record:       `{"PRIORITY":"4","_SYSTEMD_UNIT":"app.service","MESSAGE":[104,105,255]}`,
+			wantOK:       true,
+			wantUnit:     "app.service",
+			wantPriority: PriorityWarning,
+			wantMessage:  "hi�",
+		},
+		{
+			name:   "no message",
+			record: `{"PRIORITY":"6","_SYSTEMD_UNIT":"app.service"}`,
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var raw rawEntry
+			if err := json.Unmarshal([]byte(tt.record), &raw); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+
+			entry, ok := raw.toEntry()
+			if ok != tt.wantOK {
+				t.Fatalf("toEntry() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if entry.Unit != tt.wantUnit || entry.Priority != tt.wantPriority || entry.Message != tt.wantMessage {
+				t.Errorf("toEntry() = %+v, want unit %q priority %d message %q",
+					entry, tt.wantUnit, tt.wantPriority, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestEntry_PriorityName(t *testing.T) {
+	e := Entry{Priority: PriorityWarning}
+	if got := e.PriorityName(); got != "warning" {
+		t.Errorf("PriorityName() = %q, want warning", got)
+	}
+	e.Priority = 42
+	if got := e.PriorityName(); got != "unknown" {
+		t.Errorf("PriorityName() = %q, want unknown", got)
+	}
+	if !(&Entry{Priority: PriorityError}).IsCritical() || (&Entry{Priority: PriorityWarning}).IsCritical() {
+		t.Error("IsCritical() should be true up to err only")
+	}
+}
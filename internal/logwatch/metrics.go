@@ -0,0 +1,540 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package logwatch
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+)
+
+// maxMetricItems caps the users, IPs, and mounts listed per metric.
+const maxMetricItems = 10
+
+var (
+	// Service sections of the text format:
+	// " --------------------- SSHD Begin ------------------------ "
+	serviceBeginRegex = regexp.MustCompile(`(?m)^\s*-{3,}\s*(.+?)\s+Begin\s*-{3,}\s*$`)
+	serviceEndRegex   = regexp.MustCompile(`(?m)^\s*-{3,}\s*.+?\s+End\s*-{3,}\s*$`)
+
+	// Count lines: "185.220.101.4: 1843 times", "root (10.0.0.1): 5 Time(s)"
+	countLineRegex = regexp.MustCompile(`^(\s*)(.+?):\s+(\d+)\s+[Tt]imes?(?:\(s\))?\s*$`)
+	// Kernel count lines with the count first: "2 Time(s): Out of memory: ..."
+	leadingCountRegex = regexp.MustCompile(`^(\s*)(\d+)\s+[Tt]imes?(?:\(s\))?:\s+(.+?)\s*$`)
+
+	sudoUserRegex    = regexp.MustCompile(`^\s*(\S+)\s+=>\s+(\S+)\s*$`)
+	postfixLineRegex = regexp.MustCompile(`^\s*(\d[\d,]*)\s{2,}(.+?)(?:\s{2,}[\d.]+%)?\s*$`)
+	dfLineRegex      = regexp.MustCompile(`^\s*(\S+)\s+\S+\s+\S+\s+\S+\s+(\d+)%\s+(/.*?)\s*$`)
+	diskWarnRegex    = regexp.MustCompile(`^\s*(\S+)\s+=>\s+(\d+)%\s+Used`)
+	httpTotalsRegex  = regexp.MustCompile(`(\d+)\s+responses\s*\(1xx (\d+), 2xx (\d+), 3xx (\d+), 4xx (\d+), 5xx (\d+)\)`)
+	httpCodeRegex    = regexp.MustCompile(`^\s*([1-5]\d\d)\s+\S`)
+	parenthesesRegex = regexp.MustCompile(`\s*\(.*?\)`)
+)
+
+// ServiceMetrics holds the exact numbers parsed from the service sections
+// of a logwatch report, so the LLM does not have to count them.
+type ServiceMetrics struct {
+	items []metricItem
+}
+
+// metricItem is a single metric in header order.
+type metricItem struct {
+	key   string
+	value any
+}
+
+// ParseServiceMetrics parses the sshd, sudo, pam_unix, postfix, kernel,
+// disk space, and httpd sections of a logwatch report. Services that are
+// missing, or whose sections have no recognized structure, add no metrics.
+func ParseServiceMetrics(content string) *ServiceMetrics {
+	m := &ServiceMetrics{}
+	for _, section := range serviceSections(content) {
+		name := strings.ToLower(section.Name)
+		switch {
+		case strings.HasPrefix(name, "sshd"):
+			m.parseSSHD(section.Content)
+		case strings.HasPrefix(name, "sudo"):
+			m.parseSudo(section.Content)
+		case strings.HasPrefix(name, "pam_unix"):
+			m.parsePAMUnix(section.Content)
+		case strings.HasPrefix(name, "postfix"):
+			m.parsePostfix(section.Content)
+		case strings.HasPrefix(name, "kernel"):
+			m.parseKernel(section.Content)
+		case strings.HasPrefix(name, "disk space"):
+			m.parseDiskSpace(section.Content)
+		case strings.HasPrefix(name, "httpd"), name == "http":
+			m.parseHTTPD(section.Content)
+		}
+	}
+	return m
+}
+
+// serviceSections splits a report on the "--- NAME Begin ---" markers of
+// logwatch, or on the "### NAME ###" headers of parseSections when the
+// report has no such markers.
+func serviceSections(content string) []*Section {
+	matches := serviceBeginRegex.FindAllStringSubmatchIndex(content, -1)
+	if len(matches) == 0 {
+		return (&Preprocessor{}).parseSections(content)
+	}
+
+	sections := make([]*Section, 0, len(matches))
+	for i, match := range matches {
+		end := len(content)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		body := content[match[1]:end]
+		if loc := serviceEndRegex.FindStringIndex(body); loc != nil {
+			body = body[:loc[0]]
+		}
+		sections = append(sections, &Section{
+			Name:    strings.TrimSpace(content[match[2]:match[3]]),
+			Content: body,
+		})
+	}
+	return sections
+}
+
+// Empty reports whether no metrics were parsed.
+func (m *ServiceMetrics) Empty() bool {
+	return m == nil || len(m.items) == 0
+}
+
+// Map returns the metrics keyed like Analysis.Metrics.
+func (m *ServiceMetrics) Map() map[string]any {
+	if m.Empty() {
+		return nil
+	}
+	metrics := make(map[string]any, len(m.items))
+	for _, item := range m.items {
+		metrics[item.key] = item.value
+	}
+	return metrics
+}
+
+// Markers around the metrics header of the prompt.
+const (
+	metricsHeaderStart = "=== LOGWATCH METRICS (parsed from the report, exact) ===\n"
+	metricsHeaderEnd   = "=== END LOGWATCH METRICS ===\n\n"
+)
+
+// splitMetricsHeader splits content into the metrics header it starts
+// with, if any, and the report after it.
+func splitMetricsHeader(content string) (header, report string) {
+	if !strings.HasPrefix(content, metricsHeaderStart) {
+		return "", content
+	}
+	end := strings.Index(content, metricsHeaderEnd)
+	if end < 0 {
+		return "", content
+	}
+	end += len(metricsHeaderEnd)
+	return content[:end], content[end:]
+}
+
+// Header renders the metrics as the deterministic header of the prompt.
+func (m *ServiceMetrics) Header() string {
+	if m.Empty() {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(metricsHeaderStart)
+	for _, item := range m.items {
+		fmt.Fprintf(&sb, "%s: %v\n", item.key, item.value)
+	}
+	sb.WriteString(metricsHeaderEnd)
+	return sb.String()
+}
+
+func (m *ServiceMetrics) add(key string, value any) {
+	m.items = append(m.items, metricItem{key: key, value: value})
+}
+
+// addCounts adds the total of counts as key and, if not empty, the top
+// counts as breakdownKey.
+func (m *ServiceMetrics) addCounts(key, breakdownKey string, counts map[string]int) {
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+	m.add(key, total)
+	if len(counts) > 0 {
+		m.add(breakdownKey, formatCounts(counts))
+	}
+}
+
+// formatCounts renders the top counts as "root: 12, admin: 3 (+2 more)".
+func formatCounts(counts map[string]int) string {
+	items := analyzer.TopCounts(counts, maxMetricItems)
+	parts := make([]string, 0, len(items))
+	for _, item := range items {
+		parts = append(parts, fmt.Sprintf("%s: %d", item.Name, item.Count))
+	}
+	s := strings.Join(parts, ", ")
+	if more := len(counts) - len(items); more > 0 {
+		s += fmt.Sprintf(" (+%d more)", more)
+	}
+	return s
+}
+
+// countLine is a "label: N times" line of a service section.
+type countLine struct {
+	indent int
+	label  string
+	count  int
+}
+
+// parseCountLine parses a "label: N times" line.
+func parseCountLine(line string) (countLine, bool) {
+	match := countLineRegex.FindStringSubmatch(line)
+	if match == nil {
+		return countLine{}, false
+	}
+	count, err := strconv.Atoi(match[3])
+	if err != nil {
+		return countLine{}, false
+	}
+	return countLine{indent: len(match[1]), label: strings.TrimSpace(match[2]), count: count}, true
+}
+
+// indentOf returns the number of leading blanks of a line.
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
+
+// firstField returns the label up to the first blank or parenthesis.
+func firstField(label string) string {
+	if i := strings.IndexAny(label, " ("); i > 0 {
+		return label[:i]
+	}
+	return label
+}
+
+// sshdBlock classifies a block header of the sshd service.
+func sshdBlock(header string) string {
+	header = strings.ToLower(header)
+	switch {
+	case strings.HasPrefix(header, "failed logins from"):
+		return "failed"
+	case strings.HasPrefix(header, "illegal users from"), strings.HasPrefix(header, "invalid users from"):
+		return "invalid"
+	case strings.HasPrefix(header, "users logging in"), strings.HasPrefix(header, "users logged in"):
+		return "logins"
+	}
+	return ""
+}
+
+// parseSSHD counts failed logins per IP and user from the "Failed logins
+// from" and "Illegal users from" (or "Invalid users from") blocks, and
+// successful logins per user from "Users logging in through sshd".
+func (m *ServiceMetrics) parseSSHD(content string) {
+	byIP := make(map[string]int)
+	byUser := make(map[string]int)
+	logins := make(map[string]int)
+	invalid := 0
+	recognized := false
+
+	var block, loginUser string
+	ipIndent := -1
+	for line := range strings.Lines(content) {
+		line = strings.TrimRight(line, "\r\n")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		item, isCount := parseCountLine(line)
+		if !isCount {
+			if indentOf(line) <= 2 {
+				block = sshdBlock(strings.TrimSpace(line))
+				recognized = recognized || block != ""
+				ipIndent = -1
+				loginUser = ""
+			} else {
+				loginUser = strings.TrimSuffix(strings.TrimSpace(line), ":")
+			}
+			continue
+		}
+
+		switch block {
+		case "failed", "invalid":
+			// IP lines, followed by user/method lines at higher detail
+			if ipIndent < 0 {
+				ipIndent = item.indent
+			}
+			if item.indent > ipIndent {
+				user, _, _ := strings.Cut(item.label, "/")
+				byUser[user] += item.count
+				continue
+			}
+			byIP[firstField(item.label)] += item.count
+			if block == "invalid" {
+				invalid += item.count
+			}
+		case "logins":
+			if loginUser != "" && item.indent > 2 {
+				logins[loginUser] += item.count
+			} else {
+				logins[firstField(item.label)] += item.count
+			}
+		}
+	}
+
+	if !recognized {
+		return
+	}
+	m.addCounts("failedLogins", "failedLoginsByIP", byIP)
+	if len(byUser) > 0 {
+		m.add("failedLoginsByUser", formatCounts(byUser))
+	}
+	m.add("invalidUserLogins", invalid)
+	m.addCounts("sshLogins", "sshLoginsByUser", logins)
+}
+
+// parseSudo counts the commands run with sudo per user, from the
+// "user => runas" blocks listing one command per line.
+func (m *ServiceMetrics) parseSudo(content string) {
+	commands := make(map[string]int)
+	recognized := false
+
+	var user string
+	for line := range strings.Lines(content) {
+		line = strings.TrimRight(line, "\r\n")
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			user = ""
+		case strings.Trim(trimmed, "-=") == "":
+			// Underline of the user header
+		case sudoUserRegex.MatchString(line):
+			user = sudoUserRegex.FindStringSubmatch(line)[1]
+			recognized = true
+		case user != "":
+			count := 1
+			if item, ok := parseCountLine(line); ok {
+				count = item.count
+			}
+			commands[user] += count
+		}
+	}
+
+	if recognized {
+		m.addCounts("sudoCommands", "sudoCommandsByUser", commands)
+	}
+}
+
+// parsePAMUnix counts the authentication failures per user from the
+// "Authentication Failures" blocks of all services.
+func (m *ServiceMetrics) parsePAMUnix(content string) {
+	failures := make(map[string]int)
+	recognized := false
+
+	var block string
+	for line := range strings.Lines(content) {
+		line = strings.TrimRight(line, "\r\n")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		item, isCount := parseCountLine(line)
+		if !isCount {
+			block = strings.ToLower(strings.TrimSpace(line))
+			if strings.HasPrefix(block, "authentication failure") {
+				recognized = true
+			}
+			continue
+		}
+		if strings.HasPrefix(block, "authentication failure") {
+			failures[firstField(item.label)] += item.count
+		}
+		recognized = true
+	}
+
+	if recognized {
+		m.addCounts("pamAuthFailures", "pamAuthFailuresByUser", failures)
+	}
+}
+
+// postfixMetrics maps the postfix summary lines to metric keys.
+var postfixMetrics = []struct{ label, key string }{
+	{"accepted", "mailAccepted"},
+	{"rejected", "mailRejected"},
+	{"delivered", "mailDelivered"},
+	{"sent via smtp", "mailSent"},
+	{"deferred", "mailDeferred"},
+	{"bounced", "mailBounced"},
+}
+
+// parsePostfix reads the message counts of the postfix summary, such as
+// "12   Accepted   92.31%". Bounced (local) and bounced (remote) add up.
+func (m *ServiceMetrics) parsePostfix(content string) {
+	counts := make(map[string]int)
+	for line := range strings.Lines(content) {
+		match := postfixLineRegex.FindStringSubmatch(strings.TrimRight(line, "\r\n"))
+		if match == nil {
+			continue
+		}
+		label := strings.ToLower(strings.TrimSpace(parenthesesRegex.ReplaceAllString(match[2], "")))
+		count, err := strconv.Atoi(strings.ReplaceAll(match[1], ",", ""))
+		if err != nil {
+			continue
+		}
+		for _, metric := range postfixMetrics {
+			if label == metric.label {
+				counts[metric.key] += count
+			}
+		}
+	}
+
+	for _, metric := range postfixMetrics {
+		if count, ok := counts[metric.key]; ok {
+			m.add(metric.key, count)
+		}
+	}
+}
+
+// parseKernel counts the kernel errors, segmentation faults, and OOM
+// killer invocations listed by the kernel service.
+func (m *ServiceMetrics) parseKernel(content string) {
+	var kernelErrors, segfaults, oomKills int
+	recognized := false
+
+	var block string
+	for line := range strings.Lines(content) {
+		line = strings.TrimRight(line, "\r\n")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		var label string
+		var count int
+		if item, ok := parseCountLine(line); ok {
+			label, count = item.label, item.count
+		} else if match := leadingCountRegex.FindStringSubmatch(line); match != nil {
+			label = match[3]
+			count, _ = strconv.Atoi(match[2])
+		} else {
+			if indentOf(line) <= 2 {
+				block = strings.ToLower(strings.TrimSpace(line))
+			}
+			continue
+		}
+		recognized = true
+
+		lower := strings.ToLower(label)
+		switch {
+		case isOOMKill(lower) || isOOMKill(block):
+			oomKills += count
+		case strings.Contains(block, "segmentation fault") || strings.Contains(lower, "segfault"):
+			segfaults += count
+		case strings.Contains(block, "kernel errors"):
+			kernelErrors += count
+		}
+	}
+
+	if recognized {
+		m.add("kernelErrors", kernelErrors)
+		m.add("segfaults", segfaults)
+		m.add("oomKills", oomKills)
+	}
+}
+
+// isOOMKill reports whether a lowercased kernel message is about the OOM
+// killer.
+func isOOMKill(message string) bool {
+	for _, marker := range []string{"out of memory", "oom-kill", "oom_kill", "oom killer", "killed process"} {
+		if strings.Contains(message, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseDiskSpace reads the usage per mount from the df table of the disk
+// space service, or from its "/dev/sda1 => 95% Used" warnings, and reports
+// the fullest mounts first.
+func (m *ServiceMetrics) parseDiskSpace(content string) {
+	usage := make(map[string]int)
+	for line := range strings.Lines(content) {
+		line = strings.TrimRight(line, "\r\n")
+		if match := dfLineRegex.FindStringSubmatch(line); match != nil {
+			percent, _ := strconv.Atoi(match[2])
+			usage[match[3]] = percent
+		} else if match := diskWarnRegex.FindStringSubmatch(line); match != nil {
+			percent, _ := strconv.Atoi(match[2])
+			usage[match[1]] = max(usage[match[1]], percent)
+		}
+	}
+	if len(usage) == 0 {
+		return
+	}
+
+	items := analyzer.TopCounts(usage, 0)
+	parts := make([]string, 0, len(items))
+	for _, item := range items {
+		parts = append(parts, fmt.Sprintf("%d%% %s", item.Count, item.Name))
+	}
+	m.add("diskUsage", strings.Join(parts, ", "))
+	m.add("diskUsageMaxPercent", items[0].Count)
+}
+
+// parseHTTPD reads the response totals ("2345 responses (1xx 0, 2xx 2010,
+// ...)") and the requests per error code from "Requests with error
+// response codes".
+func (m *ServiceMetrics) parseHTTPD(content string) {
+	byCode := make(map[string]int)
+	responses, client, server := -1, 0, 0
+
+	var code string
+	for line := range strings.Lines(content) {
+		line = strings.TrimRight(line, "\r\n")
+		if match := httpTotalsRegex.FindStringSubmatch(line); match != nil {
+			responses, _ = strconv.Atoi(match[1])
+			client, _ = strconv.Atoi(match[5])
+			server, _ = strconv.Atoi(match[6])
+			continue
+		}
+		if item, ok := parseCountLine(line); ok {
+			if code != "" {
+				byCode[code] += item.count
+			}
+			continue
+		}
+		if match := httpCodeRegex.FindStringSubmatch(line); match != nil {
+			code = match[1]
+		} else if indentOf(line) <= 2 {
+			code = ""
+		}
+	}
+
+	if responses < 0 {
+		if len(byCode) == 0 {
+			return
+		}
+		for c, count := range byCode {
+			switch c[0] {
+			case '4':
+				client += count
+			case '5':
+				server += count
+			}
+		}
+	} else {
+		m.add("httpResponses", responses)
+	}
+	m.add("http4xx", client)
+	m.add("http5xx", server)
+	if len(byCode) > 0 {
+		codes := slices.Sorted(maps.Keys(byCode))
+		parts := make([]string, 0, len(codes))
+		for _, c := range codes {
+			parts = append(parts, fmt.Sprintf("%s: %d", c, byCode[c]))
+		}
+		m.add("httpErrorsByCode", strings.Join(parts, ", "))
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package logwatch
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// serviceSection wraps a body in the Begin/End markers of logwatch.
+func serviceSection(name, body string) string {
+	return " --------------------- " + name + " Begin ------------------------ \n\n" +
+		body +
+		"\n ---------------------- " + name + " End ------------------------- \n\n"
+}
+
+func TestParseServiceMetrics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]any
+	}{
+		{
+			name: "sshd with users",
+			content: serviceSection("SSHD", ` Failed logins from:
+    185.220.101.4: 1843 times
+       root/password: 1201 times
+       admin/password: 642 times
+    45.155.205.33 (scanner.example.net): 412 times
+       root/password: 412 times
+
+ Illegal users from:
+    185.220.101.4: 96 times
+       oracle/none: 96 times
+
+ Users logging in through sshd:
+    deploy:
+       10.0.0.12: 3 times
+       10.0.0.13: 1 time
+
+ Received disconnect:
+    11: Bye Bye [preauth] : 3 Time(s)
+`),
+			want: map[string]any{
+				"failedLogins":       2351,
+				"failedLoginsByIP":   "185.220.101.4: 1939, 45.155.205.33: 412",
+				"failedLoginsByUser": "root: 1613, admin: 642, oracle: 96",
+				"invalidUserLogins":  96,
+				"sshLogins":          4,
+				"sshLoginsByUser":    "deploy: 4",
+			},
+		},
+		{
+			name: "sshd at low detail without failures",
+			content: serviceSection("SSHD", ` Users logging in through sshd:
+    deploy: 2 times
+`),
+			want: map[string]any{
+				"failedLogins":      0,
+				"invalidUserLogins": 0,
+				"sshLogins":         2,
+				"sshLoginsByUser":   "deploy: 2",
+			},
+		},
+		{
+			name: "sudo",
+			content: serviceSection("Sudo (secure-log)", ` deploy => root
+ --------------
+ /usr/bin/systemctl restart nginx
+ /usr/bin/apt update
+
+ admin => root
+ -------------
+ /bin/bash
+`),
+			want: map[string]any{
+				"sudoCommands":       3,
+				"sudoCommandsByUser": "deploy: 2, admin: 1",
+			},
+		},
+		{
+			name: "pam_unix",
+			content: serviceSection("pam_unix", ` sshd:
+    Authentication Failures:
+       root (192.168.1.100): 5 Time(s)
+       unknown (203.0.113.4): 12 Time(s)
+    Sessions Opened:
+       deploy: 4 Time(s)
+
+ sudo:
+    Authentication Failures:
+       deploy: 1 Time(s)
+`),
+			want: map[string]any{
+				"pamAuthFailures":       18,
+				"pamAuthFailuresByUser": "unknown: 12, root: 5, deploy: 1",
+			},
+		},
+		{
+			name: "postfix",
+			content: serviceSection("Postfix", `    4.123K  Bytes accepted                               4,223
+ ========   ==================================================
+
+    1,012   Accepted                                  92.31%
+       84   Rejected                                   7.69%
+ --------   --------------------------------------------------
+    1,096   Total                                    100.00%
+
+      990   Delivered
+        2   Deferred  (3 deferrals)
+        1   Bounced (local)
+        2   Bounced (remote)
+`),
+			want: map[string]any{
+				"mailAccepted":  1012,
+				"mailRejected":  84,
+				"mailDelivered": 990,
+				"mailDeferred":  2,
+				"mailBounced":   3,
+			},
+		},
+		{
+			name: "kernel",
+			content: serviceSection("Kernel", ` WARNING:  Kernel Errors Present
+    EXT4-fs error (device sda1): ext4_find_entry:1455: inode #2: comm nginx: reading directory lblock 0 ...:  2 Time(s)
+    ata1.00: failed command: READ FPDMA QUEUED ...:  1 Time(s)
+
+ WARNING:  Segmentation Faults in these executables
+    php-fpm :  4 Time(s)
+
+ 1 Time(s): Out of memory: Killed process 1234 (java)
+`),
+			want: map[string]any{
+				"kernelErrors": 3,
+				"segfaults":    4,
+				"oomKills":     1,
+			},
+		},
+		{
+			name: "disk space",
+			content: serviceSection("Disk Space", ` Filesystem      Size  Used Avail Use% Mounted on
+ /dev/sda1        40G   38G  2.0G  95% /
+ /dev/sdb1       200G   50G  150G  25% /data
+ tmpfs           1.9G     0  1.9G   0% /dev/shm
+`),
+			want: map[string]any{
+				"diskUsage":           "95% /, 25% /data, 0% /dev/shm",
+				"diskUsageMaxPercent": 95,
+			},
+		},
+		{
+			name: "httpd",
+			content: serviceSection("httpd", ` 16.43 MB transferred in 2345 responses  (1xx 0, 2xx 2010, 3xx 100, 4xx 200, 5xx 35)
+
+ Requests with error response codes
+    404 Not Found
+       /wp-login.php: 25 Time(s)
+       /favicon.ico: 3 Time(s)
+    500 Internal Server Error
+       /api/orders: 2 Time(s)
+
+ A total of 3 sites probed the server
+    203.0.113.9: 40 Time(s)
+`),
+			want: map[string]any{
+				"httpResponses":    2345,
+				"http4xx":          200,
+				"http5xx":          35,
+				"httpErrorsByCode": "404: 28, 500: 2",
+			},
+		},
+		{
+			name:    "unstructured sections",
+			content: "################### sshd ####################\nFailed password for root from 203.0.113.5 port 22\n",
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ParseServiceMetrics(tt.content).Map()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseServiceMetrics() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseServiceMetrics_EvalReport(t *testing.T) {
+	t.Parallel()
+
+	content, err := os.ReadFile(filepath.Join("..", "..", "testdata", "eval", "logwatch-ssh-bruteforce", "logwatch.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metrics := ParseServiceMetrics(string(content))
+	header := metrics.Header()
+	for _, want := range []string{
+		"=== LOGWATCH METRICS (parsed from the report, exact) ===\nfailedLogins: 2351\n",
+		"failedLoginsByIP: 185.220.101.4: 1939, 45.155.205.33: 412\n",
+		"diskUsage: 95% /\n",
+		"kernelErrors: 2\n",
+	} {
+		if !strings.Contains(header, want) {
+			t.Errorf("Header() missing %q:\n%s", want, header)
+		}
+	}
+
+	if got := ParseServiceMetrics("no sections").Header(); got != "" {
+		t.Errorf("Header() without metrics = %q, want empty", got)
+	}
+}
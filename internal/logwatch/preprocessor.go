@@ -8,6 +8,7 @@ import (
 	"math"
 	"regexp"
 	"strings"
+	"unicode"
 
 	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
 )
@@ -18,6 +19,14 @@ var (
 	_ analyzer.BudgetPreprocessor = (*Preprocessor)(nil)
 )
 
+// Patterns used by normalizeLine, compiled once: it runs for every line.
+var (
+	ipRegex        = regexp.MustCompile(`\b\d{1,3}\.\d{1,3}\.\d{1,3}\.\d{1,3}\b`)
+	timestampRegex = regexp.MustCompile(`\b\d{1,2}:\d{2}:\d{2}\b`)
+	dateRegex      = regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}\b|\b\d{2}/\d{2}/\d{4}\b`)
+	numberRegex    = regexp.MustCompile(`\b\d+\b`)
+)
+
 // Preprocessor handles logwatch content preprocessing for large files.
 // Implements analyzer.Preprocessor interface.
 type Preprocessor struct {
@@ -71,6 +80,16 @@ func (p *Preprocessor) processWithMaxTokens(content string, maxTokens int) (stri
 		return content, nil
 	}
 
+	// The metrics header precedes the first section, which parseSections
+	// drops; keep it verbatim and shorten only the report after it.
+	if header, report := splitMetricsHeader(content); header != "" {
+		processed, err := p.processWithMaxTokens(report, max(maxTokens-p.EstimateTokens(header), 1))
+		if err != nil {
+			return "", err
+		}
+		return header + processed, nil
+	}
+
 	// Parse sections
 	sections := p.parseSections(content)
 	if len(sections) == 0 {
@@ -255,25 +274,58 @@ func (p *Preprocessor) normalizeLine(line string) string {
 		return ""
 	}
 
-	// Replace IPs with placeholder
-	ipRegex := regexp.MustCompile(`\b\d{1,3}\.\d{1,3}\.\d{1,3}\.\d{1,3}\b`)
+	// Replace IPs, timestamps, dates, and numbers with placeholders
 	line = ipRegex.ReplaceAllString(line, "IP")
-
-	// Replace timestamps
-	timestampRegex := regexp.MustCompile(`\b\d{1,2}:\d{2}:\d{2}\b`)
 	line = timestampRegex.ReplaceAllString(line, "TIME")
-
-	// Replace dates
-	dateRegex := regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}\b|\b\d{2}/\d{2}/\d{4}\b`)
 	line = dateRegex.ReplaceAllString(line, "DATE")
-
-	// Replace numbers
-	numberRegex := regexp.MustCompile(`\b\d+\b`)
 	line = numberRegex.ReplaceAllString(line, "N")
 
 	return line
 }
 
+// maxStatsLineLength caps the example line length reported by TopRepeatedLines.
+const maxStatsLineLength = 120
+
+// SectionSizes returns the line count of each logwatch section, largest first.
+func (p *Preprocessor) SectionSizes(content string, limit int) []analyzer.StatsItem {
+	counts := make(map[string]int)
+	for _, section := range p.parseSections(content) {
+		counts[section.Name] += strings.Count(section.Content, "\n") + 1
+	}
+	return analyzer.TopCounts(counts, limit)
+}
+
+// TopRepeatedLines returns the most repeated lines after the same
+// normalization used for deduplication (IPs, times, dates, and numbers
+// masked). Each item is named after the first occurrence of the line.
+func (p *Preprocessor) TopRepeatedLines(content string, limit int) []analyzer.StatsItem {
+	lineCounts := make(map[string]int)
+	lineExamples := make(map[string]string)
+	for line := range strings.SplitSeq(content, "\n") {
+		normalized := p.normalizeLine(line)
+		// Separator and banner lines repeat by design
+		if !strings.ContainsFunc(normalized, unicode.IsLetter) {
+			continue
+		}
+		lineCounts[normalized]++
+		if _, ok := lineExamples[normalized]; !ok {
+			example := strings.TrimSpace(line)
+			if runes := []rune(example); len(runes) > maxStatsLineLength {
+				example = string(runes[:maxStatsLineLength-3]) + "..."
+			}
+			lineExamples[normalized] = example
+		}
+	}
+
+	repeated := make(map[string]int)
+	for normalized, count := range lineCounts {
+		if count > 1 {
+			repeated[lineExamples[normalized]] += count
+		}
+	}
+	return analyzer.TopCounts(repeated, limit)
+}
+
 // compressByPriority compresses section content based on its priority
 func (p *Preprocessor) compressByPriority(section *Section) string {
 	var keepRatio float64
@@ -61,6 +61,7 @@ func (p *PromptBuilder) GetSystemPrompt(globalExclusions []string) string {
    - errorCount: total number of errors
    - diskUsage: disk usage percentage or description
    - Any other relevant numerical indicators
+   - The report may start with a "LOGWATCH METRICS" header parsed from the sshd, sudo, pam_unix, postfix, kernel, disk space, and httpd sections (failed logins per IP and user, sudo commands per user, disk usage per mount, ...). Its values are exact: use them as-is for the same keys and in your findings instead of counting yourself
 
 **Output Requirements:**
 
@@ -6,13 +6,22 @@ package logwatch
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
 )
 
-// Compile-time interface check
-var _ analyzer.LogReader = (*Reader)(nil)
+// Compile-time interface checks
+var (
+	_ analyzer.LogReader       = (*Reader)(nil)
+	_ analyzer.StatsReporter   = (*Reader)(nil)
+	_ analyzer.ContentReader   = (*Reader)(nil)
+	_ analyzer.MetricsReporter = (*Reader)(nil)
+)
+
+// maxStatsItems caps the sections and repeated lines listed by ReadStats.
+const maxStatsItems = 10
 
 // Reader handles reading and validating logwatch output files.
 // Implements analyzer.LogReader interface.
@@ -21,6 +30,8 @@ type Reader struct {
 	enablePreprocessing bool
 	maxTokens           int
 	preprocessor        *Preprocessor
+	lastContent         string          // raw content of the last Read, for ReadStats
+	lastMetrics         *ServiceMetrics // metrics parsed from lastContent
 }
 
 // NewReader creates a new logwatch reader
@@ -49,6 +60,27 @@ func (r *Reader) Read(sourcePath string) (string, error) {
 		return "", err
 	}
 
+	return r.process(contentStr)
+}
+
+// ReadContent implements analyzer.ContentReader.
+// Processes a logwatch report that was not read from a file, such as the
+// output of `logwatch --output stdout`.
+func (r *Reader) ReadContent(content string) (string, error) {
+	if err := r.validateContent(content); err != nil {
+		return "", fmt.Errorf("logwatch content validation failed: %w", err)
+	}
+
+	return r.process(content)
+}
+
+// process preprocesses a validated report if enabled and needed, and
+// prepends the metrics parsed from its service sections. The metrics are
+// parsed before preprocessing, so they count every line of the report.
+func (r *Reader) process(contentStr string) (string, error) {
+	r.lastContent = contentStr
+	r.lastMetrics = ParseServiceMetrics(contentStr)
+
 	// Apply preprocessing if enabled
 	if r.enablePreprocessing {
 		tokens := r.preprocessor.EstimateTokens(contentStr)
@@ -57,11 +89,38 @@ func (r *Reader) Read(sourcePath string) (string, error) {
 			if err != nil {
 				return "", fmt.Errorf("preprocessing failed: %w", err)
 			}
-			return processedContent, nil
+			contentStr = processedContent
 		}
 	}
 
-	return contentStr, nil
+	return r.lastMetrics.Header() + contentStr, nil
+}
+
+// ReadStats implements analyzer.StatsReporter.
+// Summarizes the raw report of the last Read (before preprocessing) by
+// section size and most repeated lines.
+func (r *Reader) ReadStats() *analyzer.ReadStats {
+	if r.lastContent == "" {
+		return nil
+	}
+
+	sections := r.preprocessor.parseSections(r.lastContent)
+	stats := &analyzer.ReadStats{
+		Totals: []analyzer.StatsItem{
+			{Name: "Lines", Count: strings.Count(r.lastContent, "\n") + 1},
+			{Name: "Sections", Count: len(sections)},
+		},
+	}
+	stats.AddBreakdown("Largest sections (lines)", r.preprocessor.SectionSizes(r.lastContent, maxStatsItems))
+	stats.AddBreakdown("Top repeated lines", r.preprocessor.TopRepeatedLines(r.lastContent, maxStatsItems))
+
+	return stats
+}
+
+// ReadMetrics implements analyzer.MetricsReporter.
+// Returns the metrics parsed from the service sections of the last Read.
+func (r *Reader) ReadMetrics() map[string]any {
+	return r.lastMetrics.Map()
 }
 
 // ReadLogwatchOutput reads and processes the logwatch output file.
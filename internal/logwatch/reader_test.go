@@ -1,6 +1,7 @@
 package logwatch
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -438,3 +439,111 @@ func TestRead_ExactSizeLimit(t *testing.T) {
 		t.Error("Should not error when file is exactly at size limit")
 	}
 }
+
+func TestReadStats(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "logwatch.txt")
+
+	content := "################### Logwatch 7.4.3 ####################\n" +
+		"Processing Initiated: Mon Jan 1 02:00:00 2026\n" +
+		"################### sshd ####################\n" +
+		strings.Repeat("Failed password for root from 203.0.113.5 port 22\n", 3) +
+		"Failed password for admin from 198.51.100.7 port 2222\n" +
+		"################### kernel ####################\n" +
+		"Out of memory: Killed process 1234 (php-fpm)\n"
+	if err := os.WriteFile(testFile, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	reader := NewReader(10, false, 150000)
+	if stats := reader.ReadStats(); stats != nil {
+		t.Errorf("ReadStats() before Read = %+v, want nil", stats)
+	}
+	if _, err := reader.Read(testFile); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	stats := reader.ReadStats()
+	if stats == nil {
+		t.Fatal("ReadStats() = nil")
+	}
+	if stats.Totals[1].Name != "Sections" || stats.Totals[1].Count != 3 {
+		t.Errorf("Totals = %+v, want 3 sections", stats.Totals)
+	}
+	if len(stats.Breakdowns) != 2 {
+		t.Fatalf("Breakdowns = %+v, want sections and repeated lines", stats.Breakdowns)
+	}
+	if top := stats.Breakdowns[0].Items[0]; top.Name != "sshd" {
+		t.Errorf("largest section = %+v, want sshd", top)
+	}
+	repeated := stats.Breakdowns[1].Items
+	if len(repeated) != 1 || repeated[0].Count != 3 || !strings.HasPrefix(repeated[0].Name, "Failed password for root") {
+		t.Errorf("repeated lines = %+v", repeated)
+	}
+}
+
+func TestReadContent_MetricsHeader(t *testing.T) {
+	content := " ################### Logwatch 7.11 (07/22/24) ####################\n" +
+		serviceSection("SSHD", " Failed logins from:\n    203.0.113.5: 3 times\n    198.51.100.7: 1 time\n") +
+		serviceSection("Disk Space", " Filesystem      Size  Used Avail Use% Mounted on\n /dev/sda1        40G   30G   10G  75% /var\n")
+
+	reader := NewReader(10, false, 150000)
+	if metrics := reader.ReadMetrics(); metrics != nil {
+		t.Errorf("ReadMetrics() before Read = %v, want nil", metrics)
+	}
+	got, err := reader.ReadContent(content)
+	if err != nil {
+		t.Fatalf("ReadContent() error = %v", err)
+	}
+
+	wantHeader := "=== LOGWATCH METRICS (parsed from the report, exact) ===\n" +
+		"failedLogins: 4\n" +
+		"failedLoginsByIP: 203.0.113.5: 3, 198.51.100.7: 1\n" +
+		"invalidUserLogins: 0\n" +
+		"sshLogins: 0\n" +
+		"diskUsage: 75% /var\n" +
+		"diskUsageMaxPercent: 75\n" +
+		"=== END LOGWATCH METRICS ===\n\n"
+	if got != wantHeader+content {
+		t.Errorf("ReadContent() =\n%s\nwant the metrics header followed by the report", got)
+	}
+
+	metrics := reader.ReadMetrics()
+	if metrics["failedLogins"] != 4 || metrics["diskUsage"] != "75% /var" {
+		t.Errorf("ReadMetrics() = %v", metrics)
+	}
+}
+
+func TestReadContent_MetricsHeaderSurvivesBudget(t *testing.T) {
+	var failed strings.Builder
+	failed.WriteString(" Failed logins from:\n")
+	for i := range 2000 {
+		fmt.Fprintf(&failed, "    10.0.%d.%d: 1 time\n", i/250, i%250)
+	}
+	content := " ################### Logwatch 7.11 (07/22/24) ####################\n" +
+		serviceSection("SSHD", failed.String()) +
+		serviceSection("Cron", strings.Repeat(" Routine cron job completed for user www-data\n", 2000))
+
+	reader := NewReader(10, false, 150000)
+	got, err := reader.ReadContent(content)
+	if err != nil {
+		t.Fatalf("ReadContent() error = %v", err)
+	}
+	header := reader.lastMetrics.Header()
+	if !strings.HasPrefix(got, header) || !strings.Contains(header, "failedLogins: 2000\n") {
+		t.Fatalf("ReadContent() header =\n%s", header)
+	}
+
+	// The prompt budget shortens the report, not the exact metrics
+	budget := reader.preprocessor.EstimateTokens(header) + 2000
+	processed, err := reader.preprocessor.ProcessWithBudget(got, budget)
+	if err != nil {
+		t.Fatalf("ProcessWithBudget() error = %v", err)
+	}
+	if !strings.HasPrefix(processed, header) {
+		t.Errorf("ProcessWithBudget() dropped the metrics header:\n%.500s", processed)
+	}
+	if tokens := reader.preprocessor.EstimateTokens(processed); tokens > budget {
+		t.Errorf("ProcessWithBudget() produced %d tokens, want <= %d", tokens, budget)
+	}
+}
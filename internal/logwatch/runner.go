@@ -0,0 +1,235 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package logwatch
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olegiv/logwatch-ai-go/internal/sourcecmd"
+)
+
+// DefaultBinaryPaths are searched for the logwatch binary, before PATH,
+// when none is configured: MacPorts, then Linux distributions.
+var DefaultBinaryPaths = []string{"/opt/local/bin/logwatch", "/usr/sbin/logwatch"}
+
+// Defaults of the logwatch runner, matching scripts/generate-logwatch.sh.
+const (
+	DefaultDetail = "low"
+	DefaultRange  = "yesterday"
+)
+
+// Runner invokes logwatch and captures the report from its stdout instead
+// of reading the file written by scripts/generate-logwatch.sh.
+type Runner struct {
+	Binary        string            // logwatch binary; empty searches DefaultBinaryPaths and PATH
+	Detail        string            // --detail: low, med, high, or 0-10
+	Range         string            // --range, e.g. yesterday or "between -7 days and -1 days"
+	Services      []string          // --service per name; empty runs all services
+	ServiceDetail map[string]string // detail of single services, overriding Detail
+	Hostname      string            // --hostname of the report, empty for the local host
+	Timeout       time.Duration     // total time of all logwatch runs
+	Env           []string          // names of environment variables passed to logwatch
+}
+
+// Validate checks the runner for configuration errors.
+func (r *Runner) Validate() error {
+	if err := validateDetail(r.Detail); err != nil {
+		return err
+	}
+	if strings.TrimSpace(r.Range) == "" || strings.ContainsAny(r.Range, "\r\n") {
+		return fmt.Errorf("invalid range %q", r.Range)
+	}
+	for _, service := range r.Services {
+		if service == "" || strings.ContainsAny(service, " \t\r\n") {
+			return fmt.Errorf("invalid service name %q", service)
+		}
+	}
+	for service, detail := range r.ServiceDetail {
+		if err := validateDetail(detail); err != nil {
+			return fmt.Errorf("service %s: %w", service, err)
+		}
+		if len(r.Services) > 0 && !slices.Contains(r.Services, service) {
+			return fmt.Errorf("detail override for service %s, which is not selected", service)
+		}
+	}
+	if r.Timeout <= 0 {
+		return fmt.Errorf("timeout must be positive (got: %s)", r.Timeout)
+	}
+	return nil
+}
+
+// validateDetail accepts the detail levels of logwatch: low, med, high,
+// or a number from 0 to 10.
+func validateDetail(detail string) error {
+	switch detail {
+	case "low", "med", "high":
+		return nil
+	}
+	if level, err := strconv.Atoi(detail); err == nil && level >= 0 && level <= 10 {
+		return nil
+	}
+	return fmt.Errorf("detail must be low, med, high, or 0-10 (got: %s)", detail)
+}
+
+// ParseServices parses a comma-separated list of logwatch services.
+func ParseServices(s string) []string {
+	var services []string
+	for service := range strings.SplitSeq(s, ",") {
+		if service = strings.TrimSpace(service); service != "" {
+			services = append(services, service)
+		}
+	}
+	return services
+}
+
+// ParseServiceDetail parses comma-separated service=detail overrides, such
+// as "sshd=high,http=5".
+func ParseServiceDetail(s string) (map[string]string, error) {
+	overrides := make(map[string]string)
+	for item := range strings.SplitSeq(s, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		service, detail, ok := strings.Cut(item, "=")
+		service, detail = strings.TrimSpace(service), strings.TrimSpace(detail)
+		if !ok || service == "" || detail == "" {
+			return nil, fmt.Errorf("service detail must be service=detail (got: %s)", item)
+		}
+		overrides[service] = detail
+	}
+	return overrides, nil
+}
+
+// Run runs logwatch and returns its report. Services with a detail
+// override are reported by separate logwatch runs, one per detail level,
+// appended to the report of the other services. The run fails if logwatch
+// exits with a non-zero status, the runs take longer than the timeout, or
+// the reports exceed maxBytes.
+func (r *Runner) Run(ctx context.Context, maxBytes int64) (*sourcecmd.Result, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	binary, err := r.binary()
+	if err != nil {
+		return nil, err
+	}
+
+	var report strings.Builder
+	started := time.Now()
+	for _, args := range r.invocations(binary) {
+		remaining := r.Timeout - time.Since(started)
+		if remaining <= 0 {
+			return nil, fmt.Errorf("logwatch timed out after %s", r.Timeout)
+		}
+		command := sourcecmd.Command{Args: args, Timeout: remaining, Env: r.Env}
+		result, err := sourcecmd.Run(ctx, command, maxBytes-int64(report.Len()))
+		if err != nil {
+			return nil, fmt.Errorf("logwatch failed (%s): %w", command.String(), err)
+		}
+		report.WriteString(result.Output)
+	}
+
+	return &sourcecmd.Result{
+		Output:   report.String(),
+		Started:  started,
+		Duration: time.Since(started),
+	}, nil
+}
+
+// Commands returns the logwatch command lines of Run, for logs.
+func (r *Runner) Commands() []string {
+	binary, err := r.binary()
+	if err != nil {
+		binary = "logwatch"
+	}
+	var commands []string
+	for _, args := range r.invocations(binary) {
+		commands = append(commands, (&sourcecmd.Command{Args: args}).String())
+	}
+	return commands
+}
+
+// binary returns the configured logwatch binary or the first one found.
+func (r *Runner) binary() (string, error) {
+	if r.Binary != "" {
+		return r.Binary, nil
+	}
+	for _, path := range DefaultBinaryPaths {
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, nil
+		}
+	}
+	path, err := exec.LookPath("logwatch")
+	if err != nil {
+		return "", fmt.Errorf("logwatch binary not found in %s or PATH; set LOGWATCH_BINARY",
+			strings.Join(DefaultBinaryPaths, ", "))
+	}
+	return path, nil
+}
+
+// invocations returns the arguments of each logwatch run: the services
+// without a detail override at Detail, then the overridden services
+// grouped by detail level.
+func (r *Runner) invocations(binary string) [][]string {
+	byDetail := make(map[string][]string)
+	for service, detail := range r.ServiceDetail {
+		byDetail[detail] = append(byDetail[detail], service)
+	}
+
+	var runs [][]string
+	switch {
+	case len(r.Services) > 0:
+		var rest []string
+		for _, service := range r.Services {
+			if _, overridden := r.ServiceDetail[service]; !overridden {
+				rest = append(rest, service)
+			}
+		}
+		if len(rest) > 0 {
+			runs = append(runs, r.args(binary, r.Detail, rest))
+		}
+	case len(r.ServiceDetail) > 0:
+		// All services except the overridden ones
+		services := []string{"All"}
+		for _, service := range slices.Sorted(maps.Keys(r.ServiceDetail)) {
+			services = append(services, "-"+service)
+		}
+		runs = append(runs, r.args(binary, r.Detail, services))
+	default:
+		runs = append(runs, r.args(binary, r.Detail, nil))
+	}
+
+	for _, detail := range slices.Sorted(maps.Keys(byDetail)) {
+		services := byDetail[detail]
+		slices.Sort(services)
+		runs = append(runs, r.args(binary, detail, services))
+	}
+	return runs
+}
+
+// args returns the arguments of one logwatch run.
+func (r *Runner) args(binary, detail string, services []string) []string {
+	args := []string{
+		binary,
+		"--output", "stdout",
+		"--format", "text",
+		"--detail", detail,
+		"--range", r.Range,
+	}
+	if r.Hostname != "" {
+		args = append(args, "--hostname", r.Hostname)
+	}
+	for _, service := range services {
+		args = append(args, "--service", service)
+	}
+	return args
+}
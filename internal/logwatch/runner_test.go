@@ -0,0 +1,181 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package logwatch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/olegiv/logwatch-ai-go/internal/sourcecmd"
+)
+
+// writeStub writes a logwatch stand-in that prints its arguments and runs
+// the extra shell commands.
+func writeStub(t *testing.T, extra string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "logwatch")
+	script := "#!/bin/sh\necho \"logwatch $*\"\n" + extra + "\n"
+	if err := os.WriteFile(path, []byte(script), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func newRunner(binary string) *Runner {
+	return &Runner{
+		Binary:  binary,
+		Detail:  DefaultDetail,
+		Range:   DefaultRange,
+		Timeout: 10 * time.Second,
+		Env:     sourcecmd.DefaultEnv,
+	}
+}
+
+func TestRunner_Run(t *testing.T) {
+	t.Parallel()
+
+	stub := writeStub(t, "")
+	tests := []struct {
+		name   string
+		modify func(r *Runner)
+		want   []string
+	}{
+		{
+			name: "all services",
+			want: []string{"logwatch --output stdout --format text --detail low --range yesterday"},
+		},
+		{
+			name: "services, hostname, and range",
+			modify: func(r *Runner) {
+				r.Range = "between -7 days and -1 days"
+				r.Services = []string{"sshd", "pam_unix"}
+				r.Hostname = "web1"
+			},
+			want: []string{"logwatch --output stdout --format text --detail low --range between -7 days and -1 days --hostname web1 --service sshd --service pam_unix"},
+		},
+		{
+			name: "detail overrides of all services",
+			modify: func(r *Runner) {
+				r.ServiceDetail = map[string]string{"sshd": "high", "http": "high", "kernel": "5"}
+			},
+			want: []string{
+				"logwatch --output stdout --format text --detail low --range yesterday --service All --service -http --service -kernel --service -sshd",
+				"logwatch --output stdout --format text --detail 5 --range yesterday --service kernel",
+				"logwatch --output stdout --format text --detail high --range yesterday --service http --service sshd",
+			},
+		},
+		{
+			name: "detail override of a selected service",
+			modify: func(r *Runner) {
+				r.Services = []string{"sshd", "sudo"}
+				r.ServiceDetail = map[string]string{"sshd": "10"}
+			},
+			want: []string{
+				"logwatch --output stdout --format text --detail low --range yesterday --service sudo",
+				"logwatch --output stdout --format text --detail 10 --range yesterday --service sshd",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := newRunner(stub)
+			if tt.modify != nil {
+				tt.modify(r)
+			}
+			result, err := r.Run(context.Background(), 1024*1024)
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			got := strings.Split(strings.TrimSpace(result.Output), "\n")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Run() output =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestRunner_RunFailures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		extra    string
+		timeout  time.Duration
+		maxBytes int64
+		want     string
+	}{
+		{"exit status", "echo 'no such service' >&2; exit 1", 10 * time.Second, 1024, "no such service"},
+		{"timeout", "sleep 5", 200 * time.Millisecond, 1024, "timed out"},
+		{"size cap", "head -c 4096 /dev/zero", 10 * time.Second, 1024, "exceeds maximum size"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := newRunner(writeStub(t, tt.extra))
+			r.Timeout = tt.timeout
+			_, err := r.Run(context.Background(), tt.maxBytes)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Run() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunner_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		modify func(r *Runner)
+		want   string
+	}{
+		{"invalid detail", func(r *Runner) { r.Detail = "11" }, "detail must be"},
+		{"empty range", func(r *Runner) { r.Range = " " }, "invalid range"},
+		{"invalid service", func(r *Runner) { r.Services = []string{"ss hd"} }, "invalid service name"},
+		{"invalid override", func(r *Runner) { r.ServiceDetail = map[string]string{"sshd": "max"} }, "service sshd"},
+		{
+			"override of an unselected service",
+			func(r *Runner) {
+				r.Services = []string{"sudo"}
+				r.ServiceDetail = map[string]string{"sshd": "high"}
+			},
+			"not selected",
+		},
+		{"timeout", func(r *Runner) { r.Timeout = 0 }, "timeout must be positive"},
+	}
+
+	for _, tt := range tests {
+		r := newRunner("/usr/sbin/logwatch")
+		tt.modify(r)
+		if err := r.Validate(); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: Validate() error = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestParseServiceDetail(t *testing.T) {
+	t.Parallel()
+
+	got, err := ParseServiceDetail(" sshd=high, http = 5 ,")
+	if err != nil {
+		t.Fatalf("ParseServiceDetail() error = %v", err)
+	}
+	if want := map[string]string{"sshd": "high", "http": "5"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseServiceDetail() = %v, want %v", got, want)
+	}
+
+	if _, err := ParseServiceDetail("sshd"); err == nil {
+		t.Error("ParseServiceDetail(without detail) expected error")
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package memtest measures the peak memory used by code under test. The
+// benchmarks and tests of the streaming readers use it to show that their
+// memory stays bounded however large the input is.
+package memtest
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+// sampleInterval is how often a Sampler reads the heap statistics.
+const sampleInterval = 2 * time.Millisecond
+
+// Sampler records the peak heap in use between Start and Stop.
+type Sampler struct {
+	base uint64
+	peak uint64
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// Start collects garbage and starts sampling the heap in use.
+func Start() *Sampler {
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+
+	s := &Sampler{base: stats.HeapInuse, peak: stats.HeapInuse, stop: make(chan struct{})}
+	s.wg.Go(func() {
+		ticker := time.NewTicker(sampleInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				s.sample()
+			}
+		}
+	})
+	return s
+}
+
+// sample reads the heap in use and updates the peak.
+func (s *Sampler) sample() {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	s.peak = max(s.peak, stats.HeapInuse)
+}
+
+// Stop stops sampling and returns the peak heap in use above the heap in
+// use at Start, in bytes.
+func (s *Sampler) Stop() uint64 {
+	close(s.stop)
+	s.wg.Wait()
+	s.sample()
+	if s.peak < s.base {
+		return 0
+	}
+	return s.peak - s.base
+}
+
+// MB converts bytes to megabytes for reporting.
+func MB(bytes uint64) float64 {
+	return float64(bytes) / 1024 / 1024
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package memtest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// boundedSizeMB is the size of the input CheckBoundedRead summarizes.
+const boundedSizeMB = 64
+
+// WriteFunc writes an input of about size bytes and returns its path.
+type WriteFunc func(tb testing.TB, size int64) string
+
+// ReadFunc summarizes the input at path, whose size limit is maxSizeMB.
+type ReadFunc func(path string, maxSizeMB int) (string, error)
+
+// BenchmarkRead reports the peak heap of reading inputs of growing size,
+// which stays flat for a streaming reader with bounded state.
+func BenchmarkRead(b *testing.B, write WriteFunc, read ReadFunc) {
+	for _, sizeMB := range []int64{16, 64, 256} {
+		b.Run(fmt.Sprintf("%dMB", sizeMB), func(b *testing.B) {
+			path := write(b, sizeMB<<20)
+			b.SetBytes(sizeMB << 20)
+			b.ReportAllocs()
+
+			sampler := Start()
+			for b.Loop() {
+				if _, err := read(path, int(sizeMB)*2); err != nil {
+					b.Fatalf("Read() error = %v", err)
+				}
+			}
+			b.ReportMetric(MB(sampler.Stop()), "peak-heap-MB")
+			b.ReportMetric(MB(MaxRSS()), "max-rss-MB")
+		})
+	}
+}
+
+// CheckBoundedRead checks that a large input is summarized within
+// maxPeakHeapMB, far below its size, and that the summary contains
+// limitMarker, the note a reader adds once its pattern limit is reached.
+func CheckBoundedRead(t *testing.T, write WriteFunc, read ReadFunc, maxPeakHeapMB int, limitMarker string) {
+	t.Helper()
+	if testing.Short() {
+		t.Skipf("writes a %dMB input", boundedSizeMB)
+	}
+
+	path := write(t, boundedSizeMB<<20)
+
+	sampler := Start()
+	content, err := read(path, boundedSizeMB*2)
+	peak := sampler.Stop()
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	t.Logf("peak heap %.1fMB", MB(peak))
+	if !strings.Contains(content, limitMarker) {
+		t.Error("Read() did not reach the pattern limit")
+	}
+	if MB(peak) > float64(maxPeakHeapMB) {
+		t.Errorf("peak heap = %.1fMB, want at most %dMB", MB(peak), maxPeakHeapMB)
+	}
+}
@@ -0,0 +1,11 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build !unix
+
+package memtest
+
+// MaxRSS returns 0: the peak resident set size is not available.
+func MaxRSS() uint64 {
+	return 0
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build unix
+
+package memtest
+
+import (
+	"runtime"
+	"syscall"
+)
+
+// MaxRSS returns the peak resident set size of the process in bytes, or 0
+// if it is not available. The peak covers the lifetime of the process, so
+// it only bounds the memory of the code under test from above.
+func MaxRSS() uint64 {
+	var usage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); err != nil || usage.Maxrss < 0 {
+		return 0
+	}
+	// Linux and the BSDs report kilobytes, macOS bytes
+	if runtime.GOOS == "darwin" || runtime.GOOS == "ios" {
+		return uint64(usage.Maxrss)
+	}
+	return uint64(usage.Maxrss) * 1024
+}
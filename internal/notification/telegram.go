@@ -7,12 +7,14 @@ package notification
 import (
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 	"time"
 	"unicode/utf8"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/olegiv/logwatch-ai-go/internal/ai"
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
 	internalerrors "github.com/olegiv/logwatch-ai-go/internal/errors"
 )
 
@@ -33,7 +35,8 @@ type TelegramClient struct {
 	archiveChannel  int64
 	alertsChannel   int64
 	hostname        string
-	lastMessageTime time.Time // tracks last message for rate limiting (L-01 fix)
+	sourceNames     map[string]string // display names of custom source types
+	lastMessageTime time.Time         // tracks last message for rate limiting (L-01 fix)
 }
 
 // NewTelegramClient creates a new Telegram client
@@ -44,20 +47,32 @@ func NewTelegramClient(botToken string, archiveChannel, alertsChannel int64) (*T
 		return nil, internalerrors.Wrapf(err, "failed to create Telegram bot")
 	}
 
-	// Get hostname for reports
-	hostname, err := os.Hostname()
-	if err != nil {
-		hostname = "unknown"
-	}
-
 	return &TelegramClient{
 		bot:            bot,
 		archiveChannel: archiveChannel,
 		alertsChannel:  alertsChannel,
-		hostname:       hostname,
+		hostname:       localHostname(),
 	}, nil
 }
 
+// localHostname returns the host name of this machine for reports
+func localHostname() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "unknown"
+	}
+	return hostname
+}
+
+// SetHostname sets the host shown in reports, such as the host a collected
+// logwatch report comes from. An empty hostname restores the local host.
+func (t *TelegramClient) SetHostname(hostname string) {
+	if hostname == "" {
+		hostname = localHostname()
+	}
+	t.hostname = hostname
+}
+
 // SendAnalysisReport sends the analysis report to Telegram channels
 // siteName is optional and used for multi-site Drupal deployments to identify the site in the report.
 func (t *TelegramClient) SendAnalysisReport(analysis *ai.Analysis, stats *ai.Stats, logSourceType, siteName string) error {
@@ -97,12 +112,34 @@ func writeSection(msg *strings.Builder, emoji, title string, items []string, sho
 	msg.WriteString("\n")
 }
 
+// writeEnsembleSection writes each model's status and the findings that not
+// every successful model reported.
+func writeEnsembleSection(msg *strings.Builder, ensemble *ai.EnsembleResult) {
+	fmt.Fprintf(msg, "🤝 *Model Agreement* \\(status rule\\: %s\\)\n", escapeMarkdown(ensemble.StatusRule))
+	for _, vote := range ensemble.Votes {
+		if vote.Error != "" {
+			fmt.Fprintf(msg, "• %s\\: failed\n", escapeMarkdown(vote.Model))
+			continue
+		}
+		fmt.Fprintf(msg, "• %s\\: %s %s\n", escapeMarkdown(vote.Model), ai.GetStatusEmoji(vote.Status), escapeMarkdown(vote.Status))
+	}
+
+	disagreements := ensemble.Disagreements()
+	if len(disagreements) > 0 {
+		fmt.Fprintf(msg, "_Reported by some models only_ \\(%d\\)\n", len(disagreements))
+		for i, f := range disagreements {
+			fmt.Fprintf(msg, "%d\\. %s \\[%s\\]\n", i+1, escapeMarkdown(f.Text), escapeMarkdown(strings.Join(f.Models, ", ")))
+		}
+	}
+	msg.WriteString("\n")
+}
+
 // formatMessage formats the analysis into a Telegram message
 func (t *TelegramClient) formatMessage(analysis *ai.Analysis, stats *ai.Stats, logSourceType, siteName string) string {
 	var msg strings.Builder
 
 	// Header with log source type and optional site name
-	sourceDisplayName := getLogSourceDisplayName(logSourceType)
+	sourceDisplayName := t.sourceDisplayName(logSourceType)
 	if siteName != "" {
 		fmt.Fprintf(&msg, "🔍 *%s Report* \\- %s\n", sourceDisplayName, escapeMarkdown(siteName))
 	} else {
@@ -138,6 +175,11 @@ func (t *TelegramClient) formatMessage(analysis *ai.Analysis, stats *ai.Stats, l
 	writeSection(&msg, "⚡", "Warnings", analysis.Warnings, true)
 	writeSection(&msg, "💡", "Recommendations", analysis.Recommendations, false)
 
+	// Per-model statuses and findings the models disagreed on
+	if analysis.Ensemble != nil {
+		writeEnsembleSection(&msg, analysis.Ensemble)
+	}
+
 	// Key Metrics
 	if len(analysis.Metrics) > 0 {
 		msg.WriteString("📈 *Key Metrics*\n")
@@ -302,6 +344,24 @@ func (t *TelegramClient) splitMessage(message string) []string {
 	return messages
 }
 
+// SetSourceName sets the display name of a source type that has none
+// built in, such as a custom source of custom-sources.json.
+func (t *TelegramClient) SetSourceName(logSourceType, name string) {
+	if t.sourceNames == nil {
+		t.sourceNames = make(map[string]string)
+	}
+	t.sourceNames[logSourceType] = name
+}
+
+// sourceDisplayName returns the display name of a source type: the name
+// set by SetSourceName, or the built-in one.
+func (t *TelegramClient) sourceDisplayName(logSourceType string) string {
+	if name, ok := t.sourceNames[logSourceType]; ok {
+		return name
+	}
+	return getLogSourceDisplayName(logSourceType)
+}
+
 // getLogSourceDisplayName returns a human-readable display name for log source types
 func getLogSourceDisplayName(logSourceType string) string {
 	switch logSourceType {
@@ -311,6 +371,14 @@ func getLogSourceDisplayName(logSourceType string) string {
 		return "Drupal Watchdog"
 	case "ocms":
 		return "OCMS"
+	case "journald":
+		return "Systemd Journal"
+	case "access_log":
+		return "Access Log"
+	case "syslog":
+		return "Syslog"
+	case "docker":
+		return "Docker"
 	default:
 		return "Log"
 	}
@@ -338,13 +406,14 @@ func escapeMarkdown(text string) string {
 }
 
 // SendNoEntriesReport sends an informational message when no log entries were found.
-// This is used for Drupal watchdog when there are no entries for the analyzed time period.
+// This is used for Drupal watchdog and journal exports when there are no entries for the
+// analyzed time period.
 // siteName is optional and used for multi-site Drupal deployments.
 func (t *TelegramClient) SendNoEntriesReport(logSourceType, siteName string) error {
 	var msg strings.Builder
 
 	// Header with log source type and optional site name
-	sourceDisplayName := getLogSourceDisplayName(logSourceType)
+	sourceDisplayName := t.sourceDisplayName(logSourceType)
 	if siteName != "" {
 		fmt.Fprintf(&msg, "ℹ️ *%s Report* \\- %s\n", sourceDisplayName, escapeMarkdown(siteName))
 	} else {
@@ -367,6 +436,161 @@ func (t *TelegramClient) SendNoEntriesReport(logSourceType, siteName string) err
 	return nil
 }
 
+// SendDegradedReport sends a report built without LLM analysis: the
+// reader's own statistics plus any deterministic rule findings carried in
+// analysis. Like a regular report it also goes to the alerts channel when
+// the status warrants it.
+func (t *TelegramClient) SendDegradedReport(analysis *ai.Analysis, readStats *analyzer.ReadStats, logSourceType, siteName string) error {
+	message := t.formatDegradedMessage(analysis, readStats, logSourceType, siteName)
+
+	if err := t.sendToChannel(t.archiveChannel, message); err != nil {
+		return fmt.Errorf("failed to send degraded report to archive channel: %w", err)
+	}
+
+	if t.alertsChannel != 0 && ai.ShouldTriggerAlert(analysis.SystemStatus) {
+		if err := t.sendToChannel(t.alertsChannel, message); err != nil {
+			return fmt.Errorf("failed to send degraded report to alerts channel: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// formatDegradedMessage formats a report without LLM analysis
+func (t *TelegramClient) formatDegradedMessage(analysis *ai.Analysis, readStats *analyzer.ReadStats, logSourceType, siteName string) string {
+	var msg strings.Builder
+
+	sourceDisplayName := t.sourceDisplayName(logSourceType)
+	if siteName != "" {
+		fmt.Fprintf(&msg, "⚠️ *%s Report* \\- %s\n", sourceDisplayName, escapeMarkdown(siteName))
+	} else {
+		fmt.Fprintf(&msg, "⚠️ *%s Report*\n", sourceDisplayName)
+	}
+	fmt.Fprintf(&msg, "🖥 Host\\: %s\n", escapeMarkdown(t.hostname))
+	fmt.Fprintf(&msg, "📅 Date\\: %s\n", escapeMarkdown(time.Now().Format("2006-01-02 15:04:05")))
+	fmt.Fprintf(&msg, "🌍 Timezone\\: %s\n", escapeMarkdown(time.Now().Location().String()))
+	fmt.Fprintf(&msg, "%s *Status\\:* %s\n\n", ai.GetStatusEmoji(analysis.SystemStatus), analysis.SystemStatus)
+
+	msg.WriteString("🚫 *AI analysis unavailable*\n")
+	msg.WriteString(escapeMarkdown(analysis.Summary))
+	msg.WriteString("\n\n")
+
+	writeSection(&msg, "🔴", "Critical Issues", analysis.CriticalIssues, true)
+	writeSection(&msg, "⚡", "Warnings", analysis.Warnings, true)
+
+	if readStats != nil {
+		msg.WriteString("📊 *Reader Statistics*\n")
+		for _, item := range readStats.Totals {
+			fmt.Fprintf(&msg, "• %s\\: %d\n", escapeMarkdown(item.Name), item.Count)
+		}
+		msg.WriteString("\n")
+
+		for _, breakdown := range readStats.Breakdowns {
+			fmt.Fprintf(&msg, "*%s*\n", escapeMarkdown(breakdown.Title))
+			for _, item := range breakdown.Items {
+				fmt.Fprintf(&msg, "• %s\\: %d\n", escapeMarkdown(item.Name), item.Count)
+			}
+			msg.WriteString("\n")
+		}
+	}
+
+	writeSection(&msg, "💡", "Recommendations", analysis.Recommendations, false)
+
+	return msg.String()
+}
+
+// FleetHost is the outcome of one host of a multi-host run
+type FleetHost struct {
+	Name   string
+	Status string // System status, empty if the host reported no analysis
+	Failed bool   // The analysis of the host failed
+}
+
+// SendFleetReport sends the roll-up of a multi-host run, ranking the hosts
+// from failed and worst status to best. It goes to the alerts channel when
+// a host failed or its status warrants an alert.
+func (t *TelegramClient) SendFleetReport(logSourceType string, hosts []FleetHost) error {
+	message := t.formatFleetMessage(logSourceType, hosts)
+
+	if err := t.sendToChannel(t.archiveChannel, message); err != nil {
+		return fmt.Errorf("failed to send fleet report to archive channel: %w", err)
+	}
+
+	alert := false
+	for _, host := range hosts {
+		if host.Failed || ai.ShouldTriggerAlert(host.Status) {
+			alert = true
+			break
+		}
+	}
+	if t.alertsChannel != 0 && alert {
+		if err := t.sendToChannel(t.alertsChannel, message); err != nil {
+			return fmt.Errorf("failed to send fleet report to alerts channel: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// formatFleetMessage formats the roll-up of a multi-host run
+func (t *TelegramClient) formatFleetMessage(logSourceType string, hosts []FleetHost) string {
+	ranked := slices.Clone(hosts)
+	slices.SortStableFunc(ranked, func(a, b FleetHost) int {
+		if a.Failed != b.Failed {
+			if a.Failed {
+				return -1
+			}
+			return 1
+		}
+		if rankA, rankB := ai.StatusRank(a.Status), ai.StatusRank(b.Status); rankA != rankB {
+			return rankB - rankA
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	failed := 0
+	statusCounts := make(map[string]int)
+	for _, host := range ranked {
+		if host.Failed {
+			failed++
+		} else {
+			statusCounts[host.Status]++
+		}
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "🚦 *%s Fleet Report*\n", t.sourceDisplayName(logSourceType))
+	fmt.Fprintf(&msg, "🖥 Hosts\\: %d", len(ranked))
+	if failed > 0 {
+		fmt.Fprintf(&msg, " \\(%d failed\\)", failed)
+	}
+	msg.WriteString("\n")
+	fmt.Fprintf(&msg, "📅 Date\\: %s\n", escapeMarkdown(time.Now().Format("2006-01-02 15:04:05")))
+	fmt.Fprintf(&msg, "🌍 Timezone\\: %s\n\n", escapeMarkdown(time.Now().Location().String()))
+
+	msg.WriteString("📊 *Status*\n")
+	for _, status := range []string{"Awful", "Bad", "Satisfactory", "Good", "Excellent"} {
+		if count := statusCounts[status]; count > 0 {
+			fmt.Fprintf(&msg, "• %s %s\\: %d\n", ai.GetStatusEmoji(status), status, count)
+		}
+	}
+	msg.WriteString("\n")
+
+	msg.WriteString("🏁 *Hosts by Status*\n")
+	for i, host := range ranked {
+		switch {
+		case host.Failed:
+			fmt.Fprintf(&msg, "%d\\. ❌ %s \\- analysis failed\n", i+1, escapeMarkdown(host.Name))
+		case host.Status == "":
+			fmt.Fprintf(&msg, "%d\\. ⚪ %s \\- no analysis\n", i+1, escapeMarkdown(host.Name))
+		default:
+			fmt.Fprintf(&msg, "%d\\. %s %s \\- %s\n", i+1, ai.GetStatusEmoji(host.Status), escapeMarkdown(host.Name), host.Status)
+		}
+	}
+
+	return msg.String()
+}
+
 // GetBotInfo returns information about the bot
 func (t *TelegramClient) GetBotInfo() map[string]any {
 	return map[string]any{
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/olegiv/logwatch-ai-go/internal/ai"
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
 )
 
 func TestFormatMessage(t *testing.T) {
@@ -473,6 +474,26 @@ func TestGetLogSourceDisplayName(t *testing.T) {
 			logSourceType:  "ocms",
 			expectedResult: "OCMS",
 		},
+		{
+			name:           "journald source",
+			logSourceType:  "journald",
+			expectedResult: "Systemd Journal",
+		},
+		{
+			name:           "access log source",
+			logSourceType:  "access_log",
+			expectedResult: "Access Log",
+		},
+		{
+			name:           "syslog source",
+			logSourceType:  "syslog",
+			expectedResult: "Syslog",
+		},
+		{
+			name:           "docker source",
+			logSourceType:  "docker",
+			expectedResult: "Docker",
+		},
 		{
 			name:           "unknown source",
 			logSourceType:  "unknown",
@@ -971,3 +992,145 @@ func TestConstants(t *testing.T) {
 		t.Error("baseRetryDelay should be positive")
 	}
 }
+
+func TestFormatMessage_Ensemble(t *testing.T) {
+	client := &TelegramClient{hostname: "test-server"}
+
+	analysis := &ai.Analysis{
+		SystemStatus:    "Bad",
+		Summary:         "SSH brute force",
+		CriticalIssues:  []string{"SSH brute force (2/2 models)"},
+		Warnings:        []string{"Kernel OOM killer invoked (1/2 models)"},
+		Recommendations: []string{},
+		Ensemble: &ai.EnsembleResult{
+			StatusRule: "worst",
+			Votes: []ai.EnsembleVote{
+				{Model: "anthropic:claude-haiku-4-5-20251001", Status: "Bad"},
+				{Model: "ollama:llama3.3:latest", Status: "Satisfactory"},
+			},
+			Findings: []ai.EnsembleFinding{
+				{Text: "SSH brute force", Severity: "critical", Models: []string{"a", "b"}, Agreement: 2},
+				{Text: "Kernel OOM killer invoked", Severity: "warning", Models: []string{"ollama:llama3.3:latest"}, Agreement: 1},
+			},
+		},
+	}
+	stats := &ai.Stats{Provider: "Ensemble", Model: "a + b"}
+
+	message := client.formatMessage(analysis, stats, "logwatch", "")
+
+	for _, want := range []string{
+		"Model Agreement",
+		"ollama\\:llama3\\.3\\:latest\\: 🟡 Satisfactory",
+		"Reported by some models only_ \\(1\\)",
+		"Kernel OOM killer invoked \\[ollama\\:",
+	} {
+		if !strings.Contains(message, want) {
+			t.Errorf("message missing %q:\n%s", want, message)
+		}
+	}
+}
+
+func TestFormatDegradedMessage(t *testing.T) {
+	client := &TelegramClient{hostname: "test-server"}
+
+	analysis := &ai.Analysis{
+		SystemStatus:    "Bad",
+		Summary:         "AI analysis unavailable (Ollama). Reader statistics only.",
+		CriticalIssues:  []string{"Drupal critical entries (count: 2, rule: drupal-critical)"},
+		Warnings:        []string{},
+		Recommendations: []string{"Check LLM provider connectivity"},
+	}
+	readStats := &analyzer.ReadStats{
+		Totals: []analyzer.StatsItem{{Name: "Entries", Count: 120}},
+		Breakdowns: []analyzer.StatsBreakdown{
+			{Title: "Severity", Items: []analyzer.StatsItem{{Name: "critical", Count: 2}, {Name: "error", Count: 10}}},
+		},
+	}
+
+	message := client.formatDegradedMessage(analysis, readStats, "drupal_watchdog", "production")
+
+	for _, want := range []string{
+		"Drupal Watchdog Report* \\- production",
+		"AI analysis unavailable*",
+		"🔴 *Critical Issues* \\(1\\)",
+		"Reader Statistics",
+		"• Entries\\: 120",
+		"*Severity*\n• critical\\: 2\n• error\\: 10",
+		"Check LLM provider connectivity",
+	} {
+		if !strings.Contains(message, want) {
+			t.Errorf("message missing %q:\n%s", want, message)
+		}
+	}
+	if strings.Contains(message, "Warnings") {
+		t.Errorf("empty warnings section rendered:\n%s", message)
+	}
+
+	// Without reader statistics the message still renders
+	if message := client.formatDegradedMessage(analysis, nil, "logwatch", ""); strings.Contains(message, "Reader Statistics") {
+		t.Errorf("unexpected statistics section:\n%s", message)
+	}
+}
+
+func TestFormatFleetMessage(t *testing.T) {
+	client := &TelegramClient{hostname: "collector"}
+
+	message := client.formatFleetMessage("logwatch", []FleetHost{
+		{Name: "web01", Status: "Good"},
+		{Name: "db01", Status: "Awful"},
+		{Name: "mail01"},
+		{Name: "web02", Status: "Good"},
+		{Name: "cache01", Failed: true},
+		{Name: "web03.example.com", Status: "Satisfactory"},
+	})
+
+	for _, want := range []string{
+		"Logwatch Fleet Report*",
+		"🖥 Hosts\\: 6 \\(1 failed\\)",
+		"📊 *Status*\n• 🔴 Awful\\: 1\n• 🟡 Satisfactory\\: 1\n• 🟢 Good\\: 2\n",
+		"1\\. ❌ cache01 \\- analysis failed\n" +
+			"2\\. 🔴 db01 \\- Awful\n" +
+			"3\\. 🟡 web03\\.example\\.com \\- Satisfactory\n" +
+			"4\\. 🟢 web01 \\- Good\n" +
+			"5\\. 🟢 web02 \\- Good\n" +
+			"6\\. ⚪ mail01 \\- no analysis\n",
+	} {
+		if !strings.Contains(message, want) {
+			t.Errorf("message missing %q:\n%s", want, message)
+		}
+	}
+	if strings.Contains(message, "collector") {
+		t.Errorf("fleet message names the local host:\n%s", message)
+	}
+}
+
+func TestSetHostname(t *testing.T) {
+	client := &TelegramClient{hostname: localHostname()}
+
+	client.SetHostname("web01.example.com")
+	if client.hostname != "web01.example.com" {
+		t.Errorf("hostname = %q, want web01.example.com", client.hostname)
+	}
+
+	client.SetHostname("")
+	if client.hostname != localHostname() {
+		t.Errorf("hostname = %q, want local host %q", client.hostname, localHostname())
+	}
+}
+
+func TestSetSourceName(t *testing.T) {
+	client := &TelegramClient{hostname: "test-server"}
+
+	if got := client.sourceDisplayName("orders"); got != "Log" {
+		t.Errorf("sourceDisplayName() = %q, want Log before SetSourceName", got)
+	}
+
+	client.SetSourceName("orders", "Order Service")
+	message := client.formatMessage(&ai.Analysis{SystemStatus: "Good", Summary: "Quiet day"}, &ai.Stats{}, "orders", "")
+	if !strings.Contains(message, "Order Service Report*") {
+		t.Errorf("message missing custom source name:\n%s", message)
+	}
+	if got := client.sourceDisplayName("syslog"); got != "Syslog" {
+		t.Errorf("sourceDisplayName(syslog) = %q, want Syslog", got)
+	}
+}
@@ -0,0 +1,654 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package ocms
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+)
+
+// timeFormatDateTime is the date-time format used in the digest.
+const timeFormatDateTime = "2006-01-02 15:04:05"
+
+// entriesSectionTitle names the section holding the deduplicated entries.
+const entriesSectionTitle = "Log Entries (Deduplicated)"
+
+// Limits of the digest sections. Repeats are collapsed before these apply.
+const (
+	maxTopErrors     = 20
+	maxTopWarnings   = 15
+	maxComponents    = 20
+	maxSlowEntries   = 10
+	maxDetailLines   = 20
+	maxEntryLineLen  = 400
+	maxDetailLineLen = 200
+)
+
+// Limits of the state kept while parsing, so the memory used does not grow
+// with the size of the logs. Entries beyond them are still counted.
+const (
+	// maxGroupsPerSection caps the deduplicated entries of a log file
+	maxGroupsPerSection = 2000
+	// maxRequestIDs caps the request IDs tracked for the unique count
+	maxRequestIDs = 100000
+	// maxTrackedNames caps the components and error classes counted by name
+	maxTrackedNames = 1000
+)
+
+// otherName collects the components and error classes beyond
+// maxTrackedNames.
+const otherName = "(other)"
+
+// Patterns used by normalizeMessage, compiled once: it runs for every entry.
+var (
+	uuidRegex     = regexp.MustCompile(`[a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[a-fA-F0-9]{4}-[a-fA-F0-9]{4}-[a-fA-F0-9]{12}`)
+	hexRegex      = regexp.MustCompile(`\b(?:0x[a-fA-F0-9]+|[a-fA-F0-9]{8,})\b`)
+	ipRegex       = regexp.MustCompile(`\b\d{1,3}\.\d{1,3}\.\d{1,3}\.\d{1,3}(?::\d+)?\b`)
+	durationRegex = regexp.MustCompile(`\b\d+(?:\.\d+)?(?:ns|us|µs|ms|s|m|h)\b`)
+	numberRegex   = regexp.MustCompile(`\d+`)
+)
+
+// digest aggregates parsed OCMS entries into the statistics header and the
+// deduplicated entries sent to the LLM instead of the raw lines.
+type digest struct {
+	entries        int
+	unparsed       int
+	continuation   int
+	formats        map[Format]int
+	first          time.Time
+	last           time.Time
+	levels         map[string]int
+	errorClasses   map[string]int
+	components     map[string]*componentStats
+	requestIDs     map[string]struct{}
+	moreRequestIDs bool // more request IDs than maxRequestIDs were seen
+	latencies      latencyStats
+	slowest        []slowEntry
+	sections       []*logSection
+}
+
+// logSection holds the deduplicated entries of one log file.
+type logSection struct {
+	label    string // "" for a single unlabeled log
+	day      string // day covered by a log selected by date, or ""
+	path     string
+	entries  int
+	groups   []*entryGroup
+	index    map[string]*entryGroup
+	overflow int // lines of patterns beyond maxGroupsPerSection
+}
+
+// title names the section in the summary statistics.
+func (s *logSection) title() string {
+	if s.day == "" {
+		return s.label
+	}
+	return s.label + " " + s.day
+}
+
+// entryGroup collapses repeated entries of one level, component, and
+// normalized message. The first line of the group is its example.
+type entryGroup struct {
+	level     string
+	component string
+	class     string
+	message   string // normalized, used by the top lists
+	example   string
+	details   []string // continuation lines of the example (stack traces)
+	count     int
+	first     time.Time
+	last      time.Time
+}
+
+// componentStats collects the entries of one component.
+type componentStats struct {
+	entries   int
+	errors    int
+	latencies latencyStats
+}
+
+// slowEntry is an entry listed in the slowest requests.
+type slowEntry struct {
+	latency time.Duration
+	line    string
+}
+
+func newDigest() *digest {
+	return &digest{
+		formats:      make(map[Format]int),
+		levels:       make(map[string]int),
+		errorClasses: make(map[string]int),
+		components:   make(map[string]*componentStats),
+		requestIDs:   make(map[string]struct{}),
+	}
+}
+
+// addSection parses the lines of one log file.
+func (d *digest) addSection(label, day, path, content string) {
+	parser := d.newSection(label, day, path)
+	for line := range strings.Lines(content) {
+		parser.addLine(line)
+	}
+}
+
+// sectionParser feeds the lines of one log file into the digest, one at a
+// time, so a file can be streamed instead of read into memory.
+type sectionParser struct {
+	d       *digest
+	s       *logSection
+	current *entryGroup // group whose example collects continuation lines
+	inEntry bool
+}
+
+// newSection starts the section of a log file.
+func (d *digest) newSection(label, day, path string) *sectionParser {
+	s := &logSection{label: label, day: day, path: path, index: make(map[string]*entryGroup)}
+	d.sections = append(d.sections, s)
+	return &sectionParser{d: d, s: s}
+}
+
+// addLine parses one line. Lines that are not entries are continuation
+// lines (stack traces, multi-line messages) of the previous entry, or
+// unparsed lines before the first entry.
+func (p *sectionParser) addLine(line string) {
+	d, s := p.d, p.s
+	line = strings.TrimRight(line, "\r\n")
+	if strings.TrimSpace(line) == "" {
+		return
+	}
+
+	e, ok := ParseLine(line)
+	if !ok {
+		if !p.inEntry {
+			d.unparsed++
+			d.addLine(s, "", "", "", line, line, time.Time{})
+			return
+		}
+		d.continuation++
+		if p.current != nil && len(p.current.details) < maxDetailLines {
+			p.current.details = append(p.current.details, truncate(strings.TrimSpace(line), maxDetailLineLen))
+		}
+		return
+	}
+
+	p.inEntry = true
+	s.entries++
+	d.add(e, line)
+	message := e.Message
+	if e.Error != "" {
+		message += ": " + e.Error
+	}
+	p.current = d.addLine(s, e.Level, e.Component, e.ErrorClass, message, line, e.Time)
+}
+
+// addLine adds a line to the group of its level, component, and
+// normalized message. It returns the group if the line started it, so
+// only the continuation lines of the example are kept.
+func (d *digest) addLine(s *logSection, level, component, class, message, line string, at time.Time) *entryGroup {
+	normalized := normalizeMessage(message)
+	key := level + "\x00" + component + "\x00" + normalized
+
+	g, ok := s.index[key]
+	if !ok {
+		if len(s.groups) >= maxGroupsPerSection {
+			s.overflow++
+			return nil
+		}
+		g = &entryGroup{
+			level:     level,
+			component: component,
+			class:     class,
+			message:   normalized,
+			example:   truncate(strings.TrimSpace(line), maxEntryLineLen),
+			first:     at,
+		}
+		s.index[key] = g
+		s.groups = append(s.groups, g)
+	}
+	g.count++
+	if !at.IsZero() {
+		if g.first.IsZero() || at.Before(g.first) {
+			g.first = at
+		}
+		if at.After(g.last) {
+			g.last = at
+		}
+	}
+	if ok {
+		return nil
+	}
+	return g
+}
+
+// add records the statistics of one entry.
+func (d *digest) add(e Entry, line string) {
+	d.entries++
+	d.formats[e.Format]++
+	d.levels[e.Level]++
+
+	if !e.Time.IsZero() {
+		if d.first.IsZero() || e.Time.Before(d.first) {
+			d.first = e.Time
+		}
+		if e.Time.After(d.last) {
+			d.last = e.Time
+		}
+	}
+	if e.RequestID != "" {
+		if len(d.requestIDs) < maxRequestIDs {
+			d.requestIDs[e.RequestID] = struct{}{}
+		} else if _, ok := d.requestIDs[e.RequestID]; !ok {
+			d.moreRequestIDs = true
+		}
+	}
+	if e.IsError() {
+		d.errorClasses[trackedName(d.errorClasses, e.ErrorClass)]++
+	}
+
+	if e.Component != "" {
+		name := e.Component
+		cs := d.components[name]
+		if cs == nil {
+			name = trackedName(d.components, name)
+			cs = d.components[name]
+		}
+		if cs == nil {
+			cs = &componentStats{}
+			d.components[name] = cs
+		}
+		cs.entries++
+		if e.IsError() {
+			cs.errors++
+		}
+		if e.HasLatency {
+			cs.latencies.add(e.Latency)
+		}
+	}
+
+	if e.HasLatency {
+		d.latencies.add(e.Latency)
+		d.addSlow(slowEntry{latency: e.Latency, line: truncate(line, maxEntryLineLen)})
+	}
+}
+
+// trackedName returns name, or otherName once counts holds
+// maxTrackedNames other names.
+func trackedName[V any](counts map[string]V, name string) string {
+	if _, ok := counts[name]; ok || len(counts) < maxTrackedNames {
+		return name
+	}
+	return otherName
+}
+
+// addSlow keeps the maxSlowEntries slowest entries, slowest first.
+func (d *digest) addSlow(s slowEntry) {
+	if len(d.slowest) == maxSlowEntries && s.latency <= d.slowest[len(d.slowest)-1].latency {
+		return
+	}
+	i := sort.Search(len(d.slowest), func(i int) bool { return d.slowest[i].latency < s.latency })
+	d.slowest = slices.Insert(d.slowest, i, s)
+	if len(d.slowest) > maxSlowEntries {
+		d.slowest = d.slowest[:maxSlowEntries]
+	}
+}
+
+// errorCount returns the number of ERROR and FATAL entries.
+func (d *digest) errorCount() int {
+	return d.levels[LevelError] + d.levels[LevelFatal]
+}
+
+// topGroups returns the groups of the given levels of all sections, most
+// frequent first.
+func (d *digest) topGroups(levels ...string) []*entryGroup {
+	var groups []*entryGroup
+	for _, s := range d.sections {
+		for _, g := range s.groups {
+			if slices.Contains(levels, g.level) {
+				groups = append(groups, g)
+			}
+		}
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].count > groups[j].count
+	})
+	return groups
+}
+
+// format renders the statistics header followed by the deduplicated
+// entries of each log file.
+func (d *digest) format() string {
+	var sb strings.Builder
+
+	sb.WriteString("=== OCMS LOG ANALYSIS ===\n\n")
+
+	d.writeSummary(&sb)
+	d.writeLevels(&sb)
+	d.writeTopGroups(&sb, "Top Errors", maxTopErrors, LevelFatal, LevelError)
+	d.writeErrorClasses(&sb)
+	d.writeTopGroups(&sb, "Top Warnings", maxTopWarnings, LevelWarn)
+	d.writeComponents(&sb)
+	d.writeSlowest(&sb)
+	d.writeEntries(&sb)
+
+	return sb.String()
+}
+
+func (d *digest) writeSummary(sb *strings.Builder) {
+	sb.WriteString("## Summary Statistics\n")
+	fmt.Fprintf(sb, "Entries: %d", d.entries)
+	if len(d.formats) > 0 {
+		var formats []string
+		for _, f := range []Format{FormatJSON, FormatKeyValue, FormatPlain} {
+			if d.formats[f] > 0 {
+				formats = append(formats, fmt.Sprintf("%s: %d", f, d.formats[f]))
+			}
+		}
+		fmt.Fprintf(sb, " (%s)", strings.Join(formats, ", "))
+	}
+	sb.WriteString("\n")
+	if d.unparsed > 0 {
+		fmt.Fprintf(sb, "Unparsed lines: %d\n", d.unparsed)
+	}
+	if d.continuation > 0 {
+		fmt.Fprintf(sb, "Continuation lines (stack traces, multi-line messages): %d\n", d.continuation)
+	}
+	if len(d.sections) > 1 {
+		var files []string
+		for _, s := range d.sections {
+			files = append(files, fmt.Sprintf("%s: %d", s.title(), s.entries))
+		}
+		fmt.Fprintf(sb, "Entries per log: %s\n", strings.Join(files, ", "))
+	}
+	if !d.first.IsZero() {
+		fmt.Fprintf(sb, "Time range: %s to %s\n", d.first.Format(timeFormatDateTime), d.last.Format(timeFormatDateTime))
+	}
+	fmt.Fprintf(sb, "Errors: %d\n", d.errorCount())
+	if len(d.requestIDs) > 0 {
+		fmt.Fprintf(sb, "Unique request IDs: %s\n", d.requestIDCount())
+	}
+	if d.latencies.count > 0 {
+		fmt.Fprintf(sb, "Latency: %s (%d timed entries)\n", d.latencies.format(), d.latencies.count)
+	}
+	sb.WriteString("\n")
+}
+
+func (d *digest) writeLevels(sb *strings.Builder) {
+	if d.entries == 0 {
+		return
+	}
+	sb.WriteString("## Level Breakdown\n")
+	for _, level := range levelOrder {
+		if count := d.levels[level]; count > 0 {
+			fmt.Fprintf(sb, "- %s: %d\n", level, count)
+		}
+	}
+	if count := d.levels[""]; count > 0 {
+		fmt.Fprintf(sb, "- (no level): %d\n", count)
+	}
+	sb.WriteString("\n")
+}
+
+func (d *digest) writeTopGroups(sb *strings.Builder, title string, limit int, levels ...string) {
+	groups := d.topGroups(levels...)
+	if len(groups) == 0 {
+		return
+	}
+
+	fmt.Fprintf(sb, "## %s\n", title)
+	for i, g := range groups {
+		if i >= limit {
+			fmt.Fprintf(sb, "[... %d more patterns ...]\n", len(groups)-limit)
+			break
+		}
+		fmt.Fprintf(sb, "- [%dx] %s", g.count, g.level)
+		if g.component != "" {
+			fmt.Fprintf(sb, " %s", g.component)
+		}
+		if g.class != "" {
+			fmt.Fprintf(sb, " (%s)", g.class)
+		}
+		fmt.Fprintf(sb, ": %s", truncate(g.message, maxDetailLineLen))
+		if !g.first.IsZero() {
+			if g.count > 1 && !g.first.Equal(g.last) {
+				fmt.Fprintf(sb, " [%s to %s]", g.first.Format(timeFormatDateTime), g.last.Format(timeFormatDateTime))
+			} else {
+				fmt.Fprintf(sb, " [%s]", g.first.Format(timeFormatDateTime))
+			}
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+}
+
+func (d *digest) writeErrorClasses(sb *strings.Builder) {
+	if len(d.errorClasses) == 0 {
+		return
+	}
+	sb.WriteString("## Error Classes\n")
+	for _, item := range analyzer.TopCounts(d.errorClasses, 0) {
+		fmt.Fprintf(sb, "- %s: %d\n", item.Name, item.Count)
+	}
+	sb.WriteString("\n")
+}
+
+func (d *digest) writeComponents(sb *strings.Builder) {
+	if len(d.components) == 0 {
+		return
+	}
+	counts := make(map[string]int, len(d.components))
+	for name, cs := range d.components {
+		counts[name] = cs.entries
+	}
+
+	sb.WriteString("## Components\n")
+	for _, item := range analyzer.TopCounts(counts, maxComponents) {
+		cs := d.components[item.Name]
+		fmt.Fprintf(sb, "- %s: %d (errors: %d", item.Name, cs.entries, cs.errors)
+		if cs.latencies.count > 0 {
+			fmt.Fprintf(sb, ", latency %s", cs.latencies.format())
+		}
+		sb.WriteString(")\n")
+	}
+	if len(d.components) > maxComponents {
+		fmt.Fprintf(sb, "[... %d more components ...]\n", len(d.components)-maxComponents)
+	}
+	sb.WriteString("\n")
+}
+
+func (d *digest) writeSlowest(sb *strings.Builder) {
+	if len(d.slowest) == 0 {
+		return
+	}
+	sb.WriteString("## Slowest Entries\n")
+	for _, s := range d.slowest {
+		fmt.Fprintf(sb, "- %s: %s\n", formatDuration(s.latency), s.line)
+	}
+	sb.WriteString("\n")
+}
+
+// writeEntries writes the deduplicated entries in order of first
+// occurrence, each line prefixed with its repeat count. Multiple log files
+// are labeled so the LLM can distinguish main and error log sections.
+func (d *digest) writeEntries(sb *strings.Builder) {
+	fmt.Fprintf(sb, "## %s\n", entriesSectionTitle)
+	for i, s := range d.sections {
+		if s.label != "" {
+			if i > 0 {
+				sb.WriteString("\n")
+			}
+			fmt.Fprintf(sb, "### OCMS %s LOG", strings.ToUpper(s.label))
+			if s.day != "" {
+				fmt.Fprintf(sb, " (%s)", s.day)
+			}
+			fmt.Fprintf(sb, "\nPath: %s\n\n", s.path)
+		}
+		for _, g := range s.groups {
+			if g.count > 1 {
+				fmt.Fprintf(sb, "[%dx] ", g.count)
+			}
+			sb.WriteString(g.example)
+			sb.WriteString("\n")
+			for _, detail := range g.details {
+				sb.WriteString("  ")
+				sb.WriteString(detail)
+				sb.WriteString("\n")
+			}
+		}
+		if s.overflow > 0 {
+			fmt.Fprintf(sb, "[... %d more lines of further patterns ...]\n", s.overflow)
+		}
+	}
+}
+
+// requestIDCount renders the number of unique request IDs, with a "+"
+// when there were more than could be tracked.
+func (d *digest) requestIDCount() string {
+	if d.moreRequestIDs {
+		return fmt.Sprintf("%d+", len(d.requestIDs))
+	}
+	return fmt.Sprintf("%d", len(d.requestIDs))
+}
+
+// percentile returns the nearest-rank percentile p (0-100) of sorted values.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(float64(len(sorted))*p/100+0.999999) - 1
+	rank = max(0, min(rank, len(sorted)-1))
+	return sorted[rank]
+}
+
+// formatPercentiles renders the p50, p90, p99, and maximum of latencies.
+func formatPercentiles(latencies []time.Duration) string {
+	sorted := slices.Clone(latencies)
+	slices.Sort(sorted)
+	return fmt.Sprintf("p50 %s, p90 %s, p99 %s, max %s",
+		formatDuration(percentile(sorted, 50)),
+		formatDuration(percentile(sorted, 90)),
+		formatDuration(percentile(sorted, 99)),
+		formatDuration(sorted[len(sorted)-1]))
+}
+
+// Latencies are kept exactly up to maxLatencySamples; beyond that they
+// are counted in logarithmic buckets of latencyBucketGrowth width, which
+// bounds the error of the percentiles to about 1%.
+const (
+	maxLatencySamples   = 10000
+	latencyBucketGrowth = 1.02
+)
+
+// latencyStats collects latencies for percentiles in bounded memory.
+type latencyStats struct {
+	count   int
+	max     time.Duration
+	samples []time.Duration
+	buckets map[int]int
+}
+
+// add records one latency.
+func (l *latencyStats) add(latency time.Duration) {
+	l.count++
+	l.max = max(l.max, latency)
+	if l.buckets == nil {
+		if len(l.samples) < maxLatencySamples {
+			l.samples = append(l.samples, latency)
+			return
+		}
+		l.buckets = make(map[int]int)
+		for _, sample := range l.samples {
+			l.buckets[latencyBucket(sample)]++
+		}
+		l.samples = nil
+	}
+	l.buckets[latencyBucket(latency)]++
+}
+
+// format renders the p50, p90, p99, and maximum of the latencies.
+func (l *latencyStats) format() string {
+	if l.buckets == nil {
+		return formatPercentiles(l.samples)
+	}
+
+	keys := make([]int, 0, len(l.buckets))
+	for k := range l.buckets {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	bucketPercentile := func(p float64) time.Duration {
+		rank := max(1, int(float64(l.count)*p/100+0.999999))
+		seen := 0
+		for _, k := range keys {
+			seen += l.buckets[k]
+			if seen >= rank {
+				return min(latencyBucketValue(k), l.max)
+			}
+		}
+		return l.max
+	}
+	return fmt.Sprintf("p50 %s, p90 %s, p99 %s, max %s",
+		formatDuration(bucketPercentile(50)),
+		formatDuration(bucketPercentile(90)),
+		formatDuration(bucketPercentile(99)),
+		formatDuration(l.max))
+}
+
+// latencyBucket returns the logarithmic bucket of a latency.
+func latencyBucket(latency time.Duration) int {
+	if latency <= 0 {
+		return 0
+	}
+	return int(math.Log(float64(latency))/math.Log(latencyBucketGrowth)) + 1
+}
+
+// latencyBucketValue returns the geometric middle of a bucket.
+func latencyBucketValue(bucket int) time.Duration {
+	if bucket == 0 {
+		return 0
+	}
+	return time.Duration(math.Pow(latencyBucketGrowth, float64(bucket-1)+0.5))
+}
+
+// formatDuration rounds a duration for display.
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return d.Round(10 * time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(100 * time.Microsecond).String()
+	default:
+		return d.Round(time.Microsecond).String()
+	}
+}
+
+// normalizeMessage replaces the variable parts of a message, so entries
+// that differ only in IDs, addresses, durations, or counts are grouped.
+func normalizeMessage(msg string) string {
+	msg = uuidRegex.ReplaceAllString(msg, "<uuid>")
+	msg = ipRegex.ReplaceAllString(msg, "<ip>")
+	msg = durationRegex.ReplaceAllString(msg, "<duration>")
+	msg = hexRegex.ReplaceAllString(msg, "<hex>")
+	msg = numberRegex.ReplaceAllString(msg, "<n>")
+	return strings.Join(strings.Fields(msg), " ")
+}
+
+// truncate shortens s to at most maxLen bytes without splitting a rune.
+func truncate(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	cut := maxLen - 3
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "..."
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package ocms
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLatencyStats(t *testing.T) {
+	t.Parallel()
+
+	var exact, bucketed latencyStats
+	var all []time.Duration
+	for i := range maxLatencySamples * 3 {
+		latency := time.Duration(i%1000+1) * time.Millisecond
+		all = append(all, latency)
+		bucketed.add(latency)
+		if i < 5 {
+			exact.add(latency)
+		}
+	}
+
+	if got := exact.format(); got != formatPercentiles(all[:5]) {
+		t.Errorf("format() of few samples = %q, want the exact %q", got, formatPercentiles(all[:5]))
+	}
+	if bucketed.samples != nil || len(bucketed.buckets) == 0 {
+		t.Fatalf("latencyStats kept %d samples beyond maxLatencySamples", len(bucketed.samples))
+	}
+
+	// Bucketed percentiles stay within the bucket width of the exact ones
+	slices.Sort(all)
+	var p50 float64
+	if _, err := fmt.Sscanf(bucketed.format(), "p50 %fms", &p50); err != nil {
+		t.Fatalf("format() = %q: %v", bucketed.format(), err)
+	}
+	want := float64(percentile(all, 50)) / float64(time.Millisecond)
+	if math.Abs(p50-want)/want > latencyBucketGrowth-1 {
+		t.Errorf("bucketed p50 = %vms, want about %vms", p50, want)
+	}
+	if !strings.HasSuffix(bucketed.format(), "max 1s") {
+		t.Errorf("format() = %q, want the exact maximum", bucketed.format())
+	}
+}
+
+func TestDigest_BoundedState(t *testing.T) {
+	t.Parallel()
+
+	d := newDigest()
+	parser := d.newSection("", "", "ocms.log")
+	for i := range maxGroupsPerSection + 10 {
+		parser.addLine(fmt.Sprintf("time=2026-04-26T02:15:00Z level=INFO msg=\"event %s\" request_id=r%d", strings.Repeat("x", i%50+i/50*100), i))
+	}
+	for i := range maxRequestIDs {
+		d.add(Entry{Level: LevelInfo, RequestID: fmt.Sprintf("id-%d", i)}, "")
+	}
+
+	if len(d.sections[0].groups) != maxGroupsPerSection || d.sections[0].overflow != 10 {
+		t.Errorf("groups = %d, overflow = %d", len(d.sections[0].groups), d.sections[0].overflow)
+	}
+	if len(d.requestIDs) != maxRequestIDs || !d.moreRequestIDs {
+		t.Errorf("request IDs = %d, more = %v", len(d.requestIDs), d.moreRequestIDs)
+	}
+
+	got := d.format()
+	for _, want := range []string{
+		fmt.Sprintf("Unique request IDs: %d+\n", maxRequestIDs),
+		"[... 10 more lines of further patterns ...]\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("format() missing %q", want)
+		}
+	}
+}
@@ -4,43 +4,135 @@
 package ocms
 
 import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
 	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
-	"github.com/olegiv/logwatch-ai-go/internal/logwatch"
 )
 
-// Preprocessor wraps logwatch preprocessing for OCMS log content.
-type Preprocessor struct {
-	inner *logwatch.Preprocessor
-}
-
+// Compile-time interface check
 var (
 	_ analyzer.Preprocessor       = (*Preprocessor)(nil)
 	_ analyzer.BudgetPreprocessor = (*Preprocessor)(nil)
 )
 
+// repeatPrefixRegex matches the "[12x] " prefix of a repeated entry.
+var repeatPrefixRegex = regexp.MustCompile(`^\[\d+x\] `)
+
+// sectionPriority returns the priority of a digest section. The entries
+// section is prioritized per entry by level, see renderSection.
+func sectionPriority(name string) int {
+	switch name {
+	case "Summary Statistics", "Level Breakdown", "Top Errors", "Error Classes":
+		return analyzer.SectionPriorityHigh
+	case "Top Warnings", "Components", "Slowest Entries":
+		return analyzer.SectionPriorityMedium
+	default:
+		return analyzer.SectionPriorityLow
+	}
+}
+
+// Preprocessor shortens the OCMS digest for the token budget. It keeps the
+// statistics header and error entries and samples warnings, then info and
+// debug entries.
+// Implements analyzer.Preprocessor interface.
+type Preprocessor struct {
+	*analyzer.SectionPreprocessor
+}
+
 // NewPreprocessor creates a new OCMS preprocessor.
 func NewPreprocessor(maxTokens int) *Preprocessor {
 	return &Preprocessor{
-		inner: logwatch.NewPreprocessor(maxTokens),
+		analyzer.NewSectionPreprocessor(maxTokens, sectionPriority).WithSectionRenderer(renderSection),
 	}
 }
 
-// EstimateTokens estimates token count for content.
-func (p *Preprocessor) EstimateTokens(content string) int {
-	return p.inner.EstimateTokens(content)
+// renderSection renders the entries section; the other sections are
+// shortened by their priority.
+func renderSection(sb *strings.Builder, name string, lines []string, keep func(int) float64) bool {
+	if name != entriesSectionTitle {
+		return false
+	}
+	renderEntries(sb, name, lines, keep)
+	return true
 }
 
-// Process preprocesses content when needed.
-func (p *Preprocessor) Process(content string) (string, error) {
-	return p.inner.Process(content)
+// logEntry is an entry line of the entries section with its indented
+// continuation lines.
+type logEntry struct {
+	lines    []string
+	priority int
 }
 
-// ProcessWithBudget preprocesses content against a dynamic token budget.
-func (p *Preprocessor) ProcessWithBudget(content string, maxTokens int) (string, error) {
-	return p.inner.ProcessWithBudget(content, maxTokens)
+// renderEntries renders the entries section keeping, per log file label,
+// the first entries of each level according to keep: errors are high,
+// warnings medium, and all other entries low priority.
+func renderEntries(sb *strings.Builder, name string, lines []string, keep func(int) float64) {
+	fmt.Fprintf(sb, "## %s\n", name)
+
+	var block []logEntry
+	flush := func() {
+		writeEntryBlock(sb, block, keep)
+		block = block[:0]
+	}
+	for _, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "### ") || strings.HasPrefix(line, "Path: ") || line == "":
+			// Log file labels
+			flush()
+			sb.WriteString(line)
+			sb.WriteString("\n")
+		case strings.HasPrefix(line, "  ") && len(block) > 0:
+			last := &block[len(block)-1]
+			last.lines = append(last.lines, line)
+		default:
+			block = append(block, logEntry{lines: []string{line}, priority: entryPriority(line)})
+		}
+	}
+	flush()
+	sb.WriteString("\n")
 }
 
-// ShouldProcess reports whether preprocessing is needed.
-func (p *Preprocessor) ShouldProcess(content string, maxTokens int) bool {
-	return p.inner.ShouldProcess(content, maxTokens)
+// writeEntryBlock writes the kept entries of one log file in their
+// original order and notes how many were omitted.
+func writeEntryBlock(sb *strings.Builder, entries []logEntry, keep func(int) float64) {
+	total := make(map[int]int)
+	for _, e := range entries {
+		total[e.priority]++
+	}
+
+	kept := make(map[int]int)
+	omitted := 0
+	for _, e := range entries {
+		limit := int(math.Ceil(float64(total[e.priority]) * keep(e.priority)))
+		if kept[e.priority] >= limit {
+			omitted++
+			continue
+		}
+		kept[e.priority]++
+		for _, line := range e.lines {
+			sb.WriteString(line)
+			sb.WriteString("\n")
+		}
+	}
+	if omitted > 0 {
+		fmt.Fprintf(sb, "[... %d lower-priority entries omitted due to size limits ...]\n", omitted)
+	}
+}
+
+// entryPriority returns the priority of an entry line by its level.
+func entryPriority(line string) int {
+	e, ok := ParseLine(repeatPrefixRegex.ReplaceAllString(line, ""))
+	switch {
+	case !ok:
+		return analyzer.SectionPriorityLow
+	case e.IsError():
+		return analyzer.SectionPriorityHigh
+	case e.Level == LevelWarn:
+		return analyzer.SectionPriorityMedium
+	default:
+		return analyzer.SectionPriorityLow
+	}
 }
@@ -4,6 +4,7 @@
 package ocms
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
@@ -15,6 +16,24 @@ var (
 	_ analyzer.BudgetPreprocessor = (*Preprocessor)(nil)
 )
 
+func TestSectionPriority(t *testing.T) {
+	tests := map[string]int{
+		"Summary Statistics": analyzer.SectionPriorityHigh,
+		"Level Breakdown":    analyzer.SectionPriorityHigh,
+		"Top Errors":         analyzer.SectionPriorityHigh,
+		"Error Classes":      analyzer.SectionPriorityHigh,
+		"Top Warnings":       analyzer.SectionPriorityMedium,
+		"Components":         analyzer.SectionPriorityMedium,
+		"Slowest Entries":    analyzer.SectionPriorityMedium,
+		entriesSectionTitle:  analyzer.SectionPriorityLow,
+	}
+	for name, want := range tests {
+		if got := sectionPriority(name); got != want {
+			t.Errorf("sectionPriority(%q) = %d, want %d", name, got, want)
+		}
+	}
+}
+
 func TestPreprocessor_Basic(t *testing.T) {
 	t.Parallel()
 
@@ -36,3 +55,38 @@ func TestPreprocessor_Basic(t *testing.T) {
 		t.Fatal("Process() should not return empty content")
 	}
 }
+
+func TestPreprocessor_KeepsErrorsOverInfo(t *testing.T) {
+	t.Parallel()
+
+	var raw strings.Builder
+	for i := range 400 {
+		fmt.Fprintf(&raw, "2026-04-26T02:15:00Z INFO [page%d] cache warmed for page %d\n", i, i)
+	}
+	raw.WriteString("2026-04-26T02:16:00Z ERROR [db] query failed: database is locked\n")
+	raw.WriteString("2026-04-26T02:16:01Z WARN [http] slow request took 2s\n")
+
+	reader := NewReader(10, false, 1000)
+	content, err := reader.ReadContent(raw.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := NewPreprocessor(1000)
+	processed, err := p.ProcessWithBudget(content, 1500)
+	if err != nil {
+		t.Fatalf("ProcessWithBudget() error = %v", err)
+	}
+	if p.EstimateTokens(processed) > 1500 {
+		t.Errorf("processed content has %d tokens, want <= 1500", p.EstimateTokens(processed))
+	}
+	for _, want := range []string{
+		"## Summary Statistics\nEntries: 402",
+		"2026-04-26T02:16:00Z ERROR [db] query failed: database is locked",
+		"lower-priority entries omitted due to size limits",
+	} {
+		if !strings.Contains(processed, want) {
+			t.Errorf("processed content missing %q:\n%s", want, processed)
+		}
+	}
+}
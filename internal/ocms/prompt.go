@@ -41,6 +41,16 @@ func (p *PromptBuilder) GetLogType() string {
 func (p *PromptBuilder) GetSystemPrompt(globalExclusions []string) string {
 	return `You are a senior site reliability engineer and security analyst focused on OCMS platform operations. Your role is to analyze OCMS logs and provide actionable insights.
 
+**Input Format:**
+The OCMS logs are parsed (JSON, slog key=value, and plain timestamped lines) into a statistics header followed by the deduplicated entries:
+- Summary Statistics: entry count per line format, time range, error count, unique request IDs, latency percentiles
+- Level Breakdown: entries per level (FATAL, ERROR, WARN, INFO, DEBUG)
+- Top Errors / Top Warnings: "- [12x] ERROR db (database): query failed: ..." means 12 entries of that pattern from the db component with the database error class, with their time range
+- Error Classes: errors grouped by class (timeout, database, network, auth, panic, ...)
+- Components: entries, errors, and latency percentiles per component
+- Slowest Entries: the entries with the highest latency
+- Log Entries (Deduplicated): the entries in order of first occurrence; "[12x]" prefixes repeated entries, indented lines are stack traces or continuation lines, and "### OCMS MAIN LOG" / "### OCMS ERROR LOG" label the log files, followed by the day they cover when logs were selected by date
+
 **Analysis Framework:**
 
 1. **System Status Assessment** - Classify overall service health:
@@ -14,21 +14,55 @@ import (
 	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
 )
 
-// LogFile identifies one OCMS log file to read.
+// NoEntriesContent is returned by ReadAppended when no lines were appended
+// to the OCMS logs since the previous run.
+// Use IsNoEntriesContent() to check for this condition.
+const NoEntriesContent = "=== NO NEW OCMS LOG ENTRIES ===\n\nNo lines were appended to the OCMS logs since the previous run.\nThis typically means the site was idle."
+
+// IsNoEntriesContent checks if the content indicates no new OCMS log lines were found.
+func IsNoEntriesContent(content string) bool {
+	return strings.HasPrefix(content, "=== NO NEW OCMS LOG ENTRIES ===")
+}
+
+// LogFile identifies one OCMS log file to read. Day is set for rotated
+// logs selected by date or rotation index; such logs are labeled with
+// their day and are not subject to the maximum log age.
 type LogFile struct {
 	Kind string
 	Path string
+	Day  string
 }
 
-// Reader handles reading and validating OCMS log files.
+// AppendedLog holds the lines appended to an OCMS log file since the
+// previous run.
+type AppendedLog struct {
+	LogFile
+	Content string
+}
+
+// Reader handles reading and validating OCMS log files. Entries are
+// parsed into a statistics header and deduplicated entries (see ParseLine)
+// instead of being sent to the LLM as raw lines.
 type Reader struct {
 	maxSizeMB           int
 	enablePreprocessing bool
 	maxTokens           int
 	preprocessor        *Preprocessor
+	digest              *digest // digest of the last read, for ReadStats
 }
 
-var _ analyzer.LogReader = (*Reader)(nil)
+var (
+	_ analyzer.LogReader     = (*Reader)(nil)
+	_ analyzer.StatsReporter = (*Reader)(nil)
+	_ analyzer.ContentReader = (*Reader)(nil)
+)
+
+const (
+	// maxStatsItems caps the breakdowns of ReadStats.
+	maxStatsItems = 10
+	// maxLogAge is the maximum age of the current and yesterday's logs.
+	maxLogAge = 24 * time.Hour
+)
 
 // NewReader creates a new OCMS reader.
 func NewReader(maxSizeMB int, enablePreprocessing bool, maxTokens int) *Reader {
@@ -40,31 +74,83 @@ func NewReader(maxSizeMB int, enablePreprocessing bool, maxTokens int) *Reader {
 	}
 }
 
-// Read reads and validates OCMS log content.
+// Read reads and validates OCMS log content. The file is streamed into
+// the digest line by line, so its size is not limited by memory.
 func (r *Reader) Read(sourcePath string) (string, error) {
-	contentStr, err := r.readRaw(sourcePath)
-	if err != nil {
+	r.digest = nil
+
+	d := newDigest()
+	if err := r.streamSection(d, LogFile{Path: sourcePath}, false, maxLogAge); err != nil {
 		return "", err
 	}
 
-	return r.preprocessIfNeeded(contentStr)
+	return r.finish(d)
 }
 
-func (r *Reader) readRaw(sourcePath string) (string, error) {
-	contentStr, err := analyzer.ReadSourceFileWithGuards(
-		sourcePath,
-		analyzer.FileReadOptions{
-			SourceLabel: "ocms log",
-			MaxSizeMB:   r.maxSizeMB,
-			MaxAge:      24 * time.Hour,
-		},
-		r.validateContent,
-	)
+// ReadContent implements analyzer.ContentReader.
+// Processes OCMS log content that was not read from a file.
+func (r *Reader) ReadContent(content string) (string, error) {
+	r.digest = nil
+
+	if err := r.validateContent(content); err != nil {
+		return "", fmt.Errorf("ocms log content validation failed: %w", err)
+	}
+
+	return r.process([]AppendedLog{{Content: content}}, false)
+}
+
+// streamSection parses a log file line by line into a section of the
+// digest. Labeled sections are titled with the kind of the log.
+func (r *Reader) streamSection(d *digest, file LogFile, labeled bool, maxAge time.Duration) error {
+	scanner, err := analyzer.OpenSourceLines(file.Path, analyzer.FileReadOptions{
+		SourceLabel: "ocms log",
+		MaxSizeMB:   r.maxSizeMB,
+		MaxAge:      maxAge,
+	})
 	if err != nil {
-		return "", err
+		return err
+	}
+	defer func() { _ = scanner.Close() }()
+
+	label := ""
+	if labeled {
+		label = file.Kind
+	}
+	parser := d.newSection(label, file.Day, file.Path)
+	for scanner.Scan() {
+		parser.addLine(scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if scanner.BytesRead() == 0 {
+		return fmt.Errorf("ocms log content validation failed: %w", r.validateContent(""))
+	}
+
+	return nil
+}
+
+// process parses the log files into the digest sent to the LLM. Labeled
+// files are listed in separate sections of the deduplicated entries so the
+// LLM can distinguish main and error log sections.
+func (r *Reader) process(logs []AppendedLog, labeled bool) (string, error) {
+	d := newDigest()
+	for _, l := range logs {
+		label := ""
+		if labeled {
+			label = l.Kind
+		}
+		d.addSection(label, l.Day, l.Path, l.Content)
 	}
 
-	return contentStr, nil
+	return r.finish(d)
+}
+
+// finish formats the digest and preprocesses it if needed.
+func (r *Reader) finish(d *digest) (string, error) {
+	r.digest = d
+
+	return r.preprocessIfNeeded(d.format())
 }
 
 func (r *Reader) preprocessIfNeeded(content string) (string, error) {
@@ -82,8 +168,9 @@ func (r *Reader) preprocessIfNeeded(content string) (string, error) {
 	return content, nil
 }
 
-// ReadFiles reads one or more OCMS log files. Multiple files are combined with
-// labels so the LLM can distinguish main and error log sections. Missing
+// ReadFiles reads one or more OCMS log files. Multiple files, and files
+// selected by day, are combined with labels so the LLM can distinguish main
+// and error log sections and the days they cover. Missing
 // files are tolerated in multi-file mode — a site with no errors won't have
 // error.log (or its rotated .1) and that's a normal case. Fails only if
 // every requested file is missing.
@@ -91,45 +178,106 @@ func (r *Reader) ReadFiles(files []LogFile) (string, error) {
 	if len(files) == 0 {
 		return "", fmt.Errorf("no OCMS log files specified")
 	}
-	if len(files) == 1 {
+	if len(files) == 1 && files[0].Day == "" {
 		return r.Read(files[0].Path)
 	}
+	r.digest = nil
 
-	var combined strings.Builder
+	d := newDigest()
 	var skipped []string
-	written := 0
 	for _, file := range files {
-		content, err := r.readRaw(file.Path)
-		if err != nil {
+		maxAge := maxLogAge
+		if file.Day != "" {
+			maxAge = 0
+		}
+		if err := r.streamSection(d, file, true, maxAge); err != nil {
 			// Tolerate missing rotated files in multi-file mode without a
-			// separate os.Stat call — going through readRaw alone keeps the
-			// existence check and the read in one path lookup, removing a
-			// TOCTOU window.
+			// separate os.Stat call — going through streamSection alone
+			// keeps the existence check and the read in one path lookup,
+			// removing a TOCTOU window.
 			if errors.Is(err, fs.ErrNotExist) {
 				skipped = append(skipped, fmt.Sprintf("%s (%s)", file.Kind, file.Path))
 				continue
 			}
 			return "", fmt.Errorf("failed to read OCMS %s log %s: %w", file.Kind, file.Path, err)
 		}
+	}
+
+	if len(d.sections) == 0 {
+		return "", fmt.Errorf("no readable OCMS log files (all missing): %s", strings.Join(skipped, ", "))
+	}
+
+	return r.finish(d)
+}
+
+// ReadAppended processes the lines appended to OCMS log files since the
+// previous run (incremental mode). Files without new lines are left out;
+// if no file has any, NoEntriesContent is returned.
+func (r *Reader) ReadAppended(logs []AppendedLog) (string, error) {
+	r.digest = nil
 
-		if written > 0 {
-			combined.WriteString("\n\n")
+	var sections []AppendedLog
+	for _, appended := range logs {
+		if strings.TrimSpace(appended.Content) != "" {
+			sections = append(sections, appended)
 		}
-		combined.WriteString("### OCMS ")
-		combined.WriteString(strings.ToUpper(file.Kind))
-		combined.WriteString(" LOG\n")
-		combined.WriteString("Path: ")
-		combined.WriteString(file.Path)
-		combined.WriteString("\n\n")
-		combined.WriteString(content)
-		written++
+	}
+	if len(sections) == 0 {
+		return NoEntriesContent, nil
 	}
 
-	if written == 0 {
-		return "", fmt.Errorf("no readable OCMS log files (all missing): %s", strings.Join(skipped, ", "))
+	return r.process(sections, len(logs) > 1)
+}
+
+// ReadStats implements analyzer.StatsReporter.
+// Summarizes the entries of the last read by level, error pattern, error
+// class, and component.
+func (r *Reader) ReadStats() *analyzer.ReadStats {
+	d := r.digest
+	if d == nil {
+		return nil
+	}
+
+	stats := &analyzer.ReadStats{
+		Totals: []analyzer.StatsItem{
+			{Name: "Entries", Count: d.entries},
+			{Name: "Error entries", Count: d.errorCount()},
+			{Name: "Warnings", Count: d.levels[LevelWarn]},
+		},
+	}
+	if d.unparsed > 0 {
+		stats.Totals = append(stats.Totals, analyzer.StatsItem{Name: "Unparsed lines", Count: d.unparsed})
+	}
+	if len(d.requestIDs) > 0 {
+		name := "Unique request IDs"
+		if d.moreRequestIDs {
+			name += " (at least)"
+		}
+		stats.Totals = append(stats.Totals, analyzer.StatsItem{Name: name, Count: len(d.requestIDs)})
+	}
+
+	levelCounts := make(map[string]int, len(d.levels))
+	for level, count := range d.levels {
+		if level != "" {
+			levelCounts[level] = count
+		}
+	}
+	stats.AddBreakdown("Levels", analyzer.TopCounts(levelCounts, maxStatsItems))
+
+	errorCounts := make(map[string]int)
+	for _, g := range d.topGroups(LevelFatal, LevelError) {
+		errorCounts[truncate(g.message, maxDetailLineLen)] += g.count
+	}
+	stats.AddBreakdown("Top errors", analyzer.TopCounts(errorCounts, maxStatsItems))
+	stats.AddBreakdown("Error classes", analyzer.TopCounts(d.errorClasses, maxStatsItems))
+
+	componentCounts := make(map[string]int, len(d.components))
+	for name, cs := range d.components {
+		componentCounts[name] = cs.entries
 	}
+	stats.AddBreakdown("Components", analyzer.TopCounts(componentCounts, maxStatsItems))
 
-	return r.preprocessIfNeeded(combined.String())
+	return stats
 }
 
 // Validate validates OCMS log content.
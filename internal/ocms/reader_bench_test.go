@@ -0,0 +1,80 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package ocms
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/olegiv/logwatch-ai-go/internal/memtest"
+)
+
+// writeOCMSLog writes an OCMS log of about size bytes, mixing JSON and
+// key=value lines with request IDs, latencies, repeated errors, and
+// messages unique enough to exceed the pattern limits of the digest.
+func writeOCMSLog(tb testing.TB, size int64) string {
+	tb.Helper()
+
+	path := filepath.Join(tb.TempDir(), "ocms.log")
+	file, err := os.Create(path)
+	if err != nil {
+		tb.Fatalf("Create() error = %v", err)
+	}
+	w := bufio.NewWriterSize(file, 1<<20)
+
+	start := time.Date(2026, 4, 26, 0, 0, 0, 0, time.UTC)
+	components := []string{"http", "db", "cache", "auth"}
+	var written int64
+	for i := 0; written < size; i++ {
+		ts := start.Add(time.Duration(i) * time.Millisecond).Format(time.RFC3339Nano)
+		component := components[i%len(components)]
+		var n int
+		switch {
+		case i%10 == 0:
+			// Unique messages, such as a handler logging its input
+			n, err = fmt.Fprintf(w, "time=%s level=WARN msg=\"unexpected field %x in payload %s\" component=%s request_id=req-%d\n",
+				ts, i*7919, strings.Repeat("y", i%17), component, i)
+		case i%7 == 0:
+			n, err = fmt.Fprintf(w, "time=%s level=ERROR msg=\"query failed\" component=db request_id=req-%d err=\"sqlite: database is locked\"\n",
+				ts, i)
+		default:
+			n, err = fmt.Fprintf(w, `{"time":%q,"level":"INFO","msg":"HTTP request","component":%q,"request_id":"req-%d","path":"/page/%d","duration":%d}`+"\n",
+				ts, component, i, i%300, (i%2000+1)*int(time.Millisecond/10))
+		}
+		if err != nil {
+			tb.Fatalf("Fprintf() error = %v", err)
+		}
+		written += int64(n)
+	}
+	if err := w.Flush(); err != nil {
+		tb.Fatalf("Flush() error = %v", err)
+	}
+	if err := file.Close(); err != nil {
+		tb.Fatalf("Close() error = %v", err)
+	}
+	return path
+}
+
+// readBenchmark summarizes a generated log for the memory checks.
+func readBenchmark(path string, maxSizeMB int) (string, error) {
+	return NewReader(maxSizeMB, false, 150000).Read(path)
+}
+
+// BenchmarkReader_Read reports the peak heap of streaming logs of
+// growing size, which stays flat since the digest state is bounded.
+func BenchmarkReader_Read(b *testing.B) {
+	memtest.BenchmarkRead(b, writeOCMSLog, readBenchmark)
+}
+
+// TestReader_Read_BoundedMemory checks that a large log is summarized
+// within a fixed heap, far below its size: the digest state is capped, not
+// proportional to the lines read.
+func TestReader_Read_BoundedMemory(t *testing.T) {
+	memtest.CheckBoundedRead(t, writeOCMSLog, readBenchmark, 40, "more lines of further patterns")
+}
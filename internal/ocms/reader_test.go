@@ -30,8 +30,19 @@ func TestReader_Read_Success(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Read() error = %v", err)
 	}
-	if got != content {
-		t.Errorf("Read() content mismatch")
+	// Repeated entries are collapsed below the statistics header
+	for _, want := range []string{
+		"=== OCMS LOG ANALYSIS ===",
+		"Entries: 4 (plain: 4)",
+		"- INFO: 4",
+		"[4x] 2026-04-26T02:15:00Z INFO request processed successfully\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Read() missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Count(got, "request processed successfully") != 1 {
+		t.Errorf("Read() did not deduplicate the entries:\n%s", got)
 	}
 }
 
@@ -50,8 +61,8 @@ func TestReader_Read_ShortNonEmptyLog(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Read() error = %v", err)
 	}
-	if got != content {
-		t.Errorf("Read() content mismatch")
+	if !strings.Contains(got, "- WARN: 1") || !strings.Contains(got, content) {
+		t.Errorf("Read() = %q", got)
 	}
 }
 
@@ -102,6 +113,52 @@ func TestReader_ReadFiles_CombinedContent(t *testing.T) {
 	}
 }
 
+func TestReader_ReadFiles_LabeledByDay(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	olderLog := filepath.Join(tmpDir, "ocms.log.2")
+	newerLog := filepath.Join(tmpDir, "ocms.log.1")
+	if err := os.WriteFile(olderLog, []byte("2026-04-24T10:00:00Z INFO older day\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(newerLog, []byte("2026-04-25T10:00:00Z INFO newer day\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// Logs selected by day are read regardless of their age
+	old := time.Now().Add(-72 * time.Hour)
+	if err := os.Chtimes(olderLog, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := NewReader(10, false, 1000)
+	got, err := reader.ReadFiles([]LogFile{
+		{Kind: "main", Path: olderLog, Day: "2026-04-24"},
+		{Kind: "main", Path: newerLog, Day: "2026-04-25"},
+	})
+	if err != nil {
+		t.Fatalf("ReadFiles() error = %v", err)
+	}
+	for _, want := range []string{
+		"Entries per log: main 2026-04-24: 1, main 2026-04-25: 1",
+		"### OCMS MAIN LOG (2026-04-24)\nPath: " + olderLog,
+		"### OCMS MAIN LOG (2026-04-25)\nPath: " + newerLog,
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("combined content missing %q:\n%s", want, got)
+		}
+	}
+
+	// A single day is labeled too
+	got, err = reader.ReadFiles([]LogFile{{Kind: "main", Path: olderLog, Day: "2026-04-24"}})
+	if err != nil {
+		t.Fatalf("ReadFiles(single day) error = %v", err)
+	}
+	if !strings.Contains(got, "### OCMS MAIN LOG (2026-04-24)") {
+		t.Fatalf("single day content missing label:\n%s", got)
+	}
+}
+
 func TestReader_ReadFiles_MissingSecondFileTolerated(t *testing.T) {
 	t.Parallel()
 
@@ -149,6 +206,40 @@ func TestReader_ReadFiles_AllMissingFails(t *testing.T) {
 	}
 }
 
+func TestReader_ReadAppended(t *testing.T) {
+	t.Parallel()
+
+	reader := NewReader(10, false, 1000)
+	mainLog := LogFile{Kind: "main", Path: "/srv/ocms/logs/ocms.log"}
+	errorLog := LogFile{Kind: "error", Path: "/srv/ocms/logs/error.log"}
+
+	// Files without new lines are left out
+	got, err := reader.ReadAppended([]AppendedLog{
+		{LogFile: mainLog, Content: "2026-04-26T02:15:00Z INFO new event\n"},
+		{LogFile: errorLog},
+	})
+	if err != nil {
+		t.Fatalf("ReadAppended() error = %v", err)
+	}
+	if !strings.Contains(got, "### OCMS MAIN LOG") || strings.Contains(got, "### OCMS ERROR LOG") {
+		t.Fatalf("ReadAppended() = %q", got)
+	}
+
+	// A single file is not labeled
+	got, err = reader.ReadAppended([]AppendedLog{{LogFile: mainLog, Content: "line\n"}})
+	if err != nil || !strings.HasSuffix(got, "\nline\n") || strings.Contains(got, "### OCMS") {
+		t.Fatalf("ReadAppended(single) = %q, %v", got, err)
+	}
+
+	got, err = reader.ReadAppended([]AppendedLog{{LogFile: mainLog}, {LogFile: errorLog, Content: "\n"}})
+	if err != nil || !IsNoEntriesContent(got) {
+		t.Fatalf("ReadAppended(nothing new) = %q, %v", got, err)
+	}
+	if reader.ReadStats() != nil {
+		t.Errorf("ReadStats() after no new lines = %+v, want nil", reader.ReadStats())
+	}
+}
+
 func TestReader_Read_TooOld(t *testing.T) {
 	t.Parallel()
 
@@ -170,6 +261,28 @@ func TestReader_Read_TooOld(t *testing.T) {
 	}
 }
 
+func TestReader_Read_EmptyOrTooLarge(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	empty := filepath.Join(tmpDir, "empty.log")
+	if err := os.WriteFile(empty, nil, 0o600); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	if _, err := NewReader(10, false, 1000).Read(empty); err == nil || !strings.Contains(err.Error(), "ocms log file is empty") {
+		t.Errorf("Read(empty) error = %v, want the empty file error", err)
+	}
+
+	large := filepath.Join(tmpDir, "large.log")
+	line := "2026-04-26T02:15:00Z INFO request processed successfully\n"
+	if err := os.WriteFile(large, []byte(strings.Repeat(line, (1<<20)/len(line)+1)), 0o600); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	if _, err := NewReader(1, false, 1000).Read(large); err == nil || !strings.Contains(err.Error(), "exceeds maximum size of 1MB") {
+		t.Errorf("Read(large) error = %v, want the size limit", err)
+	}
+}
+
 func TestReader_Validate(t *testing.T) {
 	t.Parallel()
 
@@ -184,3 +297,85 @@ func TestReader_Validate(t *testing.T) {
 		t.Fatalf("unexpected validation error: %v", err)
 	}
 }
+
+func TestReader_ReadStats(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "ocms.log")
+	content := "2026-04-26T02:15:00Z ERROR db timeout after 30 seconds\n" +
+		"2026-04-26T02:16:00Z ERROR db timeout after 31 seconds\n" +
+		"2026-04-26T02:17:00Z INFO request processed\n"
+	if err := os.WriteFile(testFile, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	reader := NewReader(10, false, 1000)
+	if _, err := reader.Read(testFile); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	stats := reader.ReadStats()
+	if stats == nil {
+		t.Fatal("ReadStats() = nil")
+	}
+	if stats.Totals[1].Name != "Error entries" || stats.Totals[1].Count != 2 {
+		t.Errorf("Totals = %+v, want 2 error entries", stats.Totals)
+	}
+	for _, b := range stats.Breakdowns {
+		if b.Title == "Top errors" {
+			if b.Items[0].Name != "db timeout after <n> seconds" || b.Items[0].Count != 2 {
+				t.Errorf("Top errors = %+v, want the repeated timeout", b.Items)
+			}
+			return
+		}
+	}
+	t.Errorf("Breakdowns = %+v, want top errors", stats.Breakdowns)
+}
+
+func TestReader_ReadContent_StructuredDigest(t *testing.T) {
+	t.Parallel()
+
+	content := `{"time":"2026-04-26T02:15:00Z","level":"INFO","msg":"HTTP request","component":"http","request_id":"r1","duration":12000000}
+{"time":"2026-04-26T02:15:01Z","level":"INFO","msg":"HTTP request","component":"http","request_id":"r2","duration":30000000}
+{"time":"2026-04-26T02:15:02Z","level":"ERROR","msg":"query failed","component":"db","request_id":"r3","error":"sqlite: database is locked"}
+time=2026-04-26T02:15:03.000Z level=ERROR msg="query failed" component=db request_id=r4 err="sqlite: database is locked"
+time=2026-04-26T02:15:04.000Z level=WARN msg="slow request" component=http request_id=r5 latency=2.5s
+panic: runtime error: invalid memory address or nil pointer dereference
+goroutine 1 [running]:
+main.main()
+	/src/main.go:12 +0x1d
+`
+
+	reader := NewReader(10, false, 1000)
+	got, err := reader.ReadContent(content)
+	if err != nil {
+		t.Fatalf("ReadContent() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"Entries: 6 (json: 3, key=value: 2, plain: 1)",
+		"Continuation lines (stack traces, multi-line messages): 3",
+		"Time range: 2026-04-26 02:15:00 to 2026-04-26 02:15:04",
+		"Unique request IDs: 5",
+		"Latency: p50 30ms, p90 2.5s, p99 2.5s, max 2.5s (3 timed entries)",
+		"- FATAL: 1\n- ERROR: 2\n- WARN: 1\n- INFO: 2\n",
+		// JSON and slog text lines of the same error are one pattern
+		"- [2x] ERROR db (database): query failed: sqlite: database is locked",
+		"- database: 2\n",
+		"- panic: 1\n",
+		"- http: 3 (errors: 0, latency p50 30ms, p90 2.5s, p99 2.5s, max 2.5s)",
+		"- 2.5s: time=2026-04-26T02:15:04.000Z",
+		"[2x] {\"time\":\"2026-04-26T02:15:00Z\"",
+		"panic: runtime error: invalid memory address or nil pointer dereference\n  goroutine 1 [running]:\n  main.main()\n  /src/main.go:12 +0x1d\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("ReadContent() missing %q:\n%s", want, got)
+		}
+	}
+
+	stats := reader.ReadStats()
+	if stats == nil || stats.Totals[0].Count != 6 || stats.Totals[1].Count != 3 {
+		t.Errorf("ReadStats() = %+v", stats)
+	}
+}
@@ -0,0 +1,447 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package ocms
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format is the line format of an OCMS log entry.
+type Format string
+
+// Line formats recognized by ParseLine.
+const (
+	FormatJSON     Format = "json"      // slog JSONHandler and other JSON loggers
+	FormatKeyValue Format = "key=value" // slog TextHandler (logfmt)
+	FormatPlain    Format = "plain"     // timestamped text lines, Go's log package
+)
+
+// Normalized log levels. Entries without a level have an empty Level.
+const (
+	LevelDebug = "DEBUG"
+	LevelInfo  = "INFO"
+	LevelWarn  = "WARN"
+	LevelError = "ERROR"
+	LevelFatal = "FATAL"
+)
+
+// levelOrder lists the levels from most to least severe.
+var levelOrder = []string{LevelFatal, LevelError, LevelWarn, LevelInfo, LevelDebug}
+
+// levelAliases maps lowercase level names used by common loggers to the
+// normalized levels.
+var levelAliases = map[string]string{
+	"trace":     LevelDebug,
+	"debug":     LevelDebug,
+	"dbg":       LevelDebug,
+	"info":      LevelInfo,
+	"inf":       LevelInfo,
+	"notice":    LevelInfo,
+	"warn":      LevelWarn,
+	"warning":   LevelWarn,
+	"wrn":       LevelWarn,
+	"error":     LevelError,
+	"err":       LevelError,
+	"erro":      LevelError,
+	"fatal":     LevelFatal,
+	"panic":     LevelFatal,
+	"crit":      LevelFatal,
+	"critical":  LevelFatal,
+	"alert":     LevelFatal,
+	"emerg":     LevelFatal,
+	"emergency": LevelFatal,
+}
+
+// Attribute keys, compared after lowercasing and removing "_", "-", and
+// "." (so request_id, requestId, and X-Request-Id all match "requestid").
+var (
+	timeKeys       = []string{"time", "ts", "timestamp", "@timestamp", "datetime"}
+	levelKeys      = []string{"level", "lvl", "severity", "loglevel"}
+	messageKeys    = []string{"msg", "message"}
+	componentKeys  = []string{"component", "module", "logger", "subsystem", "service", "handler", "category"}
+	requestIDKeys  = []string{"requestid", "reqid", "xrequestid", "traceid", "correlationid", "rid"}
+	errorKeys      = []string{"error", "err", "errormessage", "exception"}
+	errorClassKeys = []string{"errorclass", "errortype", "errtype", "errorkind", "exceptionclass"}
+	latencyKeys    = []string{"latency", "duration", "elapsed", "took", "responsetime", "latencyms", "durationms", "elapsedms", "responsetimems"}
+)
+
+// timeLayouts are the timestamp layouts of plain lines and string time
+// attributes, tried in order.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006/01/02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999Z0700",
+}
+
+var (
+	// plainLineRegex matches a timestamp at the start of a plain line.
+	plainLineRegex = regexp.MustCompile(`^(\d{4}[-/]\d{2}[-/]\d{2}(?:[T ]\d{2}:\d{2}:\d{2}(?:[.,]\d+)?(?:Z|[+-]\d{2}:?\d{2})?)?)\s+(.*)$`)
+	// plainLevelRegex matches a level token such as INFO, [WARN], or error:.
+	plainLevelRegex = regexp.MustCompile(`^\[?([A-Za-z]+)\]?:?(?:\s+|$)`)
+	// plainComponentRegex matches a bracketed component after the level.
+	plainComponentRegex = regexp.MustCompile(`^\[([\w./-]+)\]:?\s+`)
+	// plainLatencyRegex matches durations such as "in 15ms" or "took 1.2s".
+	plainLatencyRegex = regexp.MustCompile(`(?i)\b(?:in|took|after|latency|duration|elapsed)[:=]?\s*(\d+(?:\.\d+)?(?:ns|us|µs|ms|s))\b`)
+	// runtimeCrashRegex matches the first line of a Go runtime crash.
+	runtimeCrashRegex = regexp.MustCompile(`^(?:panic|fatal error): `)
+)
+
+// Entry is one parsed OCMS log line.
+type Entry struct {
+	Format     Format
+	Time       time.Time // zero if the line carries no timestamp
+	Level      string    // one of the Level constants, or ""
+	Component  string
+	RequestID  string
+	Latency    time.Duration
+	HasLatency bool
+	Error      string // error attribute, if any
+	ErrorClass string // set for ERROR and FATAL entries
+	Message    string
+}
+
+// IsError reports whether the entry has the ERROR or FATAL level.
+func (e Entry) IsError() bool {
+	return e.Level == LevelError || e.Level == LevelFatal
+}
+
+// ParseLine parses an OCMS log line in JSON, key=value (slog), or plain
+// format. Plain lines must start with a timestamp or a level; other lines,
+// such as stack trace lines, are not entries.
+func ParseLine(line string) (Entry, bool) {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" {
+		return Entry{}, false
+	}
+
+	var e Entry
+	var ok bool
+	switch {
+	case strings.HasPrefix(trimmed, "{"):
+		e, ok = parseJSONLine(trimmed)
+	case strings.HasPrefix(trimmed, "time=") || strings.HasPrefix(trimmed, "level="):
+		e, ok = parseKeyValueLine(trimmed)
+	}
+	if !ok {
+		e, ok = parsePlainLine(trimmed)
+	}
+	if !ok {
+		return Entry{}, false
+	}
+
+	if e.IsError() {
+		e.ErrorClass = classifyError(e.ErrorClass, e.Error+" "+e.Message)
+	} else {
+		e.ErrorClass = ""
+	}
+	return e, true
+}
+
+// parseJSONLine parses a JSON object line.
+func parseJSONLine(line string) (Entry, bool) {
+	decoder := json.NewDecoder(strings.NewReader(line))
+	decoder.UseNumber()
+	var fields map[string]any
+	if err := decoder.Decode(&fields); err != nil {
+		return Entry{}, false
+	}
+
+	attrs := make([]attr, 0, len(fields))
+	for key, value := range fields {
+		switch v := value.(type) {
+		case string:
+			attrs = append(attrs, attr{key: key, value: v})
+		case json.Number:
+			attrs = append(attrs, attr{key: key, value: v.String(), number: true})
+		case nil:
+		default:
+			// Nested objects and arrays are kept as compact JSON
+			raw, _ := json.Marshal(v)
+			attrs = append(attrs, attr{key: key, value: string(bytes.TrimSpace(raw))})
+		}
+	}
+	// Map order is random; sort so duplicate keys resolve the same way
+	sort.Slice(attrs, func(i, j int) bool { return attrs[i].key < attrs[j].key })
+	return entryFromAttrs(FormatJSON, attrs)
+}
+
+// parseKeyValueLine parses a slog TextHandler (logfmt) line.
+func parseKeyValueLine(line string) (Entry, bool) {
+	attrs, ok := parseKeyValues(line)
+	if !ok {
+		return Entry{}, false
+	}
+	return entryFromAttrs(FormatKeyValue, attrs)
+}
+
+// parsePlainLine parses "[timestamp] [LEVEL] [[component]] message".
+func parsePlainLine(line string) (Entry, bool) {
+	e := Entry{Format: FormatPlain}
+	rest := line
+
+	if m := plainLineRegex.FindStringSubmatch(line); m != nil {
+		e.Time = parseTime(m[1])
+		rest = m[2]
+	}
+
+	if e.Time.IsZero() && runtimeCrashRegex.MatchString(line) {
+		// The Go runtime writes panics to stderr without a timestamp
+		e.Level = LevelFatal
+		e.ErrorClass = "panic"
+		e.Message = line
+		return e, true
+	}
+
+	if m := plainLevelRegex.FindStringSubmatch(rest); m != nil {
+		if level, ok := levelAliases[strings.ToLower(m[1])]; ok {
+			e.Level = level
+			rest = rest[len(m[0]):]
+		}
+	}
+	if e.Time.IsZero() && e.Level == "" {
+		return Entry{}, false
+	}
+
+	if m := plainComponentRegex.FindStringSubmatch(rest); m != nil {
+		e.Component = m[1]
+		rest = rest[len(m[0]):]
+	}
+	e.Message = rest
+
+	// Plain messages often end with slog-style attributes
+	if attrs, ok := parseKeyValues(trailingKeyValues(rest)); ok {
+		applyAttrs(&e, attrs)
+	}
+	if !e.HasLatency {
+		if m := plainLatencyRegex.FindStringSubmatch(rest); m != nil {
+			e.Latency, e.HasLatency = parseLatency(m[1], false, "")
+		}
+	}
+	return e, true
+}
+
+// attr is a key and value of a structured line.
+type attr struct {
+	key    string
+	value  string
+	number bool // a JSON number
+}
+
+// entryFromAttrs builds an entry from the attributes of a structured line.
+// A structured line needs at least a level or a message.
+func entryFromAttrs(format Format, attrs []attr) (Entry, bool) {
+	e := Entry{Format: format}
+	hasMessage := false
+	for _, a := range attrs {
+		key := normalizeKey(a.key)
+		switch {
+		case matchesKey(key, timeKeys) && e.Time.IsZero():
+			e.Time = parseTime(a.value)
+		case matchesKey(key, levelKeys) && e.Level == "":
+			e.Level = normalizeLevel(a.value)
+		case matchesKey(key, messageKeys) && !hasMessage:
+			e.Message = a.value
+			hasMessage = true
+		}
+	}
+	if e.Level == "" && !hasMessage {
+		return Entry{}, false
+	}
+	applyAttrs(&e, attrs)
+	return e, true
+}
+
+// applyAttrs sets the component, request ID, error, and latency of an
+// entry from its attributes.
+func applyAttrs(e *Entry, attrs []attr) {
+	for _, a := range attrs {
+		key := normalizeKey(a.key)
+		switch {
+		case matchesKey(key, componentKeys) && e.Component == "":
+			e.Component = a.value
+		case matchesKey(key, requestIDKeys) && e.RequestID == "":
+			e.RequestID = a.value
+		case matchesKey(key, errorClassKeys) && e.ErrorClass == "":
+			e.ErrorClass = a.value
+		case matchesKey(key, errorKeys) && e.Error == "":
+			e.Error = a.value
+		case matchesKey(key, latencyKeys) && !e.HasLatency:
+			e.Latency, e.HasLatency = parseLatency(a.value, a.number, key)
+		}
+	}
+}
+
+// parseKeyValues parses space-separated key=value pairs with optionally
+// quoted values, as written by slog's TextHandler.
+func parseKeyValues(s string) ([]attr, bool) {
+	var attrs []attr
+	for {
+		s = strings.TrimLeft(s, " \t")
+		if s == "" {
+			break
+		}
+		eq := strings.IndexByte(s, '=')
+		if eq <= 0 || strings.ContainsAny(s[:eq], " \t\"") {
+			return nil, false
+		}
+		key := s[:eq]
+		s = s[eq+1:]
+
+		var value string
+		if strings.HasPrefix(s, `"`) {
+			end := closingQuote(s)
+			if end < 0 {
+				return nil, false
+			}
+			unquoted, err := strconv.Unquote(s[:end+1])
+			if err != nil {
+				return nil, false
+			}
+			value, s = unquoted, s[end+1:]
+		} else {
+			end := strings.IndexAny(s, " \t")
+			if end < 0 {
+				end = len(s)
+			}
+			value, s = s[:end], s[end:]
+		}
+		attrs = append(attrs, attr{key: key, value: value})
+	}
+	return attrs, len(attrs) > 0
+}
+
+// closingQuote returns the index of the quote that closes the quoted
+// string at the start of s, or -1.
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// trailingKeyValues returns the key=value pairs at the end of a plain
+// message, starting at the first word that contains "=".
+func trailingKeyValues(message string) string {
+	for i := 0; i < len(message); {
+		end := strings.IndexByte(message[i:], ' ')
+		word := message[i:]
+		if end >= 0 {
+			word = message[i : i+end]
+		}
+		if eq := strings.IndexByte(word, '='); eq > 0 {
+			return message[i:]
+		}
+		if end < 0 {
+			break
+		}
+		i += end + 1
+	}
+	return ""
+}
+
+// keySeparators removes the separators of attribute keys, built once:
+// normalizeKey runs for every attribute of every line.
+var keySeparators = strings.NewReplacer("_", "", "-", "", ".", "")
+
+// normalizeKey lowercases an attribute key and removes separators.
+func normalizeKey(key string) string {
+	return keySeparators.Replace(strings.ToLower(key))
+}
+
+func matchesKey(key string, keys []string) bool {
+	for _, k := range keys {
+		if key == k {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeLevel maps a level name to a Level constant. slog's offset
+// levels such as "ERROR+2" map to their base level.
+func normalizeLevel(level string) string {
+	base := strings.ToLower(strings.TrimSpace(level))
+	if i := strings.IndexAny(base, "+-"); i > 0 {
+		base = base[:i]
+	}
+	return levelAliases[base]
+}
+
+// parseTime parses a timestamp in one of timeLayouts.
+func parseTime(s string) time.Time {
+	s = strings.Replace(s, ",", ".", 1)
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// parseLatency parses a duration attribute. Strings with a unit ("1.5ms")
+// are parsed as Go durations. Bare numbers are milliseconds for keys ending
+// in "ms" and, like slog's JSONHandler encodes time.Duration, nanoseconds
+// otherwise.
+func parseLatency(value string, number bool, key string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if d, err := time.ParseDuration(strings.Replace(value, "µs", "us", 1)); err == nil && !number {
+		return d, d >= 0
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	if strings.HasSuffix(key, "ms") {
+		return time.Duration(n * float64(time.Millisecond)), true
+	}
+	return time.Duration(n), true
+}
+
+// errorClasses maps lowercase substrings of error messages to error
+// classes, in order of precedence.
+var errorClasses = []struct {
+	class    string
+	keywords []string
+}{
+	{"panic", []string{"panic", "nil pointer", "index out of range", "runtime error"}},
+	{"timeout", []string{"deadline exceeded", "timeout", "timed out"}},
+	{"database", []string{"sql", "database", "sqlite", "mysql", "postgres", "deadlock", "constraint failed", "no such table"}},
+	{"network", []string{"connection refused", "connection reset", "broken pipe", "no such host", "network is unreachable", "tls handshake"}},
+	{"auth", []string{"unauthorized", "forbidden", "permission denied", "csrf", "invalid token", "authentication", "login failed"}},
+	{"resource", []string{"out of memory", "too many open files", "no space left", "disk full"}},
+	{"not_found", []string{"not found", "no such file"}},
+	{"template", []string{"template", "render"}},
+	{"validation", []string{"validation", "invalid"}},
+}
+
+// classifyError returns the explicit error class of an error entry, or
+// the class derived from its error and message text.
+func classifyError(explicit, text string) string {
+	if explicit = strings.TrimSpace(explicit); explicit != "" {
+		return explicit
+	}
+	lower := strings.ToLower(text)
+	for _, c := range errorClasses {
+		for _, keyword := range c.keywords {
+			if strings.Contains(lower, keyword) {
+				return c.class
+			}
+		}
+	}
+	return "other"
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package ocms
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLine(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		line string
+		want Entry
+	}{
+		{
+			name: "slog JSON",
+			line: `{"time":"2026-04-26T02:15:00.5Z","level":"INFO","msg":"HTTP request","component":"http","request_id":"f3a9","status":200,"duration":1500000}`,
+			want: Entry{
+				Format:     FormatJSON,
+				Time:       time.Date(2026, 4, 26, 2, 15, 0, 500000000, time.UTC),
+				Level:      LevelInfo,
+				Component:  "http",
+				RequestID:  "f3a9",
+				Latency:    1500 * time.Microsecond,
+				HasLatency: true,
+				Message:    "HTTP request",
+			},
+		},
+		{
+			name: "JSON error with explicit class and latency in ms",
+			line: `{"ts":"2026-04-26T02:15:01Z","severity":"error","message":"payment failed","error":"card declined","error_type":"gateway","latency_ms":250}`,
+			want: Entry{
+				Format:     FormatJSON,
+				Time:       time.Date(2026, 4, 26, 2, 15, 1, 0, time.UTC),
+				Level:      LevelError,
+				Latency:    250 * time.Millisecond,
+				HasLatency: true,
+				Error:      "card declined",
+				ErrorClass: "gateway",
+				Message:    "payment failed",
+			},
+		},
+		{
+			name: "slog text",
+			line: `time=2026-04-26T02:15:02.000Z level=ERROR+2 msg="query failed" component=db X-Request-Id=b7 err="sqlite: database is locked" latency=1.2s`,
+			want: Entry{
+				Format:     FormatKeyValue,
+				Time:       time.Date(2026, 4, 26, 2, 15, 2, 0, time.UTC),
+				Level:      LevelError,
+				Component:  "db",
+				RequestID:  "b7",
+				Latency:    1200 * time.Millisecond,
+				HasLatency: true,
+				Error:      "sqlite: database is locked",
+				ErrorClass: "database",
+				Message:    "query failed",
+			},
+		},
+		{
+			name: "plain with component and attributes",
+			line: "2026-04-26 02:15:03 [WARN] [scheduler] job slow request_id=c1",
+			want: Entry{
+				Format:    FormatPlain,
+				Time:      time.Date(2026, 4, 26, 2, 15, 3, 0, time.UTC),
+				Level:     LevelWarn,
+				Component: "scheduler",
+				RequestID: "c1",
+				Message:   "job slow request_id=c1",
+			},
+		},
+		{
+			name: "plain latency",
+			line: "2026-04-26T02:15:04Z INFO request completed in 15ms",
+			want: Entry{
+				Format:     FormatPlain,
+				Time:       time.Date(2026, 4, 26, 2, 15, 4, 0, time.UTC),
+				Level:      LevelInfo,
+				Latency:    15 * time.Millisecond,
+				HasLatency: true,
+				Message:    "request completed in 15ms",
+			},
+		},
+		{
+			name: "Go log package without level",
+			line: "2026/04/26 02:15:05 server started",
+			want: Entry{
+				Format:  FormatPlain,
+				Time:    time.Date(2026, 4, 26, 2, 15, 5, 0, time.UTC),
+				Message: "server started",
+			},
+		},
+		{
+			name: "level without timestamp",
+			line: "ERROR upstream connection refused",
+			want: Entry{
+				Format:     FormatPlain,
+				Level:      LevelError,
+				ErrorClass: "network",
+				Message:    "upstream connection refused",
+			},
+		},
+		{
+			name: "runtime panic",
+			line: "panic: runtime error: index out of range [3] with length 2",
+			want: Entry{
+				Format:     FormatPlain,
+				Level:      LevelFatal,
+				ErrorClass: "panic",
+				Message:    "panic: runtime error: index out of range [3] with length 2",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := ParseLine(tt.line)
+			if !ok {
+				t.Fatalf("ParseLine(%q) not parsed", tt.line)
+			}
+			if got != tt.want {
+				t.Errorf("ParseLine() =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLine_NotEntries(t *testing.T) {
+	t.Parallel()
+
+	for _, line := range []string{
+		"",
+		"goroutine 1 [running]:",
+		"\t/src/main.go:12 +0x1d",
+		"main.main()",
+		`{"not": "a log entry"}`,
+		"request processed",
+	} {
+		if e, ok := ParseLine(line); ok {
+			t.Errorf("ParseLine(%q) = %+v, want no entry", line, e)
+		}
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		explicit, text, want string
+	}{
+		{"", "context deadline exceeded", "timeout"},
+		{"", "UNIQUE constraint failed: users.email", "database"},
+		{"", "dial tcp 10.0.0.5:6379: connect: connection refused", "network"},
+		{"", "csrf token mismatch", "auth"},
+		{"", "something odd happened", "other"},
+		{"ValidationError", "invalid email", "ValidationError"},
+	}
+	for _, tt := range tests {
+		if got := classifyError(tt.explicit, tt.text); got != tt.want {
+			t.Errorf("classifyError(%q, %q) = %q, want %q", tt.explicit, tt.text, got, tt.want)
+		}
+	}
+}
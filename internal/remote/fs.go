@@ -0,0 +1,286 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package remote
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+)
+
+// readBufferSize is the read size of remote files. SFTP reads larger than
+// one packet are sent concurrently, so large reads cut the round trips.
+const readBufferSize = 1 << 20
+
+// Compile-time interface check
+var _ analyzer.SourceFS = (*FS)(nil)
+
+// FS implements analyzer.SourceFS for sftp:// source paths. Connections
+// are opened on first use and reused until Close, so the files of a run
+// share one connection per host and user.
+type FS struct {
+	hosts map[string]Host
+
+	mu    sync.Mutex
+	conns map[string]*connection // keyed by user@address
+}
+
+// connection is an SFTP session over an SSH connection.
+type connection struct {
+	ssh  *ssh.Client
+	sftp *sftp.Client
+}
+
+// NewFS creates a remote file system of the configured hosts.
+func NewFS(hosts map[string]Host) *FS {
+	return &FS{
+		hosts: hosts,
+		conns: make(map[string]*connection),
+	}
+}
+
+// Stat implements analyzer.SourceFS.Stat.
+func (f *FS) Stat(name string) (fs.FileInfo, error) {
+	client, loc, err := f.client(name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := client.Stat(loc.Path)
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+	}
+	return info, nil
+}
+
+// Open implements analyzer.SourceFS.Open.
+func (f *FS) Open(name string) (io.ReadCloser, error) {
+	client, loc, err := f.client(name)
+	if err != nil {
+		return nil, err
+	}
+	file, err := client.Open(loc.Path)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	return &remoteFile{Reader: bufio.NewReaderSize(file, readBufferSize), file: file}, nil
+}
+
+// Glob implements analyzer.SourceFS.Glob. Patterns are matched on the
+// remote host; the matches are source paths of the same host and user.
+func (f *FS) Glob(pattern string) ([]string, error) {
+	client, loc, err := f.client(pattern)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := client.Glob(loc.Path)
+	if err != nil {
+		return nil, fmt.Errorf("glob %s: %w", pattern, err)
+	}
+	for i, match := range matches {
+		matchLoc := loc
+		matchLoc.Path = match
+		matches[i] = matchLoc.String()
+	}
+	return matches, nil
+}
+
+// Close closes all connections.
+func (f *FS) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var errs []error
+	for key, conn := range f.conns {
+		if err := conn.sftp.Close(); err != nil {
+			errs = append(errs, err)
+		}
+		if err := conn.ssh.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+			errs = append(errs, err)
+		}
+		delete(f.conns, key)
+	}
+	return errors.Join(errs...)
+}
+
+// ListHosts returns the configured host names sorted alphabetically.
+func (f *FS) ListHosts() []string {
+	names := make([]string, 0, len(f.hosts))
+	for name := range f.hosts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// client returns the SFTP client of a source path, connecting to its host
+// on first use.
+func (f *FS) client(name string) (*sftp.Client, Location, error) {
+	loc, err := ParseURL(name)
+	if err != nil {
+		return nil, Location{}, err
+	}
+	host, ok := f.hosts[loc.Host]
+	if !ok {
+		return nil, Location{}, fmt.Errorf("remote host '%s' not found (available: %v)", loc.Host, f.ListHosts())
+	}
+
+	user := host.User
+	if loc.User != "" {
+		user = loc.User
+	}
+	address := host.address(loc.Host, loc.Port)
+	key := user + "@" + address
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if conn, ok := f.conns[key]; ok {
+		return conn.sftp, loc, nil
+	}
+
+	conn, err := dial(&host, user, address)
+	if err != nil {
+		return nil, Location{}, fmt.Errorf("remote host '%s': %w", loc.Host, err)
+	}
+	f.conns[key] = conn
+	return conn.sftp, loc, nil
+}
+
+// dial opens an SFTP session, authenticating with the private key of the
+// host and verifying the host key against its known_hosts file.
+func dial(host *Host, user, address string) (*connection, error) {
+	signer, err := loadIdentity(host.IdentityFile)
+	if err != nil {
+		return nil, err
+	}
+
+	knownHostsPath, err := host.knownHostsPath()
+	if err != nil {
+		return nil, err
+	}
+	hostKeyCallback, err := knownhosts.New(knownHostsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read known_hosts: %w", err)
+	}
+
+	config := &ssh.ClientConfig{
+		User:              user,
+		Auth:              []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback:   verifyHostKey(hostKeyCallback, knownHostsPath),
+		HostKeyAlgorithms: hostKeyAlgorithms(hostKeyCallback, address),
+		Timeout:           host.timeout(),
+	}
+	sshClient, err := ssh.Dial("tcp", address, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", address, err)
+	}
+
+	sftpClient, err := sftp.NewClient(sshClient)
+	if err != nil {
+		_ = sshClient.Close()
+		return nil, fmt.Errorf("failed to start SFTP session on %s: %w", address, err)
+	}
+
+	return &connection{ssh: sshClient, sftp: sftpClient}, nil
+}
+
+// loadIdentity reads a private key. Like OpenSSH, keys readable by other
+// users are refused.
+func loadIdentity(path string) (ssh.Signer, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read identity file: %w", err)
+	}
+	if info.Mode().Perm()&0o077 != 0 {
+		return nil, fmt.Errorf("identity file %s is accessible by other users (mode %04o), use chmod 600", path, info.Mode().Perm())
+	}
+
+	data, err := os.ReadFile(path) // #nosec G304 -- operator-configured identity file
+	if err != nil {
+		return nil, fmt.Errorf("failed to read identity file: %w", err)
+	}
+	signer, err := ssh.ParsePrivateKey(data)
+	if err != nil {
+		var passphraseErr *ssh.PassphraseMissingError
+		if errors.As(err, &passphraseErr) {
+			return nil, fmt.Errorf("identity file %s is encrypted; use an unencrypted key readable only by the analyzer user", path)
+		}
+		return nil, fmt.Errorf("invalid identity file %s: %w", path, err)
+	}
+	return signer, nil
+}
+
+// verifyHostKey wraps the known_hosts callback with errors that tell an
+// unknown host from a changed host key.
+func verifyHostKey(callback ssh.HostKeyCallback, knownHostsPath string) ssh.HostKeyCallback {
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		err := callback(hostname, remote, key)
+		var keyErr *knownhosts.KeyError
+		if !errors.As(err, &keyErr) {
+			return err
+		}
+		if len(keyErr.Want) == 0 {
+			return fmt.Errorf("host key of %s (%s) is not in %s; add it with ssh-keyscan after verifying its fingerprint",
+				hostname, ssh.FingerprintSHA256(key), knownHostsPath)
+		}
+		return fmt.Errorf("host key of %s (%s) does not match %s; the host key changed or the connection is intercepted",
+			hostname, ssh.FingerprintSHA256(key), knownHostsPath)
+	}
+}
+
+// hostKeyAlgorithms returns the algorithms of the known keys of address,
+// so the server presents a key that known_hosts can verify rather than
+// its preferred one. Nil, for an unknown host, keeps the defaults.
+func hostKeyAlgorithms(callback ssh.HostKeyCallback, address string) []string {
+	// A key of no known type makes the callback list the known keys
+	err := callback(address, &net.TCPAddr{IP: net.IPv4zero}, placeholderKey{})
+	var keyErr *knownhosts.KeyError
+	if !errors.As(err, &keyErr) {
+		return nil
+	}
+
+	var algorithms []string
+	for _, known := range keyErr.Want {
+		keyType := known.Key.Type()
+		candidates := []string{keyType}
+		if keyType == ssh.KeyAlgoRSA {
+			candidates = []string{ssh.KeyAlgoRSASHA512, ssh.KeyAlgoRSASHA256, ssh.KeyAlgoRSA}
+		}
+		for _, algorithm := range candidates {
+			if !slices.Contains(algorithms, algorithm) {
+				algorithms = append(algorithms, algorithm)
+			}
+		}
+	}
+	return algorithms
+}
+
+// placeholderKey is a public key that matches no known_hosts entry.
+type placeholderKey struct{}
+
+func (placeholderKey) Type() string                        { return "placeholder" }
+func (placeholderKey) Marshal() []byte                     { return []byte("placeholder") }
+func (placeholderKey) Verify([]byte, *ssh.Signature) error { return fmt.Errorf("placeholder key") }
+
+// remoteFile is a buffered remote file.
+type remoteFile struct {
+	*bufio.Reader
+	file *sftp.File
+}
+
+func (f *remoteFile) Close() error {
+	return f.file.Close()
+}
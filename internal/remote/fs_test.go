@@ -0,0 +1,361 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package remote
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+)
+
+// testServer is an in-process SSH server with a read-only SFTP subsystem
+// serving the local file system.
+type testServer struct {
+	address string
+	hostKey ssh.Signer
+	dir     string // directory of the client's key and known_hosts files
+}
+
+// newTestKey generates an ed25519 key pair.
+func newTestKey(t *testing.T) (ssh.Signer, ed25519.PrivateKey) {
+	t.Helper()
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer, private
+}
+
+// startTestServer starts a server that accepts the returned host's key
+// for user "logreader".
+func startTestServer(t *testing.T) (*testServer, Host) {
+	t.Helper()
+
+	hostKey, _ := newTestKey(t)
+	clientKey, clientPrivate := newTestKey(t)
+
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(meta ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if meta.User() == "logreader" && bytes.Equal(key.Marshal(), clientKey.PublicKey().Marshal()) {
+				return nil, nil
+			}
+			return nil, errors.New("unauthorized")
+		},
+	}
+	config.AddHostKey(hostKey)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveTestConn(conn, config)
+		}
+	}()
+
+	server := &testServer{address: listener.Addr().String(), hostKey: hostKey, dir: t.TempDir()}
+
+	block, err := ssh.MarshalPrivateKey(clientPrivate, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	identityFile := filepath.Join(server.dir, "id_ed25519")
+	if err := os.WriteFile(identityFile, pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	knownHosts := filepath.Join(server.dir, "known_hosts")
+	server.writeKnownHosts(t, knownHosts, hostKey.PublicKey())
+
+	host, port, _ := net.SplitHostPort(server.address)
+	portNumber, _ := strconv.Atoi(port)
+	return server, Host{
+		Address:        host,
+		Port:           portNumber,
+		User:           "logreader",
+		IdentityFile:   identityFile,
+		KnownHosts:     knownHosts,
+		TimeoutSeconds: 5,
+	}
+}
+
+// writeKnownHosts writes a known_hosts file listing key for the server.
+func (s *testServer) writeKnownHosts(t *testing.T, path string, key ssh.PublicKey) {
+	t.Helper()
+	line := knownhosts.Line([]string{knownhosts.Normalize(s.address)}, key) + "\n"
+	if err := os.WriteFile(path, []byte(line), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func serveTestConn(conn net.Conn, config *ssh.ServerConfig) {
+	defer func() { _ = conn.Close() }()
+	_, channels, requests, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(requests)
+
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			for req := range channelRequests {
+				ok := req.Type == "subsystem" && len(req.Payload) > 4 && string(req.Payload[4:]) == "sftp"
+				_ = req.Reply(ok, nil)
+				if !ok {
+					continue
+				}
+				server, err := sftp.NewServer(channel, sftp.ReadOnly())
+				if err != nil {
+					_ = channel.Close()
+					return
+				}
+				_ = server.Serve()
+				_ = channel.Close()
+				return
+			}
+		}()
+	}
+}
+
+// newTestFS returns a file system of the host named "app01".
+func newTestFS(t *testing.T, host Host) *FS {
+	t.Helper()
+	fsys := NewFS(map[string]Host{"app01": host})
+	t.Cleanup(func() { _ = fsys.Close() })
+	return fsys
+}
+
+func TestFS_StatOpenGlob(t *testing.T) {
+	t.Parallel()
+
+	_, host := startTestServer(t)
+	fsys := newTestFS(t, host)
+
+	logDir := t.TempDir()
+	content := "Jan  1 00:00:01 app01 sshd[1]: Accepted publickey for deploy\n"
+	if err := os.WriteFile(filepath.Join(logDir, "auth.log"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(logDir, "auth.log.1"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	name := "sftp://app01" + filepath.Join(logDir, "auth.log")
+	info, err := fsys.Stat(name)
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+	if info.Size() != int64(len(content)) {
+		t.Errorf("Stat().Size() = %d, want %d", info.Size(), len(content))
+	}
+
+	file, err := fsys.Open(name)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	data, err := io.ReadAll(file)
+	_ = file.Close()
+	if err != nil || string(data) != content {
+		t.Errorf("Open() read %q, %v; want %q", data, err, content)
+	}
+
+	matches, err := fsys.Glob("sftp://app01" + filepath.Join(logDir, "auth.log*"))
+	if err != nil {
+		t.Fatalf("Glob() error = %v", err)
+	}
+	if len(matches) != 2 || matches[0] != name || matches[1] != name+".1" {
+		t.Errorf("Glob() = %v", matches)
+	}
+
+	_, err = fsys.Stat("sftp://app01" + filepath.Join(logDir, "missing.log"))
+	if !errors.Is(err, fs.ErrNotExist) || !strings.Contains(err.Error(), "sftp://app01") {
+		t.Errorf("Stat(missing) error = %v, want not exist with URL", err)
+	}
+
+	_, err = fsys.Stat("sftp://web01/var/log/syslog")
+	if err == nil || !strings.Contains(err.Error(), "not found (available: [app01])") {
+		t.Errorf("Stat(unknown host) error = %v", err)
+	}
+
+	if len(fsys.conns) != 1 {
+		t.Errorf("connections = %d, want 1 reused connection", len(fsys.conns))
+	}
+}
+
+func TestFS_ReadSourceFileWithGuards(t *testing.T) {
+	// Registers the sftp scheme, so not parallel
+	_, host := startTestServer(t)
+	fsys := newTestFS(t, host)
+	analyzer.RegisterSourceFS(Scheme, fsys)
+	t.Cleanup(func() { analyzer.RegisterSourceFS(Scheme, nil) })
+
+	logDir := t.TempDir()
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	_, _ = gz.Write([]byte("rotated line one\nrotated line two\n"))
+	_ = gz.Close()
+	if err := os.WriteFile(filepath.Join(logDir, "app.log.1.gz"), compressed.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(logDir, "app.log"), []byte("live line\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := analyzer.FileReadOptions{SourceLabel: "app", MaxSizeMB: 1, MaxAge: 24 * time.Hour}
+	accept := func(string) error { return nil }
+
+	content, err := analyzer.ReadSourceFileWithGuards("sftp://app01"+filepath.Join(logDir, "app.log*"), opts, accept)
+	if err != nil {
+		t.Fatalf("ReadSourceFileWithGuards() error = %v", err)
+	}
+	if content != "rotated line one\nrotated line two\nlive line\n" {
+		t.Errorf("ReadSourceFileWithGuards() = %q", content)
+	}
+
+	info, err := analyzer.GetSourceFileInfo("sftp://app01" + filepath.Join(logDir, "app.log*"))
+	if err != nil || info["files"] != 2 {
+		t.Errorf("GetSourceFileInfo() = %v, %v", info, err)
+	}
+
+	// Size guard on the remote file size
+	large := filepath.Join(logDir, "large.log")
+	if err := os.WriteFile(large, bytes.Repeat([]byte("x"), 2*1024*1024), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, err = analyzer.ReadSourceFileWithGuards("sftp://app01"+large, opts, accept)
+	if err == nil || !strings.Contains(err.Error(), "exceeds maximum size") {
+		t.Errorf("ReadSourceFileWithGuards(large) error = %v, want size error", err)
+	}
+
+	// Age guard on the remote modification time
+	old := filepath.Join(logDir, "old.log")
+	if err := os.WriteFile(old, []byte("old line\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	oldTime := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(old, oldTime, oldTime); err != nil {
+		t.Fatal(err)
+	}
+	_, err = analyzer.ReadSourceFileWithGuards("sftp://app01"+old, opts, accept)
+	if err == nil || !strings.Contains(err.Error(), "too old") {
+		t.Errorf("ReadSourceFileWithGuards(old) error = %v, want age error", err)
+	}
+
+	_, err = analyzer.ReadSourceFileWithGuards("sftp://app01"+filepath.Join(logDir, "missing.log"), opts, accept)
+	if err == nil || !strings.Contains(err.Error(), "app file not found: sftp://app01") {
+		t.Errorf("ReadSourceFileWithGuards(missing) error = %v, want not found", err)
+	}
+}
+
+func TestFS_HostKeyVerification(t *testing.T) {
+	t.Parallel()
+
+	server, host := startTestServer(t)
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(logFile, []byte("line\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	name := "sftp://app01" + logFile
+
+	// Changed host key
+	otherKey, _ := newTestKey(t)
+	server.writeKnownHosts(t, host.KnownHosts, otherKey.PublicKey())
+	_, err := newTestFS(t, host).Stat(name)
+	if err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Errorf("Stat(changed host key) error = %v", err)
+	}
+
+	// Unknown host
+	if err := os.WriteFile(host.KnownHosts, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, err = newTestFS(t, host).Stat(name)
+	if err == nil || !strings.Contains(err.Error(), "is not in") {
+		t.Errorf("Stat(unknown host) error = %v", err)
+	}
+
+	// Missing known_hosts file
+	missing := host
+	missing.KnownHosts = filepath.Join(t.TempDir(), "known_hosts")
+	_, err = newTestFS(t, missing).Stat(name)
+	if err == nil || !strings.Contains(err.Error(), "known_hosts") {
+		t.Errorf("Stat(missing known_hosts) error = %v", err)
+	}
+}
+
+func TestFS_Authentication(t *testing.T) {
+	t.Parallel()
+
+	server, host := startTestServer(t)
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(logFile, []byte("line\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// User of the URL overrides the user of the host
+	_, err := newTestFS(t, host).Stat("sftp://root@app01" + logFile)
+	if err == nil || !strings.Contains(err.Error(), "unable to authenticate") {
+		t.Errorf("Stat(wrong user) error = %v", err)
+	}
+
+	// Key not authorized by the server
+	_, otherPrivate := newTestKey(t)
+	block, err := ssh.MarshalPrivateKey(otherPrivate, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := host
+	other.IdentityFile = filepath.Join(server.dir, "id_other")
+	if err := os.WriteFile(other.IdentityFile, pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, err = newTestFS(t, other).Stat("sftp://app01" + logFile)
+	if err == nil || !strings.Contains(err.Error(), "unable to authenticate") {
+		t.Errorf("Stat(unauthorized key) error = %v", err)
+	}
+
+	// Key readable by other users
+	if err := os.Chmod(other.IdentityFile, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = newTestFS(t, other).Stat("sftp://app01" + logFile)
+	if err == nil || !strings.Contains(err.Error(), "accessible by other users") {
+		t.Errorf("Stat(open key permissions) error = %v", err)
+	}
+}
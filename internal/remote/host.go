@@ -0,0 +1,161 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package remote reads log files on other hosts over SFTP. Source paths
+// like sftp://app01/var/log/syslog name a host configured in
+// remote-hosts.json, which holds its address, user, private key, and
+// known_hosts file. Registered with analyzer.RegisterSourceFS, the remote
+// files pass the same size and age guards as local ones.
+package remote
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Scheme is the URL scheme of remote source paths.
+const Scheme = "sftp"
+
+// Connection defaults of hosts that do not set them.
+const (
+	DefaultPort    = 22
+	DefaultTimeout = 30 * time.Second
+)
+
+// hostNameRegex matches host names of remote source paths.
+var hostNameRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Host configures the SSH connection of a remote host in remote-hosts.json.
+type Host struct {
+	Address        string `json:"address"`         // Host name or IP to connect to (default: the host name of the URL)
+	Port           int    `json:"port"`            // SSH port (default: 22)
+	User           string `json:"user"`            // Login user
+	IdentityFile   string `json:"identity_file"`   // Unencrypted private key file
+	KnownHosts     string `json:"known_hosts"`     // known_hosts file (default: ~/.ssh/known_hosts)
+	TimeoutSeconds int    `json:"timeout_seconds"` // Connect timeout (default: 30)
+}
+
+// Validate checks the host for configuration errors.
+func (h *Host) Validate() error {
+	if h.User == "" {
+		return fmt.Errorf("user is required")
+	}
+	if h.IdentityFile == "" {
+		return fmt.Errorf("identity_file is required")
+	}
+	if h.Port < 0 || h.Port > 65535 {
+		return fmt.Errorf("port must be between 1 and 65535 (got: %d)", h.Port)
+	}
+	if h.TimeoutSeconds < 0 {
+		return fmt.Errorf("timeout_seconds must not be negative (got: %d)", h.TimeoutSeconds)
+	}
+	return nil
+}
+
+// address returns the host and port to connect to.
+func (h *Host) address(name string, port int) string {
+	host := h.Address
+	if host == "" {
+		host = name
+	}
+	if port == 0 {
+		port = h.Port
+	}
+	if port == 0 {
+		port = DefaultPort
+	}
+	return net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+// knownHostsPath returns the known_hosts file of the host.
+func (h *Host) knownHostsPath() (string, error) {
+	if h.KnownHosts != "" {
+		return h.KnownHosts, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("known_hosts is not set and the home directory is unknown: %w", err)
+	}
+	return filepath.Join(home, ".ssh", "known_hosts"), nil
+}
+
+// timeout returns the connect timeout of the host.
+func (h *Host) timeout() time.Duration {
+	if h.TimeoutSeconds > 0 {
+		return time.Duration(h.TimeoutSeconds) * time.Second
+	}
+	return DefaultTimeout
+}
+
+// ValidateHostName checks a host name of remote-hosts.json.
+func ValidateHostName(name string) error {
+	if !hostNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid host name %q (letters, digits, '.', '_', and '-')", name)
+	}
+	return nil
+}
+
+// Location is a parsed remote source path:
+// sftp://[user@]host[:port]/path. The path is taken literally, so it may
+// contain glob patterns (*, ?, [...]) and braces; it is not URL-decoded.
+type Location struct {
+	Host string // Host name of remote-hosts.json
+	User string // User of the URL, empty for the user of the host
+	Port int    // Port of the URL, 0 for the port of the host
+	Path string // Absolute path on the host
+}
+
+// ParseURL parses a remote source path.
+func ParseURL(sourcePath string) (Location, error) {
+	rest, ok := strings.CutPrefix(sourcePath, Scheme+"://")
+	if !ok {
+		return Location{}, fmt.Errorf("remote source path must start with %s:// (got: %s)", Scheme, sourcePath)
+	}
+
+	slash := strings.IndexByte(rest, '/')
+	if slash < 0 || slash == len(rest)-1 {
+		return Location{}, fmt.Errorf("remote source path has no file path: %s", sourcePath)
+	}
+	authority, loc := rest[:slash], Location{Path: rest[slash:]}
+
+	if user, host, ok := strings.Cut(authority, "@"); ok {
+		if user == "" {
+			return Location{}, fmt.Errorf("remote source path has an empty user: %s", sourcePath)
+		}
+		loc.User, authority = user, host
+	}
+	if host, port, ok := strings.Cut(authority, ":"); ok {
+		n, err := strconv.Atoi(port)
+		if err != nil || n < 1 || n > 65535 {
+			return Location{}, fmt.Errorf("remote source path has an invalid port %q: %s", port, sourcePath)
+		}
+		loc.Port, authority = n, host
+	}
+	if err := ValidateHostName(authority); err != nil {
+		return Location{}, fmt.Errorf("remote source path %s: %w", sourcePath, err)
+	}
+	loc.Host = authority
+
+	return loc, nil
+}
+
+// String returns the remote source path of the location.
+func (l Location) String() string {
+	var sb strings.Builder
+	sb.WriteString(Scheme + "://")
+	if l.User != "" {
+		sb.WriteString(l.User + "@")
+	}
+	sb.WriteString(l.Host)
+	if l.Port != 0 {
+		sb.WriteString(":" + strconv.Itoa(l.Port))
+	}
+	sb.WriteString(l.Path)
+	return sb.String()
+}
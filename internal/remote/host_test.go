@@ -0,0 +1,77 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package remote
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		sourcePath string
+		want       Location
+		wantErr    string
+	}{
+		{"sftp://app01/var/log/syslog", Location{Host: "app01", Path: "/var/log/syslog"}, ""},
+		{"sftp://deploy@app01.example.com:2222/var/log/app.log.{3..1}.gz", Location{Host: "app01.example.com", User: "deploy", Port: 2222, Path: "/var/log/app.log.{3..1}.gz"}, ""},
+		{"sftp://app01/var/log/syslog.?#1", Location{Host: "app01", Path: "/var/log/syslog.?#1"}, ""},
+		{"/var/log/syslog", Location{}, "must start with sftp://"},
+		{"sftp://app01", Location{}, "no file path"},
+		{"sftp://app01/", Location{}, "no file path"},
+		{"sftp://@app01/var/log/syslog", Location{}, "empty user"},
+		{"sftp://app01:ssh/var/log/syslog", Location{}, "invalid port"},
+		{"sftp://app 01/var/log/syslog", Location{}, "invalid host name"},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseURL(tt.sourcePath)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseURL(%q) error = %v, want %q", tt.sourcePath, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseURL(%q) error = %v", tt.sourcePath, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseURL(%q) = %+v, want %+v", tt.sourcePath, got, tt.want)
+		}
+		if got.String() != tt.sourcePath {
+			t.Errorf("ParseURL(%q).String() = %q", tt.sourcePath, got.String())
+		}
+	}
+}
+
+func TestHost_Validate(t *testing.T) {
+	t.Parallel()
+
+	valid := Host{User: "logreader", IdentityFile: "/opt/logwatch-ai/.ssh/id_ed25519"}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if got := valid.address("app01", 0); got != "app01:22" {
+		t.Errorf("address() = %q, want app01:22", got)
+	}
+
+	tests := []struct {
+		name string
+		host Host
+		want string
+	}{
+		{"missing user", Host{IdentityFile: "/k"}, "user is required"},
+		{"missing identity file", Host{User: "u"}, "identity_file is required"},
+		{"invalid port", Host{User: "u", IdentityFile: "/k", Port: 70000}, "port"},
+		{"negative timeout", Host{User: "u", IdentityFile: "/k", TimeoutSeconds: -1}, "timeout_seconds"},
+	}
+	for _, tt := range tests {
+		if err := tt.host.Validate(); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: Validate() error = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
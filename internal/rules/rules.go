@@ -0,0 +1,509 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package rules implements a deterministic rule engine that runs alongside
+// the LLM analysis.
+//
+// Some conditions must always be reported regardless of how the model
+// judges them: an OOM kill, hundreds of SSH failures, a Drupal emergency.
+// Rules are loaded from rules.json and evaluated directly against the
+// reader output (and, for Drupal, the parsed watchdog entries) before the
+// LLM is called. Matches are then injected into the analysis as guaranteed
+// findings and raise `systemStatus` to at least the rule's floor. When the
+// LLM is unreachable, the matches are applied to the degraded report built
+// from reader statistics, so alerts still go out.
+//
+// Patterns use Go's RE2 syntax, which guarantees linear-time matching, so
+// the rule file is not a ReDoS vector even for large inputs.
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/olegiv/logwatch-ai-go/internal/ai"
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+	"github.com/olegiv/logwatch-ai-go/internal/drupal"
+)
+
+// maxConfigFileSize caps the size of rules.json read from disk. The file is
+// operator-authored and should be tiny; 1 MiB is far beyond any realistic
+// use.
+const maxConfigFileSize = 1 << 20 // 1 MiB
+
+// supportedVersions lists the rules.json schema versions this build
+// understands.
+var supportedVersions = []string{"1.0"}
+
+// maxRules caps the number of rules in a single file.
+const maxRules = 100
+
+// maxPatternLength caps the byte length of a single regular expression.
+const maxPatternLength = 1000
+
+// maxFindingRunes caps the rune length of the finding text of a rule.
+const maxFindingRunes = 300
+
+// countGroup is the name of the optional capture group whose numeric value
+// is summed instead of counting matching lines.
+const countGroup = "count"
+
+// Severity levels of a rule finding.
+const (
+	SeverityCritical = "critical"
+	SeverityWarning  = "warning"
+)
+
+// Config represents the parsed rules.json file.
+type Config struct {
+	Version string `json:"version"`
+	Rules   []Rule `json:"rules"`
+}
+
+// Rule is a single deterministic condition.
+//
+// A rule matches when its count reaches MinCount. With Pattern set, the
+// count is the number of reader output lines matching the pattern, or the
+// sum of the named group "count" when the pattern defines one. With Drupal
+// set, the count is the number of watchdog entries matching the condition.
+// A rule sets either Pattern or Drupal, not both.
+type Rule struct {
+	// Name identifies the rule in findings and logs
+	Name string `json:"name"`
+
+	// Sources limits the rule to log source types (empty = all sources)
+	Sources []string `json:"sources,omitempty"`
+
+	// Sites limits the rule to site IDs (empty = all sites)
+	Sites []string `json:"sites,omitempty"`
+
+	// Pattern is an RE2 regular expression matched against each line of
+	// the reader output
+	Pattern string `json:"pattern,omitempty"`
+
+	// Drupal matches parsed watchdog entries (drupal_watchdog only)
+	Drupal *DrupalCondition `json:"drupal,omitempty"`
+
+	// MinCount is the threshold that triggers the rule (default 1)
+	MinCount int `json:"min_count,omitempty"`
+
+	// Severity places the finding in criticalIssues or warnings
+	Severity string `json:"severity"`
+
+	// StatusFloor is the minimum systemStatus when the rule matches
+	StatusFloor string `json:"status_floor,omitempty"`
+
+	// Finding is the text of the injected finding (default: rule name)
+	Finding string `json:"finding,omitempty"`
+
+	pattern        *regexp.Regexp
+	messagePattern *regexp.Regexp
+}
+
+// DrupalCondition matches Drupal watchdog entries. All set fields must
+// match for an entry to count.
+type DrupalCondition struct {
+	// MaxSeverity matches entries at or above this RFC 5424 severity
+	// (numerically lower or equal, 0 = emergency)
+	MaxSeverity *int `json:"max_severity,omitempty"`
+
+	// Types matches entries of one of these types (case-insensitive)
+	Types []string `json:"types,omitempty"`
+
+	// MessagePattern is an RE2 regular expression matched against the
+	// entry message, both the template and the message with placeholders
+	// substituted
+	MessagePattern string `json:"message_pattern,omitempty"`
+}
+
+// Input is the data rules are evaluated against. Drupal rules count
+// DrupalTally when set, DrupalEntries otherwise.
+type Input struct {
+	SourceType    string
+	SiteID        string
+	Content       string
+	DrupalEntries []drupal.WatchdogEntry
+	DrupalTally   *DrupalTally
+}
+
+// Match is a rule that triggered during evaluation.
+type Match struct {
+	Rule        string
+	Severity    string
+	StatusFloor string
+	Count       int
+	Finding     string
+}
+
+// Validate checks the configuration and compiles all patterns. It is called
+// by Load and must be called on hand-constructed Config values before
+// Evaluate.
+func (c *Config) Validate() error {
+	version := strings.TrimSpace(c.Version)
+	if version == "" {
+		return fmt.Errorf("version is required")
+	}
+	if !slices.Contains(supportedVersions, version) {
+		return fmt.Errorf("unsupported version %q: this build supports %v", c.Version, supportedVersions)
+	}
+	if len(c.Rules) > maxRules {
+		return fmt.Errorf("too many rules (%d); maximum allowed is %d", len(c.Rules), maxRules)
+	}
+
+	seen := make(map[string]struct{}, len(c.Rules))
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("rules[%d]: %w", i, err)
+		}
+		if _, dup := seen[rule.Name]; dup {
+			return fmt.Errorf("rules[%d]: duplicate rule name %q", i, rule.Name)
+		}
+		seen[rule.Name] = struct{}{}
+	}
+
+	return nil
+}
+
+func (r *Rule) validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	for _, source := range r.Sources {
+		if _, err := analyzer.ParseSourceType(source); err != nil {
+			return fmt.Errorf("rule %q: %w", r.Name, err)
+		}
+	}
+	for _, site := range r.Sites {
+		if strings.TrimSpace(site) == "" {
+			return fmt.Errorf("rule %q: empty site ID", r.Name)
+		}
+	}
+
+	switch {
+	case r.Pattern == "" && r.Drupal == nil:
+		return fmt.Errorf("rule %q: either pattern or drupal is required", r.Name)
+	case r.Pattern != "" && r.Drupal != nil:
+		return fmt.Errorf("rule %q: pattern and drupal are mutually exclusive", r.Name)
+	}
+
+	if r.Pattern != "" {
+		re, err := compilePattern(r.Pattern)
+		if err != nil {
+			return fmt.Errorf("rule %q: pattern: %w", r.Name, err)
+		}
+		r.pattern = re
+	}
+
+	if r.Drupal != nil {
+		if err := r.validateDrupal(); err != nil {
+			return fmt.Errorf("rule %q: drupal: %w", r.Name, err)
+		}
+	}
+
+	if r.MinCount < 0 {
+		return fmt.Errorf("rule %q: min_count must be positive", r.Name)
+	}
+	if r.MinCount == 0 {
+		r.MinCount = 1
+	}
+
+	switch r.Severity {
+	case SeverityCritical, SeverityWarning:
+	default:
+		return fmt.Errorf("rule %q: severity must be %q or %q", r.Name, SeverityCritical, SeverityWarning)
+	}
+
+	if r.StatusFloor != "" && ai.StatusRank(r.StatusFloor) < 0 {
+		return fmt.Errorf("rule %q: invalid status_floor %q", r.Name, r.StatusFloor)
+	}
+
+	r.Finding = strings.Join(strings.Fields(r.Finding), " ")
+	if r.Finding == "" {
+		r.Finding = r.Name
+	}
+	if runes := []rune(r.Finding); len(runes) > maxFindingRunes {
+		return fmt.Errorf("rule %q: finding too long (%d characters, max %d)", r.Name, len(runes), maxFindingRunes)
+	}
+
+	return nil
+}
+
+func (r *Rule) validateDrupal() error {
+	cond := r.Drupal
+	if cond.MaxSeverity == nil && len(cond.Types) == 0 && cond.MessagePattern == "" {
+		return fmt.Errorf("at least one of max_severity, types, message_pattern is required")
+	}
+	if cond.MaxSeverity != nil && (*cond.MaxSeverity < drupal.SeverityEmergency || *cond.MaxSeverity > drupal.SeverityDebug) {
+		return fmt.Errorf("max_severity must be between %d and %d", drupal.SeverityEmergency, drupal.SeverityDebug)
+	}
+	if cond.MessagePattern != "" {
+		re, err := compilePattern(cond.MessagePattern)
+		if err != nil {
+			return fmt.Errorf("message_pattern: %w", err)
+		}
+		r.messagePattern = re
+	}
+	return nil
+}
+
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if len(pattern) > maxPatternLength {
+		return nil, fmt.Errorf("too long (%d bytes, max %d)", len(pattern), maxPatternLength)
+	}
+	return regexp.Compile(pattern)
+}
+
+// Evaluate runs every applicable rule against the input and returns the
+// rules that reached their threshold, in file order.
+func (c *Config) Evaluate(in Input) []Match {
+	if c == nil || len(c.Rules) == 0 {
+		return nil
+	}
+
+	var lines []string
+	var matches []Match
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if !rule.applies(in) {
+			continue
+		}
+
+		var count int
+		switch {
+		case rule.pattern != nil:
+			if lines == nil {
+				lines = strings.Split(in.Content, "\n")
+			}
+			count = rule.countLines(lines)
+		case rule.Drupal != nil && in.DrupalTally != nil:
+			count = in.DrupalTally.count(rule)
+		case rule.Drupal != nil:
+			count = rule.countEntries(in.DrupalEntries)
+		}
+
+		if count < rule.MinCount {
+			continue
+		}
+		matches = append(matches, Match{
+			Rule:        rule.Name,
+			Severity:    rule.Severity,
+			StatusFloor: rule.StatusFloor,
+			Count:       count,
+			Finding:     fmt.Sprintf("%s (count: %d, rule: %s)", rule.Finding, count, rule.Name),
+		})
+	}
+
+	return matches
+}
+
+// applies reports whether the rule is scoped to the input's source and site.
+func (r *Rule) applies(in Input) bool {
+	if len(r.Sources) > 0 && !slices.Contains(r.Sources, in.SourceType) {
+		return false
+	}
+	if len(r.Sites) > 0 && !slices.Contains(r.Sites, in.SiteID) {
+		return false
+	}
+	// Drupal conditions only make sense when entries were parsed
+	if r.Drupal != nil && in.SourceType != string(analyzer.LogSourceDrupalWatchdog) {
+		return false
+	}
+	return true
+}
+
+func (r *Rule) countLines(lines []string) int {
+	groupIndex := r.pattern.SubexpIndex(countGroup)
+
+	count := 0
+	for _, line := range lines {
+		if groupIndex < 0 {
+			if r.pattern.MatchString(line) {
+				count++
+			}
+			continue
+		}
+		for _, m := range r.pattern.FindAllStringSubmatch(line, -1) {
+			n, err := strconv.Atoi(m[groupIndex])
+			if err != nil || n < 0 {
+				// Non-numeric capture: count the occurrence itself
+				n = 1
+			}
+			count += n
+		}
+	}
+	return count
+}
+
+func (r *Rule) countEntries(entries []drupal.WatchdogEntry) int {
+	count := 0
+	for i := range entries {
+		if r.matchesEntry(&entries[i]) {
+			count++
+		}
+	}
+	return count
+}
+
+// matchesEntry reports whether a watchdog entry meets the Drupal condition.
+func (r *Rule) matchesEntry(entry *drupal.WatchdogEntry) bool {
+	cond := r.Drupal
+	if cond.MaxSeverity != nil && entry.Severity > *cond.MaxSeverity {
+		return false
+	}
+	if len(cond.Types) > 0 && !slices.ContainsFunc(cond.Types, func(t string) bool {
+		return strings.EqualFold(t, entry.Type)
+	}) {
+		return false
+	}
+	if r.messagePattern != nil && !r.messagePattern.MatchString(entry.Message) &&
+		!r.messagePattern.MatchString(entry.RenderedMessage()) {
+		return false
+	}
+	return true
+}
+
+// DrupalTally counts the watchdog entries matching each Drupal rule while
+// a reader parses them, so the rules are evaluated without retaining the
+// entries of a large export.
+type DrupalTally struct {
+	counts map[*Rule]int
+}
+
+// NewDrupalTally returns a tally for the Drupal rules of the configuration.
+// Pass its Add method to drupal.Reader.SetEntryObserver and the tally to
+// Evaluate as Input.DrupalTally.
+func (c *Config) NewDrupalTally() *DrupalTally {
+	tally := &DrupalTally{counts: make(map[*Rule]int)}
+	if c == nil {
+		return tally
+	}
+	for i := range c.Rules {
+		if rule := &c.Rules[i]; rule.Drupal != nil {
+			tally.counts[rule] = 0
+		}
+	}
+	return tally
+}
+
+// Add counts an entry for every Drupal rule it matches.
+func (t *DrupalTally) Add(entry *drupal.WatchdogEntry) {
+	for rule, count := range t.counts {
+		if rule.matchesEntry(entry) {
+			t.counts[rule] = count + 1
+		}
+	}
+}
+
+// count returns the entries counted for a rule.
+func (t *DrupalTally) count(rule *Rule) int {
+	return t.counts[rule]
+}
+
+// Apply injects rule matches into an LLM analysis as guaranteed findings
+// and raises the system status to the highest matched floor. Findings that
+// the analysis already contains verbatim are not duplicated.
+func Apply(analysis *ai.Analysis, matches []Match) {
+	if analysis == nil || len(matches) == 0 {
+		return
+	}
+
+	var critical, warnings []string
+	for _, m := range matches {
+		switch m.Severity {
+		case SeverityCritical:
+			if !slices.Contains(analysis.CriticalIssues, m.Finding) {
+				critical = append(critical, m.Finding)
+			}
+		default:
+			if !slices.Contains(analysis.Warnings, m.Finding) {
+				warnings = append(warnings, m.Finding)
+			}
+		}
+	}
+	// Rule findings go first: they are the ones the operator asked for
+	analysis.CriticalIssues = append(critical, analysis.CriticalIssues...)
+	analysis.Warnings = append(warnings, analysis.Warnings...)
+
+	if floor := StatusFloor(matches); ai.StatusRank(floor) > ai.StatusRank(analysis.SystemStatus) {
+		analysis.SystemStatus = floor
+	}
+}
+
+// StatusFloor returns the highest status floor among the matches, or an
+// empty string when no match defines one.
+func StatusFloor(matches []Match) string {
+	floor := ""
+	for _, m := range matches {
+		if ai.StatusRank(m.StatusFloor) > ai.StatusRank(floor) {
+			floor = m.StatusFloor
+		}
+	}
+	return floor
+}
+
+// Load reads and parses rules.json.
+//
+// If explicitPath is non-empty, only that path is tried and a missing file
+// is an error. Otherwise the standard search paths are tried in priority
+// order; if none exist, Load returns (nil, "", nil) so the caller can
+// treat the feature as optional.
+func Load(explicitPath string) (*Config, string, error) {
+	for _, path := range buildSearchPaths(explicitPath) {
+		info, err := os.Stat(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, "", fmt.Errorf("failed to stat %s: %w", path, err)
+		}
+		if info.Size() > maxConfigFileSize {
+			return nil, "", fmt.Errorf("rules config %s too large: %d bytes (max %d)", path, info.Size(), maxConfigFileSize)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		var cfg Config
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return nil, "", fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return nil, "", fmt.Errorf("invalid rules config in %s: %w", path, err)
+		}
+
+		return &cfg, path, nil
+	}
+
+	if explicitPath != "" {
+		return nil, "", fmt.Errorf("rules config not found: %s", explicitPath)
+	}
+
+	return nil, "", nil
+}
+
+func buildSearchPaths(explicitPath string) []string {
+	if explicitPath != "" {
+		return []string{explicitPath}
+	}
+	paths := []string{
+		"./rules.json",
+		"./configs/rules.json",
+		"/opt/logwatch-ai/rules.json",
+	}
+	if home := os.Getenv("HOME"); home != "" {
+		paths = append(paths, filepath.Join(home, ".config", "logwatch-ai", "rules.json"))
+	}
+	return paths
+}
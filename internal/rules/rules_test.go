@@ -0,0 +1,353 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/olegiv/logwatch-ai-go/internal/ai"
+	"github.com/olegiv/logwatch-ai-go/internal/drupal"
+)
+
+func intPtr(v int) *int { return &v }
+
+func mustConfig(t *testing.T, rules ...Rule) *Config {
+	t.Helper()
+	cfg := &Config{Version: "1.0", Rules: rules}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	return cfg
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr string
+	}{
+		{
+			name:    "missing version",
+			cfg:     Config{},
+			wantErr: "version is required",
+		},
+		{
+			name:    "unsupported version",
+			cfg:     Config{Version: "9.9"},
+			wantErr: "unsupported version",
+		},
+		{
+			name:    "missing name",
+			cfg:     Config{Version: "1.0", Rules: []Rule{{Pattern: "x", Severity: SeverityWarning}}},
+			wantErr: "name is required",
+		},
+		{
+			name:    "no condition",
+			cfg:     Config{Version: "1.0", Rules: []Rule{{Name: "r", Severity: SeverityWarning}}},
+			wantErr: "either pattern or drupal is required",
+		},
+		{
+			name: "pattern and drupal",
+			cfg: Config{Version: "1.0", Rules: []Rule{{
+				Name: "r", Pattern: "x", Drupal: &DrupalCondition{MaxSeverity: intPtr(2)}, Severity: SeverityWarning,
+			}}},
+			wantErr: "mutually exclusive",
+		},
+		{
+			name:    "invalid regex",
+			cfg:     Config{Version: "1.0", Rules: []Rule{{Name: "r", Pattern: "(", Severity: SeverityWarning}}},
+			wantErr: "pattern",
+		},
+		{
+			name:    "backreference is not RE2",
+			cfg:     Config{Version: "1.0", Rules: []Rule{{Name: "r", Pattern: `(a)\1`, Severity: SeverityWarning}}},
+			wantErr: "pattern",
+		},
+		{
+			name:    "pattern too long",
+			cfg:     Config{Version: "1.0", Rules: []Rule{{Name: "r", Pattern: strings.Repeat("a", maxPatternLength+1), Severity: SeverityWarning}}},
+			wantErr: "too long",
+		},
+		{
+			name:    "invalid severity",
+			cfg:     Config{Version: "1.0", Rules: []Rule{{Name: "r", Pattern: "x", Severity: "high"}}},
+			wantErr: "severity must be",
+		},
+		{
+			name:    "invalid status floor",
+			cfg:     Config{Version: "1.0", Rules: []Rule{{Name: "r", Pattern: "x", Severity: SeverityWarning, StatusFloor: "Critical"}}},
+			wantErr: "invalid status_floor",
+		},
+		{
+			name:    "invalid source",
+			cfg:     Config{Version: "1.0", Rules: []Rule{{Name: "r", Pattern: "x", Severity: SeverityWarning, Sources: []string{"nginx"}}}},
+			wantErr: "invalid log source type",
+		},
+		{
+			name:    "negative min_count",
+			cfg:     Config{Version: "1.0", Rules: []Rule{{Name: "r", Pattern: "x", Severity: SeverityWarning, MinCount: -1}}},
+			wantErr: "min_count",
+		},
+		{
+			name: "empty drupal condition",
+			cfg: Config{Version: "1.0", Rules: []Rule{{
+				Name: "r", Drupal: &DrupalCondition{}, Severity: SeverityWarning,
+			}}},
+			wantErr: "at least one of",
+		},
+		{
+			name: "drupal severity out of range",
+			cfg: Config{Version: "1.0", Rules: []Rule{{
+				Name: "r", Drupal: &DrupalCondition{MaxSeverity: intPtr(8)}, Severity: SeverityWarning,
+			}}},
+			wantErr: "max_severity",
+		},
+		{
+			name: "duplicate names",
+			cfg: Config{Version: "1.0", Rules: []Rule{
+				{Name: "r", Pattern: "x", Severity: SeverityWarning},
+				{Name: "r", Pattern: "y", Severity: SeverityWarning},
+			}},
+			wantErr: "duplicate rule name",
+		},
+		{
+			name: "valid",
+			cfg: Config{Version: "1.0", Rules: []Rule{
+				{Name: "oom", Pattern: "Out of memory", Severity: SeverityCritical, StatusFloor: "Bad"},
+				{Name: "drupal", Drupal: &DrupalCondition{MaxSeverity: intPtr(2), Types: []string{"php"}}, Severity: SeverityCritical},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_Defaults(t *testing.T) {
+	cfg := mustConfig(t, Rule{Name: " oom ", Pattern: "x", Severity: SeverityWarning})
+	rule := cfg.Rules[0]
+	if rule.Name != "oom" || rule.MinCount != 1 || rule.Finding != "oom" {
+		t.Errorf("defaults not applied: %+v", rule)
+	}
+}
+
+func TestEvaluate_Pattern(t *testing.T) {
+	content := strings.Join([]string{
+		"kernel: Out of memory: Killed process 1234 (php-fpm)",
+		"sshd: Failed password for root from 203.0.113.5",
+		"kernel: Out of memory: Killed process 5678 (mysqld)",
+	}, "\n")
+
+	cfg := mustConfig(t,
+		Rule{Name: "oom", Pattern: `Out of memory: Killed process`, Severity: SeverityCritical, Finding: "OOM killer terminated processes"},
+		Rule{Name: "oom-threshold", Pattern: `Out of memory`, MinCount: 3, Severity: SeverityCritical},
+		Rule{Name: "ssh", Pattern: `Failed password`, Severity: SeverityWarning},
+	)
+
+	matches := cfg.Evaluate(Input{SourceType: "logwatch", Content: content})
+	if len(matches) != 2 {
+		t.Fatalf("got %d matches, want 2: %+v", len(matches), matches)
+	}
+	if matches[0].Rule != "oom" || matches[0].Count != 2 {
+		t.Errorf("unexpected first match: %+v", matches[0])
+	}
+	if matches[0].Finding != "OOM killer terminated processes (count: 2, rule: oom)" {
+		t.Errorf("Finding = %q", matches[0].Finding)
+	}
+	if matches[1].Rule != "ssh" || matches[1].Count != 1 {
+		t.Errorf("unexpected second match: %+v", matches[1])
+	}
+}
+
+func TestEvaluate_CountGroup(t *testing.T) {
+	// Logwatch summarizes repeated events as "<line>: N Time(s)"
+	content := strings.Join([]string{
+		" --------------------- SSHD Begin ------------------------",
+		" Failed logins from:",
+		"    203.0.113.5: 420 Time(s)",
+		"    198.51.100.7: 95 Time(s)",
+		"    192.0.2.1: abc Time(s)",
+	}, "\n")
+
+	rule := Rule{
+		Name:     "ssh-brute-force",
+		Pattern:  `^\s+[0-9a-fA-F.:]+: (?P<count>\S+) Time\(s\)`,
+		MinCount: 501,
+		Severity: SeverityCritical,
+	}
+
+	matches := mustConfig(t, rule).Evaluate(Input{SourceType: "logwatch", Content: content})
+	if len(matches) != 1 || matches[0].Count != 516 {
+		t.Fatalf("matches = %+v, want one match with count 516", matches)
+	}
+
+	rule.MinCount = 600
+	if matches := mustConfig(t, rule).Evaluate(Input{SourceType: "logwatch", Content: content}); len(matches) != 0 {
+		t.Errorf("expected no match below threshold, got %+v", matches)
+	}
+}
+
+func TestEvaluate_Scope(t *testing.T) {
+	cfg := mustConfig(t,
+		Rule{Name: "ocms-only", Sources: []string{"ocms"}, Pattern: "panic", Severity: SeverityCritical},
+		Rule{Name: "site-a", Sites: []string{"a"}, Pattern: "panic", Severity: SeverityCritical},
+	)
+
+	if got := cfg.Evaluate(Input{SourceType: "logwatch", SiteID: "b", Content: "panic"}); len(got) != 0 {
+		t.Errorf("expected no matches, got %+v", got)
+	}
+	got := cfg.Evaluate(Input{SourceType: "ocms", SiteID: "a", Content: "panic"})
+	if len(got) != 2 {
+		t.Errorf("expected 2 matches, got %+v", got)
+	}
+}
+
+func TestEvaluate_Drupal(t *testing.T) {
+	entries := []drupal.WatchdogEntry{
+		{Type: "php", Severity: drupal.SeverityCritical, Message: "Uncaught PDOException: SQLSTATE[HY000]"},
+		{Type: "PHP", Severity: drupal.SeverityError, Message: "Notice: Undefined index"},
+		{Type: "cron", Severity: drupal.SeverityEmergency, Message: "Cron run exceeded the time limit"},
+		{Type: "access denied", Severity: drupal.SeverityWarning, Message: "/admin"},
+		{Type: "php", Severity: drupal.SeverityError, Message: "%type: @message",
+			Variables: `a:2:{s:5:"%type";s:12:"PDOException";s:8:"@message";s:7:"refused";}`},
+	}
+
+	cfg := mustConfig(t,
+		Rule{Name: "drupal-critical", Drupal: &DrupalCondition{MaxSeverity: intPtr(drupal.SeverityCritical)}, Severity: SeverityCritical, StatusFloor: "Bad"},
+		Rule{Name: "php-errors", Drupal: &DrupalCondition{MaxSeverity: intPtr(drupal.SeverityError), Types: []string{"php"}}, Severity: SeverityWarning},
+		Rule{Name: "pdo", Drupal: &DrupalCondition{MessagePattern: `PDOException`}, Severity: SeverityCritical},
+	)
+
+	matches := cfg.Evaluate(Input{SourceType: "drupal_watchdog", DrupalEntries: entries})
+	counts := map[string]int{}
+	for _, m := range matches {
+		counts[m.Rule] = m.Count
+	}
+	want := map[string]int{"drupal-critical": 2, "php-errors": 3, "pdo": 2}
+	for rule, n := range want {
+		if counts[rule] != n {
+			t.Errorf("rule %s count = %d, want %d", rule, counts[rule], n)
+		}
+	}
+
+	// A tally fed while the entries are parsed gives the same counts
+	tally := cfg.NewDrupalTally()
+	for i := range entries {
+		tally.Add(&entries[i])
+	}
+	for _, m := range cfg.Evaluate(Input{SourceType: "drupal_watchdog", DrupalTally: tally}) {
+		if m.Count != want[m.Rule] {
+			t.Errorf("tally rule %s count = %d, want %d", m.Rule, m.Count, want[m.Rule])
+		}
+	}
+
+	// Drupal conditions never apply to other sources
+	if got := cfg.Evaluate(Input{SourceType: "logwatch", DrupalEntries: entries}); len(got) != 0 {
+		t.Errorf("expected no matches for logwatch, got %+v", got)
+	}
+}
+
+func TestEvaluate_NilConfig(t *testing.T) {
+	var cfg *Config
+	if got := cfg.Evaluate(Input{Content: "anything"}); got != nil {
+		t.Errorf("Evaluate() on nil config = %+v", got)
+	}
+}
+
+func TestApply(t *testing.T) {
+	analysis := &ai.Analysis{
+		SystemStatus:   "Good",
+		CriticalIssues: []string{"Disk almost full"},
+		Warnings:       []string{"dup (count: 1, rule: w)"},
+	}
+	matches := []Match{
+		{Rule: "oom", Severity: SeverityCritical, StatusFloor: "Bad", Count: 2, Finding: "OOM (count: 2, rule: oom)"},
+		{Rule: "w", Severity: SeverityWarning, Count: 1, Finding: "dup (count: 1, rule: w)"},
+		{Rule: "x", Severity: SeverityWarning, StatusFloor: "Satisfactory", Count: 1, Finding: "x (count: 1, rule: x)"},
+	}
+
+	Apply(analysis, matches)
+
+	if analysis.SystemStatus != "Bad" {
+		t.Errorf("SystemStatus = %q, want Bad", analysis.SystemStatus)
+	}
+	if len(analysis.CriticalIssues) != 2 || analysis.CriticalIssues[0] != "OOM (count: 2, rule: oom)" {
+		t.Errorf("CriticalIssues = %v", analysis.CriticalIssues)
+	}
+	if len(analysis.Warnings) != 2 || analysis.Warnings[0] != "x (count: 1, rule: x)" {
+		t.Errorf("Warnings = %v", analysis.Warnings)
+	}
+
+	// A worse LLM status is never lowered
+	awful := &ai.Analysis{SystemStatus: "Awful"}
+	Apply(awful, matches)
+	if awful.SystemStatus != "Awful" {
+		t.Errorf("SystemStatus = %q, want Awful", awful.SystemStatus)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	t.Run("valid file", func(t *testing.T) {
+		path := filepath.Join(tmpDir, "rules.json")
+		content := `{"version":"1.0","rules":[{"name":"oom","pattern":"Out of memory","severity":"critical","status_floor":"Bad"}]}`
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		cfg, found, err := Load(path)
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if found != path || len(cfg.Rules) != 1 || cfg.Rules[0].pattern == nil {
+			t.Errorf("Load() = %+v, %q", cfg, found)
+		}
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		path := filepath.Join(tmpDir, "bad.json")
+		if err := os.WriteFile(path, []byte(`{"version":"1.0","rules":[{"name":"r","pattern":"(","severity":"critical"}]}`), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := Load(path); err == nil || !strings.Contains(err.Error(), "invalid rules config") {
+			t.Errorf("Load() error = %v", err)
+		}
+	})
+
+	t.Run("explicit missing path", func(t *testing.T) {
+		if _, _, err := Load(filepath.Join(tmpDir, "missing.json")); err == nil {
+			t.Error("expected error for missing explicit path")
+		}
+	})
+
+	t.Run("example config", func(t *testing.T) {
+		if _, _, err := Load(filepath.Join("..", "..", "configs", "rules.json.example")); err != nil {
+			t.Errorf("Load(example) error = %v", err)
+		}
+	})
+
+	t.Run("no discoverable file", func(t *testing.T) {
+		t.Setenv("HOME", "/nonexistent-home-for-rules-test")
+		t.Chdir(t.TempDir())
+		cfg, found, err := Load("")
+		if err != nil || cfg != nil || found != "" {
+			t.Errorf("Load(\"\") = %+v, %q, %v", cfg, found, err)
+		}
+	})
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package sourcecmd runs the command of a command-based log source, such as
+// `drush watchdog:show --format=json` or `logwatch --output stdout`, and
+// captures its stdout so the matching reader can analyze it directly
+// instead of an export file written by a script.
+package sourcecmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultEnv lists the environment variables passed to a source command
+// when no allow-list is configured. Everything else, including API keys
+// and bot tokens of the analyzer, stays hidden from the command.
+var DefaultEnv = []string{"PATH", "HOME", "LANG", "LC_ALL", "TZ"}
+
+// DefaultTimeout is the time a source command may run when none is configured.
+const DefaultTimeout = 5 * time.Minute
+
+// Command describes a program whose stdout is analyzed instead of a log file.
+type Command struct {
+	Args    []string      // program and arguments; no shell is involved
+	Dir     string        // working directory, empty for the current one
+	Timeout time.Duration // the process group is killed when it runs longer
+	Env     []string      // names of environment variables passed to the process
+	User    string        // optional user name or UID to run as (requires root)
+}
+
+// Validate checks the command for configuration errors.
+func (c *Command) Validate() error {
+	if len(c.Args) == 0 || c.Args[0] == "" {
+		return fmt.Errorf("command is empty")
+	}
+	if c.Timeout <= 0 {
+		return fmt.Errorf("timeout must be positive (got: %s)", c.Timeout)
+	}
+	for _, name := range c.Env {
+		if name == "" || strings.ContainsAny(name, "= ") {
+			return fmt.Errorf("invalid environment variable name %q", name)
+		}
+	}
+	return nil
+}
+
+// String returns the command line for logs, quoting arguments as needed.
+func (c *Command) String() string {
+	quoted := make([]string, len(c.Args))
+	for i, arg := range c.Args {
+		quoted[i] = quoteArg(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// quoteArg single-quotes an argument that ParseArgs would otherwise split
+// or unescape.
+func quoteArg(arg string) string {
+	if arg != "" && !strings.ContainsAny(arg, " \t\n'\"\\") {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// ParseArgs splits a command line into arguments the way a POSIX shell
+// would, without expanding variables, globs, or other shell syntax:
+// whitespace separates arguments, single quotes preserve everything,
+// double quotes allow backslash escapes of `"` and `\`, and a backslash
+// outside quotes escapes the next character.
+func ParseArgs(s string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		inArg   bool
+		quote   rune
+		escaped bool
+	)
+
+	for _, ch := range s {
+		switch {
+		case escaped:
+			if quote == '"' && ch != '"' && ch != '\\' {
+				current.WriteRune('\\')
+			}
+			current.WriteRune(ch)
+			escaped = false
+		case quote == '\'':
+			if ch == '\'' {
+				quote = 0
+			} else {
+				current.WriteRune(ch)
+			}
+		case ch == '\\':
+			escaped = true
+			inArg = true
+		case quote == '"':
+			if ch == '"' {
+				quote = 0
+			} else {
+				current.WriteRune(ch)
+			}
+		case ch == '\'' || ch == '"':
+			quote = ch
+			inArg = true
+		case ch == ' ' || ch == '\t' || ch == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(ch)
+			inArg = true
+		}
+	}
+
+	if escaped {
+		return nil, fmt.Errorf("command ends with an unfinished escape")
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("command has an unterminated %c quote", quote)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("command is empty")
+	}
+	return args, nil
+}
+
+// ParseEnvList parses a comma-separated list of environment variable names.
+// An empty list returns DefaultEnv.
+func ParseEnvList(s string) []string {
+	var names []string
+	for name := range strings.SplitSeq(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return append([]string(nil), DefaultEnv...)
+	}
+	return names
+}
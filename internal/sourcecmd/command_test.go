@@ -0,0 +1,90 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package sourcecmd
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"drush watchdog:show --format=json", []string{"drush", "watchdog:show", "--format=json"}},
+		{"  logwatch\t--output stdout \n", []string{"logwatch", "--output", "stdout"}},
+		{`journalctl --since 'yesterday 00:00' --until "today 00:00"`, []string{"journalctl", "--since", "yesterday 00:00", "--until", "today 00:00"}},
+		{`printf "%s \"x\" \\ \n" a\ b`, []string{"printf", `%s "x" \ \n`, "a b"}},
+		{`echo '' "" x`, []string{"echo", "", "", "x"}},
+		{`echo $HOME`, []string{"echo", "$HOME"}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseArgs(tt.input)
+		if err != nil {
+			t.Errorf("ParseArgs(%q) error = %v", tt.input, err)
+			continue
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("ParseArgs(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseArgs_Errors(t *testing.T) {
+	for input, want := range map[string]string{
+		"":                "empty",
+		"   ":             "empty",
+		`drush "unclosed`: "unterminated",
+		"logwatch 'x":     "unterminated",
+		`echo x\`:         "escape",
+	} {
+		if _, err := ParseArgs(input); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseArgs(%q) error = %v, want %q", input, err, want)
+		}
+	}
+}
+
+func TestCommand_String(t *testing.T) {
+	c := Command{Args: []string{"journalctl", "--since", "yesterday 00:00", "it's", ""}}
+	want := `journalctl --since 'yesterday 00:00' 'it'\''s' ''`
+	if got := c.String(); got != want {
+		t.Errorf("String() = %s, want %s", got, want)
+	}
+
+	// The quoted form parses back to the same arguments
+	parsed, err := ParseArgs(c.String())
+	if err != nil || !slices.Equal(parsed, c.Args) {
+		t.Errorf("ParseArgs(String()) = %q, %v", parsed, err)
+	}
+}
+
+func TestCommand_Validate(t *testing.T) {
+	valid := Command{Args: []string{"true"}, Timeout: time.Second, Env: DefaultEnv}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	for name, c := range map[string]Command{
+		"empty":    {Timeout: time.Second},
+		"timeout":  {Args: []string{"true"}},
+		"env name": {Args: []string{"true"}, Timeout: time.Second, Env: []string{"A=B"}},
+	} {
+		if err := c.Validate(); err == nil {
+			t.Errorf("Validate(%s) should fail", name)
+		}
+	}
+}
+
+func TestParseEnvList(t *testing.T) {
+	if got := ParseEnvList(" PATH, DRUSH_OPTIONS ,,"); !slices.Equal(got, []string{"PATH", "DRUSH_OPTIONS"}) {
+		t.Errorf("ParseEnvList() = %q", got)
+	}
+	if got := ParseEnvList(""); !slices.Equal(got, DefaultEnv) {
+		t.Errorf("ParseEnvList(empty) = %q, want DefaultEnv", got)
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package sourcecmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"strings"
+	"time"
+)
+
+// maxStderrBytes is how much of the end of stderr is kept for error messages.
+const maxStderrBytes = 4096
+
+// waitDelay is how long Run waits for the output pipes to close after the
+// process exited or was killed, in case a child process still holds them.
+const waitDelay = 5 * time.Second
+
+// Result is the captured output of a successful source command.
+type Result struct {
+	Output   string
+	Started  time.Time
+	Duration time.Duration
+}
+
+// SourceInfo returns metadata about the output with the keys of
+// analyzer.LogReader.GetSourceInfo: size_bytes, size_mb, modified, and
+// age_hours, plus the duration of the run.
+func (r *Result) SourceInfo() map[string]any {
+	finished := r.Started.Add(r.Duration)
+	return map[string]any{
+		"size_bytes":       int64(len(r.Output)),
+		"size_mb":          float64(len(r.Output)) / 1024 / 1024,
+		"modified":         finished,
+		"age_hours":        time.Since(finished).Hours(),
+		"duration_seconds": r.Duration.Seconds(),
+	}
+}
+
+// Run executes the command and returns its stdout. The command fails if
+// it exits with a non-zero status, runs longer than its timeout, or writes
+// more than maxBytes to stdout; errors include the end of its stderr.
+func Run(ctx context.Context, c Command, maxBytes int64) (*Result, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, c.Args[0], c.Args[1:]...)
+	cmd.Dir = c.Dir
+	cmd.WaitDelay = waitDelay
+
+	runAs, err := configureProcess(cmd, c.User)
+	if err != nil {
+		return nil, err
+	}
+	cmd.Env = environment(c.Env, runAs)
+
+	stdout := &limitedBuffer{max: maxBytes}
+	stderr := &tailBuffer{max: maxStderrBytes}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	started := time.Now()
+	err = cmd.Run()
+	duration := time.Since(started)
+
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, fmt.Errorf("source command timed out after %s%s", c.Timeout, stderr.suffix())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("source command failed: %w%s", err, stderr.suffix())
+	}
+	if stdout.exceeded {
+		return nil, fmt.Errorf("source command output exceeds maximum size of %dMB", maxBytes/1024/1024)
+	}
+
+	return &Result{
+		Output:   stdout.String(),
+		Started:  started,
+		Duration: duration,
+	}, nil
+}
+
+// environment builds the environment of the command from the allow-listed
+// variables of the analyzer. When the command runs as another user, HOME,
+// USER, and LOGNAME describe that user instead.
+func environment(names []string, runAs *user.User) []string {
+	env := make([]string, 0, len(names))
+	for _, name := range names {
+		value, ok := os.LookupEnv(name)
+		if runAs != nil {
+			switch name {
+			case "HOME":
+				value, ok = runAs.HomeDir, true
+			case "USER", "LOGNAME":
+				value, ok = runAs.Username, true
+			}
+		}
+		if ok {
+			env = append(env, name+"="+value)
+		}
+	}
+	return env
+}
+
+// limitedBuffer keeps up to max bytes and drains the rest, so that a
+// command writing too much finishes instead of blocking on a full pipe.
+type limitedBuffer struct {
+	strings.Builder
+	max      int64
+	exceeded bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.exceeded {
+		return len(p), nil
+	}
+	if int64(b.Len()+len(p)) > b.max {
+		b.exceeded = true
+		return len(p), nil
+	}
+	return b.Builder.Write(p)
+}
+
+// tailBuffer keeps the last max bytes written to it.
+type tailBuffer struct {
+	buf []byte
+	max int
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.buf = append(b.buf, p...)
+	if len(b.buf) > b.max {
+		b.buf = b.buf[len(b.buf)-b.max:]
+	}
+	return len(p), nil
+}
+
+// suffix formats the captured stderr for an error message.
+func (b *tailBuffer) suffix() string {
+	text := strings.TrimSpace(string(b.buf))
+	if text == "" {
+		return ""
+	}
+	return ": " + text
+}
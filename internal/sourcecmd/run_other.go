@@ -0,0 +1,23 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build !unix
+
+package sourcecmd
+
+import (
+	"fmt"
+	"os/exec"
+	"os/user"
+	"runtime"
+)
+
+// configureProcess starts the command as is: process groups and running
+// as another user need Unix. A timeout kills the command, but not the
+// processes it started.
+func configureProcess(_ *exec.Cmd, userName string) (*user.User, error) {
+	if userName != "" {
+		return nil, fmt.Errorf("running the source command as %q is not supported on %s", userName, runtime.GOOS)
+	}
+	return nil, nil
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build unix
+
+package sourcecmd
+
+import (
+	"context"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func shell(script string) Command {
+	return Command{Args: []string{"/bin/sh", "-c", script}, Timeout: 10 * time.Second, Env: DefaultEnv}
+}
+
+func TestRun(t *testing.T) {
+	result, err := Run(context.Background(), shell("echo first; echo second; echo noise >&2"), 1024*1024)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if result.Output != "first\nsecond\n" {
+		t.Errorf("Output = %q, want stdout only", result.Output)
+	}
+
+	info := result.SourceInfo()
+	if info["size_bytes"] != int64(13) {
+		t.Errorf("SourceInfo() size_bytes = %v", info["size_bytes"])
+	}
+	for _, key := range []string{"size_mb", "age_hours", "duration_seconds"} {
+		if _, ok := info[key].(float64); !ok {
+			t.Errorf("SourceInfo()[%q] = %v, want float64", key, info[key])
+		}
+	}
+}
+
+func TestRun_Dir(t *testing.T) {
+	dir := t.TempDir()
+	c := shell("pwd")
+	c.Dir = dir
+
+	result, err := Run(context.Background(), c, 1024)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	want, _ := filepath.EvalSymlinks(dir)
+	if got, _ := filepath.EvalSymlinks(strings.TrimSpace(result.Output)); got != want {
+		t.Errorf("working directory = %q, want %q", got, want)
+	}
+}
+
+func TestRun_EnvAllowList(t *testing.T) {
+	t.Setenv("SOURCECMD_TEST_SECRET", "sk-ant-secret")
+	t.Setenv("SOURCECMD_TEST_PASSED", "visible")
+
+	c := shell("echo \"secret=$SOURCECMD_TEST_SECRET passed=$SOURCECMD_TEST_PASSED\"")
+	c.Env = []string{"PATH", "SOURCECMD_TEST_PASSED"}
+
+	result, err := Run(context.Background(), c, 1024)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if got := strings.TrimSpace(result.Output); got != "secret= passed=visible" {
+		t.Errorf("Output = %q, only allow-listed variables should be passed", got)
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		command  Command
+		maxBytes int64
+		want     string
+	}{
+		{"exit status", shell("echo 'drush: site not bootstrapped' >&2; exit 3"), 1024, "exit status 3: drush: site not bootstrapped"},
+		{"output too large", shell("head -c 5000 /dev/zero"), 1024, "exceeds maximum size"},
+		{"missing program", Command{Args: []string{"/nonexistent/logwatch"}, Timeout: time.Second}, 1024, "source command failed"},
+		{"invalid command", Command{Timeout: time.Second}, 1024, "command is empty"},
+		{"unknown user", Command{Args: []string{"true"}, Timeout: time.Second, User: "no-such-user-for-tests"}, 1024, "not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Run(context.Background(), tt.command, tt.maxBytes)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Run() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_Timeout(t *testing.T) {
+	c := shell("sleep 30 & sleep 30")
+	c.Timeout = 200 * time.Millisecond
+
+	started := time.Now()
+	_, err := Run(context.Background(), c, 1024)
+	if err == nil || !strings.Contains(err.Error(), "timed out after 200ms") {
+		t.Errorf("Run() error = %v, want timeout", err)
+	}
+	// The whole process group is killed, so the background sleep does not
+	// keep the output pipe open until waitDelay.
+	if elapsed := time.Since(started); elapsed > 3*time.Second {
+		t.Errorf("Run() took %s after timeout", elapsed)
+	}
+}
+
+func TestEnvironment_RunAs(t *testing.T) {
+	t.Setenv("HOME", "/home/analyzer")
+	u, credential, err := lookupCredential("0") // by UID
+	if err != nil {
+		t.Skipf("no user with UID 0: %v", err)
+	}
+	if credential.Uid != 0 {
+		t.Errorf("credential UID = %d, want 0", credential.Uid)
+	}
+
+	env := environment([]string{"HOME", "LOGNAME", "UNSET_FOR_TESTS"}, u)
+	want := []string{"HOME=" + u.HomeDir, "LOGNAME=" + u.Username}
+	if strings.Join(env, ",") != strings.Join(want, ",") {
+		t.Errorf("environment() = %q, want %q", env, want)
+	}
+}
+
+func TestProcessCredential(t *testing.T) {
+	credential := &syscall.Credential{Uid: 65534, Gid: 65534, Groups: []uint32{65534}}
+	tests := []struct {
+		name    string
+		euid    int
+		want    *syscall.Credential
+		wantErr bool
+	}{
+		{"root switches user", 0, credential, false},
+		{"non-root runs as itself", 65534, nil, false},
+		{"non-root cannot switch user", 1000, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := processCredential(credential, tt.euid)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("processCredential() = %v, %v, want %v (error %v)", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRun_AsCurrentUser(t *testing.T) {
+	current, err := user.Current()
+	if err != nil {
+		t.Skipf("no current user: %v", err)
+	}
+	cmd := shell("id -u")
+	cmd.User = current.Username
+
+	result, err := Run(context.Background(), cmd, 1024)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if got := strings.TrimSpace(result.Output); got != current.Uid {
+		t.Errorf("Run() ran as UID %s, want %s", got, current.Uid)
+	}
+}
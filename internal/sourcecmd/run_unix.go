@@ -0,0 +1,88 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build unix
+
+package sourcecmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"strconv"
+	"syscall"
+)
+
+// configureProcess runs the command in its own process group so that a
+// timeout also kills the processes it started (drush runs PHP, logwatch
+// runs perl), and as userName when set. It returns the user the command
+// runs as, or nil for the user of the analyzer.
+func configureProcess(cmd *exec.Cmd, userName string) (*user.User, error) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+
+	if userName == "" {
+		return nil, nil
+	}
+	u, credential, err := lookupCredential(userName)
+	if err != nil {
+		return nil, err
+	}
+	if cmd.SysProcAttr.Credential, err = processCredential(credential, os.Geteuid()); err != nil {
+		return nil, fmt.Errorf("running the source command as %q requires root", userName)
+	}
+	return u, nil
+}
+
+// processCredential returns the credential to start the command with from
+// a process with effective UID euid. Only root may switch users; a non-root
+// analyzer configured to run commands as itself starts them unchanged, since
+// setting the supplementary groups would fail with EPERM.
+func processCredential(credential *syscall.Credential, euid int) (*syscall.Credential, error) {
+	switch {
+	case euid == 0:
+		return credential, nil
+	case credential.Uid == uint32(euid):
+		return nil, nil
+	default:
+		return nil, syscall.EPERM
+	}
+}
+
+// lookupCredential resolves a user name or numeric UID to the credential
+// of the user, including supplementary groups.
+func lookupCredential(name string) (*user.User, *syscall.Credential, error) {
+	u, err := user.Lookup(name)
+	if err != nil {
+		var unknown user.UnknownUserError
+		if !errors.As(err, &unknown) {
+			return nil, nil, fmt.Errorf("failed to look up source command user %q: %w", name, err)
+		}
+		if u, err = user.LookupId(name); err != nil {
+			return nil, nil, fmt.Errorf("source command user %q not found", name)
+		}
+	}
+
+	uid, err := strconv.ParseUint(u.Uid, 10, 32)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unsupported UID %q of user %q", u.Uid, name)
+	}
+	gid, err := strconv.ParseUint(u.Gid, 10, 32)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unsupported GID %q of user %q", u.Gid, name)
+	}
+
+	credential := &syscall.Credential{Uid: uint32(uid), Gid: uint32(gid)}
+	if groupIDs, err := u.GroupIds(); err == nil {
+		for _, id := range groupIDs {
+			if g, err := strconv.ParseUint(id, 10, 32); err == nil {
+				credential.Groups = append(credential.Groups, uint32(g))
+			}
+		}
+	}
+	return u, credential, nil
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package storage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// maxArchivedPromptBytes caps the decompressed size of an archived prompt so
+// a corrupted or crafted row cannot exhaust memory when it is read back.
+const maxArchivedPromptBytes = 32 * 1024 * 1024 // 32 MiB
+
+// PromptArchive holds the exact prompts sent to the LLM for one summary,
+// kept so follow-up questions can be asked about the same log content.
+type PromptArchive struct {
+	SummaryID    int64
+	CreatedAt    time.Time
+	SystemPrompt string
+	UserPrompt   string
+}
+
+// SavePromptArchive stores the gzip-compressed prompts for a summary,
+// replacing any existing archive for the same summary ID.
+func (s *Storage) SavePromptArchive(archive *PromptArchive) error {
+	systemPrompt, err := compressPrompt(archive.SystemPrompt)
+	if err != nil {
+		return fmt.Errorf("failed to compress system prompt: %w", err)
+	}
+
+	userPrompt, err := compressPrompt(archive.UserPrompt)
+	if err != nil {
+		return fmt.Errorf("failed to compress user prompt: %w", err)
+	}
+
+	createdAt := archive.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	query := `
+		INSERT OR REPLACE INTO prompt_archives (summary_id, created_at, system_prompt, user_prompt)
+		VALUES (?, ?, ?, ?)
+	`
+	if _, err := s.db.Exec(query, archive.SummaryID, createdAt.Format(time.RFC3339), systemPrompt, userPrompt); err != nil {
+		return fmt.Errorf("failed to insert prompt archive: %w", err)
+	}
+
+	return nil
+}
+
+// GetPromptArchive retrieves the archived prompts for a summary. It returns
+// an error wrapping ErrNotFound if none were archived or they have expired.
+func (s *Storage) GetPromptArchive(summaryID int64) (*PromptArchive, error) {
+	var (
+		createdAt                string
+		systemPrompt, userPrompt []byte
+	)
+
+	err := s.db.QueryRow(`
+		SELECT created_at, system_prompt, user_prompt
+		FROM prompt_archives
+		WHERE summary_id = ?
+	`, summaryID).Scan(&createdAt, &systemPrompt, &userPrompt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("prompt archive for summary %d: %w", summaryID, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query prompt archive: %w", err)
+	}
+
+	ts, err := time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse timestamp: %w", err)
+	}
+
+	archive := &PromptArchive{SummaryID: summaryID, CreatedAt: ts}
+	if archive.SystemPrompt, err = decompressPrompt(systemPrompt); err != nil {
+		return nil, fmt.Errorf("failed to decompress system prompt: %w", err)
+	}
+	if archive.UserPrompt, err = decompressPrompt(userPrompt); err != nil {
+		return nil, fmt.Errorf("failed to decompress user prompt: %w", err)
+	}
+
+	return archive, nil
+}
+
+// CleanupOldPromptArchives deletes archives older than N days, along with any
+// archive whose summary no longer exists.
+func (s *Storage) CleanupOldPromptArchives(days int) (int64, error) {
+	cutoffDate := time.Now().AddDate(0, 0, -days).Format(time.RFC3339)
+
+	query := `
+		DELETE FROM prompt_archives
+		WHERE created_at < ?
+		   OR summary_id NOT IN (SELECT id FROM summaries)
+	`
+	result, err := s.db.Exec(query, cutoffDate)
+	if err != nil {
+		return 0, fmt.Errorf("failed to cleanup old prompt archives: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return affected, nil
+}
+
+func compressPrompt(prompt string) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(prompt)); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decompressPrompt(data []byte) (string, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = zr.Close() }()
+
+	out, err := io.ReadAll(io.LimitReader(zr, maxArchivedPromptBytes+1))
+	if err != nil {
+		return "", err
+	}
+	if len(out) > maxArchivedPromptBytes {
+		return "", fmt.Errorf("archived prompt exceeds %d bytes", maxArchivedPromptBytes)
+	}
+	return string(out), nil
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package storage
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newArchiveTestStorage(t *testing.T) *Storage {
+	t.Helper()
+	storage, err := New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	t.Cleanup(func() { _ = storage.Close() })
+	return storage
+}
+
+func saveArchiveTestSummary(t *testing.T, storage *Storage, ts time.Time) int64 {
+	t.Helper()
+	summary := &Summary{
+		Timestamp:     ts,
+		LogSourceType: "logwatch",
+		SystemStatus:  "Good",
+		Summary:       "ok",
+	}
+	if err := storage.SaveSummary(summary); err != nil {
+		t.Fatalf("SaveSummary() error = %v", err)
+	}
+	return summary.ID
+}
+
+func TestGetSummary(t *testing.T) {
+	storage := newArchiveTestStorage(t)
+	id := saveArchiveTestSummary(t, storage, time.Now())
+
+	summary, err := storage.GetSummary(id)
+	if err != nil {
+		t.Fatalf("GetSummary() error = %v", err)
+	}
+	if summary.ID != id || summary.SystemStatus != "Good" {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+
+	if _, err := storage.GetSummary(id + 100); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetSummary(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestPromptArchive_RoundTrip(t *testing.T) {
+	storage := newArchiveTestStorage(t)
+	id := saveArchiveTestSummary(t, storage, time.Now())
+
+	userPrompt := strings.Repeat("sshd[123]: Failed password for root from 203.0.113.5\n", 2000)
+	if err := storage.SavePromptArchive(&PromptArchive{SummaryID: id, SystemPrompt: "system", UserPrompt: userPrompt}); err != nil {
+		t.Fatalf("SavePromptArchive() error = %v", err)
+	}
+
+	var storedSize int
+	if err := storage.db.QueryRow(`SELECT length(user_prompt) FROM prompt_archives WHERE summary_id = ?`, id).Scan(&storedSize); err != nil {
+		t.Fatal(err)
+	}
+	if storedSize >= len(userPrompt)/10 {
+		t.Errorf("user prompt stored as %d bytes, expected compression of %d bytes", storedSize, len(userPrompt))
+	}
+
+	archive, err := storage.GetPromptArchive(id)
+	if err != nil {
+		t.Fatalf("GetPromptArchive() error = %v", err)
+	}
+	if archive.SystemPrompt != "system" || archive.UserPrompt != userPrompt {
+		t.Error("archived prompts do not round-trip")
+	}
+
+	if _, err := storage.GetPromptArchive(id + 1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetPromptArchive(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestCleanupOldPromptArchives(t *testing.T) {
+	storage := newArchiveTestStorage(t)
+
+	recentID := saveArchiveTestSummary(t, storage, time.Now())
+	oldID := saveArchiveTestSummary(t, storage, time.Now().AddDate(0, 0, -30))
+
+	archives := []*PromptArchive{
+		{SummaryID: recentID, SystemPrompt: "s", UserPrompt: "recent"},
+		{SummaryID: oldID, CreatedAt: time.Now().AddDate(0, 0, -30), SystemPrompt: "s", UserPrompt: "old"},
+		{SummaryID: 9999, SystemPrompt: "s", UserPrompt: "orphan"},
+	}
+	for _, a := range archives {
+		if err := storage.SavePromptArchive(a); err != nil {
+			t.Fatalf("SavePromptArchive() error = %v", err)
+		}
+	}
+
+	deleted, err := storage.CleanupOldPromptArchives(14)
+	if err != nil {
+		t.Fatalf("CleanupOldPromptArchives() error = %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("deleted %d archives, want 2", deleted)
+	}
+
+	if _, err := storage.GetPromptArchive(recentID); err != nil {
+		t.Errorf("recent archive should be kept: %v", err)
+	}
+	if _, err := storage.GetPromptArchive(oldID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("old archive should be deleted, got %v", err)
+	}
+}
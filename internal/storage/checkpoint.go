@@ -0,0 +1,60 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/olegiv/logwatch-ai-go/internal/tail"
+)
+
+// GetFileCheckpoint returns the checkpoint of the log file at path, or nil
+// if the file was never read incrementally.
+func (s *Storage) GetFileCheckpoint(path string) (*tail.Checkpoint, error) {
+	cp := &tail.Checkpoint{Path: path}
+	var inode int64
+
+	err := s.db.QueryRow(`
+		SELECT inode, offset, head_hash, head_len
+		FROM file_checkpoints
+		WHERE path = ?
+	`, path).Scan(&inode, &cp.Offset, &cp.HeadHash, &cp.HeadLen)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query file checkpoint: %w", err)
+	}
+
+	cp.Inode = uint64(inode)
+	return cp, nil
+}
+
+// SaveFileCheckpoints stores the checkpoints of a run in one transaction,
+// replacing the previous checkpoints of the same paths.
+func (s *Storage) SaveFileCheckpoints(checkpoints []tail.Checkpoint) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	updatedAt := time.Now().Format(time.RFC3339)
+	for _, cp := range checkpoints {
+		if _, err := tx.Exec(`
+			INSERT OR REPLACE INTO file_checkpoints (path, inode, offset, head_hash, head_len, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, cp.Path, int64(cp.Inode), cp.Offset, cp.HeadHash, cp.HeadLen, updatedAt); err != nil {
+			return fmt.Errorf("failed to save file checkpoint for %s: %w", cp.Path, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit file checkpoints: %w", err)
+	}
+	return nil
+}
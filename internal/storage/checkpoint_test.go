@@ -0,0 +1,44 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package storage
+
+import (
+	"math"
+	"testing"
+
+	"github.com/olegiv/logwatch-ai-go/internal/tail"
+)
+
+func TestFileCheckpoint_RoundTrip(t *testing.T) {
+	storage := newArchiveTestStorage(t)
+
+	cp, err := storage.GetFileCheckpoint("/var/log/messages")
+	if err != nil || cp != nil {
+		t.Fatalf("GetFileCheckpoint(unknown) = %+v, %v, want nil", cp, err)
+	}
+
+	checkpoints := []tail.Checkpoint{
+		{Path: "/var/log/messages", Inode: math.MaxUint64, Offset: 1024, HeadHash: "abc", HeadLen: 1024},
+		{Path: "/var/log/auth.log", Inode: 42, Offset: 10, HeadHash: "def", HeadLen: 10},
+	}
+	if err := storage.SaveFileCheckpoints(checkpoints); err != nil {
+		t.Fatalf("SaveFileCheckpoints() error = %v", err)
+	}
+
+	// A later run replaces the checkpoint of the same path
+	checkpoints[0].Offset = 2048
+	if err := storage.SaveFileCheckpoints(checkpoints[:1]); err != nil {
+		t.Fatalf("SaveFileCheckpoints() error = %v", err)
+	}
+
+	for _, want := range []tail.Checkpoint{checkpoints[0], checkpoints[1]} {
+		got, err := storage.GetFileCheckpoint(want.Path)
+		if err != nil {
+			t.Fatalf("GetFileCheckpoint(%s) error = %v", want.Path, err)
+		}
+		if got == nil || *got != want {
+			t.Errorf("GetFileCheckpoint(%s) = %+v, want %+v", want.Path, got, want)
+		}
+	}
+}
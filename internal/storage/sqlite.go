@@ -7,10 +7,12 @@ package storage
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -18,6 +20,9 @@ import (
 	_ "modernc.org/sqlite"
 )
 
+// ErrNotFound is returned when a requested record does not exist
+var ErrNotFound = errors.New("not found")
+
 // Storage handles database operations
 type Storage struct {
 	db *sql.DB
@@ -27,8 +32,9 @@ type Storage struct {
 type Summary struct {
 	ID              int64
 	Timestamp       time.Time
-	LogSourceType   string // "logwatch", "drupal_watchdog", or "ocms"
+	LogSourceType   string // Source type, e.g. "logwatch", "drupal_watchdog", "ocms", or "journald"
 	SiteName        string // Site identifier (empty for logwatch, site ID for Drupal/OCMS multi-site)
+	Host            string // Host of a collected logwatch report (empty for the local host)
 	SystemStatus    string
 	Summary         string
 	CriticalIssues  []string
@@ -38,12 +44,15 @@ type Summary struct {
 	InputTokens     int
 	OutputTokens    int
 	CostUSD         float64
+	Ensemble        json.RawMessage // Ensemble votes and merged findings (nil for single-provider runs)
+	Degraded        bool            // True when no LLM analysis was available (reader statistics only)
 }
 
 // SourceFilter specifies filtering criteria for log source and site
 type SourceFilter struct {
-	LogSourceType string // Required: "logwatch", "drupal_watchdog", or "ocms"
+	LogSourceType string // Required: source type, e.g. "logwatch" or "journald"
 	SiteName      string // Optional: site identifier for Drupal/OCMS multi-site
+	Host          string // Optional: host of a collected logwatch report
 }
 
 // Database configuration constants (L-04 fix)
@@ -101,7 +110,7 @@ func New(dbPath string) (*Storage, error) {
 const (
 	// currentSchemaVersion is the latest schema version
 	// Increment this when adding new migrations
-	currentSchemaVersion = 2
+	currentSchemaVersion = 7
 )
 
 // initSchema creates the database schema if it doesn't exist
@@ -176,6 +185,31 @@ func (s *Storage) migrateSchema(currentVersion int) error {
 			if err := s.migrateV2(); err != nil {
 				return fmt.Errorf("migration v2 failed: %w", err)
 			}
+		case 2:
+			// Migration 2 -> 3: Add prompt_archives table for follow-up questions
+			if err := s.migrateV3(); err != nil {
+				return fmt.Errorf("migration v3 failed: %w", err)
+			}
+		case 3:
+			// Migration 3 -> 4: Add ensemble column for multi-provider runs
+			if err := s.migrateV4(); err != nil {
+				return fmt.Errorf("migration v4 failed: %w", err)
+			}
+		case 4:
+			// Migration 4 -> 5: Add degraded flag for runs without LLM analysis
+			if err := s.migrateV5(); err != nil {
+				return fmt.Errorf("migration v5 failed: %w", err)
+			}
+		case 5:
+			// Migration 5 -> 6: Add file_checkpoints table for incremental reads
+			if err := s.migrateV6(); err != nil {
+				return fmt.Errorf("migration v6 failed: %w", err)
+			}
+		case 6:
+			// Migration 6 -> 7: Add host column for multi-host logwatch runs
+			if err := s.migrateV7(); err != nil {
+				return fmt.Errorf("migration v7 failed: %w", err)
+			}
 		}
 	}
 
@@ -260,6 +294,85 @@ func (s *Storage) migrateV2() error {
 	return nil
 }
 
+// migrateV3 adds the prompt_archives table holding the compressed prompts
+// that produced each summary, keyed by summary ID
+func (s *Storage) migrateV3() error {
+	log.Printf("storage: running migration v3 - add prompt_archives table")
+
+	schema := `
+	CREATE TABLE IF NOT EXISTS prompt_archives (
+		summary_id INTEGER PRIMARY KEY,
+		created_at TEXT NOT NULL,
+		system_prompt BLOB NOT NULL,
+		user_prompt BLOB NOT NULL
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_prompt_archives_created_at ON prompt_archives(created_at);
+	`
+
+	_, err := s.db.Exec(schema)
+	return err
+}
+
+// migrateV4 adds the ensemble column holding per-model votes and merged
+// findings of ensemble runs (NULL for single-provider runs)
+func (s *Storage) migrateV4() error {
+	log.Printf("storage: running migration v4 - add ensemble column")
+
+	if _, err := s.db.Exec(`ALTER TABLE summaries ADD COLUMN ensemble TEXT`); err != nil {
+		return fmt.Errorf("failed to add ensemble column: %w", err)
+	}
+
+	return nil
+}
+
+// migrateV5 adds the degraded flag marking runs whose report was built from
+// reader statistics because no LLM provider was reachable
+func (s *Storage) migrateV5() error {
+	log.Printf("storage: running migration v5 - add degraded column")
+
+	if _, err := s.db.Exec(`ALTER TABLE summaries ADD COLUMN degraded INTEGER NOT NULL DEFAULT 0`); err != nil {
+		return fmt.Errorf("failed to add degraded column: %w", err)
+	}
+
+	return nil
+}
+
+// migrateV6 adds the file_checkpoints table holding, per log file path, the
+// position up to which incremental reads have covered it
+func (s *Storage) migrateV6() error {
+	log.Printf("storage: running migration v6 - add file_checkpoints table")
+
+	schema := `
+	CREATE TABLE IF NOT EXISTS file_checkpoints (
+		path TEXT PRIMARY KEY,
+		inode INTEGER NOT NULL,
+		offset INTEGER NOT NULL,
+		head_hash TEXT NOT NULL,
+		head_len INTEGER NOT NULL,
+		updated_at TEXT NOT NULL
+	);
+	`
+
+	_, err := s.db.Exec(schema)
+	return err
+}
+
+// migrateV7 adds the host column identifying the host of a collected
+// logwatch report, so each host of a multi-host run keeps its own history
+func (s *Storage) migrateV7() error {
+	log.Printf("storage: running migration v7 - add host column")
+
+	if _, err := s.db.Exec(`ALTER TABLE summaries ADD COLUMN host TEXT NOT NULL DEFAULT ''`); err != nil {
+		return fmt.Errorf("failed to add host column: %w", err)
+	}
+	if _, err := s.db.Exec(`CREATE INDEX IF NOT EXISTS idx_source_site_host ON summaries(log_source_type, site_name, host)`); err != nil {
+		return fmt.Errorf("failed to create source_site_host index: %w", err)
+	}
+
+	return nil
+}
+
 // SaveSummary saves a new summary to the database
 func (s *Storage) SaveSummary(summary *Summary) error {
 	// Marshal JSON fields
@@ -292,17 +405,23 @@ func (s *Storage) SaveSummary(summary *Summary) error {
 	// Insert into database
 	query := `
 		INSERT INTO summaries (
-			timestamp, log_source_type, site_name, system_status, summary,
+			timestamp, log_source_type, site_name, host, system_status, summary,
 			critical_issues, warnings, recommendations, metrics,
-			input_tokens, output_tokens, cost_usd
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			input_tokens, output_tokens, cost_usd, ensemble, degraded
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
+	var ensemble any
+	if len(summary.Ensemble) > 0 {
+		ensemble = string(summary.Ensemble)
+	}
+
 	result, err := s.db.Exec(
 		query,
 		summary.Timestamp.Format(time.RFC3339),
 		logSourceType,
 		summary.SiteName,
+		summary.Host,
 		summary.SystemStatus,
 		summary.Summary,
 		string(criticalIssuesJSON),
@@ -312,6 +431,8 @@ func (s *Storage) SaveSummary(summary *Summary) error {
 		summary.InputTokens,
 		summary.OutputTokens,
 		summary.CostUSD,
+		ensemble,
+		summary.Degraded,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to insert summary: %w", err)
@@ -326,6 +447,31 @@ func (s *Storage) SaveSummary(summary *Summary) error {
 	return nil
 }
 
+// GetSummary retrieves a single summary by ID. It returns an error wrapping
+// ErrNotFound if no summary has that ID.
+func (s *Storage) GetSummary(id int64) (*Summary, error) {
+	rows, err := s.db.Query(`
+		SELECT id, timestamp, log_source_type, site_name, host, system_status, summary,
+		       critical_issues, warnings, recommendations, metrics,
+		       input_tokens, output_tokens, cost_usd, ensemble, degraded
+		FROM summaries
+		WHERE id = ?
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query summary: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("failed to query summary: %w", err)
+		}
+		return nil, fmt.Errorf("summary %d: %w", id, ErrNotFound)
+	}
+
+	return s.scanSummary(rows)
+}
+
 // GetRecentSummaries retrieves summaries from the last N days, filtered by source and site
 func (s *Storage) GetRecentSummaries(days int, filter *SourceFilter) ([]*Summary, error) {
 	cutoffDate := time.Now().AddDate(0, 0, -days).Format(time.RFC3339)
@@ -336,19 +482,19 @@ func (s *Storage) GetRecentSummaries(days int, filter *SourceFilter) ([]*Summary
 	// Build complete query based on filter to avoid SQL fragment concatenation
 	if filter != nil && filter.LogSourceType != "" {
 		query = `
-			SELECT id, timestamp, log_source_type, site_name, system_status, summary,
+			SELECT id, timestamp, log_source_type, site_name, host, system_status, summary,
 			       critical_issues, warnings, recommendations, metrics,
-			       input_tokens, output_tokens, cost_usd
+			       input_tokens, output_tokens, cost_usd, ensemble, degraded
 			FROM summaries
-			WHERE timestamp >= ? AND log_source_type = ? AND site_name = ?
+			WHERE timestamp >= ? AND log_source_type = ? AND site_name = ? AND host = ?
 			ORDER BY timestamp DESC
 		`
-		args = []any{cutoffDate, filter.LogSourceType, filter.SiteName}
+		args = []any{cutoffDate, filter.LogSourceType, filter.SiteName, filter.Host}
 	} else {
 		query = `
-			SELECT id, timestamp, log_source_type, site_name, system_status, summary,
+			SELECT id, timestamp, log_source_type, site_name, host, system_status, summary,
 			       critical_issues, warnings, recommendations, metrics,
-			       input_tokens, output_tokens, cost_usd
+			       input_tokens, output_tokens, cost_usd, ensemble, degraded
 			FROM summaries
 			WHERE timestamp >= ?
 			ORDER BY timestamp DESC
@@ -382,11 +528,14 @@ func (s *Storage) GetRecentSummaries(days int, filter *SourceFilter) ([]*Summary
 
 // GetHistoricalContext retrieves recent summaries formatted for Claude context
 // If filter is provided, only summaries matching the source type and site are included
+// Degraded summaries are left out: they were built from reader statistics
+// without an analysis, so their status is no trend data.
 func (s *Storage) GetHistoricalContext(days int, filter *SourceFilter) (string, error) {
-	summaries, err := s.GetRecentSummaries(days, filter)
+	recent, err := s.GetRecentSummaries(days, filter)
 	if err != nil {
 		return "", err
 	}
+	summaries := slices.DeleteFunc(recent, func(sum *Summary) bool { return sum.Degraded })
 
 	if len(summaries) == 0 {
 		return "", nil
@@ -414,6 +563,30 @@ func (s *Storage) GetHistoricalContext(days int, filter *SourceFilter) (string,
 	return context.String(), nil
 }
 
+// GetLastRunTime returns the timestamp of the most recent summary of the
+// source type and site in filter, or the zero time if there is none.
+func (s *Storage) GetLastRunTime(filter *SourceFilter) (time.Time, error) {
+	var timestamp string
+	err := s.db.QueryRow(`
+		SELECT timestamp FROM summaries
+		WHERE log_source_type = ? AND site_name = ? AND host = ?
+		ORDER BY timestamp DESC
+		LIMIT 1
+	`, filter.LogSourceType, filter.SiteName, filter.Host).Scan(&timestamp)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to query last run: %w", err)
+	}
+
+	ts, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse timestamp: %w", err)
+	}
+	return ts, nil
+}
+
 // CleanupOldSummaries deletes summaries older than N days
 func (s *Storage) CleanupOldSummaries(days int) (int64, error) {
 	cutoffDate := time.Now().AddDate(0, 0, -days).Format(time.RFC3339)
@@ -441,10 +614,10 @@ func (s *Storage) GetStatistics(filter *SourceFilter) (map[string]any, error) {
 
 	// Build complete queries based on filter to avoid SQL fragment concatenation
 	if filter != nil && filter.LogSourceType != "" {
-		args = []any{filter.LogSourceType, filter.SiteName}
-		countQuery = `SELECT COUNT(*) FROM summaries WHERE log_source_type = ? AND site_name = ?`
-		statusQuery = `SELECT system_status, COUNT(*) FROM summaries WHERE log_source_type = ? AND site_name = ? GROUP BY system_status`
-		costQuery = `SELECT COALESCE(SUM(cost_usd), 0) FROM summaries WHERE log_source_type = ? AND site_name = ?`
+		args = []any{filter.LogSourceType, filter.SiteName, filter.Host}
+		countQuery = `SELECT COUNT(*) FROM summaries WHERE log_source_type = ? AND site_name = ? AND host = ?`
+		statusQuery = `SELECT system_status, COUNT(*) FROM summaries WHERE log_source_type = ? AND site_name = ? AND host = ? GROUP BY system_status`
+		costQuery = `SELECT COALESCE(SUM(cost_usd), 0) FROM summaries WHERE log_source_type = ? AND site_name = ? AND host = ?`
 	} else {
 		countQuery = `SELECT COUNT(*) FROM summaries`
 		statusQuery = `SELECT system_status, COUNT(*) FROM summaries GROUP BY system_status`
@@ -499,18 +672,20 @@ func (s *Storage) scanSummary(rows *sql.Rows) (*Summary, error) {
 	var (
 		id                                                    int64
 		timestamp                                             string
-		logSourceType, siteName                               string
+		logSourceType, siteName, host                         string
 		systemStatus, summaryText                             string
 		criticalIssuesJSON, warningsJSON, recommendationsJSON string
 		metricsJSON                                           string
 		inputTokens, outputTokens                             int
 		costUSD                                               float64
+		ensemble                                              sql.NullString
+		degraded                                              bool
 	)
 
 	err := rows.Scan(
-		&id, &timestamp, &logSourceType, &siteName, &systemStatus, &summaryText,
+		&id, &timestamp, &logSourceType, &siteName, &host, &systemStatus, &summaryText,
 		&criticalIssuesJSON, &warningsJSON, &recommendationsJSON,
-		&metricsJSON, &inputTokens, &outputTokens, &costUSD,
+		&metricsJSON, &inputTokens, &outputTokens, &costUSD, &ensemble, &degraded,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan row: %w", err)
@@ -544,6 +719,7 @@ func (s *Storage) scanSummary(rows *sql.Rows) (*Summary, error) {
 		Timestamp:       ts,
 		LogSourceType:   logSourceType,
 		SiteName:        siteName,
+		Host:            host,
 		SystemStatus:    systemStatus,
 		Summary:         summaryText,
 		CriticalIssues:  criticalIssues,
@@ -553,9 +729,19 @@ func (s *Storage) scanSummary(rows *sql.Rows) (*Summary, error) {
 		InputTokens:     inputTokens,
 		OutputTokens:    outputTokens,
 		CostUSD:         costUSD,
+		Ensemble:        ensembleJSON(ensemble),
+		Degraded:        degraded,
 	}, nil
 }
 
+// ensembleJSON returns the stored ensemble JSON, or nil if the run had none
+func ensembleJSON(value sql.NullString) json.RawMessage {
+	if !value.Valid || value.String == "" {
+		return nil
+	}
+	return json.RawMessage(value.String)
+}
+
 // Close closes the database connection
 func (s *Storage) Close() error {
 	if s.db != nil {
@@ -1135,3 +1135,150 @@ func TestSaveWithDefaultLogSourceType(t *testing.T) {
 		t.Errorf("Expected LogSourceType 'logwatch', got '%s'", summaries[0].LogSourceType)
 	}
 }
+
+func TestSaveSummary_Ensemble(t *testing.T) {
+	storage, err := New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	defer func() { _ = storage.Close() }()
+
+	ensemble := `{"statusRule":"worst","votes":[{"model":"a","status":"Bad"}],"findings":[]}`
+	withEnsemble := &Summary{Timestamp: time.Now(), SystemStatus: "Bad", Summary: "s", Ensemble: []byte(ensemble)}
+	single := &Summary{Timestamp: time.Now(), SystemStatus: "Good", Summary: "s"}
+	for _, s := range []*Summary{withEnsemble, single} {
+		if err := storage.SaveSummary(s); err != nil {
+			t.Fatalf("SaveSummary() error = %v", err)
+		}
+	}
+
+	got, err := storage.GetSummary(withEnsemble.ID)
+	if err != nil {
+		t.Fatalf("GetSummary() error = %v", err)
+	}
+	if string(got.Ensemble) != ensemble {
+		t.Errorf("Ensemble = %s, want %s", got.Ensemble, ensemble)
+	}
+
+	got, err = storage.GetSummary(single.ID)
+	if err != nil {
+		t.Fatalf("GetSummary() error = %v", err)
+	}
+	if got.Ensemble != nil {
+		t.Errorf("single-provider Ensemble = %s, want nil", got.Ensemble)
+	}
+}
+
+func TestSaveSummary_Degraded(t *testing.T) {
+	storage, err := New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	defer func() { _ = storage.Close() }()
+
+	degraded := &Summary{Timestamp: time.Now(), SystemStatus: "Satisfactory", Summary: "AI analysis unavailable", Degraded: true}
+	if err := storage.SaveSummary(degraded); err != nil {
+		t.Fatalf("SaveSummary() error = %v", err)
+	}
+
+	got, err := storage.GetSummary(degraded.ID)
+	if err != nil {
+		t.Fatalf("GetSummary() error = %v", err)
+	}
+	if !got.Degraded {
+		t.Error("Degraded = false, want true")
+	}
+
+	// Degraded runs are not trend data for later analyses
+	context, err := storage.GetHistoricalContext(7, nil)
+	if err != nil {
+		t.Fatalf("GetHistoricalContext() error = %v", err)
+	}
+	if context != "" {
+		t.Errorf("historical context of only a degraded run = %q, want empty", context)
+	}
+
+	analyzed := &Summary{Timestamp: time.Now(), SystemStatus: "Bad", Summary: "SSH brute force"}
+	if err := storage.SaveSummary(analyzed); err != nil {
+		t.Fatalf("SaveSummary() error = %v", err)
+	}
+	context, err = storage.GetHistoricalContext(7, nil)
+	if err != nil {
+		t.Fatalf("GetHistoricalContext() error = %v", err)
+	}
+	if !strings.Contains(context, "Previous 1 analysis summaries:") || !strings.Contains(context, "SSH brute force") || strings.Contains(context, "AI analysis unavailable") {
+		t.Errorf("historical context should list only the analyzed run:\n%s", context)
+	}
+}
+
+func TestGetLastRunTime(t *testing.T) {
+	storage, err := New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	defer func() { _ = storage.Close() }()
+
+	filter := &SourceFilter{LogSourceType: "drupal_watchdog", SiteName: "production"}
+	last, err := storage.GetLastRunTime(filter)
+	if err != nil || !last.IsZero() {
+		t.Fatalf("GetLastRunTime() on empty database = %v, %v", last, err)
+	}
+
+	now := time.Now().Truncate(time.Second)
+	for _, summary := range []*Summary{
+		{Timestamp: now.Add(-2 * time.Hour), LogSourceType: "drupal_watchdog", SiteName: "production", SystemStatus: "Good"},
+		{Timestamp: now.Add(-time.Hour), LogSourceType: "drupal_watchdog", SiteName: "production", SystemStatus: "Good"},
+		{Timestamp: now, LogSourceType: "drupal_watchdog", SiteName: "staging", SystemStatus: "Good"},
+	} {
+		if err := storage.SaveSummary(summary); err != nil {
+			t.Fatalf("SaveSummary() error = %v", err)
+		}
+	}
+
+	last, err = storage.GetLastRunTime(filter)
+	if err != nil || !last.Equal(now.Add(-time.Hour)) {
+		t.Errorf("GetLastRunTime() = %v, %v, want %v", last, err, now.Add(-time.Hour))
+	}
+}
+
+func TestSourceFilter_Host(t *testing.T) {
+	storage, err := New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	defer func() { _ = storage.Close() }()
+
+	now := time.Now().Truncate(time.Second)
+	for _, summary := range []*Summary{
+		{Timestamp: now.Add(-time.Hour), LogSourceType: "logwatch", Host: "web01", SystemStatus: "Good", Summary: "web01 run"},
+		{Timestamp: now, LogSourceType: "logwatch", Host: "db01", SystemStatus: "Bad", Summary: "db01 run"},
+		{Timestamp: now, LogSourceType: "logwatch", SystemStatus: "Excellent", Summary: "local run"},
+	} {
+		if err := storage.SaveSummary(summary); err != nil {
+			t.Fatalf("SaveSummary() error = %v", err)
+		}
+	}
+
+	filter := &SourceFilter{LogSourceType: "logwatch", Host: "web01"}
+	summaries, err := storage.GetRecentSummaries(7, filter)
+	if err != nil {
+		t.Fatalf("GetRecentSummaries() error = %v", err)
+	}
+	if len(summaries) != 1 || summaries[0].Host != "web01" || summaries[0].Summary != "web01 run" {
+		t.Errorf("GetRecentSummaries(web01) = %+v, want the web01 run only", summaries)
+	}
+
+	last, err := storage.GetLastRunTime(filter)
+	if err != nil || !last.Equal(now.Add(-time.Hour)) {
+		t.Errorf("GetLastRunTime(web01) = %v, %v, want %v", last, err, now.Add(-time.Hour))
+	}
+
+	// The local host keeps its own history
+	summaries, err = storage.GetRecentSummaries(7, &SourceFilter{LogSourceType: "logwatch"})
+	if err != nil {
+		t.Fatalf("GetRecentSummaries() error = %v", err)
+	}
+	if len(summaries) != 1 || summaries[0].Host != "" || summaries[0].SystemStatus != "Excellent" {
+		t.Errorf("GetRecentSummaries(local) = %+v, want the local run only", summaries)
+	}
+}
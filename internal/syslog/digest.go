@@ -0,0 +1,332 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package syslog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+)
+
+// timeFormatDateTime is the standard date-time format of the digest.
+const timeFormatDateTime = "2006-01-02 15:04:05"
+
+// digestTitle names the summary section of the digest.
+const digestTitle = "Syslog Digest"
+
+// Limits of the formatted digest. Repeats are collapsed before these apply.
+const (
+	maxGroupsPerProgram = 30
+	maxProgramsListed   = 40
+	maxMessageLen       = 200
+)
+
+// markMessage is the periodic keep-alive line of syslogd.
+const markMessage = "-- MARK --"
+
+// digest aggregates syslog entries by program and message pattern.
+type digest struct {
+	entries    int
+	parsed     int
+	unparsed   int
+	first      time.Time
+	last       time.Time
+	hosts      map[string]bool
+	severities map[int]int
+	facilities map[int]int
+	programs   map[string]*programStats
+	// Target of "last message repeated N times"
+	lastProgram *programStats
+	lastGroup   *messageGroup
+}
+
+// programStats collects the entries of one program, which become one
+// digest section.
+type programStats struct {
+	entries    int
+	pids       map[string]bool
+	severities map[int]int
+	groups     []*messageGroup
+	index      map[string]*messageGroup
+}
+
+// messageGroup collapses repeated messages of one program and severity.
+type messageGroup struct {
+	severity int
+	example  string
+	count    int
+	first    time.Time
+	last     time.Time
+	ips      map[string]bool
+}
+
+func newDigest() *digest {
+	return &digest{
+		hosts:      make(map[string]bool),
+		severities: make(map[int]int),
+		facilities: make(map[int]int),
+		programs:   make(map[string]*programStats),
+	}
+}
+
+// add records one parsed line.
+func (d *digest) add(e Entry) {
+	d.parsed++
+
+	if e.Program == unknownProgram {
+		if n, ok := lastRepeatedCount(e.Message); ok {
+			d.repeatLast(n)
+			return
+		}
+		if e.Message == markMessage {
+			return
+		}
+	}
+
+	d.entries += e.Count
+	if !e.Timestamp.IsZero() {
+		if d.first.IsZero() || e.Timestamp.Before(d.first) {
+			d.first = e.Timestamp
+		}
+		if e.Timestamp.After(d.last) {
+			d.last = e.Timestamp
+		}
+	}
+	if e.Hostname != "" {
+		d.hosts[e.Hostname] = true
+	}
+	d.severities[e.Severity] += e.Count
+	if e.Facility != Unknown {
+		d.facilities[e.Facility] += e.Count
+	}
+
+	// Sub-programs such as postfix/smtpd share the section of their suite
+	section, _, _ := strings.Cut(e.Program, "/")
+	message := e.Message
+	if section != e.Program {
+		message = e.Program + ": " + message
+	}
+
+	ps, ok := d.programs[section]
+	if !ok {
+		ps = &programStats{
+			pids:       make(map[string]bool),
+			severities: make(map[int]int),
+			index:      make(map[string]*messageGroup),
+		}
+		d.programs[section] = ps
+	}
+	ps.entries += e.Count
+	ps.severities[e.Severity] += e.Count
+	if e.PID != "" {
+		ps.pids[e.PID] = true
+	}
+
+	key := fmt.Sprintf("%d\x00%s", e.Severity, analyzer.NormalizeMessage(message))
+	g, ok := ps.index[key]
+	if !ok {
+		g = &messageGroup{
+			severity: e.Severity,
+			example:  message,
+			first:    e.Timestamp,
+			last:     e.Timestamp,
+			ips:      make(map[string]bool),
+		}
+		ps.index[key] = g
+		ps.groups = append(ps.groups, g)
+	}
+	g.count += e.Count
+	if e.Timestamp.Before(g.first) {
+		g.first = e.Timestamp
+	}
+	if e.Timestamp.After(g.last) {
+		g.last = e.Timestamp
+	}
+	for _, ip := range analyzer.FindIPv4(e.Message) {
+		g.ips[ip] = true
+	}
+	d.lastProgram, d.lastGroup = ps, g
+}
+
+// repeatLast counts n more occurrences of the previous message.
+func (d *digest) repeatLast(n int) {
+	g := d.lastGroup
+	if g == nil {
+		return
+	}
+	g.count += n
+	d.entries += n
+	d.severities[g.severity] += n
+	d.lastProgram.entries += n
+	d.lastProgram.severities[g.severity] += n
+}
+
+// criticalCount returns the number of entries with error severity or higher.
+func (d *digest) criticalCount() int {
+	count := 0
+	for s := SeverityEmergency; s <= SeverityError; s++ {
+		count += d.severities[s]
+	}
+	return count
+}
+
+// hasSeverity reports whether any entry carried a priority.
+func (d *digest) hasSeverity() bool {
+	for severity := range d.severities {
+		if severity != Unknown {
+			return true
+		}
+	}
+	return false
+}
+
+// format renders the digest as a logwatch-like report: a summary section
+// followed by one "#### program ####" section per program, in alphabetical
+// order like logwatch services.
+func (d *digest) format() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "################### %s ###################\n", digestTitle)
+	fmt.Fprintf(&sb, "Entries: %d\n", d.entries)
+	if d.unparsed > 0 {
+		fmt.Fprintf(&sb, "Unparsed lines: %d\n", d.unparsed)
+	}
+	if !d.first.IsZero() {
+		fmt.Fprintf(&sb, "Date range: %s to %s\n", d.first.Format(timeFormatDateTime), d.last.Format(timeFormatDateTime))
+	}
+	if len(d.hosts) > 0 {
+		fmt.Fprintf(&sb, "Hosts: %s\n", strings.Join(analyzer.SortedKeys(d.hosts), ", "))
+	}
+	fmt.Fprintf(&sb, "Programs: %d\n", len(d.programs))
+	if d.hasSeverity() {
+		fmt.Fprintf(&sb, "Severity: %s\n", severityDetail(d.severities, SeverityDebug))
+	}
+	if len(d.facilities) > 0 {
+		fmt.Fprintf(&sb, "Facility: %s\n", facilityDetail(d.facilities))
+	}
+
+	sb.WriteString("\nEntries by program:\n")
+	programs := analyzer.TopCounts(d.programCounts(), 0)
+	for i, p := range programs {
+		if i >= maxProgramsListed {
+			fmt.Fprintf(&sb, "... and %d more programs\n", len(programs)-maxProgramsListed)
+			break
+		}
+		detail := severityDetail(d.programs[p.Name].severities, SeverityWarning)
+		if detail != "" {
+			detail = " (" + detail + ")"
+		}
+		fmt.Fprintf(&sb, "- %s: %d%s\n", p.Name, p.Count, detail)
+	}
+
+	names := make([]string, 0, len(d.programs))
+	for name := range d.programs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		ps := d.programs[name]
+		fmt.Fprintf(&sb, "\n################### %s ###################\n", name)
+		fmt.Fprintf(&sb, "Entries: %d", ps.entries)
+		if len(ps.pids) > 1 {
+			fmt.Fprintf(&sb, " (%d processes)", len(ps.pids))
+		}
+		sb.WriteString("\n")
+		writeGroups(&sb, sortGroups(ps.groups))
+	}
+
+	return sb.String()
+}
+
+// programCounts returns the entry count of each program.
+func (d *digest) programCounts() map[string]int {
+	counts := make(map[string]int, len(d.programs))
+	for name, ps := range d.programs {
+		counts[name] = ps.entries
+	}
+	return counts
+}
+
+// sortGroups orders groups most severe first, then most frequent first.
+// Messages of unknown severity rank with notices.
+func sortGroups(groups []*messageGroup) []*messageGroup {
+	sorted := append([]*messageGroup(nil), groups...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ri, rj := severityRank(sorted[i].severity), severityRank(sorted[j].severity)
+		if ri != rj {
+			return ri < rj
+		}
+		return sorted[i].count > sorted[j].count
+	})
+	return sorted
+}
+
+func severityRank(severity int) int {
+	if severity == Unknown {
+		return SeverityNotice
+	}
+	return severity
+}
+
+// writeGroups writes one line per message group, up to maxGroupsPerProgram.
+func writeGroups(sb *strings.Builder, groups []*messageGroup) {
+	for i, g := range groups {
+		if i >= maxGroupsPerProgram {
+			fmt.Fprintf(sb, "... and %d more unique messages\n", len(groups)-maxGroupsPerProgram)
+			break
+		}
+
+		message := analyzer.TruncateMessage(g.example, maxMessageLen)
+		if name := SeverityName[g.severity]; name != "" {
+			message = name + ": " + message
+		}
+		if len(g.ips) > 1 {
+			message += fmt.Sprintf(" (%d distinct IPs)", len(g.ips))
+		}
+
+		switch {
+		case g.count == 1 && !g.last.IsZero():
+			fmt.Fprintf(sb, "- [%s] %s\n", g.last.Format(timeFormatDateTime), message)
+		case g.count == 1:
+			fmt.Fprintf(sb, "- %s\n", message)
+		case g.first.IsZero():
+			fmt.Fprintf(sb, "- [%dx] %s\n", g.count, message)
+		default:
+			fmt.Fprintf(sb, "- [%dx, %s to %s] %s\n",
+				g.count, g.first.Format(timeFormatDateTime), g.last.Format(timeFormatDateTime), message)
+		}
+	}
+}
+
+// severityDetail lists the counts of known severities up to maxSeverity,
+// followed by the count of entries without a severity when listing all.
+func severityDetail(counts map[int]int, maxSeverity int) string {
+	var parts []string
+	for s := SeverityEmergency; s <= maxSeverity; s++ {
+		if counts[s] > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %d", SeverityName[s], counts[s]))
+		}
+	}
+	if maxSeverity == SeverityDebug && counts[Unknown] > 0 {
+		parts = append(parts, fmt.Sprintf("unknown: %d", counts[Unknown]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// facilityDetail lists the facility counts, busiest first.
+func facilityDetail(counts map[int]int) string {
+	named := make(map[string]int, len(counts))
+	for code, count := range counts {
+		named[FacilityName[code]] = count
+	}
+	var parts []string
+	for _, item := range analyzer.TopCounts(named, 0) {
+		parts = append(parts, fmt.Sprintf("%s: %d", item.Name, item.Count))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package syslog
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/olegiv/logwatch-ai-go/internal/analyzer"
+	"github.com/olegiv/logwatch-ai-go/internal/logwatch"
+)
+
+// NoEntriesContent is returned when the syslog file contains no messages.
+// This is a valid state for quiet hosts on a rotated log.
+// Use IsNoEntriesContent() to check for this condition.
+const NoEntriesContent = "=== NO SYSLOG ENTRIES ===\n\nNo syslog messages were found for the analyzed time period.\nThis typically means the log was rotated empty or the host was idle."
+
+// maxStatsItems limits the breakdowns of ReadStats.
+const maxStatsItems = 10
+
+// IsNoEntriesContent checks if the content indicates no syslog messages were found.
+func IsNoEntriesContent(content string) bool {
+	return strings.HasPrefix(content, "=== NO SYSLOG ENTRIES ===")
+}
+
+// Compile-time interface checks
+var (
+	_ analyzer.LogReader     = (*Reader)(nil)
+	_ analyzer.StatsReporter = (*Reader)(nil)
+	_ analyzer.ContentReader = (*Reader)(nil)
+)
+
+// Reader handles reading raw syslog files.
+// Implements analyzer.LogReader interface.
+//
+// The digest uses logwatch section headers, so it is preprocessed by the
+// logwatch Preprocessor: program sections such as sshd, sudo, and kernel
+// get the same priorities as the corresponding logwatch services.
+type Reader struct {
+	maxSizeMB           int
+	enablePreprocessing bool
+	maxTokens           int
+	preprocessor        *logwatch.Preprocessor
+	digest              *digest
+}
+
+// NewReader creates a new syslog reader.
+func NewReader(maxSizeMB int, enablePreprocessing bool, maxTokens int) *Reader {
+	return &Reader{
+		maxSizeMB:           maxSizeMB,
+		enablePreprocessing: enablePreprocessing,
+		maxTokens:           maxTokens,
+		preprocessor:        logwatch.NewPreprocessor(maxTokens),
+	}
+}
+
+// Read implements analyzer.LogReader.Read.
+// Parses the syslog file and returns a logwatch-like digest with one
+// section per program.
+func (r *Reader) Read(sourcePath string) (string, error) {
+	r.digest = nil
+
+	content, err := analyzer.ReadSourceFileWithGuards(
+		sourcePath,
+		analyzer.FileReadOptions{
+			SourceLabel: "syslog",
+			MaxSizeMB:   r.maxSizeMB,
+			MaxAge:      24 * time.Hour,
+		},
+		func(string) error { return nil }, // an empty log is a valid idle period
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return r.ReadContent(content)
+}
+
+// ReadContent implements analyzer.ContentReader.
+// Digests syslog lines that were not read from a file.
+func (r *Reader) ReadContent(content string) (string, error) {
+	r.digest = nil
+
+	d, err := parse(content, time.Now())
+	if err != nil {
+		return "", err
+	}
+	r.digest = d
+
+	if d.entries == 0 {
+		return NoEntriesContent, nil
+	}
+
+	formattedContent := d.format()
+
+	if err := r.Validate(formattedContent); err != nil {
+		return "", fmt.Errorf("syslog content validation failed: %w", err)
+	}
+
+	if r.enablePreprocessing && r.preprocessor.ShouldProcess(formattedContent, r.maxTokens) {
+		processedContent, err := r.preprocessor.Process(formattedContent)
+		if err != nil {
+			return "", fmt.Errorf("preprocessing failed: %w", err)
+		}
+		return processedContent, nil
+	}
+
+	return formattedContent, nil
+}
+
+// parse aggregates the syslog lines. A log in which no line is recognized
+// is an error: the file is most likely not a syslog file.
+func parse(content string, ref time.Time) (*digest, error) {
+	d := newDigest()
+	for line := range strings.Lines(content) {
+		line = strings.TrimRight(line, "\r\n")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		entry, ok := ParseLine(line, ref)
+		if !ok {
+			d.unparsed++
+			continue
+		}
+		d.add(entry)
+	}
+
+	if d.parsed == 0 && d.unparsed > 0 {
+		return nil, fmt.Errorf("no syslog lines recognized (%d lines skipped)", d.unparsed)
+	}
+	return d, nil
+}
+
+// ReadStats implements analyzer.StatsReporter.
+// Summarizes the messages of the last Read by program, severity, facility,
+// and repeated message.
+func (r *Reader) ReadStats() *analyzer.ReadStats {
+	d := r.digest
+	if d == nil {
+		return nil
+	}
+
+	stats := &analyzer.ReadStats{
+		Totals: []analyzer.StatsItem{
+			{Name: "Entries", Count: d.entries},
+			{Name: "Programs", Count: len(d.programs)},
+			{Name: "Hosts", Count: len(d.hosts)},
+		},
+	}
+	if d.hasSeverity() {
+		stats.Totals = append(stats.Totals, analyzer.StatsItem{Name: "Critical/error entries", Count: d.criticalCount()})
+	}
+	if d.unparsed > 0 {
+		stats.Totals = append(stats.Totals, analyzer.StatsItem{Name: "Unparsed lines", Count: d.unparsed})
+	}
+
+	stats.AddBreakdown("Programs", analyzer.TopCounts(d.programCounts(), maxStatsItems))
+
+	var severities []analyzer.StatsItem
+	for s := SeverityEmergency; s <= SeverityDebug; s++ {
+		if count := d.severities[s]; count > 0 {
+			severities = append(severities, analyzer.StatsItem{Name: SeverityName[s], Count: count})
+		}
+	}
+	stats.AddBreakdown("Severity", severities)
+
+	facilities := make(map[string]int, len(d.facilities))
+	for code, count := range d.facilities {
+		facilities[FacilityName[code]] = count
+	}
+	stats.AddBreakdown("Facility", analyzer.TopCounts(facilities, maxStatsItems))
+
+	repeated := make(map[string]int)
+	for name, ps := range d.programs {
+		for _, g := range ps.groups {
+			if g.count > 1 {
+				repeated[name+": "+analyzer.TruncateMessage(g.example, 80)] += g.count
+			}
+		}
+	}
+	stats.AddBreakdown("Top repeated messages", analyzer.TopCounts(repeated, maxStatsItems))
+
+	return stats
+}
+
+// Validate implements analyzer.LogReader.Validate.
+// Performs basic validation on the formatted digest.
+func (r *Reader) Validate(content string) error {
+	if len(content) == 0 {
+		return fmt.Errorf("syslog content is empty")
+	}
+
+	// NoEntriesContent is a valid state - no messages for the time period
+	if IsNoEntriesContent(content) {
+		return nil
+	}
+
+	if len(content) < 50 {
+		return fmt.Errorf("syslog content seems too small to be valid (only %d bytes)", len(content))
+	}
+
+	return nil
+}
+
+// GetSourceInfo implements analyzer.LogReader.GetSourceInfo.
+// Returns metadata about the syslog file.
+func (r *Reader) GetSourceInfo(sourcePath string) (map[string]any, error) {
+	return analyzer.GetSourceFileInfo(sourcePath)
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package syslog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeLog(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "auth.log")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write temp file: %v", err)
+	}
+	return path
+}
+
+func sampleLog() string {
+	lines := []string{
+		"Jan  1 02:00:01 web1 sshd[100]: Failed password for root from 203.0.113.5 port 4001 ssh2",
+		"Jan  1 02:00:02 web1 sshd[101]: Failed password for root from 203.0.113.6 port 4002 ssh2",
+		"Jan  1 02:00:03 web1 sshd[102]: Failed password for root from 203.0.113.7 port 4003 ssh2",
+		"Jan  1 02:00:04 web1 last message repeated 2 times",
+		"Jan  1 02:05:00 web1 sshd[200]: Accepted publickey for deploy from 192.0.2.10 port 50122 ssh2",
+		"Jan  1 02:10:00 web1 sudo:   deploy : TTY=pts/0 ; PWD=/home/deploy ; USER=root ; COMMAND=/usr/bin/apt upgrade",
+		"Jan  1 02:20:00 web1 CRON[300]: pam_unix(cron:session): session opened for user root(uid=0) by (uid=0)",
+		"Jan  1 02:30:00 web1 kernel: [ 1234.5678] Out of memory: Killed process 4242 (php-fpm)",
+		"Jan  1 02:40:00 web1 postfix/smtpd[811]: connect from unknown[192.0.2.1]",
+		"Jan  1 02:40:01 web1 postfix/qmgr[812]: 4A1B2C3D: removed",
+		"Jan  1 02:50:00 web1 -- MARK --",
+		"this line is not syslog",
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func TestReader_Read(t *testing.T) {
+	r := NewReader(10, false, 150000)
+
+	result, err := r.Read(writeLog(t, sampleLog()))
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"################### Syslog Digest ###################",
+		"Entries: 11",
+		"Unparsed lines: 1",
+		"Hosts: web1",
+		"Programs: 5",
+		"- sshd: 6",
+		"################### sshd ###################",
+		"Entries: 6 (4 processes)",
+		"Failed password for root from 203.0.113.5 port 4001 ssh2 (3 distinct IPs)",
+		"[5x, ",
+		"################### postfix ###################",
+		"postfix/smtpd: connect from unknown[192.0.2.1]",
+		"################### kernel ###################",
+		"Out of memory: Killed process 4242 (php-fpm)",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Read() result missing %q\n%s", want, result)
+		}
+	}
+
+	if strings.Contains(result, "MARK") || strings.Contains(result, "Severity:") {
+		t.Errorf("Read() result should skip MARK lines and omit unknown severities\n%s", result)
+	}
+
+	// Program sections follow the summary in alphabetical order, like logwatch services
+	if strings.Index(result, "# CRON #") > strings.Index(result, "# kernel #") ||
+		strings.Index(result, "# kernel #") > strings.Index(result, "# sshd #") {
+		t.Errorf("program sections out of order\n%s", result)
+	}
+}
+
+func TestReader_Read_Severity(t *testing.T) {
+	r := NewReader(10, false, 150000)
+
+	content := strings.Join([]string{
+		"Jan  1 02:00:00 alpine authpriv.warn sshd[99]: Invalid user admin from 198.51.100.7 port 4022",
+		"Jan  1 02:00:01 alpine daemon.err crond[5]: USER root pid 77 cmd /etc/periodic/daily failed",
+		"Jan  1 02:00:02 alpine daemon.info crond[5]: USER root pid 78 cmd run-parts /etc/periodic/hourly",
+	}, "\n")
+	result, err := r.Read(writeLog(t, content))
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"Severity: err: 1, warning: 1, info: 1",
+		"Facility: daemon: 2, authpriv: 1",
+		"- crond: 2 (err: 1)",
+		"err: USER root pid 77 cmd /etc/periodic/daily failed",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Read() result missing %q\n%s", want, result)
+		}
+	}
+
+	// Errors are listed before informational messages of the same program
+	if strings.Index(result, "daily failed") > strings.Index(result, "hourly") {
+		t.Error("error messages should come first within a section")
+	}
+}
+
+func TestReader_Read_Preprocessing(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteString("Jan  1 02:00:00 web1 sshd[1]: Failed password for invalid user admin from 203.0.113.5 port 22 ssh2\n")
+	for i := range 3000 {
+		fmt.Fprintf(&sb, "Jan  1 02:%02d:%02d web1 chatty[%d]: routine message variant %c%c with some padding text\n",
+			(i/60)%60, i%60, i, 'a'+i%26, 'a'+(i/26)%26)
+	}
+
+	r := NewReader(10, true, 2000)
+	result, err := r.Read(writeLog(t, sb.String()))
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	if r.preprocessor.EstimateTokens(result) > 2000 {
+		t.Errorf("preprocessed digest has %d tokens, want <= 2000", r.preprocessor.EstimateTokens(result))
+	}
+	// The logwatch Preprocessor keeps security sections such as sshd
+	if !strings.Contains(result, "Failed password for invalid user admin") {
+		t.Errorf("preprocessing dropped the sshd section\n%s", result)
+	}
+}
+
+func TestReader_Read_Empty(t *testing.T) {
+	r := NewReader(10, false, 150000)
+
+	for _, content := range []string{"\n", "Jan  1 02:50:00 web1 -- MARK --\n"} {
+		result, err := r.Read(writeLog(t, content))
+		if err != nil {
+			t.Fatalf("Read(%q) error = %v", content, err)
+		}
+		if !IsNoEntriesContent(result) {
+			t.Errorf("Read(%q) = %q, want NoEntriesContent", content, result)
+		}
+		if err := r.Validate(result); err != nil {
+			t.Errorf("Validate(NoEntriesContent) error = %v", err)
+		}
+	}
+}
+
+func TestReader_Read_Errors(t *testing.T) {
+	r := NewReader(10, false, 150000)
+
+	if _, err := r.Read(writeLog(t, "first garbage line\nsecond garbage line\n")); err == nil || !strings.Contains(err.Error(), "2 lines skipped") {
+		t.Errorf("Read() error = %v, want unrecognized lines error", err)
+	}
+
+	if _, err := r.Read(filepath.Join(t.TempDir(), "missing.log")); err == nil || !strings.Contains(err.Error(), "syslog file not found") {
+		t.Errorf("Read() error = %v, want not found error", err)
+	}
+
+	old := writeLog(t, sampleLog())
+	past := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(old, past, past); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+	if _, err := r.Read(old); err == nil || !strings.Contains(err.Error(), "too old") {
+		t.Errorf("Read() error = %v, want stale file error", err)
+	}
+}
+
+func TestReader_ReadStats(t *testing.T) {
+	r := NewReader(10, false, 150000)
+	if r.ReadStats() != nil {
+		t.Error("ReadStats() before Read should be nil")
+	}
+
+	if _, err := r.Read(writeLog(t, sampleLog())); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	stats := r.ReadStats()
+	if stats == nil {
+		t.Fatal("ReadStats() = nil")
+	}
+
+	totals := make(map[string]int)
+	for _, item := range stats.Totals {
+		totals[item.Name] = item.Count
+	}
+	want := map[string]int{"Entries": 11, "Programs": 5, "Hosts": 1, "Unparsed lines": 1}
+	for name, count := range want {
+		if totals[name] != count {
+			t.Errorf("Totals[%q] = %d, want %d", name, totals[name], count)
+		}
+	}
+	if _, ok := totals["Critical/error entries"]; ok {
+		t.Error("Totals should omit error entries when no line carries a severity")
+	}
+
+	var titles []string
+	for _, b := range stats.Breakdowns {
+		titles = append(titles, b.Title)
+	}
+	if got := strings.Join(titles, ", "); got != "Programs, Top repeated messages" {
+		t.Errorf("Breakdowns = %s", got)
+	}
+	if top := stats.Breakdowns[0].Items[0]; top.Name != "sshd" || top.Count != 6 {
+		t.Errorf("top program = %+v", top)
+	}
+}
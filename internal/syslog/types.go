@@ -0,0 +1,338 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package syslog provides log analysis for raw syslog files such as
+// /var/log/auth.log, /var/log/syslog, and /var/log/messages. It parses
+// RFC 3164 and RFC 5424 lines and renders a logwatch-like digest with one
+// section per program, for hosts that have no logwatch installed.
+package syslog
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Severity levels of syslog messages (RFC 5424).
+const (
+	SeverityEmergency = 0 // System is unusable
+	SeverityAlert     = 1 // Action must be taken immediately
+	SeverityCritical  = 2 // Critical conditions
+	SeverityError     = 3 // Error conditions
+	SeverityWarning   = 4 // Warning conditions
+	SeverityNotice    = 5 // Normal but significant condition
+	SeverityInfo      = 6 // Informational messages
+	SeverityDebug     = 7 // Debug-level messages
+)
+
+// Unknown marks a facility or severity that the line does not carry.
+// Files written by rsyslog and syslog-ng usually omit both.
+const Unknown = -1
+
+// SeverityName maps severity levels to their syslog names.
+var SeverityName = map[int]string{
+	SeverityEmergency: "emerg",
+	SeverityAlert:     "alert",
+	SeverityCritical:  "crit",
+	SeverityError:     "err",
+	SeverityWarning:   "warning",
+	SeverityNotice:    "notice",
+	SeverityInfo:      "info",
+	SeverityDebug:     "debug",
+}
+
+// FacilityName maps facility codes to their syslog names.
+var FacilityName = map[int]string{
+	0:  "kern",
+	1:  "user",
+	2:  "mail",
+	3:  "daemon",
+	4:  "auth",
+	5:  "syslog",
+	6:  "lpr",
+	7:  "news",
+	8:  "uucp",
+	9:  "cron",
+	10: "authpriv",
+	11: "ftp",
+	12: "ntp",
+	13: "security",
+	14: "console",
+	15: "clock",
+	16: "local0",
+	17: "local1",
+	18: "local2",
+	19: "local3",
+	20: "local4",
+	21: "local5",
+	22: "local6",
+	23: "local7",
+}
+
+// severityAliases lists the alternative severity names accepted in the
+// "facility.severity" field that BusyBox syslogd writes.
+var severityAliases = map[string]int{
+	"panic": SeverityEmergency,
+	"error": SeverityError,
+	"warn":  SeverityWarning,
+}
+
+// unknownProgram groups lines without a recognizable program tag.
+const unknownProgram = "unknown"
+
+// timeLayoutRFC3164 is the BSD syslog timestamp; the day is space-padded.
+const timeLayoutRFC3164 = "Jan _2 15:04:05"
+
+// Patterns used by the parser, compiled once: they run for every line.
+var (
+	priRegex          = regexp.MustCompile(`^<(\d{1,3})>`)
+	rfc3164TimeRegex  = regexp.MustCompile(`^[A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2}`)
+	isoTimeRegex      = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:Z|[+-]\d{2}:\d{2})`)
+	tagRegex          = regexp.MustCompile(`^([^\s:\[]+)(?:\[([^\]]*)\])?:\s?`)
+	repeatedRegex     = regexp.MustCompile(`^message repeated (\d+) times: \[ ?(.*?) ?\]$`)
+	lastRepeatedRegex = regexp.MustCompile(`last message repeated (\d+) times?$`)
+)
+
+// Entry represents a single syslog message.
+type Entry struct {
+	Timestamp time.Time
+	Hostname  string
+	Facility  int // Unknown when the line carries no priority
+	Severity  int // Unknown when the line carries no priority
+	Program   string
+	PID       string
+	Message   string
+	Count     int // occurrences the line stands for, > 1 for "message repeated N times"
+}
+
+// SeverityName returns the syslog name of the entry severity, or "" when
+// the severity is unknown.
+func (e *Entry) SeverityName() string {
+	return SeverityName[e.Severity]
+}
+
+// FacilityName returns the syslog name of the entry facility, or "" when
+// the facility is unknown.
+func (e *Entry) FacilityName() string {
+	return FacilityName[e.Facility]
+}
+
+// IsCritical returns true if the entry has error severity or higher.
+func (e *Entry) IsCritical() bool {
+	return e.Severity >= SeverityEmergency && e.Severity <= SeverityError
+}
+
+// ParseLine parses one syslog line in any of the layouts found in log files:
+//
+//	<34>1 2026-01-01T02:00:00.003Z host sshd 1234 - - message     (RFC 5424)
+//	<34>Jan  1 02:00:00 host sshd[1234]: message                  (RFC 3164)
+//	Jan  1 02:00:00 host sshd[1234]: message                      (rsyslog, syslog-ng)
+//	2026-01-01T02:00:00.123456+00:00 host sshd[1234]: message     (rsyslog high precision)
+//	Jan  1 02:00:00 host auth.info sshd[1234]: message            (BusyBox syslogd)
+//
+// RFC 3164 timestamps carry no year: the year is taken from ref, or the
+// year before when the timestamp would otherwise lie more than a day after
+// ref. Lines without a recognizable timestamp are reported as not ok.
+func ParseLine(line string, ref time.Time) (Entry, bool) {
+	entry := Entry{Facility: Unknown, Severity: Unknown, Count: 1}
+
+	rest := line
+	if m := priRegex.FindStringSubmatch(rest); m != nil {
+		pri, _ := strconv.Atoi(m[1])
+		if pri > 191 {
+			return Entry{}, false
+		}
+		entry.Facility, entry.Severity = pri/8, pri%8
+		rest = rest[len(m[0]):]
+
+		if after, ok := strings.CutPrefix(rest, "1 "); ok {
+			if !parseRFC5424(after, &entry) {
+				return Entry{}, false
+			}
+			return entry, true
+		}
+	}
+
+	switch {
+	case rfc3164TimeRegex.MatchString(rest):
+		ts, err := time.ParseInLocation(timeLayoutRFC3164, rest[:15], time.Local)
+		if err != nil {
+			return Entry{}, false
+		}
+		entry.Timestamp = withYear(ts, ref)
+		rest = rest[15:]
+	case isoTimeRegex.MatchString(rest):
+		raw := isoTimeRegex.FindString(rest)
+		ts, err := time.Parse(time.RFC3339Nano, raw)
+		if err != nil {
+			return Entry{}, false
+		}
+		entry.Timestamp = ts
+		rest = rest[len(raw):]
+	default:
+		return Entry{}, false
+	}
+
+	rest = strings.TrimLeft(rest, " ")
+	entry.Hostname, rest, _ = strings.Cut(rest, " ")
+	rest = strings.TrimLeft(rest, " ")
+
+	// BusyBox syslogd writes "facility.severity" after the hostname
+	if field, after, ok := strings.Cut(rest, " "); ok {
+		if facility, severity, ok := parseFacilitySeverity(field); ok {
+			entry.Facility, entry.Severity = facility, severity
+			rest = after
+		}
+	}
+
+	if m := tagRegex.FindStringSubmatch(rest); m != nil {
+		entry.Program, entry.PID = m[1], m[2]
+		rest = rest[len(m[0]):]
+	} else {
+		entry.Program = unknownProgram
+	}
+	entry.Message = strings.TrimSpace(rest)
+	applyRepeat(&entry)
+
+	return entry, true
+}
+
+// parseRFC5424 parses the part of an RFC 5424 line after "<PRI>1 ":
+// TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA [MSG].
+func parseRFC5424(rest string, entry *Entry) bool {
+	fields := make([]string, 0, 5)
+	for range 5 {
+		field, after, ok := strings.Cut(rest, " ")
+		if !ok {
+			return false
+		}
+		fields = append(fields, field)
+		rest = after
+	}
+
+	if fields[0] != "-" {
+		ts, err := time.Parse(time.RFC3339Nano, fields[0])
+		if err != nil {
+			return false
+		}
+		entry.Timestamp = ts
+	}
+	entry.Hostname = nilValue(fields[1])
+	entry.Program = nilValue(fields[2])
+	if entry.Program == "" {
+		entry.Program = unknownProgram
+	}
+	entry.PID = nilValue(fields[3])
+
+	structuredData, message := splitStructuredData(rest)
+	message = strings.TrimPrefix(message, "\ufeff")
+	if message == "" && structuredData != "-" {
+		message = structuredData
+	}
+	entry.Message = strings.TrimSpace(message)
+	applyRepeat(entry)
+
+	return true
+}
+
+// splitStructuredData splits RFC 5424 STRUCTURED-DATA (the nil value "-" or
+// one or more [elements]) from the message that follows it.
+func splitStructuredData(s string) (structuredData, message string) {
+	if !strings.HasPrefix(s, "[") {
+		sd, msg, _ := strings.Cut(s, " ")
+		return sd, msg
+	}
+
+	inQuotes := false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\' && inQuotes:
+			i++ // skip the escaped character
+		case c == '"':
+			inQuotes = !inQuotes
+		case c == ']' && !inQuotes:
+			if i+1 < len(s) && s[i+1] == '[' {
+				continue
+			}
+			return s[:i+1], strings.TrimPrefix(s[i+1:], " ")
+		}
+	}
+	return s, ""
+}
+
+// parseFacilitySeverity parses a "facility.severity" field such as
+// "authpriv.notice".
+func parseFacilitySeverity(field string) (facility, severity int, ok bool) {
+	facilityName, severityName, found := strings.Cut(field, ".")
+	if !found {
+		return 0, 0, false
+	}
+
+	facility = Unknown
+	for code, name := range FacilityName {
+		if name == facilityName {
+			facility = code
+			break
+		}
+	}
+	if facility == Unknown {
+		return 0, 0, false
+	}
+
+	if s, ok := severityAliases[severityName]; ok {
+		return facility, s, true
+	}
+	for level, name := range SeverityName {
+		if name == severityName {
+			return facility, level, true
+		}
+	}
+	return 0, 0, false
+}
+
+// applyRepeat expands rsyslog's "message repeated N times: [ msg ]"
+// reduction into the repeated message and its count.
+func applyRepeat(entry *Entry) {
+	m := repeatedRegex.FindStringSubmatch(entry.Message)
+	if m == nil {
+		return
+	}
+	if n, err := strconv.Atoi(m[1]); err == nil && n > 0 {
+		entry.Message = m[2]
+		entry.Count = n
+	}
+}
+
+// lastRepeatedCount reports whether the line is a classic syslogd "last
+// message repeated N times" marker, which repeats the previous line.
+func lastRepeatedCount(line string) (int, bool) {
+	m := lastRepeatedRegex.FindStringSubmatch(line)
+	if m == nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(m[1])
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	return n, true
+}
+
+// withYear places a year-less timestamp in the year of ref, or the year
+// before when it would otherwise lie in the future (December lines read in
+// January).
+func withYear(ts, ref time.Time) time.Time {
+	t := time.Date(ref.Year(), ts.Month(), ts.Day(), ts.Hour(), ts.Minute(), ts.Second(), 0, ts.Location())
+	if t.After(ref.Add(24 * time.Hour)) {
+		t = t.AddDate(-1, 0, 0)
+	}
+	return t
+}
+
+// nilValue maps the RFC 5424 nil value "-" to "".
+func nilValue(s string) string {
+	if s == "-" {
+		return ""
+	}
+	return s
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package syslog
+
+import (
+	"testing"
+	"time"
+)
+
+var testRef = time.Date(2026, 1, 2, 12, 0, 0, 0, time.Local)
+
+func TestParseLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want Entry
+	}{
+		{
+			name: "rsyslog file",
+			line: "Jan  1 02:13:45 web1 sshd[1234]: Failed password for root from 203.0.113.5 port 22 ssh2",
+			want: Entry{
+				Timestamp: time.Date(2026, 1, 1, 2, 13, 45, 0, time.Local),
+				Hostname:  "web1", Facility: Unknown, Severity: Unknown,
+				Program: "sshd", PID: "1234",
+				Message: "Failed password for root from 203.0.113.5 port 22 ssh2", Count: 1,
+			},
+		},
+		{
+			name: "RFC 3164 with priority",
+			line: "<34>Jan 11 22:14:15 mymachine su: 'su root' failed for lonvick on /dev/pts/8",
+			want: Entry{
+				Timestamp: time.Date(2025, 1, 11, 22, 14, 15, 0, time.Local),
+				Hostname:  "mymachine", Facility: 4, Severity: SeverityCritical,
+				Program: "su",
+				Message: "'su root' failed for lonvick on /dev/pts/8", Count: 1,
+			},
+		},
+		{
+			name: "rsyslog high precision",
+			line: "2026-01-01T02:00:00.123456+00:00 web1 kernel: [ 1234.5678] Out of memory: Killed process 4242 (php-fpm)",
+			want: Entry{
+				Timestamp: time.Date(2026, 1, 1, 2, 0, 0, 123456000, time.UTC),
+				Hostname:  "web1", Facility: Unknown, Severity: Unknown,
+				Program: "kernel",
+				Message: "[ 1234.5678] Out of memory: Killed process 4242 (php-fpm)", Count: 1,
+			},
+		},
+		{
+			name: "BusyBox syslogd",
+			line: "Jan  1 02:00:00 alpine authpriv.warn sshd[99]: Invalid user admin from 198.51.100.7 port 4022",
+			want: Entry{
+				Timestamp: time.Date(2026, 1, 1, 2, 0, 0, 0, time.Local),
+				Hostname:  "alpine", Facility: 10, Severity: SeverityWarning,
+				Program: "sshd", PID: "99",
+				Message: "Invalid user admin from 198.51.100.7 port 4022", Count: 1,
+			},
+		},
+		{
+			name: "RFC 5424",
+			line: `<165>1 2026-01-01T02:00:00.003Z mymachine.example.com evntslog 42 ID47 [exampleSDID@32473 iut="3" eventSource="App]lication"] ` + "\ufeffAn application event log entry",
+			want: Entry{
+				Timestamp: time.Date(2026, 1, 1, 2, 0, 0, 3000000, time.UTC),
+				Hostname:  "mymachine.example.com", Facility: 20, Severity: SeverityNotice,
+				Program: "evntslog", PID: "42",
+				Message: "An application event log entry", Count: 1,
+			},
+		},
+		{
+			name: "RFC 5424 nil values",
+			line: "<11>1 - - - - - - disk full",
+			want: Entry{
+				Facility: 1, Severity: SeverityError,
+				Program: unknownProgram,
+				Message: "disk full", Count: 1,
+			},
+		},
+		{
+			name: "rsyslog repeated message",
+			line: "Jan  1 02:00:00 web1 sshd[1]: message repeated 5 times: [ Failed password for root from 203.0.113.5 port 22 ssh2]",
+			want: Entry{
+				Timestamp: time.Date(2026, 1, 1, 2, 0, 0, 0, time.Local),
+				Hostname:  "web1", Facility: Unknown, Severity: Unknown,
+				Program: "sshd", PID: "1",
+				Message: "Failed password for root from 203.0.113.5 port 22 ssh2", Count: 5,
+			},
+		},
+		{
+			name: "sub-program tag",
+			line: "Jan  1 02:00:00 mx postfix/smtpd[811]: connect from unknown[192.0.2.1]",
+			want: Entry{
+				Timestamp: time.Date(2026, 1, 1, 2, 0, 0, 0, time.Local),
+				Hostname:  "mx", Facility: Unknown, Severity: Unknown,
+				Program: "postfix/smtpd", PID: "811",
+				Message: "connect from unknown[192.0.2.1]", Count: 1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseLine(tt.line, testRef)
+			if !ok {
+				t.Fatalf("ParseLine(%q) failed", tt.line)
+			}
+			if !got.Timestamp.Equal(tt.want.Timestamp) {
+				t.Errorf("Timestamp = %v, want %v", got.Timestamp, tt.want.Timestamp)
+			}
+			got.Timestamp = tt.want.Timestamp
+			if got != tt.want {
+				t.Errorf("ParseLine() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLine_Invalid(t *testing.T) {
+	for _, line := range []string{
+		"not a syslog line",
+		"<999>Jan  1 02:00:00 host prog: priority out of range",
+		"<13>1 2026-01-01T02:00:00Z truncated",
+		"01/Jan/2026:02:00:00 +0000 wrong timestamp",
+	} {
+		if _, ok := ParseLine(line, testRef); ok {
+			t.Errorf("ParseLine(%q) should fail", line)
+		}
+	}
+}
+
+func TestEntry_Names(t *testing.T) {
+	e, _ := ParseLine("<38>Jan  1 02:00:00 host sshd: x", testRef)
+	if e.FacilityName() != "auth" || e.SeverityName() != "info" || e.IsCritical() {
+		t.Errorf("names = %q/%q critical=%v", e.FacilityName(), e.SeverityName(), e.IsCritical())
+	}
+
+	e, _ = ParseLine("Jan  1 02:00:00 host sshd: x", testRef)
+	if e.FacilityName() != "" || e.SeverityName() != "" || e.IsCritical() {
+		t.Errorf("names without priority = %q/%q", e.FacilityName(), e.SeverityName())
+	}
+}
+
+func TestWithYear(t *testing.T) {
+	ts, _ := time.ParseInLocation(timeLayoutRFC3164, "Dec 31 23:59:59", time.Local)
+	if got := withYear(ts, testRef); got.Year() != 2025 {
+		t.Errorf("withYear(Dec 31) in January = %v, want 2025", got)
+	}
+
+	ts, _ = time.ParseInLocation(timeLayoutRFC3164, "Jan  3 00:30:00", time.Local)
+	if got := withYear(ts, testRef); got.Year() != 2026 {
+		t.Errorf("withYear(Jan 3) = %v, want 2026 (clock skew tolerance)", got)
+	}
+}
+
+func TestLastRepeatedCount(t *testing.T) {
+	if n, ok := lastRepeatedCount("last message repeated 3 times"); !ok || n != 3 {
+		t.Errorf("lastRepeatedCount() = %d, %v", n, ok)
+	}
+	if _, ok := lastRepeatedCount("Failed password for root"); ok {
+		t.Error("lastRepeatedCount() should not match regular messages")
+	}
+}
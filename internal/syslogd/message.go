@@ -0,0 +1,86 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package syslogd
+
+import (
+	"net/netip"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/olegiv/logwatch-ai-go/internal/syslog"
+)
+
+var (
+	// hostNameRegex matches host names that are spooled: they name files
+	// in the spool directory, so path separators are excluded.
+	hostNameRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,252}$`)
+
+	// priRegex matches the PRI part of a message.
+	priRegex = regexp.MustCompile(`^<\d{1,3}>`)
+)
+
+// validHostName returns true if the name is a valid spool host name.
+func validHostName(name string) bool {
+	return hostNameRegex.MatchString(name)
+}
+
+// SenderHostName returns the spool host name of a sender address, used
+// for messages that carry no valid host name. IPv6 colons are replaced by
+// underscores, since host names name files.
+func SenderHostName(addr netip.Addr) string {
+	return strings.ReplaceAll(addr.Unmap().String(), ":", "_")
+}
+
+// normalizeMessage turns a received message into a single syslog line that
+// syslog.ParseLine accepts, and returns the host it is spooled for.
+//
+// Messages with a timestamp and a valid host name (RFC 5424, or RFC 3164
+// as sent by most devices) are spooled as received, under their host name.
+// Otherwise the message is spooled under the sender address: RFC 3164
+// messages without a host name get the sender inserted after the
+// timestamp, and messages without a timestamp are stamped with the time
+// of receipt. Empty messages are reported as not ok.
+func normalizeMessage(msg []byte, sender netip.Addr, received time.Time) (host, line string, ok bool) {
+	text := strings.Map(func(r rune) rune {
+		switch r {
+		case '\n', '\r', '\t':
+			return ' '
+		case 0:
+			return -1
+		}
+		return r
+	}, string(msg))
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", "", false
+	}
+
+	senderHost := SenderHostName(sender)
+	pri := priRegex.FindString(text)
+	body := strings.TrimLeft(text[len(pri):], " ")
+
+	entry, parsed := syslog.ParseLine(text, received)
+	switch {
+	case parsed && validHostName(entry.Hostname):
+		return entry.Hostname, text, true
+	case parsed && strings.HasPrefix(body, "1 "):
+		// RFC 5424 with the nil host name: the spool names the host
+		return senderHost, text, true
+	case parsed:
+		// RFC 3164 without a host name: the tag follows the timestamp
+		// "Jan _2 15:04:05" or an ISO timestamp without spaces
+		skip := strings.IndexByte(body, ' ')
+		if body[0] >= 'A' && body[0] <= 'Z' {
+			skip = min(15, len(body))
+		}
+		rest := ""
+		if skip >= 0 {
+			rest = strings.TrimLeft(body[skip:], " ")
+		}
+		return senderHost, pri + entry.Timestamp.Format(time.RFC3339) + " " + senderHost + " " + rest, true
+	default:
+		return senderHost, pri + received.Format(time.RFC3339) + " " + senderHost + " " + body, true
+	}
+}
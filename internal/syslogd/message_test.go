@@ -0,0 +1,112 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package syslogd
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/olegiv/logwatch-ai-go/internal/syslog"
+)
+
+func TestNormalizeMessage(t *testing.T) {
+	received := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	sender := netip.MustParseAddr("192.0.2.10")
+
+	tests := []struct {
+		name     string
+		msg      string
+		wantHost string
+		wantLine string
+		wantOK   bool
+	}{
+		{
+			name:     "RFC 5424",
+			msg:      "<34>1 2026-10-18T11:59:00Z router01 sshd 42 - - Failed password for root\n",
+			wantHost: "router01",
+			wantLine: "<34>1 2026-10-18T11:59:00Z router01 sshd 42 - - Failed password for root",
+			wantOK:   true,
+		},
+		{
+			name:     "RFC 5424 without host name",
+			msg:      "<34>1 2026-10-18T11:59:00Z - sshd 42 - - Failed password for root",
+			wantHost: "192.0.2.10",
+			wantLine: "<34>1 2026-10-18T11:59:00Z - sshd 42 - - Failed password for root",
+			wantOK:   true,
+		},
+		{
+			name:     "RFC 3164",
+			msg:      "<38>Oct 18 11:59:00 ap-lobby dropbear[812]: Password auth succeeded for 'root'",
+			wantHost: "ap-lobby",
+			wantLine: "<38>Oct 18 11:59:00 ap-lobby dropbear[812]: Password auth succeeded for 'root'",
+			wantOK:   true,
+		},
+		{
+			name:     "RFC 3164 without host name",
+			msg:      "<38>Oct 18 11:59:00 dropbear[812]: Password auth succeeded for 'root'",
+			wantHost: "192.0.2.10",
+			wantLine: "<38>" + time.Date(2026, 10, 18, 11, 59, 0, 0, time.Local).Format(time.RFC3339) +
+				" 192.0.2.10 dropbear[812]: Password auth succeeded for 'root'",
+			wantOK: true,
+		},
+		{
+			name:     "no timestamp",
+			msg:      "<13>kernel: link eth0 down",
+			wantHost: "192.0.2.10",
+			wantLine: "<13>2026-10-18T12:00:00Z 192.0.2.10 kernel: link eth0 down",
+			wantOK:   true,
+		},
+		{
+			name:     "multi-line message is flattened",
+			msg:      "<13>Oct 18 11:59:00 nas01 backup: job failed\n\ttrace line\r\n",
+			wantHost: "nas01",
+			wantLine: "<13>Oct 18 11:59:00 nas01 backup: job failed  trace line",
+			wantOK:   true,
+		},
+		{
+			name:   "empty",
+			msg:    " \r\n\x00",
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host, line, ok := normalizeMessage([]byte(tt.msg), sender, received)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if host != tt.wantHost {
+				t.Errorf("host = %q, want %q", host, tt.wantHost)
+			}
+			if line != tt.wantLine {
+				t.Errorf("line = %q, want %q", line, tt.wantLine)
+			}
+			// Spooled lines must be readable by the syslog reader
+			if _, parsed := syslog.ParseLine(line, received); !parsed {
+				t.Errorf("spooled line %q is not parsed by syslog.ParseLine", line)
+			}
+		})
+	}
+}
+
+func TestSenderHostName(t *testing.T) {
+	tests := map[string]string{
+		"192.0.2.10":        "192.0.2.10",
+		"::ffff:192.0.2.10": "192.0.2.10",
+		"2001:db8::1":       "2001_db8__1",
+	}
+	for addr, want := range tests {
+		if got := SenderHostName(netip.MustParseAddr(addr)); got != want {
+			t.Errorf("SenderHostName(%s) = %q, want %q", addr, got, want)
+		}
+		if !validHostName(SenderHostName(netip.MustParseAddr(addr))) {
+			t.Errorf("SenderHostName(%s) is not a valid host name", addr)
+		}
+	}
+}
@@ -0,0 +1,417 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package syslogd receives syslog messages forwarded over the network by
+// hosts that cannot run logwatch or the analyzer themselves, such as
+// routers and appliances. Messages arrive over UDP, TCP, or TLS (RFC 5424
+// with RFC 3164 fallback) and are buffered per sending host in an on-disk
+// spool, whose windows are analyzed as syslog files.
+package syslogd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Limits of the listeners.
+const (
+	// MaxMessageSize is the longest message received; longer TCP messages
+	// are truncated. RFC 5424 requires receivers to accept 2048 octets.
+	MaxMessageSize = 8192
+
+	// maxConnections limits concurrent TCP connections; further
+	// connections are closed right away.
+	maxConnections = 256
+
+	// idleTimeout closes TCP connections that send nothing for this long.
+	// Senders reconnect when they have messages again.
+	idleTimeout = 10 * time.Minute
+
+	// handshakeTimeout bounds the TLS handshake.
+	handshakeTimeout = 30 * time.Second
+)
+
+// Config configures the listeners of a Receiver.
+type Config struct {
+	UDPAddress      string // e.g. ":514", empty disables UDP
+	TCPAddress      string // e.g. ":6514", empty disables TCP
+	TLSCertFile     string // Certificate and key enable TLS on the TCP listener
+	TLSKeyFile      string
+	TLSClientCAFile string         // Optional CA that senders must present a certificate of
+	AllowedSenders  []netip.Prefix // Empty accepts all senders
+}
+
+// Validate checks the configuration for errors.
+func (c *Config) Validate() error {
+	if c.UDPAddress == "" && c.TCPAddress == "" {
+		return fmt.Errorf("a UDP or TCP listen address is required")
+	}
+	for _, address := range []string{c.UDPAddress, c.TCPAddress} {
+		if address == "" {
+			continue
+		}
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			return fmt.Errorf("invalid listen address %q: %w", address, err)
+		}
+	}
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		return fmt.Errorf("TLS requires both a certificate and a key")
+	}
+	if c.TLSCertFile != "" && c.TCPAddress == "" {
+		return fmt.Errorf("TLS requires a TCP listen address")
+	}
+	if c.TLSClientCAFile != "" && c.TLSCertFile == "" {
+		return fmt.Errorf("a client CA requires TLS")
+	}
+	return nil
+}
+
+// HasTLS returns true if the TCP listener uses TLS.
+func (c *Config) HasTLS() bool {
+	return c.TLSCertFile != ""
+}
+
+// ParseAllowedSenders parses a comma-separated list of IP addresses and
+// CIDR prefixes.
+func ParseAllowedSenders(value string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for field := range strings.SplitSeq(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if strings.Contains(field, "/") {
+			prefix, err := netip.ParsePrefix(field)
+			if err != nil {
+				return nil, fmt.Errorf("invalid sender prefix %q: %w", field, err)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sender address %q: %w", field, err)
+		}
+		addr = addr.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
+// Stats counts the messages of a Receiver.
+type Stats struct {
+	Received int64 // Messages spooled
+	Dropped  int64 // Messages dropped because the spool was full or had too many hosts
+	Denied   int64 // Messages and connections of senders not allowed
+}
+
+// Receiver listens for syslog messages and appends them to a Spool.
+type Receiver struct {
+	config    Config
+	spool     *Spool
+	tlsConfig *tls.Config
+
+	udp net.PacketConn
+	tcp net.Listener
+
+	wg    sync.WaitGroup
+	conns chan struct{} // Semaphore of TCP connections
+
+	received atomic.Int64
+	dropped  atomic.Int64
+	denied   atomic.Int64
+}
+
+// NewReceiver creates a receiver that spools to spool. TLS certificates
+// are loaded here, so errors surface before listening.
+func NewReceiver(config Config, spool *Spool) (*Receiver, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	r := &Receiver{
+		config: config,
+		spool:  spool,
+		conns:  make(chan struct{}, maxConnections),
+	}
+	if config.HasTLS() {
+		tlsConfig, err := loadTLSConfig(&config)
+		if err != nil {
+			return nil, err
+		}
+		r.tlsConfig = tlsConfig
+	}
+	return r, nil
+}
+
+// loadTLSConfig loads the server certificate and the optional client CA.
+func loadTLSConfig(config *Config) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(config.TLSCertFile, config.TLSKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if config.TLSClientCAFile != "" {
+		pem, err := os.ReadFile(config.TLSClientCAFile) // #nosec G304 -- operator-configured CA file
+		if err != nil {
+			return nil, fmt.Errorf("failed to read TLS client CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in TLS client CA %s", config.TLSClientCAFile)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConfig, nil
+}
+
+// Start binds the listeners and receives messages in the background until
+// ctx is done. Use Wait to wait for the listeners to stop.
+func (r *Receiver) Start(ctx context.Context) error {
+	if r.config.UDPAddress != "" {
+		conn, err := net.ListenPacket("udp", r.config.UDPAddress)
+		if err != nil {
+			return fmt.Errorf("failed to listen on UDP %s: %w", r.config.UDPAddress, err)
+		}
+		r.udp = conn
+	}
+	if r.config.TCPAddress != "" {
+		listener, err := net.Listen("tcp", r.config.TCPAddress)
+		if err != nil {
+			if r.udp != nil {
+				_ = r.udp.Close()
+			}
+			return fmt.Errorf("failed to listen on TCP %s: %w", r.config.TCPAddress, err)
+		}
+		r.tcp = listener
+	}
+
+	if r.udp != nil {
+		r.wg.Go(r.serveUDP)
+	}
+	if r.tcp != nil {
+		r.wg.Go(func() { r.serveTCP(ctx) })
+	}
+	r.wg.Go(func() {
+		<-ctx.Done()
+		if r.udp != nil {
+			_ = r.udp.Close()
+		}
+		if r.tcp != nil {
+			_ = r.tcp.Close()
+		}
+	})
+	return nil
+}
+
+// Wait waits until the listeners and connections are closed after the
+// context of Start is done.
+func (r *Receiver) Wait() {
+	r.wg.Wait()
+}
+
+// UDPAddr returns the address of the UDP listener, or nil.
+func (r *Receiver) UDPAddr() net.Addr {
+	if r.udp == nil {
+		return nil
+	}
+	return r.udp.LocalAddr()
+}
+
+// TCPAddr returns the address of the TCP listener, or nil.
+func (r *Receiver) TCPAddr() net.Addr {
+	if r.tcp == nil {
+		return nil
+	}
+	return r.tcp.Addr()
+}
+
+// Stats returns the message counters since the receiver was created.
+func (r *Receiver) Stats() Stats {
+	return Stats{
+		Received: r.received.Load(),
+		Dropped:  r.dropped.Load(),
+		Denied:   r.denied.Load(),
+	}
+}
+
+// serveUDP receives one message per datagram.
+func (r *Receiver) serveUDP() {
+	buf := make([]byte, 65535)
+	for {
+		n, addr, err := r.udp.ReadFrom(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			continue
+		}
+		sender, ok := addrIP(addr)
+		if !ok || !r.allowed(sender) {
+			r.denied.Add(1)
+			continue
+		}
+		r.handle(buf[:min(n, MaxMessageSize)], sender)
+	}
+}
+
+// serveTCP accepts connections until the listener is closed.
+func (r *Receiver) serveTCP(ctx context.Context) {
+	for {
+		conn, err := r.tcp.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			continue
+		}
+		sender, ok := addrIP(conn.RemoteAddr())
+		if !ok || !r.allowed(sender) {
+			r.denied.Add(1)
+			_ = conn.Close()
+			continue
+		}
+		select {
+		case r.conns <- struct{}{}:
+		default:
+			_ = conn.Close()
+			continue
+		}
+		r.wg.Go(func() {
+			defer func() { <-r.conns }()
+			r.serveConn(ctx, conn, sender)
+		})
+	}
+}
+
+// serveConn receives the messages of a TCP or TLS connection.
+func (r *Receiver) serveConn(ctx context.Context, conn net.Conn, sender netip.Addr) {
+	defer func() { _ = conn.Close() }()
+
+	// Close the connection when the receiver stops
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	if r.tlsConfig != nil {
+		tlsConn := tls.Server(conn, r.tlsConfig)
+		_ = conn.SetDeadline(time.Now().Add(handshakeTimeout))
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			return
+		}
+		_ = conn.SetDeadline(time.Time{})
+		conn = tlsConn
+	}
+
+	reader := bufio.NewReaderSize(conn, MaxMessageSize+1)
+	for {
+		_ = conn.SetReadDeadline(time.Now().Add(idleTimeout))
+		msg, err := readFrame(reader)
+		if len(msg) > 0 {
+			r.handle(msg, sender)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// readFrame reads a message of a TCP stream (RFC 6587): octet-counted
+// ("LEN SP MSG", as required by RFC 5425 for TLS) when it starts with a
+// digit, otherwise terminated by LF. Messages longer than MaxMessageSize
+// are truncated.
+func readFrame(reader *bufio.Reader) ([]byte, error) {
+	first, err := reader.Peek(1)
+	if err != nil {
+		return nil, err
+	}
+
+	if first[0] >= '1' && first[0] <= '9' {
+		header, err := reader.ReadSlice(' ')
+		if err != nil {
+			return nil, fmt.Errorf("invalid octet count: %w", err)
+		}
+		length, err := strconv.Atoi(string(header[:len(header)-1]))
+		if err != nil || length <= 0 {
+			return nil, fmt.Errorf("invalid octet count %q", header)
+		}
+		msg := make([]byte, min(length, MaxMessageSize))
+		if _, err := io.ReadFull(reader, msg); err != nil {
+			return nil, err
+		}
+		if length > len(msg) {
+			if _, err := reader.Discard(length - len(msg)); err != nil {
+				return msg, err
+			}
+		}
+		return msg, nil
+	}
+
+	line, err := reader.ReadSlice('\n')
+	if errors.Is(err, bufio.ErrBufferFull) {
+		msg := bytes.Clone(line[:MaxMessageSize])
+		// Skip the rest of the overlong message
+		for errors.Is(err, bufio.ErrBufferFull) {
+			_, err = reader.ReadSlice('\n')
+		}
+		return msg, err
+	}
+	return bytes.Clone(line), err
+}
+
+// handle spools a received message.
+func (r *Receiver) handle(msg []byte, sender netip.Addr) {
+	host, line, ok := normalizeMessage(msg, sender, time.Now())
+	if !ok {
+		return
+	}
+	if err := r.spool.Append(host, line); err != nil {
+		r.dropped.Add(1)
+		return
+	}
+	r.received.Add(1)
+}
+
+// allowed returns true if the sender may send messages.
+func (r *Receiver) allowed(sender netip.Addr) bool {
+	if len(r.config.AllowedSenders) == 0 {
+		return true
+	}
+	for _, prefix := range r.config.AllowedSenders {
+		if prefix.Contains(sender) {
+			return true
+		}
+	}
+	return false
+}
+
+// addrIP returns the IP address of a UDP or TCP address.
+func addrIP(addr net.Addr) (netip.Addr, bool) {
+	var ip net.IP
+	switch a := addr.(type) {
+	case *net.UDPAddr:
+		ip = a.IP
+	case *net.TCPAddr:
+		ip = a.IP
+	default:
+		return netip.Addr{}, false
+	}
+	parsed, ok := netip.AddrFromSlice(ip)
+	return parsed.Unmap(), ok
+}
@@ -0,0 +1,262 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package syslogd
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// startTestReceiver starts a receiver on loopback ports.
+func startTestReceiver(t *testing.T, config Config) (*Receiver, *Spool) {
+	t.Helper()
+
+	spool, err := OpenSpool(t.TempDir(), 1<<20, 10)
+	if err != nil {
+		t.Fatalf("OpenSpool() error = %v", err)
+	}
+	receiver, err := NewReceiver(config, spool)
+	if err != nil {
+		t.Fatalf("NewReceiver() error = %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := receiver.Start(ctx); err != nil {
+		cancel()
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(func() {
+		cancel()
+		receiver.Wait()
+		_ = spool.Close()
+	})
+	return receiver, spool
+}
+
+// waitForMessages waits until the receiver handled n messages.
+func waitForMessages(t *testing.T, receiver *Receiver, n int64) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		stats := receiver.Stats()
+		if stats.Received+stats.Dropped >= n {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("received %+v, want %d messages", receiver.Stats(), n)
+}
+
+// spooledLines rotates the spool and returns the spooled lines by host.
+func spooledLines(t *testing.T, spool *Spool) map[string][]string {
+	t.Helper()
+	windows, _, err := spool.Rotate()
+	if err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+	lines := make(map[string][]string)
+	for _, window := range windows {
+		data, err := os.ReadFile(window.Path)
+		if err != nil {
+			t.Fatalf("ReadFile() error = %v", err)
+		}
+		lines[window.Host] = strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	}
+	return lines
+}
+
+func TestReceiver_UDPAndTCP(t *testing.T) {
+	t.Parallel()
+
+	receiver, spool := startTestReceiver(t, Config{UDPAddress: "127.0.0.1:0", TCPAddress: "127.0.0.1:0"})
+
+	udp, err := net.Dial("udp", receiver.UDPAddr().String())
+	if err != nil {
+		t.Fatalf("Dial(udp) error = %v", err)
+	}
+	defer func() { _ = udp.Close() }()
+	if _, err := udp.Write([]byte("<38>Oct 18 11:59:00 router01 dropbear[812]: Bad password attempt for 'root'")); err != nil {
+		t.Fatalf("Write(udp) error = %v", err)
+	}
+
+	tcp, err := net.Dial("tcp", receiver.TCPAddr().String())
+	if err != nil {
+		t.Fatalf("Dial(tcp) error = %v", err)
+	}
+	// LF-terminated and octet-counted framing on one connection
+	octetCounted := "<34>1 2026-10-18T11:59:00Z nas01 sshd 42 - - Accepted publickey for admin"
+	stream := "<13>Oct 18 11:59:01 nas01 kernel: disk sda: I/O error\n" +
+		strconv.Itoa(len(octetCounted)) + " " + octetCounted + "kernel: link eth0 down\n"
+	if _, err := tcp.Write([]byte(stream)); err != nil {
+		t.Fatalf("Write(tcp) error = %v", err)
+	}
+	_ = tcp.Close()
+
+	waitForMessages(t, receiver, 4)
+	lines := spooledLines(t, spool)
+
+	if got := lines["router01"]; len(got) != 1 || !strings.Contains(got[0], "Bad password attempt") {
+		t.Errorf("router01 lines = %q", got)
+	}
+	want := []string{
+		"<13>Oct 18 11:59:01 nas01 kernel: disk sda: I/O error",
+		octetCounted,
+	}
+	if got := lines["nas01"]; len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("nas01 lines = %q, want %q", got, want)
+	}
+	// A message without a timestamp is spooled under the sender address
+	if got := lines["127.0.0.1"]; len(got) != 1 || !strings.HasSuffix(got[0], " 127.0.0.1 kernel: link eth0 down") {
+		t.Errorf("127.0.0.1 lines = %q, want a stamped line", got)
+	}
+}
+
+func TestReceiver_AllowedSenders(t *testing.T) {
+	t.Parallel()
+
+	allowed, err := ParseAllowedSenders("192.0.2.0/24, 2001:db8::1")
+	if err != nil {
+		t.Fatalf("ParseAllowedSenders() error = %v", err)
+	}
+	receiver, spool := startTestReceiver(t, Config{UDPAddress: "127.0.0.1:0", AllowedSenders: allowed})
+
+	udp, err := net.Dial("udp", receiver.UDPAddr().String())
+	if err != nil {
+		t.Fatalf("Dial(udp) error = %v", err)
+	}
+	defer func() { _ = udp.Close() }()
+	if _, err := udp.Write([]byte("<13>Oct 18 11:59:00 router01 kernel: denied")); err != nil {
+		t.Fatalf("Write(udp) error = %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for receiver.Stats().Denied == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if stats := receiver.Stats(); stats.Denied != 1 || stats.Received != 0 {
+		t.Errorf("stats = %+v, want one denied message", stats)
+	}
+	if lines := spooledLines(t, spool); len(lines) != 0 {
+		t.Errorf("spooled lines = %v, want none", lines)
+	}
+
+	if !receiver.allowed(netip.MustParseAddr("192.0.2.77")) || !receiver.allowed(netip.MustParseAddr("2001:db8::1")) {
+		t.Error("allowed senders are denied")
+	}
+	if _, err := ParseAllowedSenders("192.0.2.0/33"); err == nil {
+		t.Error("ParseAllowedSenders() accepted an invalid prefix")
+	}
+}
+
+func TestReceiver_TLS(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	certFile, keyFile, cert := writeTestCertificate(t, dir)
+	receiver, spool := startTestReceiver(t, Config{TCPAddress: "127.0.0.1:0", TLSCertFile: certFile, TLSKeyFile: keyFile})
+
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+	conn, err := tls.Dial("tcp", receiver.TCPAddr().String(), &tls.Config{RootCAs: roots, ServerName: "localhost", MinVersion: tls.VersionTLS12})
+	if err != nil {
+		t.Fatalf("tls.Dial() error = %v", err)
+	}
+	msg := "<34>1 2026-10-18T11:59:00Z fw01 filterlog - - - block in on wan"
+	writer := bufio.NewWriter(conn)
+	_, _ = writer.WriteString(strconv.Itoa(len(msg)) + " " + msg)
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Write(tls) error = %v", err)
+	}
+	_ = conn.Close()
+
+	waitForMessages(t, receiver, 1)
+	if got := spooledLines(t, spool)["fw01"]; len(got) != 1 || got[0] != msg {
+		t.Errorf("fw01 lines = %q, want %q", got, msg)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr string
+	}{
+		{name: "UDP only", config: Config{UDPAddress: ":514"}},
+		{name: "no listener", config: Config{}, wantErr: "listen address is required"},
+		{name: "invalid address", config: Config{UDPAddress: "514"}, wantErr: "invalid listen address"},
+		{name: "key without certificate", config: Config{TCPAddress: ":6514", TLSKeyFile: "key.pem"}, wantErr: "both a certificate and a key"},
+		{name: "TLS without TCP", config: Config{UDPAddress: ":514", TLSCertFile: "cert.pem", TLSKeyFile: "key.pem"}, wantErr: "requires a TCP listen address"},
+		{name: "client CA without TLS", config: Config{TCPAddress: ":601", TLSClientCAFile: "ca.pem"}, wantErr: "client CA requires TLS"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// writeTestCertificate writes a self-signed certificate for localhost.
+func writeTestCertificate(t *testing.T, dir string) (certFile, keyFile string, cert *x509.Certificate) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate() error = %v", err)
+	}
+	cert, err = x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("ParseCertificate() error = %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalECPrivateKey() error = %v", err)
+	}
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return certFile, keyFile, cert
+}
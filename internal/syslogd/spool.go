@@ -0,0 +1,236 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package syslogd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Spool file extensions: messages are appended to <host>.log until Rotate
+// renames it to <host>.<unix nanoseconds>.window for analysis.
+const (
+	liveExt   = ".log"
+	windowExt = ".window"
+)
+
+// Errors of Spool.Append. The message is dropped and counted.
+var (
+	ErrSpoolFull    = errors.New("spool of the host is full")
+	ErrTooManyHosts = errors.New("too many hosts in the spool")
+)
+
+// Window holds the messages of one host received since the previous
+// rotation, one message per line.
+type Window struct {
+	Host    string
+	Path    string
+	Dropped int // Messages dropped because the spool of the host was full
+}
+
+// Spool buffers received messages per host in files of a directory. Each
+// host may spool up to maxBytes per window; at most maxHosts hosts are
+// spooled per window. Files survive restarts: live files are appended to
+// again, and windows left by an interrupted run are returned by the next
+// Rotate.
+type Spool struct {
+	dir      string
+	maxBytes int64
+	maxHosts int
+
+	mu       sync.Mutex
+	hosts    map[string]*hostSpool
+	rejected int // Messages of hosts beyond maxHosts
+}
+
+// hostSpool is the live file of a host.
+type hostSpool struct {
+	file    *os.File
+	size    int64
+	dropped int
+}
+
+// OpenSpool opens the spool in dir, creating the directory if needed.
+func OpenSpool(dir string, maxBytes int64, maxHosts int) (*Spool, error) {
+	if maxBytes <= 0 {
+		return nil, fmt.Errorf("spool size must be positive (got: %d)", maxBytes)
+	}
+	if maxHosts <= 0 {
+		return nil, fmt.Errorf("spool host limit must be positive (got: %d)", maxHosts)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create spool directory: %w", err)
+	}
+
+	s := &Spool{
+		dir:      dir,
+		maxBytes: maxBytes,
+		maxHosts: maxHosts,
+		hosts:    make(map[string]*hostSpool),
+	}
+
+	// Continue the live files of the previous run
+	live, err := filepath.Glob(filepath.Join(dir, "*"+liveExt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list spool directory: %w", err)
+	}
+	for _, path := range live {
+		host := strings.TrimSuffix(filepath.Base(path), liveExt)
+		if !validHostName(host) {
+			continue
+		}
+		if _, err := s.open(host); err != nil {
+			_ = s.Close()
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// Dir returns the spool directory.
+func (s *Spool) Dir() string {
+	return s.dir
+}
+
+// Append adds a message to the live file of the host. The host must be a
+// valid host name (see HostName) and the message a single line.
+func (s *Spool) Append(host, message string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hs, ok := s.hosts[host]
+	if !ok {
+		if len(s.hosts) >= s.maxHosts {
+			s.rejected++
+			return ErrTooManyHosts
+		}
+		var err error
+		if hs, err = s.open(host); err != nil {
+			return err
+		}
+	}
+
+	n := int64(len(message) + 1)
+	if hs.size+n > s.maxBytes {
+		hs.dropped++
+		return ErrSpoolFull
+	}
+	if _, err := hs.file.WriteString(message + "\n"); err != nil {
+		return fmt.Errorf("failed to write spool of %s: %w", host, err)
+	}
+	hs.size += n
+	return nil
+}
+
+// open opens the live file of a host for appending. The caller holds mu
+// or has exclusive access.
+func (s *Spool) open(host string) (*hostSpool, error) {
+	path := filepath.Join(s.dir, host+liveExt)
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600) // #nosec G304 -- host name validated, below the spool directory
+	if err != nil {
+		return nil, fmt.Errorf("failed to open spool of %s: %w", host, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to open spool of %s: %w", host, err)
+	}
+	hs := &hostSpool{file: file, size: info.Size()}
+	s.hosts[host] = hs
+	return hs, nil
+}
+
+// Rotate closes the current window: the live files become window files,
+// and later messages start new live files. It returns the windows to
+// analyze, including those left by an interrupted run, sorted by host,
+// and the number of messages rejected because of the host limit.
+// Windows are removed with Remove once analyzed.
+func (s *Spool) Rotate() ([]Window, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Windows of an interrupted run come first, so they are analyzed
+	// before the newer window of the same host
+	windows, err := s.staleWindows()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	suffix := "." + strconv.FormatInt(time.Now().UnixNano(), 10) + windowExt
+	var errs []error
+	for host, hs := range s.hosts {
+		delete(s.hosts, host)
+		if err := hs.file.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close spool of %s: %w", host, err))
+		}
+		livePath := filepath.Join(s.dir, host+liveExt)
+		if hs.size == 0 {
+			_ = os.Remove(livePath)
+			continue
+		}
+		windowPath := filepath.Join(s.dir, host+suffix)
+		if err := os.Rename(livePath, windowPath); err != nil {
+			errs = append(errs, fmt.Errorf("failed to rotate spool of %s: %w", host, err))
+			continue
+		}
+		windows = append(windows, Window{Host: host, Path: windowPath, Dropped: hs.dropped})
+	}
+
+	sort.SliceStable(windows, func(i, j int) bool { return windows[i].Host < windows[j].Host })
+	rejected := s.rejected
+	s.rejected = 0
+	return windows, rejected, errors.Join(errs...)
+}
+
+// staleWindows returns the window files in the spool directory, oldest
+// first.
+func (s *Spool) staleWindows() ([]Window, error) {
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*"+windowExt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list spool directory: %w", err)
+	}
+	sort.Strings(paths)
+
+	var windows []Window
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), windowExt)
+		dot := strings.LastIndexByte(name, '.')
+		if dot < 0 || !validHostName(name[:dot]) {
+			continue
+		}
+		windows = append(windows, Window{Host: name[:dot], Path: path})
+	}
+	return windows, nil
+}
+
+// Remove deletes an analyzed window.
+func (s *Spool) Remove(window Window) error {
+	if err := os.Remove(window.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove spool window: %w", err)
+	}
+	return nil
+}
+
+// Close closes the live files. They are kept for the next run.
+func (s *Spool) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var errs []error
+	for host, hs := range s.hosts {
+		if err := hs.file.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close spool of %s: %w", host, err))
+		}
+		delete(s.hosts, host)
+	}
+	return errors.Join(errs...)
+}
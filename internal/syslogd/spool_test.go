@@ -0,0 +1,137 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package syslogd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSpool_AppendRotate(t *testing.T) {
+	t.Parallel()
+
+	spool, err := OpenSpool(t.TempDir(), 64, 2)
+	if err != nil {
+		t.Fatalf("OpenSpool() error = %v", err)
+	}
+	defer func() { _ = spool.Close() }()
+
+	for _, msg := range []struct{ host, line string }{
+		{"router01", "first message"},
+		{"router01", "second message"},
+		{"nas01", "nas message"},
+	} {
+		if err := spool.Append(msg.host, msg.line); err != nil {
+			t.Fatalf("Append(%s) error = %v", msg.host, err)
+		}
+	}
+
+	// The spool of a host is bounded
+	if err := spool.Append("router01", string(make([]byte, 40))); !errors.Is(err, ErrSpoolFull) {
+		t.Errorf("Append() over the size limit error = %v, want ErrSpoolFull", err)
+	}
+	// So is the number of hosts
+	if err := spool.Append("ap01", "message"); !errors.Is(err, ErrTooManyHosts) {
+		t.Errorf("Append() over the host limit error = %v, want ErrTooManyHosts", err)
+	}
+
+	windows, rejected, err := spool.Rotate()
+	if err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+	if rejected != 1 {
+		t.Errorf("rejected = %d, want 1", rejected)
+	}
+	if len(windows) != 2 || windows[0].Host != "nas01" || windows[1].Host != "router01" {
+		t.Fatalf("windows = %+v, want nas01 and router01", windows)
+	}
+	if windows[1].Dropped != 1 {
+		t.Errorf("router01 dropped = %d, want 1", windows[1].Dropped)
+	}
+	data, err := os.ReadFile(windows[1].Path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(data) != "first message\nsecond message\n" {
+		t.Errorf("window = %q", data)
+	}
+
+	// Messages after the rotation start a new window
+	if err := spool.Append("ap01", "later message"); err != nil {
+		t.Fatalf("Append() after Rotate() error = %v", err)
+	}
+	for _, window := range windows {
+		if err := spool.Remove(window); err != nil {
+			t.Fatalf("Remove() error = %v", err)
+		}
+	}
+	windows, _, err = spool.Rotate()
+	if err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+	if len(windows) != 1 || windows[0].Host != "ap01" {
+		t.Errorf("windows = %+v, want ap01", windows)
+	}
+}
+
+func TestSpool_Reopen(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	spool, err := OpenSpool(dir, 1024, 10)
+	if err != nil {
+		t.Fatalf("OpenSpool() error = %v", err)
+	}
+	if err := spool.Append("router01", "before restart"); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if err := spool.Append("nas01", "not analyzed before restart"); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	windows, _, err := spool.Rotate()
+	if err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+	// The window of router01 is analyzed, the one of nas01 is left over
+	if err := spool.Remove(windows[1]); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if err := spool.Append("router01", "live before restart"); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if err := spool.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	spool, err = OpenSpool(dir, 1024, 10)
+	if err != nil {
+		t.Fatalf("OpenSpool() error = %v", err)
+	}
+	defer func() { _ = spool.Close() }()
+	if err := spool.Append("router01", "after restart"); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	windows, _, err = spool.Rotate()
+	if err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+	if len(windows) != 2 || windows[0].Host != "nas01" || windows[1].Host != "router01" {
+		t.Fatalf("windows = %+v, want the stale nas01 window and router01", windows)
+	}
+	data, err := os.ReadFile(windows[1].Path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(data) != "live before restart\nafter restart\n" {
+		t.Errorf("window = %q", data)
+	}
+
+	live, _ := filepath.Glob(filepath.Join(dir, "*"+liveExt))
+	if len(live) != 0 {
+		t.Errorf("live files after Rotate() = %v, want none", live)
+	}
+}
@@ -0,0 +1,17 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build !unix
+
+package tail
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+)
+
+// fileInode fails: following rotation needs the inode numbers of Unix.
+func fileInode(os.FileInfo) (uint64, error) {
+	return 0, fmt.Errorf("incremental reading is not supported on %s", runtime.GOOS)
+}
@@ -0,0 +1,19 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build unix
+
+package tail
+
+import (
+	"os"
+	"syscall"
+)
+
+// fileInode returns the inode number of a file.
+func fileInode(info os.FileInfo) (uint64, error) {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		return stat.Ino, nil
+	}
+	return 0, nil
+}
@@ -0,0 +1,233 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package tail reads the lines appended to a log file since a checkpoint,
+// following truncation and rotation to ".1", so consecutive runs cover an
+// append-only log without duplicates or gaps.
+package tail
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+)
+
+// RotatedSuffix is appended to the path of a log file rotated by logrotate.
+const RotatedSuffix = ".1"
+
+// headHashSize is the number of leading bytes hashed to recognize a file
+// after it was renamed, or replaced by a file that reuses its inode.
+const headHashSize = 4096
+
+// Checkpoint is the position in a log file up to which it was read.
+type Checkpoint struct {
+	Path     string
+	Inode    uint64
+	Offset   int64  // bytes read so far, always at the start of a line
+	HeadHash string // SHA-256 of the first HeadLen bytes
+	HeadLen  int64
+}
+
+// Result is the outcome of a Read.
+type Result struct {
+	Content    string
+	Checkpoint Checkpoint // where the next read continues
+	Rotated    bool       // the rest of the rotated file was read before the new file
+	Reset      bool       // the file was truncated or replaced; read from its start
+	Skipped    int64      // oldest new bytes skipped to stay within the size limit
+}
+
+// segment is a byte range of an open file.
+type segment struct {
+	file       *os.File
+	start, end int64
+}
+
+// Read returns the complete lines appended to the file at path since the
+// checkpoint, or the whole file without one.
+//
+// The file is the one of the checkpoint if it has the same inode, is not
+// shorter than the offset, and starts with the same bytes. Otherwise, if
+// path+".1" starts with the same bytes, the file was rotated: the rest of
+// the rotated file is read, then the new file from its start. If neither
+// matches, the file was truncated or replaced and is read from its start.
+// A checkpoint of a file without complete lines has no bytes to compare;
+// path+".1" is then the rotated file only if it has the checkpoint's
+// inode.
+//
+// A trailing line without a newline is left for the next read, as it may
+// still be written. If more than maxBytes are new, the oldest lines are
+// skipped.
+func Read(path string, from *Checkpoint, maxBytes int64) (*Result, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("%s is not a regular file", path)
+	}
+	inode, err := fileInode(info)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	var segments []segment
+
+	switch {
+	case from == nil:
+		segments = []segment{{file: file, end: info.Size()}}
+	case inode == from.Inode && matches(file, info, from):
+		segments = []segment{{file: file, start: from.Offset, end: info.Size()}}
+	default:
+		rotated, rotatedInfo, openErr := openFile(path + RotatedSuffix)
+		if openErr == nil {
+			defer func() { _ = rotated.Close() }()
+		}
+		if openErr == nil && matches(rotated, rotatedInfo, from) {
+			result.Rotated = true
+			segments = []segment{
+				{file: rotated, start: from.Offset, end: rotatedInfo.Size()},
+				{file: file, end: info.Size()},
+			}
+		} else {
+			result.Reset = true
+			segments = []segment{{file: file, end: info.Size()}}
+		}
+	}
+
+	content, offset, skipped, err := readSegments(segments, maxBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	result.Content = content
+	result.Skipped = skipped
+
+	headLen := min(offset, headHashSize)
+	headHash, err := hashHead(file, headLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	result.Checkpoint = Checkpoint{
+		Path:     path,
+		Inode:    inode,
+		Offset:   offset,
+		HeadHash: headHash,
+		HeadLen:  headLen,
+	}
+
+	return result, nil
+}
+
+// openFile opens a file and returns its info.
+func openFile(path string) (*os.File, os.FileInfo, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, nil, err
+	}
+	return file, info, nil
+}
+
+// matches reports whether the file holds the content read up to the
+// checkpoint: it is not shorter than the offset and has the same head.
+// A checkpoint without a head, taken when no complete line had been
+// written yet, cannot tell files apart by content; only the file with the
+// checkpoint's inode matches it.
+func matches(file *os.File, info os.FileInfo, cp *Checkpoint) bool {
+	if !info.Mode().IsRegular() || info.Size() < cp.Offset || info.Size() < cp.HeadLen {
+		return false
+	}
+	if cp.HeadLen == 0 {
+		inode, err := fileInode(info)
+		return err == nil && cp.Inode != 0 && inode == cp.Inode
+	}
+	headHash, err := hashHead(file, cp.HeadLen)
+	return err == nil && headHash == cp.HeadHash
+}
+
+// hashHead returns the hex SHA-256 of the first n bytes of the file.
+func hashHead(file *os.File, n int64) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, io.NewSectionReader(file, 0, n)); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// readSegments reads the segments in order and returns their content, the
+// offset in the last segment's file after the last complete line, and the
+// number of bytes skipped to keep the content within maxBytes.
+func readSegments(segments []segment, maxBytes int64) (string, int64, int64, error) {
+	var total int64
+	for _, s := range segments {
+		total += s.end - s.start
+	}
+
+	// Skip the oldest bytes beyond the limit; the partial line at the new
+	// start is dropped below
+	var skipped int64
+	excess := total - maxBytes
+	for i := range segments {
+		if excess <= 0 {
+			break
+		}
+		n := min(excess, segments[i].end-segments[i].start)
+		segments[i].start += n
+		excess -= n
+		skipped += n
+	}
+
+	var buf bytes.Buffer
+	for i, s := range segments {
+		data, err := io.ReadAll(io.NewSectionReader(s.file, s.start, s.end-s.start))
+		if err != nil {
+			return "", 0, 0, err
+		}
+		if skipped > 0 && buf.Len() == 0 && s.start > 0 && len(data) > 0 && !atLineStart(s.file, s.start) {
+			// The skipped bytes ended mid-line
+			cut := bytes.IndexByte(data, '\n') + 1
+			if cut == 0 {
+				cut = len(data)
+			}
+			data = data[cut:]
+			skipped += int64(cut)
+			s.start += int64(cut)
+		}
+
+		if i < len(segments)-1 {
+			buf.Write(data)
+			if len(data) > 0 && data[len(data)-1] != '\n' {
+				buf.WriteByte('\n')
+			}
+			continue
+		}
+
+		// Leave an incomplete last line for the next read
+		complete := bytes.LastIndexByte(data, '\n') + 1
+		buf.Write(data[:complete])
+		return buf.String(), s.start + int64(complete), skipped, nil
+	}
+
+	return buf.String(), 0, skipped, nil
+}
+
+// atLineStart reports whether offset is at the start of a line.
+func atLineStart(file *os.File, offset int64) bool {
+	prev := make([]byte, 1)
+	_, err := file.ReadAt(prev, offset-1)
+	return err == nil && prev[0] == '\n'
+}
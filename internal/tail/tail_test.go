@@ -0,0 +1,235 @@
+// Copyright (c) 2025-2026 Oleg Ivanchenko
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build unix
+
+package tail
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testMaxBytes = 1 << 20
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func appendFile(t *testing.T, path, content string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	if _, err := f.WriteString(content); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// readNext reads the file from the checkpoint and returns the result.
+func readNext(t *testing.T, path string, from *Checkpoint) *Result {
+	t.Helper()
+	result, err := Read(path, from, testMaxBytes)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	return result
+}
+
+func TestRead_Appended(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	writeFile(t, path, "one\ntwo\n")
+
+	first := readNext(t, path, nil)
+	if first.Content != "one\ntwo\n" || first.Checkpoint.Offset != 8 || first.Reset || first.Rotated {
+		t.Fatalf("first Read() = %+v", first)
+	}
+
+	// Nothing new
+	if again := readNext(t, path, &first.Checkpoint); again.Content != "" || again.Checkpoint.Offset != 8 {
+		t.Fatalf("Read(no change) = %+v", again)
+	}
+
+	// The incomplete last line waits for the next read
+	appendFile(t, path, "three\nfou")
+	second := readNext(t, path, &first.Checkpoint)
+	if second.Content != "three\n" || second.Checkpoint.Offset != 14 {
+		t.Fatalf("second Read() = %+v", second)
+	}
+
+	appendFile(t, path, "r\n")
+	third := readNext(t, path, &second.Checkpoint)
+	if third.Content != "four\n" || third.Checkpoint.Offset != 19 {
+		t.Fatalf("third Read() = %+v", third)
+	}
+}
+
+func TestRead_RotatedByRename(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	writeFile(t, path, "one\n")
+	first := readNext(t, path, nil)
+
+	// Lines written before the rotation, then logrotate's create mode
+	appendFile(t, path, "two\n")
+	if err := os.Rename(path, path+RotatedSuffix); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, path, "three\n")
+
+	result := readNext(t, path, &first.Checkpoint)
+	if result.Content != "two\nthree\n" || !result.Rotated || result.Reset {
+		t.Fatalf("Read(rotated) = %+v", result)
+	}
+
+	appendFile(t, path, "four\n")
+	if next := readNext(t, path, &result.Checkpoint); next.Content != "four\n" || next.Rotated {
+		t.Fatalf("Read(after rotation) = %+v", next)
+	}
+}
+
+func TestRead_CopyTruncate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	writeFile(t, path, "one\n")
+	first := readNext(t, path, nil)
+
+	// logrotate's copytruncate keeps the inode of the live file
+	appendFile(t, path, "two\n")
+	writeFile(t, path+RotatedSuffix, "one\ntwo\n")
+	writeFile(t, path, "three\n")
+
+	result := readNext(t, path, &first.Checkpoint)
+	if result.Content != "two\nthree\n" || !result.Rotated {
+		t.Fatalf("Read(copytruncate) = %+v", result)
+	}
+}
+
+func TestRead_Truncated(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	writeFile(t, path, "one\ntwo\n")
+	first := readNext(t, path, nil)
+
+	// Truncated in place and rewritten, with no rotated file
+	writeFile(t, path, "ten\neleven\n")
+	result := readNext(t, path, &first.Checkpoint)
+	if result.Content != "ten\neleven\n" || !result.Reset || result.Rotated {
+		t.Fatalf("Read(truncated) = %+v", result)
+	}
+}
+
+func TestRead_ReplacedWithSameLength(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	writeFile(t, path, "aaa\n")
+	first := readNext(t, path, nil)
+
+	// Same inode and size, other content: the head hash tells them apart
+	writeFile(t, path, "bbb\nccc\n")
+	result := readNext(t, path, &first.Checkpoint)
+	if result.Content != "bbb\nccc\n" || !result.Reset {
+		t.Fatalf("Read(replaced) = %+v", result)
+	}
+}
+
+func TestRead_MaxBytes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	writeFile(t, path, "first line\nsecond line\nthird line\n")
+
+	result, err := Read(path, nil, 15)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if result.Content != "third line\n" || result.Skipped != 23 || result.Checkpoint.Offset != 34 {
+		t.Fatalf("Read(max bytes) = %+v", result)
+	}
+
+	// A limit ending at a line boundary keeps the whole line
+	result, err = Read(path, nil, 23)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if result.Content != "second line\nthird line\n" || result.Skipped != 11 {
+		t.Fatalf("Read(max bytes at boundary) = %+v", result)
+	}
+}
+
+func TestRead_Missing(t *testing.T) {
+	_, err := Read(filepath.Join(t.TempDir(), "missing.log"), nil, testMaxBytes)
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("Read() error = %v, want fs.ErrNotExist", err)
+	}
+}
+
+func TestRead_HeadHashCoversReadBytesOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	writeFile(t, path, strings.Repeat("x", headHashSize)+"\n")
+	first := readNext(t, path, nil)
+	if first.Checkpoint.HeadLen != headHashSize {
+		t.Fatalf("HeadLen = %d, want %d", first.Checkpoint.HeadLen, headHashSize)
+	}
+
+	// An incomplete line is not part of the head
+	partial := filepath.Join(t.TempDir(), "partial.log")
+	writeFile(t, partial, "done\nwriting")
+	result := readNext(t, partial, nil)
+	if result.Checkpoint.HeadLen != 5 || result.Checkpoint.Offset != 5 {
+		t.Fatalf("Checkpoint = %+v", result.Checkpoint)
+	}
+}
+
+func TestRead_FromEmptyFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	writeFile(t, path+RotatedSuffix, "old rotation\n")
+	writeFile(t, path, "")
+
+	empty := readNext(t, path, nil)
+	if empty.Content != "" || empty.Checkpoint.HeadLen != 0 {
+		t.Fatalf("Read(empty) = %+v", empty)
+	}
+
+	// Appended to the same file
+	appendFile(t, path, "first\n")
+	if appended := readNext(t, path, &empty.Checkpoint); appended.Content != "first\n" || appended.Reset || appended.Rotated {
+		t.Errorf("Read(appended) = %+v", appended)
+	}
+
+	// Replaced by a new file: the unrelated old rotation is not the
+	// previous file, however short the checkpoint's head
+	replacement := filepath.Join(dir, "app.log.new")
+	writeFile(t, replacement, "replaced\n")
+	if err := os.Rename(replacement, path); err != nil {
+		t.Fatal(err)
+	}
+	replaced := readNext(t, path, &empty.Checkpoint)
+	if replaced.Content != "replaced\n" || !replaced.Reset || replaced.Rotated {
+		t.Errorf("Read(replaced) = %+v, want only the new file", replaced)
+	}
+}
+
+func TestRead_RotatedFromEmptyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	writeFile(t, path, "")
+	empty := readNext(t, path, nil)
+
+	// Written, rotated, and written again between two runs
+	appendFile(t, path, "before rotation\n")
+	if err := os.Rename(path, path+RotatedSuffix); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, path, "after rotation\n")
+
+	rotated := readNext(t, path, &empty.Checkpoint)
+	if rotated.Content != "before rotation\nafter rotation\n" || !rotated.Rotated {
+		t.Errorf("Read(rotated) = %+v", rotated)
+	}
+}